@@ -37,6 +37,11 @@ func main() {
 
 	defer recoverPanic()
 
+	if len(os.Args) > 1 && os.Args[1] == scraperTestCommand {
+		exitCode = scraperTest(os.Args[2:])
+		return
+	}
+
 	initLogTemp()
 
 	helpFlag := false
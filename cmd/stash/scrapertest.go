@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/stashapp/stash/internal/log"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/scraper"
+)
+
+// scraperTestCommand is the name of the subcommand that runs scraperTest.
+const scraperTestCommand = "scrapertest"
+
+type scraperTestConfig struct {
+	userAgent  string
+	cdpPath    string
+	certCheck  bool
+	pythonPath string
+	proxy      string
+}
+
+func (c scraperTestConfig) GetScraperUserAgent() string {
+	return c.userAgent
+}
+
+func (c scraperTestConfig) GetScrapersPath() string {
+	return ""
+}
+
+func (c scraperTestConfig) GetScraperCDPPath() string {
+	return c.cdpPath
+}
+
+func (c scraperTestConfig) GetScraperCertCheck() bool {
+	return c.certCheck
+}
+
+func (c scraperTestConfig) GetPythonPath() string {
+	return c.pythonPath
+}
+
+func (c scraperTestConfig) GetProxy() string {
+	return c.proxy
+}
+
+// responses are never cached
+func (c scraperTestConfig) GetCachePath() string {
+	return ""
+}
+
+func (c scraperTestConfig) GetScraperCacheTTL() time.Duration {
+	return 0
+}
+
+func marshal(content interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(content); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// normalize re-indents the json, so that expected output files that were
+// edited by hand can be compared.
+func normalize(data []byte) ([]byte, error) {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+
+	return marshal(v)
+}
+
+// compare compares the output against the expected output file. Returns a
+// description of the first differing line, or an empty string if they are
+// equal.
+func compare(output []byte, expectedPath string) (string, error) {
+	expected, err := os.ReadFile(expectedPath)
+	if err != nil {
+		return "", err
+	}
+
+	expected, err = normalize(expected)
+	if err != nil {
+		return "", fmt.Errorf("parsing %s: %w", expectedPath, err)
+	}
+
+	output, err = normalize(output)
+	if err != nil {
+		return "", err
+	}
+
+	if bytes.Equal(expected, output) {
+		return "", nil
+	}
+
+	expectedLines := strings.Split(string(expected), "\n")
+	outputLines := strings.Split(string(output), "\n")
+
+	line := 0
+	for line < len(expectedLines) && line < len(outputLines) && expectedLines[line] == outputLines[line] {
+		line++
+	}
+
+	lineAt := func(lines []string) string {
+		if line < len(lines) {
+			return lines[line]
+		}
+		return "<end of file>"
+	}
+
+	return fmt.Sprintf("output differs from %s at line %d:\nexpected: %s\noutput:   %s\n", expectedPath, line+1, lineAt(expectedLines), lineAt(outputLines)), nil
+}
+
+// scraperTest runs a scrape using a single scraper config and prints the
+// scraped content as JSON. The output may be compared against an expected
+// output file to test scrapers for regressions. Returns the exit code.
+func scraperTest(args []string) int {
+	flag := pflag.NewFlagSet(scraperTestCommand, pflag.ContinueOnError)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "%s %s [OPTIONS] SCRAPERFILE (--url URL | --name NAME)\n\nOptions:\n", os.Args[0], scraperTestCommand)
+		flag.PrintDefaults()
+	}
+
+	var (
+		options scraper.HarnessOptions
+		gc      scraperTestConfig
+	)
+
+	ty := flag.StringP("type", "t", "scene", "type of content to scrape: scene, performer, gallery, group or movie")
+	flag.StringVarP(&options.URL, "url", "u", "", "url to scrape")
+	flag.StringVarP(&options.Name, "name", "n", "", "query to scrape by name")
+	flag.StringVarP(&options.Fixture, "fixture", "f", "", "file to use as the response to all requests, instead of making them")
+	expected := flag.StringP("expected", "e", "", "expected output file to compare the output against")
+	update := flag.Bool("update", false, "write the output to the expected output file")
+	timeout := flag.Duration("timeout", 2*time.Minute, "maximum duration of the scrape")
+	logLevel := flag.String("log-level", "Error", "log level: Trace, Debug, Info, Warning or Error")
+	flag.StringVar(&gc.userAgent, "user-agent", "", "user agent to use for requests")
+	flag.StringVar(&gc.cdpPath, "cdp-path", "", "path or address of chrome for scrapers using CDP")
+	flag.BoolVar(&gc.certCheck, "cert-check", true, "check the certificates of https sites")
+	flag.StringVar(&gc.pythonPath, "python", "", "path of the python executable for script scrapers")
+	flag.StringVar(&gc.proxy, "proxy", "", "proxy to use for requests")
+	if err := flag.Parse(args); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	args = flag.Args()
+	if len(args) != 1 {
+		fmt.Fprintf(os.Stderr, "Missing SCRAPERFILE argument.\n")
+		flag.Usage()
+		return 2
+	}
+
+	if *update && *expected == "" {
+		fmt.Fprintf(os.Stderr, "--update requires --expected.\n")
+		return 2
+	}
+
+	options.Type = scraper.ScrapeContentType(strings.ToUpper(*ty))
+
+	l := log.NewLogger()
+	l.Init("", true, *logLevel)
+	logger.Logger = l
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	content, err := scraper.RunScraperFile(ctx, args[0], gc, options)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	output, err := marshal(content)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	switch {
+	case *update:
+		if err := os.WriteFile(*expected, output, 0644); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	case *expected != "":
+		diff, err := compare(output, *expected)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		if diff != "" {
+			fmt.Fprint(os.Stderr, diff)
+			fmt.Fprintf(os.Stderr, "Run with --update to replace the expected output.\n")
+			return 1
+		}
+	default:
+		fmt.Print(string(output))
+	}
+
+	return 0
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/session"
 )
 
@@ -40,7 +41,7 @@ func authenticateHandler() func(http.Handler) http.Handler {
 				return
 			}
 
-			userID, err := manager.GetInstance().SessionStore.Authenticate(w, r)
+			userID, user, err := manager.GetInstance().SessionStore.Authenticate(w, r)
 			if err != nil {
 				if !errors.Is(err, session.ErrUnauthorized) {
 					http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -73,8 +74,7 @@ func authenticateHandler() func(http.Handler) http.Handler {
 
 			ctx := r.Context()
 
-			if c.HasCredentials() {
-				// authentication is required
+			if c.AuthenticationRequired() {
 				if userID == "" && !allowUnauthenticated(r) {
 					// if graphql or a non-webpage was requested, we just return a forbidden error
 					ext := path.Ext(r.URL.Path)
@@ -103,6 +103,21 @@ func authenticateHandler() func(http.Handler) http.Handler {
 			}
 
 			ctx = session.SetCurrentUserID(ctx, userID)
+			if user != nil {
+				ctx = session.SetCurrentUser(ctx, user)
+			}
+
+			// hide restricted content. Fail rather than serve unrestricted
+			// content if the restriction cannot be loaded.
+			restriction, err := manager.GetInstance().SessionStore.GetContentRestriction(r, user)
+			if err != nil {
+				logger.Errorf("Error getting content restriction: %v", err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if restriction != nil {
+				ctx = models.WithContentRestriction(ctx, restriction)
+			}
 
 			r = r.WithContext(ctx)
 
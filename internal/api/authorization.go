@@ -0,0 +1,271 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+var errForbidden = errors.New("operation is not permitted for the current user")
+
+// editorMutations may be performed by users with the editor role, in
+// addition to the viewer mutations. They only modify library content. All
+// other mutations may only be performed by admins.
+var editorMutations = map[string]bool{
+	"sceneCreate":               true,
+	"sceneUpdate":               true,
+	"sceneMerge":                true,
+	"bulkSceneUpdate":           true,
+	"sceneDestroy":              true,
+	"scenesDestroy":             true,
+	"scenesUpdate":              true,
+	"sceneGenerateScreenshot":   true,
+	"sceneMarkerCreate":         true,
+	"sceneMarkerUpdate":         true,
+	"sceneMarkerDestroy":        true,
+	"sceneAssignFile":           true,
+	"imageUpdate":               true,
+	"bulkImageUpdate":           true,
+	"imageDestroy":              true,
+	"imagesDestroy":             true,
+	"imagesUpdate":              true,
+	"imageIncrementO":           true,
+	"imageDecrementO":           true,
+	"imageResetO":               true,
+	"galleryCreate":             true,
+	"galleryUpdate":             true,
+	"bulkGalleryUpdate":         true,
+	"galleryDestroy":            true,
+	"galleriesUpdate":           true,
+	"addGalleryImages":          true,
+	"removeGalleryImages":       true,
+	"galleryChapterCreate":      true,
+	"galleryChapterUpdate":      true,
+	"galleryChapterDestroy":     true,
+	"performerCreate":           true,
+	"performerUpdate":           true,
+	"performerDestroy":          true,
+	"performersDestroy":         true,
+	"performersMerge":           true,
+	"bulkPerformerUpdate":       true,
+	"studioCreate":              true,
+	"studioUpdate":              true,
+	"studioDestroy":             true,
+	"studiosDestroy":            true,
+	"studiosMerge":              true,
+	"movieCreate":               true,
+	"movieUpdate":               true,
+	"movieDestroy":              true,
+	"moviesDestroy":             true,
+	"bulkMovieUpdate":           true,
+	"groupCreate":               true,
+	"groupUpdate":               true,
+	"groupDestroy":              true,
+	"groupsDestroy":             true,
+	"bulkGroupUpdate":           true,
+	"tagCreate":                 true,
+	"tagUpdate":                 true,
+	"tagDestroy":                true,
+	"tagsDestroy":               true,
+	"tagsMerge":                 true,
+	"bulkTagUpdate":             true,
+	"moveFiles":                 true,
+	"deleteFiles":               true,
+	"fileSetFingerprints":       true,
+	"saveFilter":                true,
+	"destroySavedFilter":        true,
+	"setDefaultFilter":          true,
+	"exportObjects":             true,
+	"metadataScan":              true,
+	"metadataGenerate":          true,
+	"metadataAutoTag":           true,
+	"metadataIdentify":          true,
+	"stashBoxBatchPerformerTag": true,
+	"stashBoxBatchStudioTag":    true,
+}
+
+// viewerMutations may be performed by users with the viewer role. They only
+// modify the activity data of the current user.
+var viewerMutations = map[string]bool{
+	"sceneIncrementO":         true,
+	"sceneDecrementO":         true,
+	"sceneAddO":               true,
+	"sceneDeleteO":            true,
+	"sceneResetO":             true,
+	"sceneSaveActivity":       true,
+	"sceneIncrementPlayCount": true,
+	"sceneAddPlay":            true,
+	"sceneDeletePlay":         true,
+	"sceneResetPlayCount":     true,
+	"userChangePassword":      true,
+	"userGenerateAPIKey":      true,
+}
+
+// viewerQueries may be performed by users with the viewer role. They read
+// library content and the settings needed by the interface. All other queries
+// and subscriptions may only be performed by editors or admins.
+var viewerQueries = map[string]bool{
+	"__schema":                  true,
+	"__type":                    true,
+	"__typename":                true,
+	"findSavedFilter":           true,
+	"findSavedFilters":          true,
+	"findDefaultFilter":         true,
+	"findScene":                 true,
+	"findSceneByHash":           true,
+	"findScenes":                true,
+	"findScenesByPathRegex":     true,
+	"sceneStreams":              true,
+	"findSceneMarkers":          true,
+	"findImage":                 true,
+	"findImages":                true,
+	"findPerformer":             true,
+	"findPerformers":            true,
+	"findStudio":                true,
+	"findStudios":               true,
+	"findMovie":                 true,
+	"findMovies":                true,
+	"findGroup":                 true,
+	"findGroups":                true,
+	"findGallery":               true,
+	"findGalleries":             true,
+	"findTag":                   true,
+	"findTags":                  true,
+	"search":                    true,
+	"markerWall":                true,
+	"sceneWall":                 true,
+	"markerStrings":             true,
+	"stats":                     true,
+	"sceneMarkerTags":           true,
+	"plugins":                   true,
+	"configuration":             true,
+	"systemStatus":              true,
+	"currentUser":               true,
+	"findUser":                  true,
+	"findUsers":                 true,
+	"currentRestrictionProfile": true,
+	"findRestrictionProfile":    true,
+	"findRestrictionProfiles":   true,
+	"allScenes":                 true,
+	"allSceneMarkers":           true,
+	"allImages":                 true,
+	"allGalleries":              true,
+	"allPerformers":             true,
+	"allTags":                   true,
+	"allStudios":                true,
+	"allMovies":                 true,
+	"version":                   true,
+	"scanCompleteSubscribe":     true,
+}
+
+// editorQueries may be performed by users with the editor role, in addition
+// to the viewer queries. They are needed to scrape library content and to
+// follow the jobs started by editor mutations. The remaining queries, such as
+// directory and logs, expose the server and may only be performed by admins.
+var editorQueries = map[string]bool{
+	"findDuplicateScenes":   true,
+	"parseSceneFilenames":   true,
+	"listScrapers":          true,
+	"scrapeSingleScene":     true,
+	"scrapeMultiScenes":     true,
+	"scrapeSingleStudio":    true,
+	"scrapeSinglePerformer": true,
+	"scrapeMultiPerformers": true,
+	"scrapeSingleGallery":   true,
+	"scrapeSingleImage":     true,
+	"scrapeSingleMovie":     true,
+	"scrapeSingleGroup":     true,
+	"scrapeURL":             true,
+	"scrapePerformerURL":    true,
+	"scrapeSceneURL":        true,
+	"scrapeGalleryURL":      true,
+	"scrapeImageURL":        true,
+	"scrapeMovieURL":        true,
+	"scrapeGroupURL":        true,
+	"jobQueue":              true,
+	"findJob":               true,
+	"jobsSubscribe":         true,
+}
+
+// currentUserRole returns the role of the current user. The configured user
+// has the admin role, as do all requests when authentication is disabled.
+// Restricted sessions have at most the viewer role, so that the restriction
+// cannot be changed or bypassed.
+func currentUserRole(ctx context.Context) models.UserRole {
+	if models.GetContentRestriction(ctx) != nil {
+		return models.UserRoleViewer
+	}
+
+	if u := currentUser(ctx); u != nil {
+		return u.Role
+	}
+
+	return models.UserRoleAdmin
+}
+
+func isAdmin(ctx context.Context) bool {
+	return currentUserRole(ctx) == models.UserRoleAdmin
+}
+
+// authorizeFieldMiddleware rejects queries, mutations and subscriptions
+// that are not permitted for the role of the current user.
+func authorizeFieldMiddleware(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc != nil {
+		switch fc.Object {
+		case "Query", "Subscription":
+			if !queryPermitted(currentUserRole(ctx), fc.Field.Name) {
+				return nil, errForbidden
+			}
+		case "Mutation":
+			if !mutationPermitted(ctx, currentUserRole(ctx), fc.Field.Name) {
+				return nil, errForbidden
+			}
+		}
+	}
+
+	return next(ctx)
+}
+
+func queryPermitted(role models.UserRole, name string) bool {
+	switch role {
+	case models.UserRoleAdmin:
+		return true
+	case models.UserRoleEditor:
+		if editorQueries[name] {
+			return true
+		}
+	}
+
+	return viewerQueries[name]
+}
+
+func mutationPermitted(ctx context.Context, role models.UserRole, name string) bool {
+	switch role {
+	case models.UserRoleAdmin:
+		return true
+	case models.UserRoleEditor:
+		if editorMutations[name] {
+			return true
+		}
+	}
+
+	if viewerMutations[name] {
+		return true
+	}
+
+	// viewers may set their own rating of a scene
+	if name == "sceneUpdate" {
+		for k := range getUpdateInputMap(ctx) {
+			if k != "id" && k != "rating100" {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func authorizeField(ctx context.Context, object string, name string) error {
+	fc := &graphql.FieldContext{
+		Object: object,
+		Field: graphql.CollectedField{
+			Field: &ast.Field{Name: name},
+		},
+	}
+
+	_, err := authorizeFieldMiddleware(graphql.WithFieldContext(ctx, fc), func(ctx context.Context) (interface{}, error) {
+		return nil, nil
+	})
+	return err
+}
+
+func TestAuthorizeFieldMiddlewareQueries(t *testing.T) {
+	viewerCtx := session.SetCurrentUser(testCtx, &models.User{ID: 1, Role: models.UserRoleViewer})
+	editorCtx := session.SetCurrentUser(testCtx, &models.User{ID: 2, Role: models.UserRoleEditor})
+	restrictedCtx := models.WithContentRestriction(testCtx, &models.ContentRestriction{ProfileID: 1})
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		object  string
+		field   string
+		wantErr error
+	}{
+		{"viewer directory", viewerCtx, "Query", "directory", errForbidden},
+		{"viewer logs", viewerCtx, "Query", "logs", errForbidden},
+		{"viewer scrapeURL", viewerCtx, "Query", "scrapeURL", errForbidden},
+		{"viewer logging subscription", viewerCtx, "Subscription", "loggingSubscribe", errForbidden},
+		{"viewer findScenes", viewerCtx, "Query", "findScenes", nil},
+		{"viewer introspection", viewerCtx, "Query", "__schema", nil},
+		{"restricted directory", restrictedCtx, "Query", "directory", errForbidden},
+		{"editor scrapeURL", editorCtx, "Query", "scrapeURL", nil},
+		{"editor directory", editorCtx, "Query", "directory", errForbidden},
+		{"admin directory", testCtx, "Query", "directory", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantErr, authorizeField(tt.ctx, tt.object, tt.field))
+		})
+	}
+}
@@ -346,6 +346,25 @@ func (t changesetTranslator) updateStashIDs(value []models.StashID, field string
 	}
 }
 
+func (t changesetTranslator) updateCustomFields(value []models.CustomField, field string) *models.UpdateCustomFields {
+	if !t.hasField(field) {
+		return nil
+	}
+
+	return &models.UpdateCustomFields{
+		CustomFields: value,
+		Mode:         models.RelationshipUpdateModeSet,
+	}
+}
+
+func (t changesetTranslator) updateCustomFieldsBulk(value *models.UpdateCustomFields, field string) *models.UpdateCustomFields {
+	if !t.hasField(field) || value == nil {
+		return nil
+	}
+
+	return value
+}
+
 func (t changesetTranslator) relatedGroupsFromMovies(value []models.SceneMovieInput) (models.RelatedGroups, error) {
 	groupsScenes, err := models.GroupsScenesFromInput(value)
 	if err != nil {
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/plugin/hook"
+)
+
+// executePreHooks executes the plugin pre-hooks of the given type for the
+// current operation. inputMap is the graphql input map of the operation.
+//
+// If a hook replaces the input, then input is overwritten with the
+// replacement, and the returned map contains the fields of the replacement.
+// The returned map should be used in place of inputMap when translating the
+// input. Changes to the id field of the input are ignored.
+func executePreHooks[T any](ctx context.Context, e hookExecutor, id int, hookType hook.TriggerEnum, input *T, inputMap map[string]interface{}) (map[string]interface{}, error) {
+	newInputMap, err := e.ExecutePreHooks(ctx, id, hookType, inputMap)
+	if err != nil {
+		return nil, err
+	}
+
+	if newInputMap == nil {
+		return inputMap, nil
+	}
+
+	if v, found := inputMap["id"]; found {
+		newInputMap["id"] = v
+	}
+
+	data, err := json.Marshal(newInputMap)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s input: %w", hookType, err)
+	}
+
+	var newInput T
+	if err := json.Unmarshal(data, &newInput); err != nil {
+		return nil, fmt.Errorf("decoding %s input: %w", hookType, err)
+	}
+
+	*input = newInput
+	return newInputMap, nil
+}
+
+// executeDestroyPreHooks executes the plugin pre-hooks of the given type for
+// a destroy operation. Destroy operations may be rejected but not modified,
+// so any replacement input is ignored.
+func executeDestroyPreHooks(ctx context.Context, e hookExecutor, id int, hookType hook.TriggerEnum, inputMap map[string]interface{}) error {
+	_, err := e.ExecutePreHooks(ctx, id, hookType, inputMap)
+	return err
+}
+
+// bulkInput is the input of a bulk operation for a single object, after
+// executing the plugin pre-hooks for the object.
+type bulkInput[T any] struct {
+	id    int
+	input T
+	// translator translates the fields of input
+	translator changesetTranslator
+}
+
+// executeBulkPreHooks executes the plugin pre-hooks of the given type for each
+// object of a bulk operation. inputMap is the graphql input map of the
+// operation. Returns the input of the operation for each object, in the order
+// of ids.
+//
+// Hooks are executed once for each object, and may replace the input for that
+// object only. The operation is rejected if a hook rejects the input for any
+// object.
+func executeBulkPreHooks[T any](ctx context.Context, e hookExecutor, ids []int, hookType hook.TriggerEnum, input T, inputMap map[string]interface{}) ([]bulkInput[T], error) {
+	ret := make([]bulkInput[T], len(ids))
+	for i, id := range ids {
+		thisInput := input
+		thisInputMap, err := executePreHooks(ctx, e, id, hookType, &thisInput, inputMap)
+		if err != nil {
+			return nil, err
+		}
+
+		ret[i] = bulkInput[T]{
+			id:    id,
+			input: thisInput,
+			translator: changesetTranslator{
+				inputMap: thisInputMap,
+			},
+		}
+	}
+
+	return ret, nil
+}
+
+// executeBulkDestroyPreHooks executes the plugin pre-hooks of the given type
+// for each object of a bulk destroy operation.
+func executeBulkDestroyPreHooks(ctx context.Context, e hookExecutor, ids []int, hookType hook.TriggerEnum, inputMap map[string]interface{}) error {
+	for _, id := range ids {
+		if err := executeDestroyPreHooks(ctx, e, id, hookType, inputMap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
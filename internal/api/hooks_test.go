@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/plugin/hook"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// preHookExecutor executes pre-hooks using the provided function.
+type preHookExecutor struct {
+	mockHookExecutor
+	fn func(id int, input map[string]interface{}) (map[string]interface{}, error)
+}
+
+func (e *preHookExecutor) ExecutePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input map[string]interface{}) (map[string]interface{}, error) {
+	return e.fn(id, input)
+}
+
+type testBulkInput struct {
+	Ids   []string `json:"ids"`
+	Title *string  `json:"title"`
+}
+
+func TestExecuteBulkPreHooks(t *testing.T) {
+	title := "title"
+	input := testBulkInput{
+		Ids:   []string{"1", "2"},
+		Title: &title,
+	}
+	inputMap := map[string]interface{}{
+		"ids":   []interface{}{"1", "2"},
+		"title": title,
+	}
+
+	errRejected := errors.New("rejected")
+
+	t.Run("replaced for one object", func(t *testing.T) {
+		var calledIDs []int
+		e := &preHookExecutor{
+			fn: func(id int, input map[string]interface{}) (map[string]interface{}, error) {
+				calledIDs = append(calledIDs, id)
+				if id != 2 {
+					return nil, nil
+				}
+
+				return map[string]interface{}{
+					"ids":   []interface{}{"3"},
+					"title": "replaced",
+				}, nil
+			},
+		}
+
+		got, err := executeBulkPreHooks(testCtx, e, []int{1, 2}, hook.SceneUpdatePre, input, inputMap)
+		if err != nil {
+			t.Fatalf("executeBulkPreHooks() error = %v", err)
+		}
+
+		assert := assert.New(t)
+		assert.Equal([]int{1, 2}, calledIDs)
+		if !assert.Len(got, 2) {
+			return
+		}
+
+		assert.Equal(1, got[0].id)
+		assert.Equal("title", *got[0].input.Title)
+		assert.True(got[0].translator.hasField("title"))
+
+		assert.Equal(2, got[1].id)
+		assert.Equal("replaced", *got[1].input.Title)
+		assert.True(got[1].translator.hasField("title"))
+
+		// the original input is unchanged
+		assert.Equal("title", *input.Title)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		e := &preHookExecutor{
+			fn: func(id int, input map[string]interface{}) (map[string]interface{}, error) {
+				if id == 2 {
+					return nil, errRejected
+				}
+				return nil, nil
+			},
+		}
+
+		_, err := executeBulkPreHooks(testCtx, e, []int{1, 2}, hook.SceneUpdatePre, input, inputMap)
+		assert.ErrorIs(t, err, errRejected)
+
+		err = executeBulkDestroyPreHooks(testCtx, e, []int{1, 2}, hook.SceneDestroyPre, inputMap)
+		assert.ErrorIs(t, err, errRejected)
+	})
+}
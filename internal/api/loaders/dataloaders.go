@@ -14,6 +14,7 @@
 //go:generate go run github.com/vektah/dataloaden SceneOHistoryLoader int []time.Time
 //go:generate go run github.com/vektah/dataloaden ScenePlayHistoryLoader int []time.Time
 //go:generate go run github.com/vektah/dataloaden SceneLastPlayedLoader int *time.Time
+//go:generate go run github.com/vektah/dataloaden SceneUserDataLoader int *github.com/stashapp/stash/pkg/models.SceneUserData
 package loaders
 
 import (
@@ -22,6 +23,7 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
 )
 
 type contextKey struct{ name string }
@@ -43,6 +45,7 @@ type Loaders struct {
 	ScenePlayHistory *ScenePlayHistoryLoader
 	SceneOHistory    *SceneOHistoryLoader
 	SceneLastPlayed  *SceneLastPlayedLoader
+	SceneUserData    *SceneUserDataLoader
 
 	ImageFiles   *ImageFileIDsLoader
 	GalleryFiles *GalleryFileIDsLoader
@@ -139,6 +142,11 @@ func (m Middleware) Middleware(next http.Handler) http.Handler {
 				maxBatch: maxBatch,
 				fetch:    m.fetchScenesLastPlayed(ctx),
 			},
+			SceneUserData: &SceneUserDataLoader{
+				wait:     wait,
+				maxBatch: maxBatch,
+				fetch:    m.fetchScenesUserData(ctx),
+			},
 			SceneOHistory: &SceneOHistoryLoader{
 				wait:     wait,
 				maxBatch: maxBatch,
@@ -163,6 +171,23 @@ func toErrorSlice(err error) []error {
 	return nil
 }
 
+// alignByID returns objs in the order of keys, with nil entries for keys that
+// were not found. FindMany omits content hidden by the content restriction,
+// so its results may not be aligned with the keys.
+func alignByID[T any](keys []int, objs []*T, getID func(o *T) int) []*T {
+	byID := make(map[int]*T, len(objs))
+	for _, o := range objs {
+		byID[getID(o)] = o
+	}
+
+	ret := make([]*T, len(keys))
+	for i, k := range keys {
+		ret[i] = byID[k]
+	}
+
+	return ret
+}
+
 func (m Middleware) fetchScenes(ctx context.Context) func(keys []int) ([]*models.Scene, []error) {
 	return func(keys []int) (ret []*models.Scene, errs []error) {
 		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
@@ -170,7 +195,11 @@ func (m Middleware) fetchScenes(ctx context.Context) func(keys []int) ([]*models
 			ret, err = m.Repository.Scene.FindMany(ctx, keys)
 			return err
 		})
-		return ret, toErrorSlice(err)
+		if err != nil {
+			return nil, toErrorSlice(err)
+		}
+
+		return alignByID(keys, ret, func(o *models.Scene) int { return o.ID }), nil
 	}
 }
 
@@ -181,8 +210,11 @@ func (m Middleware) fetchImages(ctx context.Context) func(keys []int) ([]*models
 			ret, err = m.Repository.Image.FindMany(ctx, keys)
 			return err
 		})
+		if err != nil {
+			return nil, toErrorSlice(err)
+		}
 
-		return ret, toErrorSlice(err)
+		return alignByID(keys, ret, func(o *models.Image) int { return o.ID }), nil
 	}
 }
 
@@ -193,8 +225,11 @@ func (m Middleware) fetchGalleries(ctx context.Context) func(keys []int) ([]*mod
 			ret, err = m.Repository.Gallery.FindMany(ctx, keys)
 			return err
 		})
+		if err != nil {
+			return nil, toErrorSlice(err)
+		}
 
-		return ret, toErrorSlice(err)
+		return alignByID(keys, ret, func(o *models.Gallery) int { return o.ID }), nil
 	}
 }
 
@@ -205,8 +240,11 @@ func (m Middleware) fetchPerformers(ctx context.Context) func(keys []int) ([]*mo
 			ret, err = m.Repository.Performer.FindMany(ctx, keys)
 			return err
 		})
+		if err != nil {
+			return nil, toErrorSlice(err)
+		}
 
-		return ret, toErrorSlice(err)
+		return alignByID(keys, ret, func(o *models.Performer) int { return o.ID }), nil
 	}
 }
 
@@ -239,7 +277,11 @@ func (m Middleware) fetchGroups(ctx context.Context) func(keys []int) ([]*models
 			ret, err = m.Repository.Group.FindMany(ctx, keys)
 			return err
 		})
-		return ret, toErrorSlice(err)
+		if err != nil {
+			return nil, toErrorSlice(err)
+		}
+
+		return alignByID(keys, ret, func(o *models.Group) int { return o.ID }), nil
 	}
 }
 
@@ -287,11 +329,21 @@ func (m Middleware) fetchGalleriesFileIDs(ctx context.Context) func(keys []int)
 	}
 }
 
+// sceneActivity returns the store used to read the scene history of the
+// current user.
+func (m Middleware) sceneActivity(ctx context.Context) models.SceneActivityReader {
+	if u := session.GetCurrentUser(ctx); u != nil {
+		return m.Repository.SceneUserData.ForUser(u.ID)
+	}
+
+	return m.Repository.Scene
+}
+
 func (m Middleware) fetchScenesOCount(ctx context.Context) func(keys []int) ([]int, []error) {
 	return func(keys []int) (ret []int, errs []error) {
 		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
 			var err error
-			ret, err = m.Repository.Scene.GetManyOCount(ctx, keys)
+			ret, err = m.sceneActivity(ctx).GetManyOCount(ctx, keys)
 			return err
 		})
 		return ret, toErrorSlice(err)
@@ -302,7 +354,7 @@ func (m Middleware) fetchScenesPlayCount(ctx context.Context) func(keys []int) (
 	return func(keys []int) (ret []int, errs []error) {
 		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
 			var err error
-			ret, err = m.Repository.Scene.GetManyViewCount(ctx, keys)
+			ret, err = m.sceneActivity(ctx).GetManyViewCount(ctx, keys)
 			return err
 		})
 		return ret, toErrorSlice(err)
@@ -313,7 +365,7 @@ func (m Middleware) fetchScenesOHistory(ctx context.Context) func(keys []int) ([
 	return func(keys []int) (ret [][]time.Time, errs []error) {
 		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
 			var err error
-			ret, err = m.Repository.Scene.GetManyODates(ctx, keys)
+			ret, err = m.sceneActivity(ctx).GetManyODates(ctx, keys)
 			return err
 		})
 		return ret, toErrorSlice(err)
@@ -324,7 +376,7 @@ func (m Middleware) fetchScenesPlayHistory(ctx context.Context) func(keys []int)
 	return func(keys []int) (ret [][]time.Time, errs []error) {
 		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
 			var err error
-			ret, err = m.Repository.Scene.GetManyViewDates(ctx, keys)
+			ret, err = m.sceneActivity(ctx).GetManyViewDates(ctx, keys)
 			return err
 		})
 		return ret, toErrorSlice(err)
@@ -335,7 +387,25 @@ func (m Middleware) fetchScenesLastPlayed(ctx context.Context) func(keys []int)
 	return func(keys []int) (ret []*time.Time, errs []error) {
 		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
 			var err error
-			ret, err = m.Repository.Scene.GetManyLastViewed(ctx, keys)
+			ret, err = m.sceneActivity(ctx).GetManyLastViewed(ctx, keys)
+			return err
+		})
+		return ret, toErrorSlice(err)
+	}
+}
+
+// fetchScenesUserData fetches the scene data of the current user. It returns
+// nil entries if the current user is not a local user account.
+func (m Middleware) fetchScenesUserData(ctx context.Context) func(keys []int) ([]*models.SceneUserData, []error) {
+	return func(keys []int) (ret []*models.SceneUserData, errs []error) {
+		u := session.GetCurrentUser(ctx)
+		if u == nil {
+			return make([]*models.SceneUserData, len(keys)), nil
+		}
+
+		err := m.Repository.WithDB(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = m.Repository.SceneUserData.ForUser(u.ID).GetManyUserData(ctx, keys)
 			return err
 		})
 		return ret, toErrorSlice(err)
@@ -14,6 +14,7 @@ import (
 	"github.com/stashapp/stash/pkg/plugin/hook"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 var (
@@ -30,6 +31,7 @@ var (
 
 type hookExecutor interface {
 	ExecutePostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string)
+	ExecutePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input map[string]interface{}) (map[string]interface{}, error)
 }
 
 type Resolver struct {
@@ -98,6 +100,21 @@ func (r *Resolver) ImageFile() ImageFileResolver {
 func (r *Resolver) SavedFilter() SavedFilterResolver {
 	return &savedFilterResolver{r}
 }
+func (r *Resolver) ScheduledTask() ScheduledTaskResolver {
+	return &scheduledTaskResolver{r}
+}
+func (r *Resolver) ScheduledTaskRun() ScheduledTaskRunResolver {
+	return &scheduledTaskRunResolver{r}
+}
+func (r *Resolver) User() UserResolver {
+	return &userResolver{r}
+}
+func (r *Resolver) RestrictionProfile() RestrictionProfileResolver {
+	return &restrictionProfileResolver{r}
+}
+func (r *Resolver) SearchResult() SearchResultResolver {
+	return &searchResultResolver{r}
+}
 func (r *Resolver) Plugin() PluginResolver {
 	return &pluginResolver{r}
 }
@@ -126,6 +143,11 @@ type galleryFileResolver struct{ *Resolver }
 type videoFileResolver struct{ *Resolver }
 type imageFileResolver struct{ *Resolver }
 type savedFilterResolver struct{ *Resolver }
+type scheduledTaskResolver struct{ *Resolver }
+type scheduledTaskRunResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
+type restrictionProfileResolver struct{ *Resolver }
+type searchResultResolver struct{ *Resolver }
 type pluginResolver struct{ *Resolver }
 type configResultResolver struct{ *Resolver }
 
@@ -178,6 +200,7 @@ func (r *queryResolver) Stats(ctx context.Context) (*StatsResultType, error) {
 	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
 		repo := r.repository
 		sceneQB := repo.Scene
+		activityQB := r.sceneActivity(ctx)
 		imageQB := repo.Image
 		galleryQB := repo.Gallery
 		studioQB := repo.Studio
@@ -237,7 +260,7 @@ func (r *queryResolver) Stats(ctx context.Context) (*StatsResultType, error) {
 			return err
 		}
 
-		scenesTotalOCount, err := sceneQB.GetAllOCount(ctx)
+		scenesTotalOCount, err := activityQB.GetAllOCount(ctx)
 		if err != nil {
 			return err
 		}
@@ -247,17 +270,17 @@ func (r *queryResolver) Stats(ctx context.Context) (*StatsResultType, error) {
 		}
 		totalOCount := scenesTotalOCount + imagesTotalOCount
 
-		totalPlayDuration, err := sceneQB.PlayDuration(ctx)
+		totalPlayDuration, err := activityQB.PlayDuration(ctx)
 		if err != nil {
 			return err
 		}
 
-		totalPlayCount, err := sceneQB.CountAllViews(ctx)
+		totalPlayCount, err := activityQB.CountAllViews(ctx)
 		if err != nil {
 			return err
 		}
 
-		uniqueScenePlayCount, err := sceneQB.CountUniqueViews(ctx)
+		uniqueScenePlayCount, err := activityQB.CountUniqueViews(ctx)
 		if err != nil {
 			return err
 		}
@@ -419,3 +442,11 @@ func firstError(errs []error) error {
 
 	return nil
 }
+
+// excludeHidden removes the nil entries returned by the loaders for objects
+// hidden by the content restriction.
+func excludeHidden[T any](objs []*T) []*T {
+	return sliceutil.Filter(objs, func(o *T) bool {
+		return o != nil
+	})
+}
@@ -7,6 +7,11 @@ import (
 )
 
 func (r *configResultResolver) Plugins(ctx context.Context, obj *ConfigResult, include []string) (map[string]map[string]interface{}, error) {
+	// plugin settings may contain credentials
+	if !isAdmin(ctx) {
+		return map[string]map[string]interface{}{}, nil
+	}
+
 	if len(include) == 0 {
 		ret := config.GetInstance().GetAllPluginConfiguration()
 		return ret, nil
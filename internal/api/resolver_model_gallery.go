@@ -99,7 +99,7 @@ func (r *galleryResolver) Scenes(ctx context.Context, obj *models.Gallery) (ret
 
 	var errs []error
 	ret, errs = loaders.From(ctx).SceneByID.LoadAll(obj.SceneIDs.List())
-	return ret, firstError(errs)
+	return excludeHidden(ret), firstError(errs)
 }
 
 func (r *galleryResolver) Studio(ctx context.Context, obj *models.Gallery) (ret *models.Studio, err error) {
@@ -135,7 +135,7 @@ func (r *galleryResolver) Performers(ctx context.Context, obj *models.Gallery) (
 
 	var errs []error
 	ret, errs = loaders.From(ctx).PerformerByID.LoadAll(obj.PerformerIDs.List())
-	return ret, firstError(errs)
+	return excludeHidden(ret), firstError(errs)
 }
 
 func (r *galleryResolver) ImageCount(ctx context.Context, obj *models.Gallery) (ret int, err error) {
@@ -189,3 +189,21 @@ func (r *galleryResolver) Urls(ctx context.Context, obj *models.Gallery) ([]stri
 
 	return obj.URLs.List(), nil
 }
+
+func (r *galleryResolver) CustomFields(ctx context.Context, obj *models.Gallery) ([]*models.CustomField, error) {
+	if !obj.CustomFields.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadCustomFields(ctx, r.repository.Gallery)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := obj.CustomFields.List()
+	ret := make([]*models.CustomField, len(fields))
+	for i := range fields {
+		ret[i] = &fields[i]
+	}
+
+	return ret, nil
+}
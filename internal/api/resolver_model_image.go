@@ -95,7 +95,7 @@ func (r *imageResolver) Galleries(ctx context.Context, obj *models.Image) (ret [
 
 	var errs []error
 	ret, errs = loaders.From(ctx).GalleryByID.LoadAll(obj.GalleryIDs.List())
-	return ret, firstError(errs)
+	return excludeHidden(ret), firstError(errs)
 }
 
 func (r *imageResolver) Rating100(ctx context.Context, obj *models.Image) (*int, error) {
@@ -135,7 +135,7 @@ func (r *imageResolver) Performers(ctx context.Context, obj *models.Image) (ret
 
 	var errs []error
 	ret, errs = loaders.From(ctx).PerformerByID.LoadAll(obj.PerformerIDs.List())
-	return ret, firstError(errs)
+	return excludeHidden(ret), firstError(errs)
 }
 
 func (r *imageResolver) URL(ctx context.Context, obj *models.Image) (*string, error) {
@@ -166,3 +166,21 @@ func (r *imageResolver) Urls(ctx context.Context, obj *models.Image) ([]string,
 
 	return obj.URLs.List(), nil
 }
+
+func (r *imageResolver) CustomFields(ctx context.Context, obj *models.Image) ([]*models.CustomField, error) {
+	if !obj.CustomFields.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadCustomFields(ctx, r.repository.Image)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := obj.CustomFields.List()
+	ret := make([]*models.CustomField, len(fields))
+	for i := range fields {
+		ret[i] = &fields[i]
+	}
+
+	return ret, nil
+}
@@ -128,3 +128,21 @@ func (r *groupResolver) Scenes(ctx context.Context, obj *models.Group) (ret []*m
 
 	return ret, nil
 }
+
+func (r *groupResolver) CustomFields(ctx context.Context, obj *models.Group) ([]*models.CustomField, error) {
+	if !obj.CustomFields.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadCustomFields(ctx, r.repository.Group)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := obj.CustomFields.List()
+	ret := make([]*models.CustomField, len(fields))
+	for i := range fields {
+		ret[i] = &fields[i]
+	}
+
+	return ret, nil
+}
@@ -272,3 +272,21 @@ func (r *performerResolver) Groups(ctx context.Context, obj *models.Performer) (
 func (r *performerResolver) Movies(ctx context.Context, obj *models.Performer) (ret []*models.Group, err error) {
 	return r.Groups(ctx, obj)
 }
+
+func (r *performerResolver) CustomFields(ctx context.Context, obj *models.Performer) ([]*models.CustomField, error) {
+	if !obj.CustomFields.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadCustomFields(ctx, r.repository.Performer)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := obj.CustomFields.List()
+	ret := make([]*models.CustomField, len(fields))
+	for i := range fields {
+		ret[i] = &fields[i]
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// the content hidden by a profile is only visible to admins, so that it
+// cannot be discovered from a restricted session
+
+func (r *restrictionProfileResolver) MaxRating100(ctx context.Context, obj *models.RestrictionProfile) (*int, error) {
+	if !isAdmin(ctx) {
+		return nil, errForbidden
+	}
+
+	return obj.MaxRating, nil
+}
+
+func (r *restrictionProfileResolver) Tags(ctx context.Context, obj *models.RestrictionProfile) (ret []*models.Tag, err error) {
+	if !isAdmin(ctx) {
+		return nil, errForbidden
+	}
+
+	var errs []error
+	ret, errs = loaders.From(ctx).TagByID.LoadAll(obj.TagIDs)
+	return ret, firstError(errs)
+}
+
+func (r *restrictionProfileResolver) Studios(ctx context.Context, obj *models.RestrictionProfile) (ret []*models.Studio, err error) {
+	if !isAdmin(ctx) {
+		return nil, errForbidden
+	}
+
+	var errs []error
+	ret, errs = loaders.From(ctx).StudioByID.LoadAll(obj.StudioIDs)
+	return ret, firstError(errs)
+}
+
+func (r *restrictionProfileResolver) Performers(ctx context.Context, obj *models.RestrictionProfile) (ret []*models.Performer, err error) {
+	if !isAdmin(ctx) {
+		return nil, errForbidden
+	}
+
+	var errs []error
+	ret, errs = loaders.From(ctx).PerformerByID.LoadAll(obj.PerformerIDs)
+	return ret, firstError(errs)
+}
@@ -91,16 +91,85 @@ func (r *sceneResolver) Files(ctx context.Context, obj *models.Scene) ([]*VideoF
 	return ret, nil
 }
 
+// getUserData returns the scene data of the current user. The boolean return
+// value is false if the current user is not a local user account.
+func (r *sceneResolver) getUserData(ctx context.Context, obj *models.Scene) (*models.SceneUserData, bool, error) {
+	if currentUser(ctx) == nil {
+		return nil, false, nil
+	}
+
+	ret, err := loaders.From(ctx).SceneUserData.Load(obj.ID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return ret, true, nil
+}
+
 func (r *sceneResolver) Rating(ctx context.Context, obj *models.Scene) (*int, error) {
-	if obj.Rating != nil {
-		rating := models.Rating100To5(*obj.Rating)
-		return &rating, nil
+	rating, err := r.Rating100(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if rating != nil {
+		ret := models.Rating100To5(*rating)
+		return &ret, nil
 	}
 	return nil, nil
 }
 
 func (r *sceneResolver) Rating100(ctx context.Context, obj *models.Scene) (*int, error) {
-	return obj.Rating, nil
+	data, isUser, err := r.getUserData(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isUser {
+		return obj.Rating, nil
+	}
+
+	if data == nil {
+		return nil, nil
+	}
+
+	return data.Rating, nil
+}
+
+func (r *sceneResolver) ResumeTime(ctx context.Context, obj *models.Scene) (*float64, error) {
+	data, isUser, err := r.getUserData(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isUser {
+		return &obj.ResumeTime, nil
+	}
+
+	var ret float64
+	if data != nil {
+		ret = data.ResumeTime
+	}
+
+	return &ret, nil
+}
+
+func (r *sceneResolver) PlayDuration(ctx context.Context, obj *models.Scene) (*float64, error) {
+	data, isUser, err := r.getUserData(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isUser {
+		return &obj.PlayDuration, nil
+	}
+
+	var ret float64
+	if data != nil {
+		ret = data.PlayDuration
+	}
+
+	return &ret, nil
 }
 
 func (r *sceneResolver) Paths(ctx context.Context, obj *models.Scene) (*ScenePathsType, error) {
@@ -172,7 +241,7 @@ func (r *sceneResolver) Galleries(ctx context.Context, obj *models.Scene) (ret [
 
 	var errs []error
 	ret, errs = loaders.From(ctx).GalleryByID.LoadAll(obj.GalleryIDs.List())
-	return ret, firstError(errs)
+	return excludeHidden(ret), firstError(errs)
 }
 
 func (r *sceneResolver) Studio(ctx context.Context, obj *models.Scene) (ret *models.Studio, err error) {
@@ -202,6 +271,11 @@ func (r *sceneResolver) Movies(ctx context.Context, obj *models.Scene) (ret []*S
 			return nil, err
 		}
 
+		// hidden by the content restriction
+		if movie == nil {
+			continue
+		}
+
 		sceneIdx := sm.SceneIndex
 		sceneMovie := &SceneMovie{
 			Movie:      movie,
@@ -233,6 +307,11 @@ func (r *sceneResolver) Groups(ctx context.Context, obj *models.Scene) (ret []*S
 			return nil, err
 		}
 
+		// hidden by the content restriction
+		if group == nil {
+			continue
+		}
+
 		sceneIdx := sm.SceneIndex
 		sceneGroup := &SceneGroup{
 			Group:      group,
@@ -270,7 +349,7 @@ func (r *sceneResolver) Performers(ctx context.Context, obj *models.Scene) (ret
 
 	var errs []error
 	ret, errs = loaders.From(ctx).PerformerByID.LoadAll(obj.PerformerIDs.List())
-	return ret, firstError(errs)
+	return excludeHidden(ret), firstError(errs)
 }
 
 func (r *sceneResolver) StashIds(ctx context.Context, obj *models.Scene) (ret []*models.StashID, err error) {
@@ -296,7 +375,7 @@ func (r *sceneResolver) SceneStreams(ctx context.Context, obj *models.Scene) ([]
 	builder := urlbuilders.NewSceneURLBuilder(baseURL, obj)
 	apiKey := config.GetAPIKey()
 
-	return manager.GetSceneStreamPaths(obj, builder.GetStreamURL(apiKey), config.GetMaxStreamingTranscodeSize())
+	return manager.GetSceneStreamPaths(obj, builder.GetStreamURL(apiKey), builder.GetCaptionURL(), config.GetMaxStreamingTranscodeSize())
 }
 
 func (r *sceneResolver) Interactive(ctx context.Context, obj *models.Scene) (bool, error) {
@@ -410,3 +489,21 @@ func (r *sceneResolver) OHistory(ctx context.Context, obj *models.Scene) ([]*tim
 
 	return ptrRet, nil
 }
+
+func (r *sceneResolver) CustomFields(ctx context.Context, obj *models.Scene) ([]*models.CustomField, error) {
+	if !obj.CustomFields.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadCustomFields(ctx, r.repository.Scene)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := obj.CustomFields.List()
+	ret := make([]*models.CustomField, len(fields))
+	for i := range fields {
+		ret[i] = &fields[i]
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *scheduledTaskResolver) Runs(ctx context.Context, obj *models.ScheduledTask, limit *int) (ret []*models.ScheduledTaskRun, err error) {
+	l := 0
+	if limit != nil {
+		l = *limit
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.ScheduledTask.FindRuns(ctx, obj.ID, l)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *scheduledTaskRunResolver) Status(ctx context.Context, obj *models.ScheduledTaskRun) (JobStatus, error) {
+	return JobStatus(obj.Status), nil
+}
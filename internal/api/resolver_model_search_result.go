@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/api/loaders"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// searchResultItem returns the loaded object as a search result item. A nil
+// object is returned as an error, since the item of a result is required.
+func searchResultItem[T any, P interface {
+	*T
+	models.SearchResultItem
+}](o P, err error) (models.SearchResultItem, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if o == nil {
+		return nil, fmt.Errorf("search result not found")
+	}
+
+	return o, nil
+}
+
+func (r *searchResultResolver) Item(ctx context.Context, obj *models.SearchResult) (models.SearchResultItem, error) {
+	switch obj.Type {
+	case models.SearchResultTypeScene:
+		return searchResultItem(loaders.From(ctx).SceneByID.Load(obj.ID))
+	case models.SearchResultTypeImage:
+		return searchResultItem(loaders.From(ctx).ImageByID.Load(obj.ID))
+	case models.SearchResultTypeGallery:
+		return searchResultItem(loaders.From(ctx).GalleryByID.Load(obj.ID))
+	case models.SearchResultTypePerformer:
+		return searchResultItem(loaders.From(ctx).PerformerByID.Load(obj.ID))
+	case models.SearchResultTypeStudio:
+		return searchResultItem(loaders.From(ctx).StudioByID.Load(obj.ID))
+	case models.SearchResultTypeGroup:
+		return searchResultItem(loaders.From(ctx).GroupByID.Load(obj.ID))
+	case models.SearchResultTypeTag:
+		return searchResultItem(loaders.From(ctx).TagByID.Load(obj.ID))
+	case models.SearchResultTypeSceneMarker:
+		var ret *models.SceneMarker
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			var err error
+			ret, err = r.repository.SceneMarker.Find(ctx, obj.ID)
+			return err
+		}); err != nil {
+			return nil, err
+		}
+
+		return searchResultItem(ret, nil)
+	}
+
+	return nil, fmt.Errorf("unknown search result type: %s", obj.Type)
+}
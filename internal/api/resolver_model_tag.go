@@ -171,3 +171,21 @@ func (r *tagResolver) ChildCount(ctx context.Context, obj *models.Tag) (ret int,
 
 	return ret, nil
 }
+
+func (r *tagResolver) CustomFields(ctx context.Context, obj *models.Tag) ([]*models.CustomField, error) {
+	if !obj.CustomFields.Loaded() {
+		if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+			return obj.LoadCustomFields(ctx, r.repository.Tag)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	fields := obj.CustomFields.List()
+	ret := make([]*models.CustomField, len(fields))
+	for i := range fields {
+		ret[i] = &fields[i]
+	}
+
+	return ret, nil
+}
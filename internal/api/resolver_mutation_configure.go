@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"strconv"
 
+	"github.com/stashapp/stash/internal/dlna"
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/manager/task"
@@ -269,6 +270,10 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 
 	r.setConfigBool(config.CalculateMD5, input.CalculateMd5)
 	r.setConfigInt(config.ParallelTasks, input.ParallelTasks)
+	r.setConfigInt(config.IOJobSlots, input.IoJobSlots)
+	r.setConfigInt(config.CPUJobSlots, input.CPUJobSlots)
+	r.setConfigInt(config.NetworkJobSlots, input.NetworkJobSlots)
+	r.setConfigInt(config.GeneralJobSlots, input.GeneralJobSlots)
 	r.setConfigBool(config.PreviewAudio, input.PreviewAudio)
 	r.setConfigInt(config.PreviewSegments, input.PreviewSegments)
 	r.setConfigFloat(config.PreviewSegmentDuration, input.PreviewSegmentDuration)
@@ -283,6 +288,10 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.SetString(config.MaxTranscodeSize, input.MaxTranscodeSize.String())
 	}
 
+	if input.TranscodeVideoCodec != nil {
+		c.SetString(config.TranscodeVideoCodec, input.TranscodeVideoCodec.String())
+	}
+
 	if input.MaxStreamingTranscodeSize != nil {
 		c.SetString(config.MaxStreamingTranscodeSize, input.MaxStreamingTranscodeSize.String())
 	}
@@ -323,6 +332,7 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 	}
 
 	r.setConfigInt(config.MaxSessionAge, input.MaxSessionAge)
+	r.setConfigInt(config.APIKeyRestrictionProfile, input.APIKeyRestrictionProfileID)
 	r.setConfigString(config.LogFile, input.LogFile)
 	r.setConfigBool(config.LogOut, input.LogOut)
 	r.setConfigBool(config.LogAccess, input.LogAccess)
@@ -519,6 +529,12 @@ func (r *mutationResolver) ConfigureInterface(ctx context.Context, input ConfigI
 func (r *mutationResolver) ConfigureDlna(ctx context.Context, input ConfigDLNAInput) (*ConfigDLNAResult, error) {
 	c := config.GetInstance()
 
+	if input.RendererProfiles != nil {
+		if err := dlna.ValidateRendererProfiles(input.RendererProfiles); err != nil {
+			return makeConfigDLNAResult(), err
+		}
+	}
+
 	r.setConfigString(config.DLNAServerName, input.ServerName)
 
 	if input.WhitelistedIPs != nil {
@@ -527,6 +543,12 @@ func (r *mutationResolver) ConfigureDlna(ctx context.Context, input ConfigDLNAIn
 
 	r.setConfigString(config.DLNAVideoSortOrder, input.VideoSortOrder)
 	r.setConfigInt(config.DLNAPort, input.Port)
+	r.setConfigInt(config.DLNARestrictionProfile, input.RestrictionProfileID)
+	r.setConfigInt(config.DLNAMinimumPlayPercent, input.MinimumPlayPercent)
+
+	if input.RendererProfiles != nil {
+		c.SetInterface(config.DLNARendererProfiles, input.RendererProfiles)
+	}
 
 	refresh := false
 	if input.Enabled != nil {
@@ -574,6 +596,7 @@ func (r *mutationResolver) ConfigureScraping(ctx context.Context, input ConfigSc
 	}
 
 	r.setConfigBool(config.ScraperCertCheck, input.ScraperCertCheck)
+	r.setConfigInt(config.ScraperCacheTTL, input.ScraperCacheTTL)
 
 	if refreshScraperCache {
 		manager.GetInstance().RefreshScraperCache()
@@ -31,13 +31,18 @@ func (r *mutationResolver) getGallery(ctx context.Context, id int) (ret *models.
 }
 
 func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreateInput) (*models.Gallery, error) {
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, 0, hook.GalleryCreatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	// name must be provided
 	if input.Title == "" {
 		return nil, errors.New("title must not be empty")
 	}
 
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Populate a new gallery from the input
@@ -49,8 +54,6 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 	newGallery.Photographer = translator.string(input.Photographer)
 	newGallery.Rating = input.Rating100
 
-	var err error
-
 	newGallery.Date, err = translator.datePtr(input.Date)
 	if err != nil {
 		return nil, fmt.Errorf("converting date: %w", err)
@@ -79,6 +82,8 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 		newGallery.URLs = models.NewRelatedStrings([]string{*input.URL})
 	}
 
+	newGallery.CustomFields = models.NewRelatedCustomFields(customFieldsPtrSliceToSlice(input.CustomFields))
+
 	// Start the transaction and save the gallery
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Gallery
@@ -96,8 +101,18 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 }
 
 func (r *mutationResolver) GalleryUpdate(ctx context.Context, input models.GalleryUpdateInput) (ret *models.Gallery, err error) {
+	galleryID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, galleryID, hook.GalleryUpdatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Start the transaction and save the gallery
@@ -116,6 +131,18 @@ func (r *mutationResolver) GalleryUpdate(ctx context.Context, input models.Galle
 func (r *mutationResolver) GalleriesUpdate(ctx context.Context, input []*models.GalleryUpdateInput) (ret []*models.Gallery, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
 
+	for i, gallery := range input {
+		galleryID, err := strconv.Atoi(gallery.ID)
+		if err != nil {
+			return nil, fmt.Errorf("converting id: %w", err)
+		}
+
+		inputMaps[i], err = executePreHooks(ctx, r.hookExecutor, galleryID, hook.GalleryUpdatePre, gallery, inputMaps[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Start the transaction and save the galleries
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, gallery := range input {
@@ -201,6 +228,7 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 	}
 
 	updatedGallery.URLs = translator.optionalURLs(input.Urls, input.URL)
+	updatedGallery.CustomFields = translator.updateCustomFields(input.CustomFields, "custom_fields")
 
 	updatedGallery.PrimaryFileID, err = translator.fileIDPtrFromString(input.PrimaryFileID)
 	if err != nil {
@@ -255,40 +283,18 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 		return nil, fmt.Errorf("converting ids: %w", err)
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
-	// Populate gallery from the input
-	updatedGallery := models.NewGalleryPartial()
-
-	updatedGallery.Code = translator.optionalString(input.Code, "code")
-	updatedGallery.Details = translator.optionalString(input.Details, "details")
-	updatedGallery.Photographer = translator.optionalString(input.Photographer, "photographer")
-	updatedGallery.Rating = translator.optionalInt(input.Rating100, "rating100")
-	updatedGallery.Organized = translator.optionalBool(input.Organized, "organized")
-	updatedGallery.URLs = translator.optionalURLsBulk(input.Urls, input.URL)
-
-	updatedGallery.Date, err = translator.optionalDate(input.Date, "date")
+	inputs, err := executeBulkPreHooks(ctx, r.hookExecutor, galleryIDs, hook.GalleryUpdatePre, input, getUpdateInputMap(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("converting date: %w", err)
-	}
-	updatedGallery.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
-	if err != nil {
-		return nil, fmt.Errorf("converting studio id: %w", err)
+		return nil, err
 	}
 
-	updatedGallery.PerformerIDs, err = translator.updateIdsBulk(input.PerformerIds, "performer_ids")
-	if err != nil {
-		return nil, fmt.Errorf("converting performer ids: %w", err)
-	}
-	updatedGallery.TagIDs, err = translator.updateIdsBulk(input.TagIds, "tag_ids")
-	if err != nil {
-		return nil, fmt.Errorf("converting tag ids: %w", err)
-	}
-	updatedGallery.SceneIDs, err = translator.updateIdsBulk(input.SceneIds, "scene_ids")
-	if err != nil {
-		return nil, fmt.Errorf("converting scene ids: %w", err)
+	// Populate the galleries from the input
+	updatedGalleries := make([]*models.GalleryPartial, len(inputs))
+	for i, in := range inputs {
+		updatedGalleries[i], err = bulkGalleryPartialFromInput(in.input, in.translator)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	ret := []*models.Gallery{}
@@ -297,8 +303,8 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Gallery
 
-		for _, galleryID := range galleryIDs {
-			gallery, err := qb.UpdatePartial(ctx, galleryID, updatedGallery)
+		for i, galleryID := range galleryIDs {
+			gallery, err := qb.UpdatePartial(ctx, galleryID, *updatedGalleries[i])
 			if err != nil {
 				return err
 			}
@@ -313,8 +319,8 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 
 	// execute post hooks outside of txn
 	var newRet []*models.Gallery
-	for _, gallery := range ret {
-		r.hookExecutor.ExecutePostHooks(ctx, gallery.ID, hook.GalleryUpdatePost, input, translator.getFields())
+	for i, gallery := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, gallery.ID, hook.GalleryUpdatePost, inputs[i].input, inputs[i].translator.getFields())
 
 		gallery, err := r.getGallery(ctx, gallery.ID)
 		if err != nil {
@@ -327,12 +333,54 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 	return newRet, nil
 }
 
+func bulkGalleryPartialFromInput(input BulkGalleryUpdateInput, translator changesetTranslator) (*models.GalleryPartial, error) {
+	var err error
+
+	updatedGallery := models.NewGalleryPartial()
+
+	updatedGallery.Code = translator.optionalString(input.Code, "code")
+	updatedGallery.Details = translator.optionalString(input.Details, "details")
+	updatedGallery.Photographer = translator.optionalString(input.Photographer, "photographer")
+	updatedGallery.Rating = translator.optionalInt(input.Rating100, "rating100")
+	updatedGallery.Organized = translator.optionalBool(input.Organized, "organized")
+	updatedGallery.URLs = translator.optionalURLsBulk(input.Urls, input.URL)
+	updatedGallery.CustomFields = translator.updateCustomFieldsBulk(input.CustomFields, "custom_fields")
+
+	updatedGallery.Date, err = translator.optionalDate(input.Date, "date")
+	if err != nil {
+		return nil, fmt.Errorf("converting date: %w", err)
+	}
+	updatedGallery.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
+	if err != nil {
+		return nil, fmt.Errorf("converting studio id: %w", err)
+	}
+
+	updatedGallery.PerformerIDs, err = translator.updateIdsBulk(input.PerformerIds, "performer_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting performer ids: %w", err)
+	}
+	updatedGallery.TagIDs, err = translator.updateIdsBulk(input.TagIds, "tag_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting tag ids: %w", err)
+	}
+	updatedGallery.SceneIDs, err = translator.updateIdsBulk(input.SceneIds, "scene_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting scene ids: %w", err)
+	}
+
+	return &updatedGallery, nil
+}
+
 func (r *mutationResolver) GalleryDestroy(ctx context.Context, input models.GalleryDestroyInput) (bool, error) {
 	galleryIDs, err := stringslice.StringSliceToIntSlice(input.Ids)
 	if err != nil {
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := executeBulkDestroyPreHooks(ctx, r.hookExecutor, galleryIDs, hook.GalleryDestroyPre, getUpdateInputMap(ctx)); err != nil {
+		return false, err
+	}
+
 	var galleries []*models.Gallery
 	var imgsDestroyed []*models.Image
 	fileDeleter := &image.FileDeleter{
@@ -490,6 +538,10 @@ func (r *mutationResolver) getGalleryChapter(ctx context.Context, id int) (ret *
 }
 
 func (r *mutationResolver) GalleryChapterCreate(ctx context.Context, input GalleryChapterCreateInput) (*models.GalleryChapter, error) {
+	if _, err := executePreHooks(ctx, r.hookExecutor, 0, hook.GalleryChapterCreatePre, &input, getUpdateInputMap(ctx)); err != nil {
+		return nil, err
+	}
+
 	galleryID, err := strconv.Atoi(input.GalleryID)
 	if err != nil {
 		return nil, fmt.Errorf("converting gallery id: %w", err)
@@ -529,8 +581,13 @@ func (r *mutationResolver) GalleryChapterUpdate(ctx context.Context, input Galle
 		return nil, fmt.Errorf("converting id: %w", err)
 	}
 
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, chapterID, hook.GalleryChapterUpdatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Populate gallery chapter from the input
@@ -595,6 +652,10 @@ func (r *mutationResolver) GalleryChapterDestroy(ctx context.Context, id string)
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := executeDestroyPreHooks(ctx, r.hookExecutor, chapterID, hook.GalleryChapterDestroyPre, getArgumentMap(ctx)); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.GalleryChapter
 
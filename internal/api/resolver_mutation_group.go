@@ -12,11 +12,7 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
-func groupFromGroupCreateInput(ctx context.Context, input GroupCreateInput) (*models.Group, error) {
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
+func groupFromGroupCreateInput(translator changesetTranslator, input GroupCreateInput) (*models.Group, error) {
 	// Populate a new group from the input
 	newGroup := models.NewGroup()
 
@@ -47,11 +43,22 @@ func groupFromGroupCreateInput(ctx context.Context, input GroupCreateInput) (*mo
 		newGroup.URLs = models.NewRelatedStrings(input.Urls)
 	}
 
+	newGroup.CustomFields = models.NewRelatedCustomFields(customFieldsPtrSliceToSlice(input.CustomFields))
+
 	return &newGroup, nil
 }
 
 func (r *mutationResolver) GroupCreate(ctx context.Context, input GroupCreateInput) (*models.Group, error) {
-	newGroup, err := groupFromGroupCreateInput(ctx, input)
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, 0, hook.GroupCreatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	translator := changesetTranslator{
+		inputMap: inputMap,
+	}
+
+	newGroup, err := groupFromGroupCreateInput(translator, input)
 	if err != nil {
 		return nil, err
 	}
@@ -142,6 +149,7 @@ func groupPartialFromGroupUpdateInput(translator changesetTranslator, input Grou
 	}
 
 	updatedGroup.URLs = translator.updateStrings(input.Urls, "urls")
+	updatedGroup.CustomFields = translator.updateCustomFields(customFieldsPtrSliceToSlice(input.CustomFields), "custom_fields")
 
 	return updatedGroup, nil
 }
@@ -152,8 +160,13 @@ func (r *mutationResolver) GroupUpdate(ctx context.Context, input GroupUpdateInp
 		return nil, fmt.Errorf("converting id: %w", err)
 	}
 
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, groupID, hook.GroupUpdatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	updatedGroup, err := groupPartialFromGroupUpdateInput(translator, input)
@@ -231,6 +244,7 @@ func groupPartialFromBulkGroupUpdateInput(translator changesetTranslator, input
 	}
 
 	updatedGroup.URLs = translator.optionalURLsBulk(input.Urls, nil)
+	updatedGroup.CustomFields = translator.updateCustomFieldsBulk(input.CustomFields, "custom_fields")
 
 	return updatedGroup, nil
 }
@@ -241,23 +255,27 @@ func (r *mutationResolver) BulkGroupUpdate(ctx context.Context, input BulkGroupU
 		return nil, fmt.Errorf("converting ids: %w", err)
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
-	// Populate group from the input
-	updatedGroup, err := groupPartialFromBulkGroupUpdateInput(translator, input)
+	inputs, err := executeBulkPreHooks(ctx, r.hookExecutor, groupIDs, hook.GroupUpdatePre, input, getUpdateInputMap(ctx))
 	if err != nil {
 		return nil, err
 	}
 
+	// Populate the groups from the input
+	updatedGroups := make([]models.GroupPartial, len(inputs))
+	for i, in := range inputs {
+		updatedGroups[i], err = groupPartialFromBulkGroupUpdateInput(in.translator, in.input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	ret := []*models.Group{}
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Group
 
-		for _, groupID := range groupIDs {
-			group, err := qb.UpdatePartial(ctx, groupID, updatedGroup)
+		for i, groupID := range groupIDs {
+			group, err := qb.UpdatePartial(ctx, groupID, updatedGroups[i])
 			if err != nil {
 				return err
 			}
@@ -271,10 +289,10 @@ func (r *mutationResolver) BulkGroupUpdate(ctx context.Context, input BulkGroupU
 	}
 
 	var newRet []*models.Group
-	for _, group := range ret {
+	for i, group := range ret {
 		// for backwards compatibility - run both movie and group hooks
-		r.hookExecutor.ExecutePostHooks(ctx, group.ID, hook.GroupUpdatePost, input, translator.getFields())
-		r.hookExecutor.ExecutePostHooks(ctx, group.ID, hook.MovieUpdatePost, input, translator.getFields())
+		r.hookExecutor.ExecutePostHooks(ctx, group.ID, hook.GroupUpdatePost, inputs[i].input, inputs[i].translator.getFields())
+		r.hookExecutor.ExecutePostHooks(ctx, group.ID, hook.MovieUpdatePost, inputs[i].input, inputs[i].translator.getFields())
 
 		group, err = r.getGroup(ctx, group.ID)
 		if err != nil {
@@ -293,6 +311,10 @@ func (r *mutationResolver) GroupDestroy(ctx context.Context, input GroupDestroyI
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := executeDestroyPreHooks(ctx, r.hookExecutor, id, hook.GroupDestroyPre, getUpdateInputMap(ctx)); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Group.Destroy(ctx, id)
 	}); err != nil {
@@ -312,6 +334,10 @@ func (r *mutationResolver) GroupsDestroy(ctx context.Context, groupIDs []string)
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := executeBulkDestroyPreHooks(ctx, r.hookExecutor, ids, hook.GroupDestroyPre, getArgumentMap(ctx)); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Group
 		for _, id := range ids {
@@ -28,9 +28,19 @@ func (r *mutationResolver) getImage(ctx context.Context, id int) (ret *models.Im
 	return ret, nil
 }
 
-func (r *mutationResolver) ImageUpdate(ctx context.Context, input ImageUpdateInput) (ret *models.Image, err error) {
+func (r *mutationResolver) ImageUpdate(ctx context.Context, input models.ImageUpdateInput) (ret *models.Image, err error) {
+	imageID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, imageID, hook.ImageUpdatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Start the transaction and save the image
@@ -46,9 +56,21 @@ func (r *mutationResolver) ImageUpdate(ctx context.Context, input ImageUpdateInp
 	return r.getImage(ctx, ret.ID)
 }
 
-func (r *mutationResolver) ImagesUpdate(ctx context.Context, input []*ImageUpdateInput) (ret []*models.Image, err error) {
+func (r *mutationResolver) ImagesUpdate(ctx context.Context, input []*models.ImageUpdateInput) (ret []*models.Image, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
 
+	for i, image := range input {
+		imageID, err := strconv.Atoi(image.ID)
+		if err != nil {
+			return nil, fmt.Errorf("converting id: %w", err)
+		}
+
+		inputMaps[i], err = executePreHooks(ctx, r.hookExecutor, imageID, hook.ImageUpdatePre, image, inputMaps[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Start the transaction and save the image
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, image := range input {
@@ -89,7 +111,7 @@ func (r *mutationResolver) ImagesUpdate(ctx context.Context, input []*ImageUpdat
 	return newRet, nil
 }
 
-func (r *mutationResolver) imageUpdate(ctx context.Context, input ImageUpdateInput, translator changesetTranslator) (*models.Image, error) {
+func (r *mutationResolver) imageUpdate(ctx context.Context, input models.ImageUpdateInput, translator changesetTranslator) (*models.Image, error) {
 	imageID, err := strconv.Atoi(input.ID)
 	if err != nil {
 		return nil, fmt.Errorf("converting id: %w", err)
@@ -124,6 +146,7 @@ func (r *mutationResolver) imageUpdate(ctx context.Context, input ImageUpdateInp
 	}
 
 	updatedImage.URLs = translator.optionalURLs(input.Urls, input.URL)
+	updatedImage.CustomFields = translator.updateCustomFields(input.CustomFields, "custom_fields")
 
 	updatedImage.PrimaryFileID, err = translator.fileIDPtrFromString(input.PrimaryFileID)
 	if err != nil {
@@ -199,42 +222,18 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 		return nil, fmt.Errorf("converting ids: %w", err)
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
-	// Populate image from the input
-	updatedImage := models.NewImagePartial()
-
-	updatedImage.Title = translator.optionalString(input.Title, "title")
-	updatedImage.Code = translator.optionalString(input.Code, "code")
-	updatedImage.Details = translator.optionalString(input.Details, "details")
-	updatedImage.Photographer = translator.optionalString(input.Photographer, "photographer")
-	updatedImage.Rating = translator.optionalInt(input.Rating100, "rating100")
-	updatedImage.Organized = translator.optionalBool(input.Organized, "organized")
-
-	updatedImage.Date, err = translator.optionalDate(input.Date, "date")
+	inputs, err := executeBulkPreHooks(ctx, r.hookExecutor, imageIDs, hook.ImageUpdatePre, input, getUpdateInputMap(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("converting date: %w", err)
-	}
-	updatedImage.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
-	if err != nil {
-		return nil, fmt.Errorf("converting studio id: %w", err)
+		return nil, err
 	}
 
-	updatedImage.URLs = translator.optionalURLsBulk(input.Urls, input.URL)
-
-	updatedImage.GalleryIDs, err = translator.updateIdsBulk(input.GalleryIds, "gallery_ids")
-	if err != nil {
-		return nil, fmt.Errorf("converting gallery ids: %w", err)
-	}
-	updatedImage.PerformerIDs, err = translator.updateIdsBulk(input.PerformerIds, "performer_ids")
-	if err != nil {
-		return nil, fmt.Errorf("converting performer ids: %w", err)
-	}
-	updatedImage.TagIDs, err = translator.updateIdsBulk(input.TagIds, "tag_ids")
-	if err != nil {
-		return nil, fmt.Errorf("converting tag ids: %w", err)
+	// Populate the images from the input
+	updatedImages := make([]*models.ImagePartial, len(inputs))
+	for i, in := range inputs {
+		updatedImages[i], err = bulkImagePartialFromInput(in.input, in.translator)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Start the transaction and save the images
@@ -242,7 +241,9 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 		var updatedGalleryIDs []int
 		qb := r.repository.Image
 
-		for _, imageID := range imageIDs {
+		for idx, imageID := range imageIDs {
+			updatedImage := updatedImages[idx]
+
 			i, err := r.repository.Image.Find(ctx, imageID)
 			if err != nil {
 				return err
@@ -266,7 +267,7 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 				updatedGalleryIDs = sliceutil.AppendUniques(updatedGalleryIDs, thisUpdatedGalleryIDs)
 			}
 
-			image, err := qb.UpdatePartial(ctx, imageID, updatedImage)
+			image, err := qb.UpdatePartial(ctx, imageID, *updatedImage)
 			if err != nil {
 				return err
 			}
@@ -288,8 +289,8 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 
 	// execute post hooks outside of txn
 	var newRet []*models.Image
-	for _, image := range ret {
-		r.hookExecutor.ExecutePostHooks(ctx, image.ID, hook.ImageUpdatePost, input, translator.getFields())
+	for i, image := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, image.ID, hook.ImageUpdatePost, inputs[i].input, inputs[i].translator.getFields())
 
 		image, err = r.getImage(ctx, image.ID)
 		if err != nil {
@@ -302,12 +303,56 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 	return newRet, nil
 }
 
+func bulkImagePartialFromInput(input BulkImageUpdateInput, translator changesetTranslator) (*models.ImagePartial, error) {
+	var err error
+
+	updatedImage := models.NewImagePartial()
+
+	updatedImage.Title = translator.optionalString(input.Title, "title")
+	updatedImage.Code = translator.optionalString(input.Code, "code")
+	updatedImage.Details = translator.optionalString(input.Details, "details")
+	updatedImage.Photographer = translator.optionalString(input.Photographer, "photographer")
+	updatedImage.Rating = translator.optionalInt(input.Rating100, "rating100")
+	updatedImage.Organized = translator.optionalBool(input.Organized, "organized")
+
+	updatedImage.Date, err = translator.optionalDate(input.Date, "date")
+	if err != nil {
+		return nil, fmt.Errorf("converting date: %w", err)
+	}
+	updatedImage.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
+	if err != nil {
+		return nil, fmt.Errorf("converting studio id: %w", err)
+	}
+
+	updatedImage.URLs = translator.optionalURLsBulk(input.Urls, input.URL)
+	updatedImage.CustomFields = translator.updateCustomFieldsBulk(input.CustomFields, "custom_fields")
+
+	updatedImage.GalleryIDs, err = translator.updateIdsBulk(input.GalleryIds, "gallery_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting gallery ids: %w", err)
+	}
+	updatedImage.PerformerIDs, err = translator.updateIdsBulk(input.PerformerIds, "performer_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting performer ids: %w", err)
+	}
+	updatedImage.TagIDs, err = translator.updateIdsBulk(input.TagIds, "tag_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting tag ids: %w", err)
+	}
+
+	return &updatedImage, nil
+}
+
 func (r *mutationResolver) ImageDestroy(ctx context.Context, input models.ImageDestroyInput) (ret bool, err error) {
 	imageID, err := strconv.Atoi(input.ID)
 	if err != nil {
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := executeDestroyPreHooks(ctx, r.hookExecutor, imageID, hook.ImageDestroyPre, getUpdateInputMap(ctx)); err != nil {
+		return false, err
+	}
+
 	var i *models.Image
 	fileDeleter := &image.FileDeleter{
 		Deleter: file.NewDeleter(),
@@ -348,6 +393,10 @@ func (r *mutationResolver) ImagesDestroy(ctx context.Context, input models.Image
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := executeBulkDestroyPreHooks(ctx, r.hookExecutor, imageIDs, hook.ImageDestroyPre, getUpdateInputMap(ctx)); err != nil {
+		return false, err
+	}
+
 	var images []*models.Image
 	fileDeleter := &image.FileDeleter{
 		Deleter: file.NewDeleter(),
@@ -22,3 +22,55 @@ func (r *mutationResolver) StopAllJobs(ctx context.Context) (bool, error) {
 	manager.GetInstance().JobManager.CancelAll()
 	return true, nil
 }
+
+func (r *mutationResolver) PauseJob(ctx context.Context, jobID string) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().JobManager.PauseJob(id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ResumeJob(ctx context.Context, jobID string) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().JobManager.ResumeJob(id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) SetJobPriority(ctx context.Context, jobID string, priority int) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().JobManager.SetJobPriority(id, priority); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) MoveJob(ctx context.Context, jobID string, position int) (bool, error) {
+	id, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().JobManager.MoveJob(id, position); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
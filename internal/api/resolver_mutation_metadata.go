@@ -88,8 +88,7 @@ func (r *mutationResolver) MetadataAutoTag(ctx context.Context, input manager.Au
 }
 
 func (r *mutationResolver) MetadataIdentify(ctx context.Context, input identify.Options) (string, error) {
-	t := manager.CreateIdentifyJob(input)
-	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying...", t)
+	jobID := manager.GetInstance().Identify(ctx, input)
 
 	return strconv.Itoa(jobID), nil
 }
@@ -100,17 +99,7 @@ func (r *mutationResolver) MetadataClean(ctx context.Context, input manager.Clea
 }
 
 func (r *mutationResolver) MetadataCleanGenerated(ctx context.Context, input task.CleanGeneratedOptions) (string, error) {
-	mgr := manager.GetInstance()
-	t := &task.CleanGeneratedJob{
-		Options:                  input,
-		Paths:                    mgr.Paths,
-		BlobsStorageType:         mgr.Config.GetBlobsStorage(),
-		VideoFileNamingAlgorithm: mgr.Config.GetVideoFileNamingAlgorithm(),
-		Repository:               mgr.Repository,
-		BlobCleaner:              mgr.Repository.Blob,
-	}
-	jobID := mgr.JobManager.Add(ctx, "Cleaning generated files...", t)
-
+	jobID := manager.GetInstance().CleanGenerated(ctx, input)
 	return strconv.Itoa(jobID), nil
 }
 
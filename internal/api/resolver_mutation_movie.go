@@ -25,8 +25,13 @@ func (r *mutationResolver) getGroup(ctx context.Context, id int) (ret *models.Gr
 }
 
 func (r *mutationResolver) MovieCreate(ctx context.Context, input MovieCreateInput) (*models.Group, error) {
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, 0, hook.GroupCreatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Populate a new group from the input
@@ -39,8 +44,6 @@ func (r *mutationResolver) MovieCreate(ctx context.Context, input MovieCreateInp
 	newGroup.Director = translator.string(input.Director)
 	newGroup.Synopsis = translator.string(input.Synopsis)
 
-	var err error
-
 	newGroup.Date, err = translator.datePtr(input.Date)
 	if err != nil {
 		return nil, fmt.Errorf("converting date: %w", err)
@@ -124,8 +127,13 @@ func (r *mutationResolver) MovieUpdate(ctx context.Context, input MovieUpdateInp
 		return nil, fmt.Errorf("converting id: %w", err)
 	}
 
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, groupID, hook.GroupUpdatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Populate group from the input
@@ -211,35 +219,27 @@ func (r *mutationResolver) BulkMovieUpdate(ctx context.Context, input BulkMovieU
 		return nil, fmt.Errorf("converting ids: %w", err)
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
-	// Populate group from the input
-	updatedGroup := models.NewGroupPartial()
-
-	updatedGroup.Rating = translator.optionalInt(input.Rating100, "rating100")
-	updatedGroup.Director = translator.optionalString(input.Director, "director")
-
-	updatedGroup.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
+	inputs, err := executeBulkPreHooks(ctx, r.hookExecutor, groupIDs, hook.GroupUpdatePre, input, getUpdateInputMap(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("converting studio id: %w", err)
+		return nil, err
 	}
 
-	updatedGroup.TagIDs, err = translator.updateIdsBulk(input.TagIds, "tag_ids")
-	if err != nil {
-		return nil, fmt.Errorf("converting tag ids: %w", err)
+	// Populate the groups from the input
+	updatedGroups := make([]models.GroupPartial, len(inputs))
+	for i, in := range inputs {
+		updatedGroups[i], err = groupPartialFromBulkMovieUpdateInput(in.translator, in.input)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	updatedGroup.URLs = translator.optionalURLsBulk(input.Urls, nil)
-
 	ret := []*models.Group{}
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Group
 
-		for _, groupID := range groupIDs {
-			group, err := qb.UpdatePartial(ctx, groupID, updatedGroup)
+		for i, groupID := range groupIDs {
+			group, err := qb.UpdatePartial(ctx, groupID, updatedGroups[i])
 			if err != nil {
 				return err
 			}
@@ -253,10 +253,10 @@ func (r *mutationResolver) BulkMovieUpdate(ctx context.Context, input BulkMovieU
 	}
 
 	var newRet []*models.Group
-	for _, group := range ret {
+	for i, group := range ret {
 		// for backwards compatibility - run both movie and group hooks
-		r.hookExecutor.ExecutePostHooks(ctx, group.ID, hook.GroupUpdatePost, input, translator.getFields())
-		r.hookExecutor.ExecutePostHooks(ctx, group.ID, hook.MovieUpdatePost, input, translator.getFields())
+		r.hookExecutor.ExecutePostHooks(ctx, group.ID, hook.GroupUpdatePost, inputs[i].input, inputs[i].translator.getFields())
+		r.hookExecutor.ExecutePostHooks(ctx, group.ID, hook.MovieUpdatePost, inputs[i].input, inputs[i].translator.getFields())
 
 		group, err = r.getGroup(ctx, group.ID)
 		if err != nil {
@@ -269,12 +269,37 @@ func (r *mutationResolver) BulkMovieUpdate(ctx context.Context, input BulkMovieU
 	return newRet, nil
 }
 
+func groupPartialFromBulkMovieUpdateInput(translator changesetTranslator, input BulkMovieUpdateInput) (ret models.GroupPartial, err error) {
+	updatedGroup := models.NewGroupPartial()
+
+	updatedGroup.Rating = translator.optionalInt(input.Rating100, "rating100")
+	updatedGroup.Director = translator.optionalString(input.Director, "director")
+
+	updatedGroup.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
+	if err != nil {
+		return ret, fmt.Errorf("converting studio id: %w", err)
+	}
+
+	updatedGroup.TagIDs, err = translator.updateIdsBulk(input.TagIds, "tag_ids")
+	if err != nil {
+		return ret, fmt.Errorf("converting tag ids: %w", err)
+	}
+
+	updatedGroup.URLs = translator.optionalURLsBulk(input.Urls, nil)
+
+	return updatedGroup, nil
+}
+
 func (r *mutationResolver) MovieDestroy(ctx context.Context, input MovieDestroyInput) (bool, error) {
 	id, err := strconv.Atoi(input.ID)
 	if err != nil {
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := executeDestroyPreHooks(ctx, r.hookExecutor, id, hook.GroupDestroyPre, getUpdateInputMap(ctx)); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Group.Destroy(ctx, id)
 	}); err != nil {
@@ -294,6 +319,10 @@ func (r *mutationResolver) MoviesDestroy(ctx context.Context, groupIDs []string)
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := executeBulkDestroyPreHooks(ctx, r.hookExecutor, ids, hook.GroupDestroyPre, getArgumentMap(ctx)); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Group
 		for _, id := range ids {
@@ -30,8 +30,13 @@ func (r *mutationResolver) getPerformer(ctx context.Context, id int) (ret *model
 }
 
 func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.PerformerCreateInput) (*models.Performer, error) {
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, 0, hook.PerformerCreatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Populate a new performer from the input
@@ -59,6 +64,7 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 	newPerformer.Weight = input.Weight
 	newPerformer.IgnoreAutoTag = translator.bool(input.IgnoreAutoTag)
 	newPerformer.StashIDs = models.NewRelatedStashIDs(input.StashIds)
+	newPerformer.CustomFields = models.NewRelatedCustomFields(input.CustomFields)
 
 	newPerformer.URLs = models.NewRelatedStrings([]string{})
 	if input.URL != nil {
@@ -75,8 +81,6 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input models.Per
 		newPerformer.URLs.Add(input.Urls...)
 	}
 
-	var err error
-
 	newPerformer.Birthdate, err = translator.datePtr(input.Birthdate)
 	if err != nil {
 		return nil, fmt.Errorf("converting birthdate: %w", err)
@@ -219,17 +223,7 @@ func (r *mutationResolver) handleLegacyURLs(ctx context.Context, performerID int
 	return nil
 }
 
-func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.PerformerUpdateInput) (*models.Performer, error) {
-	performerID, err := strconv.Atoi(input.ID)
-	if err != nil {
-		return nil, fmt.Errorf("converting id: %w", err)
-	}
-
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
-	// Populate performer from the input
+func (r *mutationResolver) performerPartialFromInput(input models.PerformerUpdateInput, translator changesetTranslator) (*models.PerformerPartial, error) {
 	updatedPerformer := models.NewPerformerPartial()
 
 	updatedPerformer.Name = translator.optionalString(input.Name, "name")
@@ -252,6 +246,7 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 	updatedPerformer.Weight = translator.optionalInt(input.Weight, "weight")
 	updatedPerformer.IgnoreAutoTag = translator.optionalBool(input.IgnoreAutoTag, "ignore_auto_tag")
 	updatedPerformer.StashIDs = translator.updateStashIDs(input.StashIds, "stash_ids")
+	updatedPerformer.CustomFields = translator.updateCustomFields(input.CustomFields, "custom_fields")
 
 	if translator.hasField("urls") {
 		// ensure url/twitter/instagram are not included in the input
@@ -262,10 +257,7 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		updatedPerformer.URLs = translator.updateStrings(input.Urls, "urls")
 	}
 
-	legacyURL := translator.optionalString(input.URL, "url")
-	legacyTwitter := translator.optionalString(input.Twitter, "twitter")
-	legacyInstagram := translator.optionalString(input.Instagram, "instagram")
-
+	var err error
 	updatedPerformer.Birthdate, err = translator.optionalDate(input.Birthdate, "birthdate")
 	if err != nil {
 		return nil, fmt.Errorf("converting birthdate: %w", err)
@@ -290,6 +282,33 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	return &updatedPerformer, nil
+}
+
+func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.PerformerUpdateInput) (*models.Performer, error) {
+	performerID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, performerID, hook.PerformerUpdatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	translator := changesetTranslator{
+		inputMap: inputMap,
+	}
+
+	updatedPerformer, err := r.performerPartialFromInput(input, translator)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyURL := translator.optionalString(input.URL, "url")
+	legacyTwitter := translator.optionalString(input.Twitter, "twitter")
+	legacyInstagram := translator.optionalString(input.Instagram, "instagram")
+
 	var imageData []byte
 	imageIncluded := translator.hasField("image")
 	if input.Image != nil {
@@ -304,16 +323,16 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.Per
 		qb := r.repository.Performer
 
 		if legacyURL.Set || legacyTwitter.Set || legacyInstagram.Set {
-			if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, &updatedPerformer); err != nil {
+			if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, updatedPerformer); err != nil {
 				return err
 			}
 		}
 
-		if err := performer.ValidateUpdate(ctx, performerID, updatedPerformer, qb); err != nil {
+		if err := performer.ValidateUpdate(ctx, performerID, *updatedPerformer, qb); err != nil {
 			return err
 		}
 
-		_, err = qb.UpdatePartial(ctx, performerID, updatedPerformer)
+		_, err = qb.UpdatePartial(ctx, performerID, *updatedPerformer)
 		if err != nil {
 			return err
 		}
@@ -340,11 +359,76 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 		return nil, fmt.Errorf("converting ids: %w", err)
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+	inputs, err := executeBulkPreHooks(ctx, r.hookExecutor, performerIDs, hook.PerformerUpdatePre, input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	// Populate the performers from the input
+	updatedPerformers := make([]*models.PerformerPartial, len(inputs))
+	for i, in := range inputs {
+		updatedPerformers[i], err = r.bulkPerformerPartialFromInput(in.input, in.translator)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Populate performer from the input
+	ret := []*models.Performer{}
+
+	// Start the transaction and save the performers
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		for i, in := range inputs {
+			performerID := in.id
+			updatedPerformer := updatedPerformers[i]
+
+			legacyURL := in.translator.optionalString(in.input.URL, "url")
+			legacyTwitter := in.translator.optionalString(in.input.Twitter, "twitter")
+			legacyInstagram := in.translator.optionalString(in.input.Instagram, "instagram")
+
+			if legacyURL.Set || legacyTwitter.Set || legacyInstagram.Set {
+				if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, updatedPerformer); err != nil {
+					return err
+				}
+			}
+
+			if err := performer.ValidateUpdate(ctx, performerID, *updatedPerformer, qb); err != nil {
+				return err
+			}
+
+			performer, err := qb.UpdatePartial(ctx, performerID, *updatedPerformer)
+			if err != nil {
+				return err
+			}
+
+			ret = append(ret, performer)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// execute post hooks outside of txn
+	var newRet []*models.Performer
+	for i, performer := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, performer.ID, hook.PerformerUpdatePost, inputs[i].input, inputs[i].translator.getFields())
+
+		performer, err = r.getPerformer(ctx, performer.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		newRet = append(newRet, performer)
+	}
+
+	return newRet, nil
+}
+
+func (r *mutationResolver) bulkPerformerPartialFromInput(input BulkPerformerUpdateInput, translator changesetTranslator) (*models.PerformerPartial, error) {
+	var err error
+
 	updatedPerformer := models.NewPerformerPartial()
 
 	updatedPerformer.Disambiguation = translator.optionalString(input.Disambiguation, "disambiguation")
@@ -367,6 +451,7 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 	updatedPerformer.HairColor = translator.optionalString(input.HairColor, "hair_color")
 	updatedPerformer.Weight = translator.optionalInt(input.Weight, "weight")
 	updatedPerformer.IgnoreAutoTag = translator.optionalBool(input.IgnoreAutoTag, "ignore_auto_tag")
+	updatedPerformer.CustomFields = translator.updateCustomFieldsBulk(input.CustomFields, "custom_fields")
 
 	if translator.hasField("urls") {
 		// ensure url/twitter/instagram are not included in the input
@@ -377,10 +462,6 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 		updatedPerformer.URLs = translator.updateStringsBulk(input.Urls, "urls")
 	}
 
-	legacyURL := translator.optionalString(input.URL, "url")
-	legacyTwitter := translator.optionalString(input.Twitter, "twitter")
-	legacyInstagram := translator.optionalString(input.Instagram, "instagram")
-
 	updatedPerformer.Birthdate, err = translator.optionalDate(input.Birthdate, "birthdate")
 	if err != nil {
 		return nil, fmt.Errorf("converting birthdate: %w", err)
@@ -405,50 +486,7 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
-	ret := []*models.Performer{}
-
-	// Start the transaction and save the performers
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Performer
-
-		for _, performerID := range performerIDs {
-			if legacyURL.Set || legacyTwitter.Set || legacyInstagram.Set {
-				if err := r.handleLegacyURLs(ctx, performerID, legacyURL, legacyTwitter, legacyInstagram, &updatedPerformer); err != nil {
-					return err
-				}
-			}
-
-			if err := performer.ValidateUpdate(ctx, performerID, updatedPerformer, qb); err != nil {
-				return err
-			}
-
-			performer, err := qb.UpdatePartial(ctx, performerID, updatedPerformer)
-			if err != nil {
-				return err
-			}
-
-			ret = append(ret, performer)
-		}
-
-		return nil
-	}); err != nil {
-		return nil, err
-	}
-
-	// execute post hooks outside of txn
-	var newRet []*models.Performer
-	for _, performer := range ret {
-		r.hookExecutor.ExecutePostHooks(ctx, performer.ID, hook.PerformerUpdatePost, input, translator.getFields())
-
-		performer, err = r.getPerformer(ctx, performer.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		newRet = append(newRet, performer)
-	}
-
-	return newRet, nil
+	return &updatedPerformer, nil
 }
 
 func (r *mutationResolver) PerformerDestroy(ctx context.Context, input PerformerDestroyInput) (bool, error) {
@@ -457,6 +495,10 @@ func (r *mutationResolver) PerformerDestroy(ctx context.Context, input Performer
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := executeDestroyPreHooks(ctx, r.hookExecutor, id, hook.PerformerDestroyPre, getUpdateInputMap(ctx)); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Performer.Destroy(ctx, id)
 	}); err != nil {
@@ -474,6 +516,10 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := executeBulkDestroyPreHooks(ctx, r.hookExecutor, ids, hook.PerformerDestroyPre, getArgumentMap(ctx)); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Performer
 		for _, id := range ids {
@@ -493,3 +539,75 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 
 	return true, nil
 }
+
+func (r *mutationResolver) PerformersMerge(ctx context.Context, input PerformerMergeInput) (*models.Performer, error) {
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, 0, hook.PerformerMergePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	srcIDs, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	destID, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	var values *models.PerformerPartial
+	var imageData []byte
+	imageIncluded := false
+
+	if input.Values != nil {
+		valuesMap, _ := inputMap["values"].(map[string]interface{})
+		translator := changesetTranslator{
+			inputMap: valuesMap,
+		}
+
+		// the legacy url fields are only supported by performerUpdate
+		for _, f := range []string{"url", "twitter", "instagram"} {
+			if translator.hasField(f) {
+				return nil, fmt.Errorf("%s field is not supported when merging performers, use urls instead", f)
+			}
+		}
+
+		values, err = r.performerPartialFromInput(*input.Values, translator)
+		if err != nil {
+			return nil, err
+		}
+
+		imageIncluded = translator.hasField("image")
+		if input.Values.Image != nil {
+			imageData, err = utils.ProcessImageInput(ctx, *input.Values.Image)
+			if err != nil {
+				return nil, fmt.Errorf("processing image: %w", err)
+			}
+		}
+	} else {
+		v := models.NewPerformerPartial()
+		values = &v
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		if err := performer.Merge(ctx, srcIDs, destID, *values, qb); err != nil {
+			return err
+		}
+
+		if imageIncluded {
+			if err := qb.UpdateImage(ctx, destID, imageData); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, destID, hook.PerformerMergePost, input, nil)
+	return r.getPerformer(ctx, destID)
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+// validateRestrictionProfileName checks that the name is not empty, and is
+// not used by another restriction profile.
+func (r *mutationResolver) validateRestrictionProfileName(ctx context.Context, name string, id int) error {
+	if name == "" {
+		return errors.New("name must not be empty")
+	}
+
+	existing, err := r.repository.RestrictionProfile.FindByName(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return fmt.Errorf("restriction profile %q already exists", name)
+	}
+
+	return nil
+}
+
+func (r *mutationResolver) RestrictionProfileCreate(ctx context.Context, input RestrictionProfileCreateInput) (*models.RestrictionProfile, error) {
+	if input.Pin == "" {
+		return nil, errors.New("PIN must not be empty")
+	}
+
+	newProfile := models.NewRestrictionProfile()
+	newProfile.Name = strings.TrimSpace(input.Name)
+	newProfile.MaxRating = input.MaxRating100
+
+	var err error
+	newProfile.PinHash, err = session.HashPassword(input.Pin)
+	if err != nil {
+		return nil, err
+	}
+
+	newProfile.TagIDs, err = stringslice.StringSliceToIntSlice(input.TagIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting tag ids: %w", err)
+	}
+	newProfile.StudioIDs, err = stringslice.StringSliceToIntSlice(input.StudioIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting studio ids: %w", err)
+	}
+	newProfile.PerformerIDs, err = stringslice.StringSliceToIntSlice(input.PerformerIds)
+	if err != nil {
+		return nil, fmt.Errorf("converting performer ids: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		if err := r.validateRestrictionProfileName(ctx, newProfile.Name, 0); err != nil {
+			return err
+		}
+
+		return r.repository.RestrictionProfile.Create(ctx, &newProfile)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &newProfile, nil
+}
+
+func (r *mutationResolver) RestrictionProfileUpdate(ctx context.Context, input RestrictionProfileUpdateInput) (ret *models.RestrictionProfile, err error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	var pinHash string
+	if input.Pin != nil {
+		if *input.Pin == "" {
+			return nil, errors.New("PIN must not be empty")
+		}
+
+		pinHash, err = session.HashPassword(*input.Pin)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.RestrictionProfile
+
+		ret, err = qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if ret == nil {
+			return fmt.Errorf("restriction profile with id %d not found", id)
+		}
+
+		if input.Name != nil {
+			name := strings.TrimSpace(*input.Name)
+			if err := r.validateRestrictionProfileName(ctx, name, id); err != nil {
+				return err
+			}
+			ret.Name = name
+		}
+		if pinHash != "" {
+			ret.PinHash = pinHash
+		}
+
+		maxRating := translator.optionalInt(input.MaxRating100, "max_rating100")
+		if maxRating.Set {
+			ret.MaxRating = maxRating.Ptr()
+		}
+
+		if translator.hasField("tag_ids") {
+			ret.TagIDs, err = stringslice.StringSliceToIntSlice(input.TagIds)
+			if err != nil {
+				return fmt.Errorf("converting tag ids: %w", err)
+			}
+		}
+		if translator.hasField("studio_ids") {
+			ret.StudioIDs, err = stringslice.StringSliceToIntSlice(input.StudioIds)
+			if err != nil {
+				return fmt.Errorf("converting studio ids: %w", err)
+			}
+		}
+		if translator.hasField("performer_ids") {
+			ret.PerformerIDs, err = stringslice.StringSliceToIntSlice(input.PerformerIds)
+			if err != nil {
+				return fmt.Errorf("converting performer ids: %w", err)
+			}
+		}
+
+		ret.UpdatedAt = time.Now()
+
+		return qb.Update(ctx, ret)
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) RestrictionProfileDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.RestrictionProfile.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
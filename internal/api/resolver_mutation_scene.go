@@ -32,8 +32,13 @@ func (r *mutationResolver) getScene(ctx context.Context, id int) (ret *models.Sc
 }
 
 func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCreateInput) (ret *models.Scene, err error) {
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, 0, hook.SceneCreatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	fileIDs, err := translator.fileIDSliceFromStringSlice(input.FileIds)
@@ -51,6 +56,7 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 	newScene.Rating = input.Rating100
 	newScene.Organized = translator.bool(input.Organized)
 	newScene.StashIDs = models.NewRelatedStashIDs(input.StashIds)
+	newScene.CustomFields = models.NewRelatedCustomFields(input.CustomFields)
 
 	newScene.Date, err = translator.datePtr(input.Date)
 	if err != nil {
@@ -113,8 +119,18 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input models.SceneCr
 }
 
 func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUpdateInput) (ret *models.Scene, err error) {
+	sceneID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, sceneID, hook.SceneUpdatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Start the transaction and save the scene
@@ -132,6 +148,18 @@ func (r *mutationResolver) SceneUpdate(ctx context.Context, input models.SceneUp
 func (r *mutationResolver) ScenesUpdate(ctx context.Context, input []*models.SceneUpdateInput) (ret []*models.Scene, err error) {
 	inputMaps := getUpdateInputMaps(ctx)
 
+	for i, scene := range input {
+		sceneID, err := strconv.Atoi(scene.ID)
+		if err != nil {
+			return nil, fmt.Errorf("converting id: %w", err)
+		}
+
+		inputMaps[i], err = executePreHooks(ctx, r.hookExecutor, sceneID, hook.SceneUpdatePre, scene, inputMaps[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Start the transaction and save the scenes
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		for i, scene := range input {
@@ -192,6 +220,7 @@ func scenePartialFromInput(input models.SceneUpdateInput, translator changesetTr
 	updatedScene.PlayDuration = translator.optionalFloat64(input.PlayDuration, "play_duration")
 	updatedScene.Organized = translator.optionalBool(input.Organized, "organized")
 	updatedScene.StashIDs = translator.updateStashIDs(input.StashIds, "stash_ids")
+	updatedScene.CustomFields = translator.updateCustomFields(input.CustomFields, "custom_fields")
 
 	var err error
 
@@ -262,6 +291,14 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		return nil, err
 	}
 
+	// local users have their own rating of the scene
+	if u := currentUser(ctx); u != nil && updatedScene.Rating.Set {
+		if err := r.repository.SceneUserData.ForUser(u.ID).UpdateRating(ctx, sceneID, updatedScene.Rating.Ptr()); err != nil {
+			return nil, err
+		}
+		updatedScene.Rating = models.OptionalInt{}
+	}
+
 	// ensure that title is set where scene has no file
 	if updatedScene.Title.Set && updatedScene.Title.Value == "" {
 		if err := originalScene.LoadFiles(ctx, r.repository.Scene); err != nil {
@@ -335,11 +372,70 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 		return nil, fmt.Errorf("converting ids: %w", err)
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+	inputs, err := executeBulkPreHooks(ctx, r.hookExecutor, sceneIDs, hook.SceneUpdatePre, input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
 	}
 
-	// Populate scene from the input
+	// Populate the scenes from the input
+	updatedScenes := make([]*models.ScenePartial, len(inputs))
+	for i, in := range inputs {
+		updatedScenes[i], err = bulkScenePartialFromInput(in.input, in.translator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// local users have their own rating of the scenes
+	u := currentUser(ctx)
+
+	ret := []*models.Scene{}
+
+	// Start the transaction and save the scenes
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Scene
+
+		for i, sceneID := range sceneIDs {
+			updatedScene := updatedScenes[i]
+			if u != nil && updatedScene.Rating.Set {
+				if err := r.repository.SceneUserData.ForUser(u.ID).UpdateRating(ctx, sceneID, updatedScene.Rating.Ptr()); err != nil {
+					return err
+				}
+				updatedScene.Rating = models.OptionalInt{}
+			}
+
+			scene, err := qb.UpdatePartial(ctx, sceneID, *updatedScene)
+			if err != nil {
+				return err
+			}
+
+			ret = append(ret, scene)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// execute post hooks outside of txn
+	var newRet []*models.Scene
+	for i, scene := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, scene.ID, hook.SceneUpdatePost, inputs[i].input, inputs[i].translator.getFields())
+
+		scene, err = r.getScene(ctx, scene.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		newRet = append(newRet, scene)
+	}
+
+	return newRet, nil
+}
+
+func bulkScenePartialFromInput(input BulkSceneUpdateInput, translator changesetTranslator) (*models.ScenePartial, error) {
+	var err error
+
 	updatedScene := models.NewScenePartial()
 
 	updatedScene.Title = translator.optionalString(input.Title, "title")
@@ -359,6 +455,7 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 	}
 
 	updatedScene.URLs = translator.optionalURLsBulk(input.Urls, input.URL)
+	updatedScene.CustomFields = translator.updateCustomFieldsBulk(input.CustomFields, "custom_fields")
 
 	updatedScene.PerformerIDs, err = translator.updateIdsBulk(input.PerformerIds, "performer_ids")
 	if err != nil {
@@ -385,40 +482,7 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 		}
 	}
 
-	ret := []*models.Scene{}
-
-	// Start the transaction and save the scenes
-	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
-
-		for _, sceneID := range sceneIDs {
-			scene, err := qb.UpdatePartial(ctx, sceneID, updatedScene)
-			if err != nil {
-				return err
-			}
-
-			ret = append(ret, scene)
-		}
-
-		return nil
-	}); err != nil {
-		return nil, err
-	}
-
-	// execute post hooks outside of txn
-	var newRet []*models.Scene
-	for _, scene := range ret {
-		r.hookExecutor.ExecutePostHooks(ctx, scene.ID, hook.SceneUpdatePost, input, translator.getFields())
-
-		scene, err = r.getScene(ctx, scene.ID)
-		if err != nil {
-			return nil, err
-		}
-
-		newRet = append(newRet, scene)
-	}
-
-	return newRet, nil
+	return &updatedScene, nil
 }
 
 func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneDestroyInput) (bool, error) {
@@ -427,6 +491,10 @@ func (r *mutationResolver) SceneDestroy(ctx context.Context, input models.SceneD
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := executeDestroyPreHooks(ctx, r.hookExecutor, sceneID, hook.SceneDestroyPre, getUpdateInputMap(ctx)); err != nil {
+		return false, err
+	}
+
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	var s *models.Scene
@@ -480,6 +548,10 @@ func (r *mutationResolver) ScenesDestroy(ctx context.Context, input models.Scene
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := executeBulkDestroyPreHooks(ctx, r.hookExecutor, sceneIDs, hook.SceneDestroyPre, getUpdateInputMap(ctx)); err != nil {
+		return false, err
+	}
+
 	var scenes []*models.Scene
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
@@ -637,6 +709,10 @@ func (r *mutationResolver) getSceneMarker(ctx context.Context, id int) (ret *mod
 }
 
 func (r *mutationResolver) SceneMarkerCreate(ctx context.Context, input SceneMarkerCreateInput) (*models.SceneMarker, error) {
+	if _, err := executePreHooks(ctx, r.hookExecutor, 0, hook.SceneMarkerCreatePre, &input, getUpdateInputMap(ctx)); err != nil {
+		return nil, err
+	}
+
 	sceneID, err := strconv.Atoi(input.SceneID)
 	if err != nil {
 		return nil, fmt.Errorf("converting scene id: %w", err)
@@ -686,8 +762,13 @@ func (r *mutationResolver) SceneMarkerUpdate(ctx context.Context, input SceneMar
 		return nil, fmt.Errorf("converting id: %w", err)
 	}
 
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, markerID, hook.SceneMarkerUpdatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Populate scene marker from the input
@@ -784,6 +865,10 @@ func (r *mutationResolver) SceneMarkerDestroy(ctx context.Context, id string) (b
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := executeDestroyPreHooks(ctx, r.hookExecutor, markerID, hook.SceneMarkerDestroyPre, getArgumentMap(ctx)); err != nil {
+		return false, err
+	}
+
 	fileNamingAlgo := manager.GetInstance().Config.GetVideoFileNamingAlgorithm()
 
 	fileDeleter := &scene.FileDeleter{
@@ -836,7 +921,7 @@ func (r *mutationResolver) SceneSaveActivity(ctx context.Context, id string, res
 	}
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
+		qb := r.sceneActivity(ctx)
 
 		ret, err = qb.SaveActivity(ctx, sceneID, resumeTime, playDuration)
 		return err
@@ -857,7 +942,7 @@ func (r *mutationResolver) SceneIncrementPlayCount(ctx context.Context, id strin
 	var updatedTimes []time.Time
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
+		qb := r.sceneActivity(ctx)
 
 		updatedTimes, err = qb.AddViews(ctx, sceneID, nil)
 		return err
@@ -884,7 +969,7 @@ func (r *mutationResolver) SceneAddPlay(ctx context.Context, id string, t []*tim
 	var updatedTimes []time.Time
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
+		qb := r.sceneActivity(ctx)
 
 		updatedTimes, err = qb.AddViews(ctx, sceneID, times)
 		return err
@@ -913,7 +998,7 @@ func (r *mutationResolver) SceneDeletePlay(ctx context.Context, id string, t []*
 	var updatedTimes []time.Time
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
+		qb := r.sceneActivity(ctx)
 
 		updatedTimes, err = qb.DeleteViews(ctx, sceneID, times)
 		return err
@@ -934,7 +1019,7 @@ func (r *mutationResolver) SceneResetPlayCount(ctx context.Context, id string) (
 	}
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
+		qb := r.sceneActivity(ctx)
 
 		ret, err = qb.DeleteAllViews(ctx, sceneID)
 		return err
@@ -955,7 +1040,7 @@ func (r *mutationResolver) SceneIncrementO(ctx context.Context, id string) (ret
 	var updatedTimes []time.Time
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
+		qb := r.sceneActivity(ctx)
 
 		updatedTimes, err = qb.AddO(ctx, sceneID, nil)
 		return err
@@ -976,7 +1061,7 @@ func (r *mutationResolver) SceneDecrementO(ctx context.Context, id string) (ret
 	var updatedTimes []time.Time
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
+		qb := r.sceneActivity(ctx)
 
 		updatedTimes, err = qb.DeleteO(ctx, sceneID, nil)
 		return err
@@ -994,7 +1079,7 @@ func (r *mutationResolver) SceneResetO(ctx context.Context, id string) (ret int,
 	}
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
+		qb := r.sceneActivity(ctx)
 
 		ret, err = qb.ResetO(ctx, sceneID)
 		return err
@@ -1021,7 +1106,7 @@ func (r *mutationResolver) SceneAddO(ctx context.Context, id string, t []*time.T
 	var updatedTimes []time.Time
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
+		qb := r.sceneActivity(ctx)
 
 		updatedTimes, err = qb.AddO(ctx, sceneID, times)
 		return err
@@ -1050,7 +1135,7 @@ func (r *mutationResolver) SceneDeleteO(ctx context.Context, id string, t []*tim
 	var updatedTimes []time.Time
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
-		qb := r.repository.Scene
+		qb := r.sceneActivity(ctx)
 
 		updatedTimes, err = qb.DeleteO(ctx, sceneID, times)
 		return err
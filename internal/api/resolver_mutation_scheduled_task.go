@@ -0,0 +1,95 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *mutationResolver) ScheduledTaskCreate(ctx context.Context, input ScheduledTaskCreateInput) (*models.ScheduledTask, error) {
+	newTask := models.NewScheduledTask()
+	newTask.Name = input.Name
+	newTask.Type = input.Type
+	newTask.Schedule = input.Schedule
+	newTask.Input = input.Input
+	if input.Enabled != nil {
+		newTask.Enabled = *input.Enabled
+	}
+
+	if err := manager.GetInstance().Scheduler.Create(ctx, &newTask); err != nil {
+		return nil, err
+	}
+
+	return &newTask, nil
+}
+
+func (r *mutationResolver) ScheduledTaskUpdate(ctx context.Context, input ScheduledTaskUpdateInput) (*models.ScheduledTask, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	var t *models.ScheduledTask
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		t, err = r.repository.ScheduledTask.Find(ctx, id)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if t == nil {
+		return nil, fmt.Errorf("scheduled task with id %d not found", id)
+	}
+
+	if input.Name != nil {
+		t.Name = *input.Name
+	}
+	if input.Type != nil {
+		t.Type = *input.Type
+	}
+	if input.Schedule != nil {
+		t.Schedule = *input.Schedule
+	}
+	if input.Input != nil {
+		t.Input = input.Input
+	}
+	if input.Enabled != nil {
+		t.Enabled = *input.Enabled
+	}
+
+	if err := manager.GetInstance().Scheduler.Update(ctx, t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (r *mutationResolver) ScheduledTaskDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := manager.GetInstance().Scheduler.Destroy(ctx, idInt); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) RunScheduledTask(ctx context.Context, id string) (string, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return "", fmt.Errorf("converting id: %w", err)
+	}
+
+	jobID, err := manager.GetInstance().Scheduler.RunNow(ctx, idInt)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
@@ -10,3 +10,12 @@ func (r *mutationResolver) ReloadScrapers(ctx context.Context) (bool, error) {
 	manager.GetInstance().RefreshScraperCache()
 	return true, nil
 }
+
+func (r *mutationResolver) PurgeScraperCache(ctx context.Context, scraperID *string) (int, error) {
+	id := ""
+	if scraperID != nil {
+		id = *scraperID
+	}
+
+	return manager.GetInstance().ScraperCache.PurgeResponseCache(id)
+}
@@ -25,8 +25,13 @@ func (r *mutationResolver) getStudio(ctx context.Context, id int) (ret *models.S
 }
 
 func (r *mutationResolver) StudioCreate(ctx context.Context, input models.StudioCreateInput) (*models.Studio, error) {
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, 0, hook.StudioCreatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Populate a new studio from the input
@@ -40,8 +45,7 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 	newStudio.IgnoreAutoTag = translator.bool(input.IgnoreAutoTag)
 	newStudio.Aliases = models.NewRelatedStrings(input.Aliases)
 	newStudio.StashIDs = models.NewRelatedStashIDs(input.StashIds)
-
-	var err error
+	newStudio.CustomFields = models.NewRelatedCustomFields(input.CustomFields)
 
 	newStudio.ParentID, err = translator.intPtrFromString(input.ParentID)
 	if err != nil {
@@ -91,20 +95,9 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input models.Studio
 	return r.getStudio(ctx, newStudio.ID)
 }
 
-func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.StudioUpdateInput) (*models.Studio, error) {
-	studioID, err := strconv.Atoi(input.ID)
-	if err != nil {
-		return nil, fmt.Errorf("converting id: %w", err)
-	}
-
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
-	// Populate studio from the input
+func studioPartialFromInput(input models.StudioUpdateInput, translator changesetTranslator) (*models.StudioPartial, error) {
 	updatedStudio := models.NewStudioPartial()
 
-	updatedStudio.ID = studioID
 	updatedStudio.Name = translator.optionalString(input.Name, "name")
 	updatedStudio.URL = translator.optionalString(input.URL, "url")
 	updatedStudio.Details = translator.optionalString(input.Details, "details")
@@ -113,7 +106,9 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 	updatedStudio.IgnoreAutoTag = translator.optionalBool(input.IgnoreAutoTag, "ignore_auto_tag")
 	updatedStudio.Aliases = translator.updateStrings(input.Aliases, "aliases")
 	updatedStudio.StashIDs = translator.updateStashIDs(input.StashIds, "stash_ids")
+	updatedStudio.CustomFields = translator.updateCustomFields(input.CustomFields, "custom_fields")
 
+	var err error
 	updatedStudio.ParentID, err = translator.optionalIntFromString(input.ParentID, "parent_id")
 	if err != nil {
 		return nil, fmt.Errorf("converting parent id: %w", err)
@@ -124,6 +119,31 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 		return nil, fmt.Errorf("converting tag ids: %w", err)
 	}
 
+	return &updatedStudio, nil
+}
+
+func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.StudioUpdateInput) (*models.Studio, error) {
+	studioID, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, studioID, hook.StudioUpdatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	translator := changesetTranslator{
+		inputMap: inputMap,
+	}
+
+	updatedStudio, err := studioPartialFromInput(input, translator)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedStudio.ID = studioID
+
 	// Process the base 64 encoded image string
 	var imageData []byte
 	imageIncluded := translator.hasField("image")
@@ -139,11 +159,11 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.Studio
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Studio
 
-		if err := studio.ValidateModify(ctx, updatedStudio, qb); err != nil {
+		if err := studio.ValidateModify(ctx, *updatedStudio, qb); err != nil {
 			return err
 		}
 
-		_, err = qb.UpdatePartial(ctx, updatedStudio)
+		_, err = qb.UpdatePartial(ctx, *updatedStudio)
 		if err != nil {
 			return err
 		}
@@ -169,6 +189,10 @@ func (r *mutationResolver) StudioDestroy(ctx context.Context, input StudioDestro
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := executeDestroyPreHooks(ctx, r.hookExecutor, id, hook.StudioDestroyPre, getUpdateInputMap(ctx)); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Studio.Destroy(ctx, id)
 	}); err != nil {
@@ -186,6 +210,10 @@ func (r *mutationResolver) StudiosDestroy(ctx context.Context, studioIDs []strin
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := executeBulkDestroyPreHooks(ctx, r.hookExecutor, ids, hook.StudioDestroyPre, getArgumentMap(ctx)); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Studio
 		for _, id := range ids {
@@ -205,3 +233,68 @@ func (r *mutationResolver) StudiosDestroy(ctx context.Context, studioIDs []strin
 
 	return true, nil
 }
+
+func (r *mutationResolver) StudiosMerge(ctx context.Context, input StudioMergeInput) (*models.Studio, error) {
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, 0, hook.StudioMergePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	srcIDs, err := stringslice.StringSliceToIntSlice(input.Source)
+	if err != nil {
+		return nil, fmt.Errorf("converting source ids: %w", err)
+	}
+
+	destID, err := strconv.Atoi(input.Destination)
+	if err != nil {
+		return nil, fmt.Errorf("converting destination id: %w", err)
+	}
+
+	var values *models.StudioPartial
+	var imageData []byte
+	imageIncluded := false
+
+	if input.Values != nil {
+		valuesMap, _ := inputMap["values"].(map[string]interface{})
+		translator := changesetTranslator{
+			inputMap: valuesMap,
+		}
+
+		values, err = studioPartialFromInput(*input.Values, translator)
+		if err != nil {
+			return nil, err
+		}
+
+		imageIncluded = translator.hasField("image")
+		if input.Values.Image != nil {
+			imageData, err = utils.ProcessImageInput(ctx, *input.Values.Image)
+			if err != nil {
+				return nil, fmt.Errorf("processing image: %w", err)
+			}
+		}
+	} else {
+		v := models.NewStudioPartial()
+		values = &v
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Studio
+
+		if err := studio.Merge(ctx, srcIDs, destID, *values, qb); err != nil {
+			return err
+		}
+
+		if imageIncluded {
+			if err := qb.UpdateImage(ctx, destID, imageData); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, destID, hook.StudioMergePost, input, nil)
+	return r.getStudio(ctx, destID)
+}
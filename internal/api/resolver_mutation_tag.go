@@ -25,8 +25,13 @@ func (r *mutationResolver) getTag(ctx context.Context, id int) (ret *models.Tag,
 }
 
 func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput) (*models.Tag, error) {
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, 0, hook.TagCreatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Populate a new tag from the input
@@ -34,12 +39,11 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 
 	newTag.Name = input.Name
 	newTag.Aliases = models.NewRelatedStrings(input.Aliases)
+	newTag.CustomFields = models.NewRelatedCustomFields(customFieldsPtrSliceToSlice(input.CustomFields))
 	newTag.Favorite = translator.bool(input.Favorite)
 	newTag.Description = translator.string(input.Description)
 	newTag.IgnoreAutoTag = translator.bool(input.IgnoreAutoTag)
 
-	var err error
-
 	newTag.ParentIDs, err = translator.relatedIds(input.ParentIds)
 	if err != nil {
 		return nil, fmt.Errorf("converting parent tag ids: %w", err)
@@ -94,8 +98,13 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 		return nil, fmt.Errorf("converting id: %w", err)
 	}
 
+	inputMap, err := executePreHooks(ctx, r.hookExecutor, tagID, hook.TagUpdatePre, &input, getUpdateInputMap(ctx))
+	if err != nil {
+		return nil, err
+	}
+
 	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
+		inputMap: inputMap,
 	}
 
 	// Populate tag from the input
@@ -107,6 +116,7 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 	updatedTag.Description = translator.optionalString(input.Description, "description")
 
 	updatedTag.Aliases = translator.updateStrings(input.Aliases, "aliases")
+	updatedTag.CustomFields = translator.updateCustomFields(customFieldsPtrSliceToSlice(input.CustomFields), "custom_fields")
 
 	updatedTag.ParentIDs, err = translator.updateIds(input.ParentIds, "parent_ids")
 	if err != nil {
@@ -163,27 +173,18 @@ func (r *mutationResolver) BulkTagUpdate(ctx context.Context, input BulkTagUpdat
 		return nil, fmt.Errorf("converting ids: %w", err)
 	}
 
-	translator := changesetTranslator{
-		inputMap: getUpdateInputMap(ctx),
-	}
-
-	// Populate scene from the input
-	updatedTag := models.NewTagPartial()
-
-	updatedTag.Description = translator.optionalString(input.Description, "description")
-	updatedTag.Favorite = translator.optionalBool(input.Favorite, "favorite")
-	updatedTag.IgnoreAutoTag = translator.optionalBool(input.IgnoreAutoTag, "ignore_auto_tag")
-
-	updatedTag.Aliases = translator.updateStringsBulk(input.Aliases, "aliases")
-
-	updatedTag.ParentIDs, err = translator.updateIdsBulk(input.ParentIds, "parent_ids")
+	inputs, err := executeBulkPreHooks(ctx, r.hookExecutor, tagIDs, hook.TagUpdatePre, input, getUpdateInputMap(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("converting parent tag ids: %w", err)
+		return nil, err
 	}
 
-	updatedTag.ChildIDs, err = translator.updateIdsBulk(input.ChildIds, "child_ids")
-	if err != nil {
-		return nil, fmt.Errorf("converting child tag ids: %w", err)
+	// Populate the tags from the input
+	updatedTags := make([]*models.TagPartial, len(inputs))
+	for i, in := range inputs {
+		updatedTags[i], err = bulkTagPartialFromInput(in.input, in.translator)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	ret := []*models.Tag{}
@@ -192,12 +193,13 @@ func (r *mutationResolver) BulkTagUpdate(ctx context.Context, input BulkTagUpdat
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Tag
 
-		for _, tagID := range tagIDs {
-			if err := tag.ValidateUpdate(ctx, tagID, updatedTag, qb); err != nil {
+		for i, tagID := range tagIDs {
+			updatedTag := updatedTags[i]
+			if err := tag.ValidateUpdate(ctx, tagID, *updatedTag, qb); err != nil {
 				return err
 			}
 
-			tag, err := qb.UpdatePartial(ctx, tagID, updatedTag)
+			tag, err := qb.UpdatePartial(ctx, tagID, *updatedTag)
 			if err != nil {
 				return err
 			}
@@ -212,8 +214,8 @@ func (r *mutationResolver) BulkTagUpdate(ctx context.Context, input BulkTagUpdat
 
 	// execute post hooks outside of txn
 	var newRet []*models.Tag
-	for _, tag := range ret {
-		r.hookExecutor.ExecutePostHooks(ctx, tag.ID, hook.TagUpdatePost, input, translator.getFields())
+	for i, tag := range ret {
+		r.hookExecutor.ExecutePostHooks(ctx, tag.ID, hook.TagUpdatePost, inputs[i].input, inputs[i].translator.getFields())
 
 		tag, err = r.getTag(ctx, tag.ID)
 		if err != nil {
@@ -226,12 +228,41 @@ func (r *mutationResolver) BulkTagUpdate(ctx context.Context, input BulkTagUpdat
 	return newRet, nil
 }
 
+func bulkTagPartialFromInput(input BulkTagUpdateInput, translator changesetTranslator) (*models.TagPartial, error) {
+	var err error
+
+	updatedTag := models.NewTagPartial()
+
+	updatedTag.Description = translator.optionalString(input.Description, "description")
+	updatedTag.Favorite = translator.optionalBool(input.Favorite, "favorite")
+	updatedTag.IgnoreAutoTag = translator.optionalBool(input.IgnoreAutoTag, "ignore_auto_tag")
+
+	updatedTag.Aliases = translator.updateStringsBulk(input.Aliases, "aliases")
+	updatedTag.CustomFields = translator.updateCustomFieldsBulk(input.CustomFields, "custom_fields")
+
+	updatedTag.ParentIDs, err = translator.updateIdsBulk(input.ParentIds, "parent_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting parent tag ids: %w", err)
+	}
+
+	updatedTag.ChildIDs, err = translator.updateIdsBulk(input.ChildIds, "child_ids")
+	if err != nil {
+		return nil, fmt.Errorf("converting child tag ids: %w", err)
+	}
+
+	return &updatedTag, nil
+}
+
 func (r *mutationResolver) TagDestroy(ctx context.Context, input TagDestroyInput) (bool, error) {
 	tagID, err := strconv.Atoi(input.ID)
 	if err != nil {
 		return false, fmt.Errorf("converting id: %w", err)
 	}
 
+	if err := executeDestroyPreHooks(ctx, r.hookExecutor, tagID, hook.TagDestroyPre, getUpdateInputMap(ctx)); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return r.repository.Tag.Destroy(ctx, tagID)
 	}); err != nil {
@@ -249,6 +280,10 @@ func (r *mutationResolver) TagsDestroy(ctx context.Context, tagIDs []string) (bo
 		return false, fmt.Errorf("converting ids: %w", err)
 	}
 
+	if err := executeBulkDestroyPreHooks(ctx, r.hookExecutor, ids, hook.TagDestroyPre, getArgumentMap(ctx)); err != nil {
+		return false, err
+	}
+
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Tag
 		for _, id := range ids {
@@ -270,6 +305,10 @@ func (r *mutationResolver) TagsDestroy(ctx context.Context, tagIDs []string) (bo
 }
 
 func (r *mutationResolver) TagsMerge(ctx context.Context, input TagsMergeInput) (*models.Tag, error) {
+	if _, err := executePreHooks(ctx, r.hookExecutor, 0, hook.TagMergePre, &input, getUpdateInputMap(ctx)); err != nil {
+		return nil, err
+	}
+
 	source, err := stringslice.StringSliceToIntSlice(input.Source)
 	if err != nil {
 		return nil, fmt.Errorf("converting source ids: %w", err)
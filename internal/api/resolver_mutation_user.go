@@ -0,0 +1,260 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+// validateUsername checks that the username is not empty, and is not used by
+// the configured user or by another local user.
+func (r *mutationResolver) validateUsername(ctx context.Context, username string, id int) error {
+	if username == "" {
+		return errors.New("username must not be empty")
+	}
+
+	if strings.EqualFold(username, config.GetInstance().GetUsername()) {
+		return fmt.Errorf("username %q is already in use", username)
+	}
+
+	existing, err := r.repository.User.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return fmt.Errorf("username %q is already in use", username)
+	}
+
+	return nil
+}
+
+func (r *mutationResolver) UserCreate(ctx context.Context, input UserCreateInput) (*models.User, error) {
+	// local users are only meaningful when authentication is required
+	if !config.GetInstance().AuthenticationRequired() {
+		return nil, errors.New("authentication must be configured before adding users")
+	}
+
+	if input.Password == "" {
+		return nil, errors.New("password must not be empty")
+	}
+
+	newUser := models.NewUser()
+	newUser.Username = strings.TrimSpace(input.Username)
+	newUser.Role = input.Role
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	var err error
+	newUser.RestrictionProfileID, err = translator.intPtrFromString(input.RestrictionProfileID)
+	if err != nil {
+		return nil, fmt.Errorf("converting restriction profile id: %w", err)
+	}
+
+	newUser.PasswordHash, err = session.HashPassword(input.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		if err := r.validateUsername(ctx, newUser.Username, 0); err != nil {
+			return err
+		}
+
+		return r.repository.User.Create(ctx, &newUser)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &newUser, nil
+}
+
+func (r *mutationResolver) UserUpdate(ctx context.Context, input UserUpdateInput) (ret *models.User, err error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, fmt.Errorf("converting id: %w", err)
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	restrictionProfileID, err := translator.optionalIntFromString(input.RestrictionProfileID, "restriction_profile_id")
+	if err != nil {
+		return nil, fmt.Errorf("converting restriction profile id: %w", err)
+	}
+
+	var passwordHash string
+	if input.Password != nil {
+		if *input.Password == "" {
+			return nil, errors.New("password must not be empty")
+		}
+
+		passwordHash, err = session.HashPassword(*input.Password)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.User
+
+		ret, err = qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if ret == nil {
+			return fmt.Errorf("user with id %d not found", id)
+		}
+
+		if input.Username != nil {
+			username := strings.TrimSpace(*input.Username)
+			if err := r.validateUsername(ctx, username, id); err != nil {
+				return err
+			}
+			ret.Username = username
+		}
+		if input.Role != nil {
+			ret.Role = *input.Role
+		}
+		if passwordHash != "" {
+			ret.PasswordHash = passwordHash
+		}
+		if restrictionProfileID.Set {
+			ret.RestrictionProfileID = restrictionProfileID.Ptr()
+		}
+
+		ret.UpdatedAt = time.Now()
+
+		return qb.Update(ctx, ret)
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) UserDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, fmt.Errorf("converting id: %w", err)
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.User.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) UserChangePassword(ctx context.Context, input UserChangePasswordInput) (bool, error) {
+	user := currentUser(ctx)
+	if user == nil {
+		return false, errors.New("the password of the configured user must be changed in the settings")
+	}
+
+	if input.NewPassword == "" {
+		return false, errors.New("password must not be empty")
+	}
+
+	hash, err := session.HashPassword(input.NewPassword)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.User
+
+		u, err := qb.Find(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		if u == nil || !session.CheckPassword(u.PasswordHash, input.ExistingPassword) {
+			return errors.New("existing password is incorrect")
+		}
+
+		u.PasswordHash = hash
+		u.UpdatedAt = time.Now()
+
+		return qb.Update(ctx, u)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) UserGenerateAPIKey(ctx context.Context, input UserGenerateAPIKeyInput) (string, error) {
+	user := currentUser(ctx)
+
+	// an API key would not carry the restriction applied to the session
+	if restriction := models.GetContentRestriction(ctx); restriction != nil && !restriction.Locked {
+		return "", errForbidden
+	}
+
+	var id int
+	switch {
+	case input.ID != nil:
+		var err error
+		id, err = strconv.Atoi(*input.ID)
+		if err != nil {
+			return "", fmt.Errorf("converting id: %w", err)
+		}
+
+		if (user == nil || user.ID != id) && !isAdmin(ctx) {
+			return "", errForbidden
+		}
+	case user != nil:
+		id = user.ID
+	default:
+		return "", errors.New("the API key of the configured user must be generated using generateAPIKey")
+	}
+
+	var newAPIKey string
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.User
+
+		u, err := qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if u == nil {
+			return fmt.Errorf("user with id %d not found", id)
+		}
+
+		if input.Clear == nil || !*input.Clear {
+			newAPIKey, err = manager.GenerateAPIKey(u.Username)
+			if err != nil {
+				return err
+			}
+		}
+
+		// only the hash of the key is stored
+		u.APIKeyHash = ""
+		if newAPIKey != "" {
+			u.APIKeyHash = session.HashAPIKey(newAPIKey)
+		}
+		u.UpdatedAt = time.Now()
+
+		return qb.Update(ctx, u)
+	}); err != nil {
+		return "", err
+	}
+
+	return newAPIKey, nil
+}
@@ -14,7 +14,57 @@ import (
 )
 
 func (r *queryResolver) Configuration(ctx context.Context) (*ConfigResult, error) {
-	return makeConfigResult(), nil
+	ret := makeConfigResult()
+
+	// only admins may see the credentials and server paths
+	if !isAdmin(ctx) {
+		redactConfigGeneralResult(ret.General)
+		redactConfigScrapingResult(ret.Scraping)
+	}
+
+	return ret, nil
+}
+
+// redactConfigGeneralResult removes the credentials, API keys and server
+// paths from the result.
+func redactConfigGeneralResult(ret *ConfigGeneralResult) {
+	ret.APIKey = ""
+	ret.Username = ""
+	ret.Password = ""
+
+	empty := ""
+	ret.Stashes = []*config.StashConfig{}
+	ret.DatabasePath = ""
+	ret.BackupDirectoryPath = ""
+	ret.GeneratedPath = ""
+	ret.MetadataPath = ""
+	ret.ConfigFilePath = ""
+	ret.ScrapersPath = ""
+	ret.PluginsPath = ""
+	ret.CachePath = ""
+	ret.BlobsPath = ""
+	ret.FfmpegPath = ""
+	ret.FfprobePath = ""
+	ret.LogFile = &empty
+	ret.CustomPerformerImageLocation = &empty
+	ret.PythonPath = ""
+	ret.Excludes = []string{}
+	ret.ImageExcludes = []string{}
+
+	// the stash-box endpoints are still needed to show stash ids
+	stashBoxes := make([]*models.StashBox, len(ret.StashBoxes))
+	for i, box := range ret.StashBoxes {
+		redacted := *box
+		redacted.APIKey = ""
+		stashBoxes[i] = &redacted
+	}
+	ret.StashBoxes = stashBoxes
+}
+
+// redactConfigScrapingResult removes the server paths from the result.
+func redactConfigScrapingResult(ret *ConfigScrapingResult) {
+	empty := ""
+	ret.ScraperCDPPath = &empty
 }
 
 func (r *queryResolver) Directory(ctx context.Context, path, locale *string) (*Directory, error) {
@@ -96,6 +146,10 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		CalculateMd5:                  config.IsCalculateMD5(),
 		VideoFileNamingAlgorithm:      config.GetVideoFileNamingAlgorithm(),
 		ParallelTasks:                 config.GetParallelTasks(),
+		IoJobSlots:                    config.GetIOJobSlots(),
+		CPUJobSlots:                   config.GetCPUJobSlots(),
+		NetworkJobSlots:               config.GetNetworkJobSlots(),
+		GeneralJobSlots:               config.GetGeneralJobSlots(),
 		PreviewAudio:                  config.GetPreviewAudio(),
 		PreviewSegments:               config.GetPreviewSegments(),
 		PreviewSegmentDuration:        config.GetPreviewSegmentDuration(),
@@ -104,6 +158,7 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		PreviewPreset:                 config.GetPreviewPreset(),
 		TranscodeHardwareAcceleration: config.GetTranscodeHardwareAcceleration(),
 		MaxTranscodeSize:              &maxTranscodeSize,
+		TranscodeVideoCodec:           config.GetTranscodeVideoCodec(),
 		MaxStreamingTranscodeSize:     &maxStreamingTranscodeSize,
 		WriteImageThumbnails:          config.IsWriteImageThumbnails(),
 		CreateImageClipsFromVideos:    config.IsCreateImageClipsFromVideos(),
@@ -112,6 +167,7 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 		Username:                      config.GetUsername(),
 		Password:                      config.GetPasswordHash(),
 		MaxSessionAge:                 config.GetMaxSessionAge(),
+		APIKeyRestrictionProfileID:    config.GetAPIKeyRestrictionProfile(),
 		LogFile:                       &logFile,
 		LogOut:                        config.GetLogOut(),
 		LogLevel:                      config.GetLogLevel(),
@@ -197,12 +253,15 @@ func makeConfigDLNAResult() *ConfigDLNAResult {
 	config := config.GetInstance()
 
 	return &ConfigDLNAResult{
-		ServerName:     config.GetDLNAServerName(),
-		Enabled:        config.GetDLNADefaultEnabled(),
-		Port:           config.GetDLNAPort(),
-		WhitelistedIPs: config.GetDLNADefaultIPWhitelist(),
-		Interfaces:     config.GetDLNAInterfaces(),
-		VideoSortOrder: config.GetVideoSortOrder(),
+		ServerName:           config.GetDLNAServerName(),
+		Enabled:              config.GetDLNADefaultEnabled(),
+		Port:                 config.GetDLNAPort(),
+		WhitelistedIPs:       config.GetDLNADefaultIPWhitelist(),
+		Interfaces:           config.GetDLNAInterfaces(),
+		VideoSortOrder:       config.GetVideoSortOrder(),
+		RestrictionProfileID: config.GetDLNARestrictionProfile(),
+		MinimumPlayPercent:   config.GetDLNAMinimumPlayPercent(),
+		RendererProfiles:     config.GetDLNARendererProfiles(),
 	}
 }
 
@@ -216,6 +275,7 @@ func makeConfigScrapingResult() *ConfigScrapingResult {
 		ScraperUserAgent:   &scraperUserAgent,
 		ScraperCertCheck:   config.GetScraperCertCheck(),
 		ScraperCDPPath:     &scraperCDPPath,
+		ScraperCacheTTL:    int(config.GetScraperCacheTTL().Minutes()),
 		ExcludeTagPatterns: config.GetScraperExcludeTagPatterns(),
 	}
 }
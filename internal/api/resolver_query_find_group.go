@@ -37,6 +37,7 @@ func (r *queryResolver) FindGroups(ctx context.Context, groupFilter *models.Grou
 
 		if len(idInts) > 0 {
 			groups, err = r.repository.Group.FindMany(ctx, idInts)
+			groups = excludeHidden(groups)
 			total = len(groups)
 		} else {
 			groups, total, err = r.repository.Group.Query(ctx, groupFilter, filter)
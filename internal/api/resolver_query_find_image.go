@@ -70,6 +70,7 @@ func (r *queryResolver) FindImages(
 
 		if len(imageIds) > 0 {
 			images, err = r.repository.Image.FindMany(ctx, imageIds)
+			images = excludeHidden(images)
 			if err == nil {
 				result.Count = len(images)
 				for _, s := range images {
@@ -39,6 +39,7 @@ func (r *queryResolver) FindPerformers(ctx context.Context, performerFilter *mod
 
 		if len(performerIDs) > 0 {
 			performers, err = r.repository.Performer.FindMany(ctx, performerIDs)
+			performers = excludeHidden(performers)
 			total = len(performers)
 		} else {
 			performers, total, err = r.repository.Performer.Query(ctx, performerFilter, filter)
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) CurrentRestrictionProfile(ctx context.Context) (ret *models.RestrictionProfile, err error) {
+	restriction := models.GetContentRestriction(ctx)
+	if restriction == nil {
+		return nil, nil
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.RestrictionProfile.Find(ctx, restriction.ProfileID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return ret, err
+}
+
+func (r *queryResolver) FindRestrictionProfile(ctx context.Context, id string) (ret *models.RestrictionProfile, err error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.RestrictionProfile.Find(ctx, idInt)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return ret, err
+}
+
+func (r *queryResolver) FindRestrictionProfiles(ctx context.Context) (ret []*models.RestrictionProfile, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.RestrictionProfile.All(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return ret, err
+}
@@ -0,0 +1,33 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) FindScheduledTask(ctx context.Context, id string) (ret *models.ScheduledTask, err error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.ScheduledTask.Find(ctx, idInt)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return ret, err
+}
+
+func (r *queryResolver) FindScheduledTasks(ctx context.Context) (ret []*models.ScheduledTask, err error) {
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.ScheduledTask.All(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return ret, err
+}
@@ -36,6 +36,8 @@ func jobToJobModel(j job.Job) *Job {
 	ret := &Job{
 		ID:          strconv.Itoa(j.ID),
 		Status:      JobStatus(j.Status),
+		Class:       JobClass(j.Class),
+		Priority:    j.Priority,
 		Description: j.Description,
 		SubTasks:    j.Details,
 		StartTime:   j.StartTime,
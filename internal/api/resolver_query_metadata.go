@@ -7,5 +7,18 @@ import (
 )
 
 func (r *queryResolver) SystemStatus(ctx context.Context) (*manager.SystemStatus, error) {
-	return manager.GetInstance().GetSystemStatus(), nil
+	ret := manager.GetInstance().GetSystemStatus()
+
+	// the status is needed by the interface, but only admins may see the
+	// server paths
+	if !isAdmin(ctx) {
+		ret = &manager.SystemStatus{
+			DatabaseSchema: ret.DatabaseSchema,
+			AppSchema:      ret.AppSchema,
+			Status:         ret.Status,
+			Os:             ret.Os,
+		}
+	}
+
+	return ret, nil
 }
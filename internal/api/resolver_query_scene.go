@@ -41,5 +41,5 @@ func (r *queryResolver) SceneStreams(ctx context.Context, id *string) ([]*manage
 	builder := urlbuilders.NewSceneURLBuilder(baseURL, scene)
 	apiKey := config.GetAPIKey()
 
-	return manager.GetSceneStreamPaths(scene, builder.GetStreamURL(apiKey), config.GetMaxStreamingTranscodeSize())
+	return manager.GetSceneStreamPaths(scene, builder.GetStreamURL(apiKey), builder.GetCaptionURL(), config.GetMaxStreamingTranscodeSize())
 }
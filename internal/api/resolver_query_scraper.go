@@ -127,6 +127,23 @@ func filterGalleryTags(g []*scraper.ScrapedGallery) {
 	}
 }
 
+// filterImageTags removes tags matching excluded tag patterns from the provided scraped images
+func filterImageTags(images []*scraper.ScrapedImage) {
+	excludeRegexps := compileRegexps(manager.GetInstance().Config.GetScraperExcludeTagPatterns())
+
+	var ignoredTags []string
+
+	for _, s := range images {
+		var ignored []string
+		s.Tags, ignored = filterTags(excludeRegexps, s.Tags)
+		ignoredTags = sliceutil.AppendUniques(ignoredTags, ignored)
+	}
+
+	if len(ignoredTags) > 0 {
+		logger.Debugf("Scraping ignored tags: %s", strings.Join(ignoredTags, ", "))
+	}
+}
+
 // filterGalleryTags removes tags matching excluded tag patterns from the provided scraped galleries
 func filterPerformerTags(p []*models.ScrapedPerformer) {
 	excludeRegexps := compileRegexps(manager.GetInstance().Config.GetScraperExcludeTagPatterns())
@@ -197,6 +214,24 @@ func (r *queryResolver) ScrapeGalleryURL(ctx context.Context, url string) (*scra
 	return ret, nil
 }
 
+func (r *queryResolver) ScrapeImageURL(ctx context.Context, url string) (*scraper.ScrapedImage, error) {
+	content, err := r.scraperCache().ScrapeURL(ctx, url, scraper.ScrapeContentTypeImage)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := marshalScrapedImage(content)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret != nil {
+		filterImageTags([]*scraper.ScrapedImage{ret})
+	}
+
+	return ret, nil
+}
+
 func (r *queryResolver) ScrapeMovieURL(ctx context.Context, url string) (*models.ScrapedMovie, error) {
 	content, err := r.scraperCache().ScrapeURL(ctx, url, scraper.ScrapeContentTypeMovie)
 	if err != nil {
@@ -491,6 +526,55 @@ func (r *queryResolver) ScrapeSingleGallery(ctx context.Context, source scraper.
 	return ret, nil
 }
 
+func (r *queryResolver) ScrapeSingleImage(ctx context.Context, source scraper.Source, input ScrapeSingleImageInput) ([]*scraper.ScrapedImage, error) {
+	var ret []*scraper.ScrapedImage
+
+	if source.StashBoxIndex != nil || source.StashBoxEndpoint != nil {
+		return nil, ErrNotSupported
+	}
+
+	if source.ScraperID == nil {
+		return nil, fmt.Errorf("%w: scraper_id must be set", ErrInput)
+	}
+
+	var c []scraper.ScrapedContent
+
+	switch {
+	case input.ImageID != nil:
+		imageID, err := strconv.Atoi(*input.ImageID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: image id is not an integer: '%s'", ErrInput, *input.ImageID)
+		}
+		content, err := r.scraperCache().ScrapeID(ctx, *source.ScraperID, imageID, scraper.ScrapeContentTypeImage)
+		if err != nil {
+			return nil, err
+		}
+		c = []scraper.ScrapedContent{content}
+	case input.ImageInput != nil:
+		content, err := r.scraperCache().ScrapeFragment(ctx, *source.ScraperID, scraper.Input{Image: input.ImageInput})
+		if err != nil {
+			return nil, err
+		}
+		c = []scraper.ScrapedContent{content}
+	case input.Query != nil:
+		var err error
+		c, err = r.scraperCache().ScrapeName(ctx, *source.ScraperID, *input.Query, scraper.ScrapeContentTypeImage)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrNotImplemented
+	}
+
+	ret, err := marshalScrapedImages(c)
+	if err != nil {
+		return nil, err
+	}
+
+	filterImageTags(ret)
+	return ret, nil
+}
+
 func (r *queryResolver) ScrapeSingleMovie(ctx context.Context, source scraper.Source, input ScrapeSingleMovieInput) ([]*models.ScrapedMovie, error) {
 	return nil, ErrNotSupported
 }
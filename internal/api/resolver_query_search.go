@@ -0,0 +1,24 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) Search(ctx context.Context, q string, types []models.SearchResultType, limit *int) (ret []*models.SearchResult, err error) {
+	// the limit is clamped by the store
+	l := models.DefaultSearchLimit
+	if limit != nil {
+		l = *limit
+	}
+
+	if err := r.withReadTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Search.Search(ctx, q, types, l)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
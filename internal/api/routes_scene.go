@@ -83,8 +83,8 @@ func (rs sceneRoutes) Routes() chi.Router {
 		r.Get("/scene_marker/{sceneMarkerId}/preview", rs.SceneMarkerPreview)
 		r.Get("/scene_marker/{sceneMarkerId}/screenshot", rs.SceneMarkerScreenshot)
 	})
-	r.Get("/{sceneHash}_thumbs.vtt", rs.VttThumbs)
-	r.Get("/{sceneHash}_sprite.jpg", rs.VttSprite)
+	r.With(rs.SceneHashCtx).Get("/{sceneHash}_thumbs.vtt", rs.VttThumbs)
+	r.With(rs.SceneHashCtx).Get("/{sceneHash}_sprite.jpg", rs.VttSprite)
 
 	return r
 }
@@ -153,11 +153,19 @@ func (rs sceneRoutes) streamTranscode(w http.ResponseWriter, r *http.Request, st
 	ss, _ := strconv.ParseFloat(startTime, 64)
 	resolution := r.Form.Get("resolution")
 
+	audioTrack, err := ffmpeg.ParseAudioTrack(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	options := ffmpeg.TranscodeOptions{
-		StreamType: streamType,
-		VideoFile:  f,
-		Resolution: resolution,
-		StartTime:  ss,
+		StreamType:   streamType,
+		VideoFile:    f,
+		Resolution:   resolution,
+		StartTime:    ss,
+		ClientCodecs: ffmpeg.ClientVideoCodecs(r),
+		AudioTrack:   audioTrack,
 	}
 
 	logger.Debugf("[transcode] streaming scene %d as %s", scene.ID, streamType.MimeType)
@@ -190,6 +198,12 @@ func (rs sceneRoutes) streamManifest(w http.ResponseWriter, r *http.Request, str
 		logger.Warnf("[transcode] error parsing query form: %v", err)
 	}
 
+	if r.Form.Get("adaptive") == "true" {
+		logger.Debugf("[transcode] returning adaptive %s manifest for scene %d", logName, scene.ID)
+		streamManager.ServeAdaptiveManifest(w, r, streamType, f)
+		return
+	}
+
 	resolution := r.Form.Get("resolution")
 
 	logger.Debugf("[transcode] returning %s manifest for scene %d", logName, scene.ID)
@@ -231,12 +245,19 @@ func (rs sceneRoutes) streamSegment(w http.ResponseWriter, r *http.Request, stre
 	segment := chi.URLParam(r, "segment")
 	resolution := r.Form.Get("resolution")
 
+	audioTrack, err := ffmpeg.ParseAudioTrack(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	options := ffmpeg.StreamOptions{
 		StreamType: streamType,
 		VideoFile:  f,
 		Resolution: resolution,
 		Hash:       sceneHash,
 		Segment:    segment,
+		AudioTrack: audioTrack,
 	}
 
 	streamManager.ServeSegment(w, r, options)
@@ -448,12 +469,61 @@ func (rs sceneRoutes) Caption(w http.ResponseWriter, r *http.Request, lang strin
 	}
 }
 
+// EmbeddedCaption serves the embedded subtitle stream of the primary file
+// with the given index, converted to WebVTT.
+func (rs sceneRoutes) EmbeddedCaption(w http.ResponseWriter, r *http.Request, track string) {
+	s := r.Context().Value(sceneKey).(*models.Scene)
+
+	trackIndex, err := strconv.Atoi(track)
+	if err != nil {
+		http.Error(w, video.ErrInvalidSubtitleTrack.Error(), http.StatusBadRequest)
+		return
+	}
+
+	f := s.Files.Primary()
+	if f == nil {
+		http.Error(w, "scene has no file", http.StatusNotFound)
+		return
+	}
+
+	mgr := manager.GetInstance()
+	if mgr.FFMpeg == nil {
+		http.Error(w, "ffmpeg not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	lockCtx := mgr.ReadLockManager.ReadLock(r.Context(), f.Path)
+	defer lockCtx.Cancel()
+
+	vtt, err := manager.ExtractEmbeddedSubtitle(lockCtx, f, trackIndex)
+	if errors.Is(err, video.ErrInvalidSubtitleTrack) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		if !errors.Is(r.Context().Err(), context.Canceled) {
+			logger.Warnf("[caption] error extracting subtitle track %d of %s: %v", trackIndex, f.Path, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/vtt")
+	utils.ServeStaticContent(w, r, vtt)
+}
+
 func (rs sceneRoutes) CaptionLang(w http.ResponseWriter, r *http.Request) {
 	// serve caption based on lang query param, if provided
 	if err := r.ParseForm(); err != nil {
 		logger.Warnf("[caption] error parsing query form: %v", err)
 	}
 
+	// serve an embedded subtitle track if the track query param is provided
+	if track := r.Form.Get("track"); track != "" {
+		rs.EmbeddedCaption(w, r, track)
+		return
+	}
+
 	l := r.Form.Get("lang")
 	ext := r.Form.Get("type")
 	rs.Caption(w, r, l, ext)
@@ -593,3 +663,44 @@ func (rs sceneRoutes) SceneCtx(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// SceneHashCtx responds with not found if the scene with the hash in the URL
+// is hidden by the content restriction. Hashes are not checked when content
+// is not restricted, so that generated files can be served for any hash.
+func (rs sceneRoutes) SceneHashCtx(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if models.GetContentRestriction(r.Context()) == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		sceneHash := chi.URLParam(r, "sceneHash")
+
+		var scenes []*models.Scene
+		readTxnErr := rs.withReadTxn(r, func(ctx context.Context) error {
+			var err error
+			scenes, err = rs.sceneFinder.FindByChecksum(ctx, sceneHash)
+			if err != nil || len(scenes) > 0 {
+				return err
+			}
+
+			scenes, err = rs.sceneFinder.FindByOSHash(ctx, sceneHash)
+			return err
+		})
+		if errors.Is(readTxnErr, context.Canceled) {
+			return
+		}
+		if readTxnErr != nil {
+			logger.Warnf("read transaction error on fetch scene by hash: %v", readTxnErr)
+			http.Error(w, readTxnErr.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		if len(scenes) == 0 {
+			http.Error(w, http.StatusText(404), 404)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
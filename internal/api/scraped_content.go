@@ -76,6 +76,29 @@ func marshalScrapedGalleries(content []scraper.ScrapedContent) ([]*scraper.Scrap
 	return ret, nil
 }
 
+// marshalScrapedImages converts ScrapedContent into ScrapedImage. If
+// conversion fails, an error is returned.
+func marshalScrapedImages(content []scraper.ScrapedContent) ([]*scraper.ScrapedImage, error) {
+	var ret []*scraper.ScrapedImage
+	for _, c := range content {
+		if c == nil {
+			// graphql schema requires images to be non-nil
+			continue
+		}
+
+		switch i := c.(type) {
+		case *scraper.ScrapedImage:
+			ret = append(ret, i)
+		case scraper.ScrapedImage:
+			ret = append(ret, &i)
+		default:
+			return nil, fmt.Errorf("%w: cannot turn ScrapedContent into ScrapedImage", models.ErrConversion)
+		}
+	}
+
+	return ret, nil
+}
+
 // marshalScrapedMovies converts ScrapedContent into ScrapedMovie. If conversion
 // fails, an error is returned.
 func marshalScrapedMovies(content []scraper.ScrapedContent) ([]*models.ScrapedMovie, error) {
@@ -129,6 +152,20 @@ func marshalScrapedGallery(content scraper.ScrapedContent) (*scraper.ScrapedGall
 	return g[0], nil
 }
 
+// marshalScrapedImage will marshal a single scraped image
+func marshalScrapedImage(content scraper.ScrapedContent) (*scraper.ScrapedImage, error) {
+	i, err := marshalScrapedImages([]scraper.ScrapedContent{content})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(i) == 0 {
+		return nil, nil
+	}
+
+	return i[0], nil
+}
+
 // marshalScrapedMovie will marshal a single scraped movie
 func marshalScrapedMovie(content scraper.ScrapedContent) (*models.ScrapedMovie, error) {
 	m, err := marshalScrapedMovies([]scraper.ScrapedContent{content})
@@ -40,10 +40,13 @@ import (
 )
 
 const (
-	loginEndpoint      = "/login"
-	logoutEndpoint     = "/logout"
-	gqlEndpoint        = "/graphql"
-	playgroundEndpoint = "/playground"
+	loginEndpoint        = "/login"
+	oidcLoginEndpoint    = loginEndpoint + "/oidc"
+	oidcCallbackEndpoint = oidcLoginEndpoint + "/callback"
+	logoutEndpoint       = "/logout"
+	restrictEndpoint     = "/restriction"
+	gqlEndpoint          = "/graphql"
+	playgroundEndpoint   = "/playground"
 )
 
 type Server struct {
@@ -184,6 +187,7 @@ func Initialize() (*Server, error) {
 
 	gqlSrv.SetQueryCache(gqlLru.New(1000))
 	gqlSrv.Use(gqlExtension.Introspection{})
+	gqlSrv.AroundFields(authorizeFieldMiddleware)
 
 	gqlSrv.SetErrorPresenter(gqlErrorHandler)
 
@@ -222,7 +226,11 @@ func Initialize() (*Server, error) {
 
 	r.Get(loginEndpoint, handleLogin())
 	r.Post(loginEndpoint, handleLoginPost())
+	r.Get(oidcLoginEndpoint, handleOIDCLogin())
+	r.Get(oidcCallbackEndpoint, handleOIDCCallback())
 	r.Get(logoutEndpoint, handleLogout())
+	r.Post(restrictEndpoint+"/enter", handleEnterRestriction())
+	r.Post(restrictEndpoint+"/exit", handleExitRestriction())
 	r.HandleFunc(loginEndpoint+"/*", func(w http.ResponseWriter, r *http.Request) {
 		r.URL.Path = strings.TrimPrefix(r.URL.Path, loginEndpoint)
 		w.Header().Set("Cache-Control", "no-cache")
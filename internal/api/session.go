@@ -7,6 +7,8 @@ import (
 	"html/template"
 	"io/fs"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/stashapp/stash/internal/manager"
@@ -19,6 +21,25 @@ import (
 
 const returnURLParam = "returnURL"
 
+// getOIDCReturnURL returns returnURL if it is a relative path under the proxy
+// prefix. Otherwise, the root of the proxy prefix is returned, so that the
+// login cannot be used to redirect to another site.
+func getOIDCReturnURL(r *http.Request, returnURL string) string {
+	root := getProxyPrefix(r) + "/"
+
+	// browsers treat backslashes as slashes, so /\host is the same as //host
+	if !strings.HasPrefix(returnURL, root) || strings.HasPrefix(returnURL, "//") || strings.Contains(returnURL, "\\") {
+		return root
+	}
+
+	u, err := url.Parse(returnURL)
+	if err != nil || u.Scheme != "" || u.Host != "" {
+		return root
+	}
+
+	return returnURL
+}
+
 func getLoginPage() []byte {
 	data, err := fs.ReadFile(ui.LoginUIBox, "login.html")
 	if err != nil {
@@ -30,6 +51,8 @@ func getLoginPage() []byte {
 type loginTemplateData struct {
 	URL   string
 	Error string
+	// OIDC is true if the OpenID Connect login link should be shown
+	OIDC bool
 }
 
 func serveLoginPage(w http.ResponseWriter, r *http.Request, returnURL string, loginError string) {
@@ -44,7 +67,11 @@ func serveLoginPage(w http.ResponseWriter, r *http.Request, returnURL string, lo
 	}
 
 	buffer := bytes.Buffer{}
-	err = templ.Execute(&buffer, loginTemplateData{URL: returnURL, Error: loginError})
+	err = templ.Execute(&buffer, loginTemplateData{
+		URL:   returnURL,
+		Error: loginError,
+		OIDC:  manager.GetInstance().SessionStore.OIDCEnabled(),
+	})
 	if err != nil {
 		http.Error(w, fmt.Sprintf("error: %s", err), http.StatusInternalServerError)
 		return
@@ -62,7 +89,7 @@ func handleLogin() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		returnURL := r.URL.Query().Get(returnURLParam)
 
-		if !config.GetInstance().HasCredentials() {
+		if !config.GetInstance().AuthenticationRequired() {
 			if returnURL != "" {
 				http.Redirect(w, r, returnURL, http.StatusFound)
 			} else {
@@ -106,6 +133,47 @@ func handleLoginPost() http.HandlerFunc {
 	}
 }
 
+// handleOIDCLogin redirects to the OpenID Connect provider to log in.
+func handleOIDCLogin() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		returnURL := getOIDCReturnURL(r, r.URL.Query().Get(returnURLParam))
+
+		baseURL, _ := r.Context().Value(BaseURLCtxKey).(string)
+		redirectURL := baseURL + oidcCallbackEndpoint
+
+		authURL, err := manager.GetInstance().SessionStore.StartOIDCLogin(w, r, redirectURL, returnURL)
+		if err != nil {
+			logger.Errorf("Error starting OpenID Connect login: %v", err)
+			serveLoginPage(w, r, returnURL, "Unable to log in with single sign-on")
+			return
+		}
+
+		http.Redirect(w, r, authURL, http.StatusFound)
+	}
+}
+
+// handleOIDCCallback completes the OpenID Connect login when the provider
+// redirects back.
+func handleOIDCCallback() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		returnURL, err := manager.GetInstance().SessionStore.FinishOIDCLogin(w, r)
+		if err != nil {
+			// always log the error
+			logger.Errorf("Error logging in with OpenID Connect: %v", err)
+
+			loginError := "Unable to log in with single sign-on"
+			if errors.Is(err, session.ErrUnknownExternalUser) {
+				loginError = "No user account exists for the single sign-on user"
+			}
+
+			serveLoginPage(w, r, getProxyPrefix(r)+"/", loginError)
+			return
+		}
+
+		http.Redirect(w, r, getOIDCReturnURL(r, returnURL), http.StatusFound)
+	}
+}
+
 func handleLogout() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if err := manager.GetInstance().SessionStore.Logout(w, r); err != nil {
@@ -115,10 +183,58 @@ func handleLogout() http.HandlerFunc {
 
 		// redirect to the login page if credentials are required
 		prefix := getProxyPrefix(r)
-		if config.GetInstance().HasCredentials() {
+		if config.GetInstance().AuthenticationRequired() {
 			http.Redirect(w, r, prefix+loginEndpoint, http.StatusFound)
 		} else {
 			http.Redirect(w, r, prefix+"/", http.StatusFound)
 		}
 	}
 }
+
+// handleEnterRestriction applies the restriction profile in the profile_id
+// form value to the current session.
+func handleEnterRestriction() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		profileID, err := strconv.Atoi(r.FormValue("profile_id"))
+		if err != nil {
+			http.Error(w, "invalid profile_id", http.StatusBadRequest)
+			return
+		}
+
+		err = manager.GetInstance().SessionStore.EnterRestrictionProfile(w, r, profileID)
+		if errors.Is(err, session.ErrRestricted) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		if err != nil {
+			logger.Errorf("Error applying restriction profile: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// handleExitRestriction removes the restriction profile from the current
+// session if the pin form value matches the PIN of the profile.
+func handleExitRestriction() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		user := session.GetCurrentUser(r.Context())
+
+		err := manager.GetInstance().SessionStore.ExitRestrictionProfile(w, r, user, r.FormValue("pin"))
+		switch {
+		case errors.Is(err, session.ErrInvalidPIN), errors.Is(err, session.ErrRestrictionLocked):
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		case errors.Is(err, session.ErrPINLockedOut):
+			http.Error(w, err.Error(), http.StatusTooManyRequests)
+			return
+		case err != nil:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,37 @@
+package api
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOIDCReturnURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		prefix    string
+		returnURL string
+		want      string
+	}{
+		{"empty", "", "", "/"},
+		{"relative path", "", "/scenes?q=1", "/scenes?q=1"},
+		{"absolute url", "", "https://example.com/", "/"},
+		{"protocol relative", "", "//example.com/", "/"},
+		{"backslash", "", "/\\example.com/", "/"},
+		{"no leading slash", "", "scenes", "/"},
+		{"under prefix", "/stash", "/stash/scenes", "/stash/scenes"},
+		{"outside prefix", "/stash", "/other", "/stash/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/login/oidc", nil)
+			if tt.prefix != "" {
+				r.Header.Set("X-Forwarded-Prefix", tt.prefix)
+			}
+
+			assert.Equal(t, tt.want, getOIDCReturnURL(r, tt.returnURL))
+		})
+	}
+}
@@ -8,3 +8,7 @@ import (
 func stashIDsSliceToPtrSlice(v []models.StashID) []*models.StashID {
 	return sliceutil.ValuesToPtrs(v)
 }
+
+func customFieldsPtrSliceToSlice(v []*models.CustomField) []models.CustomField {
+	return sliceutil.PtrsToValues(v)
+}
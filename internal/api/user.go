@@ -0,0 +1,25 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+// currentUser returns the local user account of the current user, or nil if
+// the current user is the configured user.
+func currentUser(ctx context.Context) *models.User {
+	return session.GetCurrentUser(ctx)
+}
+
+// sceneActivity returns the store used to read and write the scene play and
+// o history of the current user. Local user accounts have their own history,
+// while the configured user uses the history stored against the scenes.
+func (r *Resolver) sceneActivity(ctx context.Context) models.SceneActivityReaderWriter {
+	if u := currentUser(ctx); u != nil {
+		return r.repository.SceneUserData.ForUser(u.ID)
+	}
+
+	return r.repository.Scene
+}
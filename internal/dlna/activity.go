@@ -0,0 +1,158 @@
+package dlna
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// playSessionTimeout is the time after the last request for a scene from a
+// client after which further requests are considered a new play.
+const playSessionTimeout = 30 * time.Minute
+
+type activityConfig interface {
+	GetDLNAMinimumPlayPercent() int
+}
+
+type playSessionKey struct {
+	sceneID    int
+	client     string
+	transcoded bool
+}
+
+type playSession struct {
+	// number of bytes of the scene file sent to the client, or the duration
+	// of the transcoded stream sent to the client
+	streamed int64
+	counted  bool
+	lastSeen time.Time
+}
+
+// playTracker increments the play count of scenes once enough of the scene
+// file has been streamed to a client. Clients typically stream a file using
+// many range requests, so the number of bytes sent is accumulated per client
+// and scene.
+type playTracker struct {
+	repository Repository
+	config     activityConfig
+
+	mutex    sync.Mutex
+	sessions map[playSessionKey]*playSession
+}
+
+func newPlayTracker(repository Repository, config activityConfig) *playTracker {
+	return &playTracker{
+		repository: repository,
+		config:     config,
+		sessions:   make(map[playSessionKey]*playSession),
+	}
+}
+
+// addStreamed records that n bytes of the scene file were sent to the client
+// of the request. Returns true if a play should be counted for the scene.
+func (t *playTracker) addStreamed(r *http.Request, sceneID int, fileSize int64, n int64) bool {
+	return t.add(r, playSessionKey{sceneID: sceneID}, fileSize, n)
+}
+
+// addTranscoded records that d of a transcoded stream of the scene was sent
+// to the client of the request. Returns true if a play should be counted for
+// the scene.
+func (t *playTracker) addTranscoded(r *http.Request, sceneID int, duration time.Duration, d time.Duration) bool {
+	return t.add(r, playSessionKey{sceneID: sceneID, transcoded: true}, int64(duration), int64(d))
+}
+
+func (t *playTracker) add(r *http.Request, key playSessionKey, total int64, n int64) bool {
+	minimumPercent := t.config.GetDLNAMinimumPlayPercent()
+	if minimumPercent <= 0 || total <= 0 || n <= 0 {
+		return false
+	}
+
+	client, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		client = r.RemoteAddr
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	now := time.Now()
+	t.removeExpired(now)
+
+	key.client = client
+	session := t.sessions[key]
+	if session == nil {
+		session = &playSession{}
+		t.sessions[key] = session
+	}
+
+	session.streamed += n
+	session.lastSeen = now
+
+	if session.counted || session.streamed*100 < total*int64(minimumPercent) {
+		return false
+	}
+
+	session.counted = true
+	return true
+}
+
+func (t *playTracker) removeExpired(now time.Time) {
+	for k, s := range t.sessions {
+		if now.Sub(s.lastSeen) > playSessionTimeout {
+			delete(t.sessions, k)
+		}
+	}
+}
+
+// trackStream records the bytes written by the stream handler, and adds a
+// play to the scene if the minimum play percentage has been reached.
+func (t *playTracker) trackStream(w http.ResponseWriter, r *http.Request, sceneID int, fileSize int64, stream func(w http.ResponseWriter)) {
+	cw := &countingResponseWriter{ResponseWriter: w}
+	stream(cw)
+
+	if !t.addStreamed(r, sceneID, fileSize, cw.written) {
+		return
+	}
+
+	t.addPlay(sceneID)
+}
+
+// trackTranscode records the time spent streaming a transcode of the scene,
+// and adds a play to the scene if the minimum play percentage of the scene
+// duration has been reached. The size of transcoded streams is not known, and
+// renderers read them at about the playback speed.
+func (t *playTracker) trackTranscode(r *http.Request, sceneID int, duration float64, stream func()) {
+	start := time.Now()
+	stream()
+
+	if !t.addTranscoded(r, sceneID, time.Duration(duration*float64(time.Second)), time.Since(start)) {
+		return
+	}
+
+	t.addPlay(sceneID)
+}
+
+func (t *playTracker) addPlay(sceneID int) {
+	// the request context is likely cancelled if the client stopped playback
+	if err := t.repository.WithTxn(context.Background(), func(ctx context.Context) error {
+		_, err := t.repository.SceneActivity.AddViews(ctx, sceneID, nil)
+		return err
+	}); err != nil {
+		logger.Warnf("error adding play for scene %d: %v", sceneID, err)
+	}
+}
+
+type countingResponseWriter struct {
+	http.ResponseWriter
+	written int64
+}
+
+func (w *countingResponseWriter) Write(b []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(b)
+	w.written += int64(n)
+	return n, err
+}
@@ -0,0 +1,87 @@
+package dlna
+
+import (
+	"encoding/xml"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type testActivityConfig int
+
+func (c testActivityConfig) GetDLNAMinimumPlayPercent() int {
+	return int(c)
+}
+
+func TestPlayTrackerAddStreamed(t *testing.T) {
+	const fileSize = 1000
+
+	tracker := newPlayTracker(Repository{}, testActivityConfig(50))
+
+	r := httptest.NewRequest("GET", "/res?scene=1", nil)
+	r.RemoteAddr = "192.168.1.2:1234"
+
+	other := httptest.NewRequest("GET", "/res?scene=1", nil)
+	other.RemoteAddr = "192.168.1.3:1234"
+
+	if tracker.addStreamed(r, 1, fileSize, 300) {
+		t.Errorf("addStreamed() counted play below minimum percent")
+	}
+	if tracker.addStreamed(other, 1, fileSize, 300) {
+		t.Errorf("addStreamed() counted play from other client")
+	}
+	if !tracker.addStreamed(r, 1, fileSize, 200) {
+		t.Errorf("addStreamed() did not count play at minimum percent")
+	}
+	if tracker.addStreamed(r, 1, fileSize, 500) {
+		t.Errorf("addStreamed() counted play twice")
+	}
+
+	disabled := newPlayTracker(Repository{}, testActivityConfig(0))
+	if disabled.addStreamed(r, 1, fileSize, fileSize) {
+		t.Errorf("addStreamed() counted play when disabled")
+	}
+}
+
+func TestPlayTrackerAddTranscoded(t *testing.T) {
+	const duration = 10 * time.Minute
+
+	tracker := newPlayTracker(Repository{}, testActivityConfig(50))
+
+	r := httptest.NewRequest("GET", "/res?scene=1&transcode=mp4", nil)
+	r.RemoteAddr = "192.168.1.2:1234"
+
+	// bytes of direct streams are not added to the transcoded duration
+	if tracker.addStreamed(r, 1, int64(duration), int64(4*time.Minute)) {
+		t.Errorf("addStreamed() counted play below minimum percent")
+	}
+	if tracker.addTranscoded(r, 1, duration, 4*time.Minute) {
+		t.Errorf("addTranscoded() counted play below minimum percent")
+	}
+	if !tracker.addTranscoded(r, 1, duration, time.Minute) {
+		t.Errorf("addTranscoded() did not count play at minimum percent")
+	}
+}
+
+func TestSceneToContainerBookmark(t *testing.T) {
+	scene := &models.Scene{
+		ID:         1,
+		ResumeTime: 90.5,
+		Files:      models.NewRelatedVideoFiles(nil),
+	}
+
+	data, err := xml.Marshal(sceneToContainer(scene, "0", &cdsClient{host: "localhost"}))
+	if err != nil {
+		t.Fatalf("marshalling scene: %v", err)
+	}
+
+	got := string(data)
+	for _, want := range []string{"<item ", "<sec:dcmInfo>BM=90</sec:dcmInfo>", "<upnp:lastPlaybackPosition>0:01:30</upnp:lastPlaybackPosition>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("sceneToContainer() = %s, want to contain %s", got, want)
+		}
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"context"
 	"encoding/xml"
 	"fmt"
+	"mime"
 	"net/http"
 	"net/url"
 	"os"
@@ -37,6 +38,7 @@ import (
 	"github.com/anacrolix/dms/dlna"
 	"github.com/anacrolix/dms/upnp"
 	"github.com/anacrolix/dms/upnpav"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
@@ -45,6 +47,9 @@ import (
 
 var pageSize = 100
 
+// prefix of the object IDs of images, to distinguish them from scene IDs
+const imageObjectPrefix = "images/"
+
 type browse struct {
 	ObjectID       string
 	BrowseFlag     string
@@ -53,6 +58,34 @@ type browse struct {
 	RequestedCount int
 }
 
+type search struct {
+	ContainerID    string
+	SearchCriteria string
+	Filter         string
+	StartingIndex  int
+	RequestedCount int
+}
+
+type setBookmark struct {
+	ObjectID  string
+	PosSecond int64
+}
+
+// videoItem is a video item with the resume point of the scene, using the
+// properties supported by Samsung and LG clients.
+type videoItem struct {
+	upnpav.Item
+	DcmInfo              string `xml:"sec:dcmInfo,omitempty"`
+	LastPlaybackPosition string `xml:"upnp:lastPlaybackPosition,omitempty"`
+}
+
+// cdsClient is the renderer that made a ContentDirectory request.
+type cdsClient struct {
+	// host used by the renderer to reach the server
+	host    string
+	profile *RendererProfile
+}
+
 type contentDirectoryService struct {
 	*Server
 	upnp.Eventing
@@ -76,12 +109,12 @@ func (me *contentDirectoryService) updateIDString() string {
 	return fmt.Sprintf("%d", uint32(os.Getpid()))
 }
 
-func sceneToContainer(scene *models.Scene, parent string, host string) interface{} {
+func sceneToContainer(scene *models.Scene, parent string, client *cdsClient) interface{} {
 	// make stash server URL
 	// TODO - fix this
 	iconURI := (&url.URL{
 		Scheme: "http",
-		Host:   host,
+		Host:   client.host,
 		Path:   iconPath,
 		RawQuery: url.Values{
 			"scene": {strconv.Itoa(scene.ID)},
@@ -119,29 +152,139 @@ func sceneToContainer(scene *models.Scene, parent string, host string) interface
 		duration = int64(f.Duration)
 	}
 
-	item.Res = append(item.Res, upnpav.Resource{
-		URL: (&url.URL{
-			Scheme: "http",
-			Host:   host,
-			Path:   resPath,
-			RawQuery: url.Values{
-				"scene": {strconv.Itoa(scene.ID)},
-			}.Encode(),
-		}).String(),
-		ProtocolInfo: fmt.Sprintf("http-get:*:%s:%s", mimeType, dlna.ContentFeatures{
-			SupportRange: true,
-		}.String()),
-		Bitrate:  bitrate,
-		Duration: formatDurationSexagesimal(time.Duration(duration) * time.Second),
-		Size:     uint64(size),
-		// Resolution: resolution,
-	})
+	if f == nil || client.profile.canPlay(f) {
+		item.Res = append(item.Res, upnpav.Resource{
+			URL: makeSceneResURL(client.host, scene.ID, ""),
+			ProtocolInfo: fmt.Sprintf("http-get:*:%s:%s", mimeType, dlna.ContentFeatures{
+				SupportRange: true,
+			}.String()),
+			Bitrate:  bitrate,
+			Duration: formatDurationSexagesimal(time.Duration(duration) * time.Second),
+			Size:     uint64(size),
+			// Resolution: resolution,
+		})
+	} else {
+		// the renderer can't play the file, so advertise a live transcode
+		// instead. The size of the transcoded stream is not known.
+		format := client.profile.getTranscodeFormat()
+		item.Res = append(item.Res, upnpav.Resource{
+			URL: makeSceneResURL(client.host, scene.ID, format),
+			ProtocolInfo: fmt.Sprintf("http-get:*:%s:%s", transcodeFormats[format].MimeType, dlna.ContentFeatures{
+				SupportTimeSeek: true,
+				Transcoded:      true,
+			}.String()),
+			Duration: formatDurationSexagesimal(time.Duration(duration) * time.Second),
+		})
+	}
 
 	item.Res = append(item.Res, upnpav.Resource{
 		URL:          iconURI,
 		ProtocolInfo: "http-get:*:image/jpeg:DLNA.ORG_PN=JPEG_MED",
 	})
 
+	ret := videoItem{
+		Item: item,
+	}
+
+	if resumeTime := int64(scene.ResumeTime); resumeTime > 0 {
+		ret.DcmInfo = fmt.Sprintf("BM=%d", resumeTime)
+		ret.LastPlaybackPosition = formatDurationSexagesimal(time.Duration(resumeTime) * time.Second)
+	}
+
+	return ret
+}
+
+// makeSceneResURL returns the URL of the scene file. If transcodeFormat is
+// set, then the URL is of a live transcode of the file in that format.
+func makeSceneResURL(host string, sceneID int, transcodeFormat string) string {
+	query := url.Values{
+		"scene": {strconv.Itoa(sceneID)},
+	}
+	if transcodeFormat != "" {
+		query.Set("transcode", transcodeFormat)
+	}
+
+	return (&url.URL{
+		Scheme:   "http",
+		Host:     host,
+		Path:     resPath,
+		RawQuery: query.Encode(),
+	}).String()
+}
+
+func makeImageURL(host string, path string, imageID int) string {
+	return (&url.URL{
+		Scheme: "http",
+		Host:   host,
+		Path:   path,
+		RawQuery: url.Values{
+			"image": {strconv.Itoa(imageID)},
+		}.Encode(),
+	}).String()
+}
+
+func imageToContainer(image *models.Image, parent string, client *cdsClient) interface{} {
+	thumbnailURI := makeImageURL(client.host, imageThumbnailPath, image.ID)
+
+	class := "object.item.imageItem.photo"
+	mimeType := "image/jpeg"
+	var (
+		size       uint64
+		resolution string
+	)
+
+	f := image.Files.Primary()
+	if f != nil {
+		size = uint64(f.Base().Size)
+		if vf, ok := f.(models.VisualFile); ok && vf.GetWidth() > 0 && vf.GetHeight() > 0 {
+			resolution = fmt.Sprintf("%dx%d", vf.GetWidth(), vf.GetHeight())
+		}
+
+		if t := mime.TypeByExtension(filepath.Ext(f.Base().Basename)); t != "" {
+			mimeType = t
+		}
+
+		// animated clips are stored as video files
+		if _, isVideo := f.(*models.VideoFile); isVideo {
+			class = "object.item.videoItem"
+		}
+	}
+
+	obj := upnpav.Object{
+		ID:          imageObjectPrefix + strconv.Itoa(image.ID),
+		Restricted:  1,
+		ParentID:    parent,
+		Title:       image.GetTitle(),
+		Class:       class,
+		Icon:        thumbnailURI,
+		AlbumArtURI: thumbnailURI,
+	}
+
+	item := upnpav.Item{
+		Object: obj,
+		Res:    make([]upnpav.Resource, 0, 2),
+	}
+
+	contentFeatures := dlna.ContentFeatures{
+		SupportRange: true,
+	}
+	if mimeType == "image/jpeg" {
+		contentFeatures.ProfileName = "JPEG_LRG"
+	}
+
+	item.Res = append(item.Res, upnpav.Resource{
+		URL:          makeImageURL(client.host, imagePath, image.ID),
+		ProtocolInfo: fmt.Sprintf("http-get:*:%s:%s", mimeType, contentFeatures.String()),
+		Size:         size,
+		Resolution:   resolution,
+	})
+
+	// generated thumbnails are always jpeg
+	item.Res = append(item.Res, upnpav.Resource{
+		URL:          thumbnailURI,
+		ProtocolInfo: "http-get:*:image/jpeg:DLNA.ORG_PN=JPEG_TN",
+	})
+
 	return item
 }
 
@@ -172,9 +315,15 @@ func childPath(paths []string) []string {
 	return nil
 }
 
+func (me *contentDirectoryService) newClient(r *http.Request) *cdsClient {
+	return &cdsClient{
+		host:    r.Host,
+		profile: me.rendererProfile(r),
+	}
+}
+
 func (me *contentDirectoryService) Handle(action string, argsXML []byte, r *http.Request) (map[string]string, error) {
-	host := r.Host
-	// userAgent := r.UserAgent()
+	client := me.newClient(r)
 	switch action {
 	case "GetSystemUpdateID":
 		return map[string]string{
@@ -197,16 +346,23 @@ func (me *contentDirectoryService) Handle(action string, argsXML []byte, r *http
 
 		switch browse.BrowseFlag {
 		case "BrowseDirectChildren":
-			return me.handleBrowseDirectChildren(obj, host)
+			return me.handleBrowseDirectChildren(obj, client)
 		case "BrowseMetadata":
-			return me.handleBrowseMetadata(obj, host)
+			return me.handleBrowseMetadata(obj, client)
 		default:
 			return nil, upnp.Errorf(upnp.ArgumentValueInvalidErrorCode, "unhandled browse flag: %v", browse.BrowseFlag)
 		}
 	case "GetSearchCapabilities":
 		return map[string]string{
-			"SearchCaps": "",
+			"SearchCaps": searchCapabilities,
 		}, nil
+	case "Search":
+		var search search
+		if err := xml.Unmarshal([]byte(argsXML), &search); err != nil {
+			return nil, upnp.Errorf(upnp.ArgumentValueInvalidErrorCode, "cannot unmarshal search argument: %s", err.Error())
+		}
+
+		return me.handleSearch(search, client)
 	// from https://github.com/rclone/rclone/blob/master/cmd/serve/dlna/cds.go
 	// Samsung Extensions
 	case "X_GetFeatureList":
@@ -219,14 +375,54 @@ func (me *contentDirectoryService) Handle(action string, argsXML []byte, r *http
 	</Feature>
 	</Features>`}, nil
 	case "X_SetBookmark":
-		// just ignore
-		return map[string]string{}, nil
+		var bookmark setBookmark
+		if err := xml.Unmarshal([]byte(argsXML), &bookmark); err != nil {
+			return nil, upnp.Errorf(upnp.ArgumentValueInvalidErrorCode, "cannot unmarshal bookmark argument: %s", err.Error())
+		}
+
+		return me.handleSetBookmark(bookmark)
 	default:
 		return nil, upnp.InvalidActionError
 	}
 }
 
-func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host string) (map[string]string, error) {
+// handleSetBookmark sets the resume point of the scene to the position
+// reported by the client.
+func (me *contentDirectoryService) handleSetBookmark(bookmark setBookmark) (map[string]string, error) {
+	sceneID, err := strconv.Atoi(bookmark.ObjectID)
+	if err != nil {
+		return nil, upnp.Errorf(upnpav.NoSuchObjectErrorCode, "invalid object id: %s", bookmark.ObjectID)
+	}
+
+	if bookmark.PosSecond < 0 {
+		return nil, upnp.Errorf(upnp.ArgumentValueInvalidErrorCode, "invalid position: %d", bookmark.PosSecond)
+	}
+
+	var scene *models.Scene
+	resumeTime := float64(bookmark.PosSecond)
+
+	r := me.repository
+	if err := r.WithTxn(context.TODO(), func(ctx context.Context) error {
+		scene, err = r.SceneFinder.Find(ctx, sceneID)
+		if err != nil || scene == nil {
+			return err
+		}
+
+		_, err = r.SceneActivity.SaveActivity(ctx, sceneID, &resumeTime, nil)
+		return err
+	}); err != nil {
+		logger.Errorf("error setting bookmark for scene %d: %v", sceneID, err)
+		return nil, upnp.Errorf(upnp.ActionFailedErrorCode, "error setting bookmark")
+	}
+
+	if scene == nil {
+		return nil, upnp.Errorf(upnpav.NoSuchObjectErrorCode, "scene not found: %s", bookmark.ObjectID)
+	}
+
+	return map[string]string{}, nil
+}
+
+func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, client *cdsClient) (map[string]string, error) {
 	// Read folder and return children
 	// TODO: check if obj == 0 and return root objects
 	// TODO: check if special path and return files
@@ -241,53 +437,24 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 
 	// All videos
 	if obj.Path == "all" {
-		objs = me.getAllScenes(host)
+		objs = me.getAllScenes(client)
 	}
 
 	if strings.HasPrefix(obj.Path, "all/") {
 		page := getPageFromID(paths)
 		if page != nil {
-			objs = me.getPageVideos(&models.SceneFilterType{}, "all", *page, host)
-		}
-	}
-
-	// Saved searches
-	// if obj.Path == "saved-searches" {
-	// 	var savedPlaylists []models.Playlist
-	// 	db, _ := models.GetDB()
-	// 	db.Where("is_deo_enabled = ?", true).Order("ordering asc").Find(&savedPlaylists)
-	// 	db.Close()
-
-	// 	for _, playlist := range savedPlaylists {
-	// 		objs = append(objs, upnpav.Container{Object: upnpav.Object{
-	// 			ID:         "saved-searches/" + strconv.Itoa(int(playlist.ID)),
-	// 			Restricted: 1,
-	// 			ParentID:   "saved-searches",
-	// 			Class:      "object.container.storageFolder",
-	// 			Title:      playlist.Name,
-	// 		}})
-	// 	}
-	// }
-
-	// if strings.HasPrefix(obj.Path, "saved-searches/") {
-	// 	id := strings.Split(obj.Path, "/")
-
-	// 	var savedPlaylist models.Playlist
-	// 	db, _ := models.GetDB()
-	// 	db.Where("id = ?", id[1]).First(&savedPlaylist)
-	// 	db.Close()
+			objs = me.getPageVideos(&models.SceneFilterType{}, "all", *page, client)
+		}
+	}
 
-	// 	var r models.RequestSceneList
-	// 	if err := json.Unmarshal([]byte(savedPlaylist.SearchParams), &r); err == nil {
-	// 		r.IsAccessible = optional.NewBool(true)
-	// 		r.IsAvailable = optional.NewBool(true)
-	// 		data := models.QueryScenesFull(r)
+	// Saved filters
+	if obj.Path == "savedfilters" {
+		objs = me.getSavedFilters()
+	}
 
-	// 		for i := range data.Scenes {
-	// 			objs = append(objs, me.sceneToContainer(data.Scenes[i], "sites/"+id[1], host))
-	// 		}
-	// 	}
-	// }
+	if strings.HasPrefix(obj.Path, "savedfilters/") {
+		objs = me.getSavedFilterScenes(childPath(paths), client)
+	}
 
 	// Studios
 	if obj.Path == "studios" {
@@ -295,7 +462,7 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 	}
 
 	if strings.HasPrefix(obj.Path, "studios/") {
-		objs = me.getStudioScenes(childPath(paths), host)
+		objs = me.getStudioScenes(childPath(paths), client)
 	}
 
 	// Tags
@@ -304,7 +471,7 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 	}
 
 	if strings.HasPrefix(obj.Path, "tags/") {
-		objs = me.getTagScenes(childPath(paths), host)
+		objs = me.getTagScenes(childPath(paths), client)
 	}
 
 	// Performers
@@ -313,7 +480,7 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 	}
 
 	if strings.HasPrefix(obj.Path, "performers/") {
-		objs = me.getPerformerScenes(childPath(paths), host)
+		objs = me.getPerformerScenes(childPath(paths), client)
 	}
 
 	// Groups - deprecated
@@ -322,7 +489,14 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 	}
 
 	if strings.HasPrefix(obj.Path, "groups/") {
-		objs = me.getGroupScenes(childPath(paths), host)
+		objs = me.getGroupScenes(childPath(paths), client)
+	}
+
+	// Galleries
+	if obj.Path == "galleries" || strings.HasPrefix(obj.Path, "galleries/page/") {
+		objs = me.getGalleries(getPageFromID(paths))
+	} else if strings.HasPrefix(obj.Path, "galleries/") {
+		objs = me.getGalleryImages(childPath(paths), client)
 	}
 
 	// Rating
@@ -331,16 +505,20 @@ func (me *contentDirectoryService) handleBrowseDirectChildren(obj object, host s
 	}
 
 	if strings.HasPrefix(obj.Path, "rating/") {
-		objs = me.getRatingScenes(childPath(paths), host)
+		objs = me.getRatingScenes(childPath(paths), client)
 	}
 
 	return makeBrowseResult(objs, me.updateIDString())
 }
 
-func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string) (map[string]string, error) {
+func (me *contentDirectoryService) handleBrowseMetadata(obj object, client *cdsClient) (map[string]string, error) {
 	var objs []interface{}
 	var updateID string
 
+	if strings.HasPrefix(obj.Path, imageObjectPrefix) {
+		return me.handleBrowseImageMetadata(obj, client)
+	}
+
 	// if numeric, then must be scene, otherwise handle as if path
 	sceneID, err := strconv.Atoi(obj.Path)
 	if err != nil {
@@ -375,7 +553,7 @@ func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string)
 		}
 
 		if scene != nil {
-			upnpObject := sceneToContainer(scene, "-1", host)
+			upnpObject := sceneToContainer(scene, "-1", client)
 			objs = []interface{}{upnpObject}
 
 			// http://upnp.org/specs/av/UPnP-av-ContentDirectory-v1-Service.pdf
@@ -390,6 +568,148 @@ func (me *contentDirectoryService) handleBrowseMetadata(obj object, host string)
 	return makeBrowseResult(objs, updateID)
 }
 
+func (me *contentDirectoryService) handleBrowseImageMetadata(obj object, client *cdsClient) (map[string]string, error) {
+	imageID, err := strconv.Atoi(strings.TrimPrefix(obj.Path, imageObjectPrefix))
+	if err != nil {
+		return nil, upnp.Errorf(upnpav.NoSuchObjectErrorCode, "image not found")
+	}
+
+	var img *models.Image
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		img, err = r.ImageFinder.Find(ctx, imageID)
+		if img != nil {
+			err = img.LoadPrimaryFile(ctx, r.FileGetter)
+		}
+
+		return err
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	if img == nil {
+		return nil, upnp.Errorf(upnpav.NoSuchObjectErrorCode, "image not found")
+	}
+
+	objs := []interface{}{imageToContainer(img, "-1", client)}
+
+	const maxUpdateID int64 = 1 << 32
+	updateID := fmt.Sprint(img.UpdatedAt.Unix() % maxUpdateID)
+
+	return makeBrowseResult(objs, updateID)
+}
+
+// handleSearch returns the scenes matching the search criteria. Only
+// scenes can be searched, so the search container is ignored.
+func (me *contentDirectoryService) handleSearch(search search, client *cdsClient) (map[string]string, error) {
+	sf, err := parseSearchCriteria(search.SearchCriteria)
+	if err != nil {
+		return nil, upnp.Errorf(upnp.ArgumentValueInvalidErrorCode, err.Error())
+	}
+
+	var objs []interface{}
+	total := 0
+
+	if !sf.none {
+		sceneFilter := sf.filter
+		if sceneFilter == nil {
+			sceneFilter = &models.SceneFilterType{}
+		}
+
+		count := search.RequestedCount
+		if count <= 0 {
+			count = pageSize
+		}
+		start := search.StartingIndex
+		if start < 0 {
+			start = 0
+		}
+
+		r := me.repository
+		if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+			if err := sf.resolveExclusions(ctx, r); err != nil {
+				return err
+			}
+
+			scenes, n, err := me.findSceneRange(ctx, sceneFilter, start, count)
+			if err != nil {
+				return err
+			}
+
+			total = n
+			for _, s := range scenes {
+				if err := s.LoadPrimaryFile(ctx, r.FileGetter); err != nil {
+					return err
+				}
+
+				objs = append(objs, sceneToContainer(s, search.ContainerID, client))
+			}
+
+			return nil
+		}); err != nil {
+			logger.Errorf("searching scenes: %v", err)
+			return nil, upnp.Errorf(upnp.ActionFailedErrorCode, err.Error())
+		}
+	}
+
+	result, err := xml.Marshal(objs)
+	if err != nil {
+		return nil, upnp.Errorf(upnp.ActionFailedErrorCode, "could not marshal objects: %s", err.Error())
+	}
+
+	return map[string]string{
+		"TotalMatches":   fmt.Sprint(total),
+		"NumberReturned": fmt.Sprint(len(objs)),
+		"Result":         didl_lite(string(result)),
+		"UpdateID":       me.updateIDString(),
+	}, nil
+}
+
+// findSceneRange returns count scenes matching sceneFilter, starting at the
+// scene with index start, and the total number of matching scenes. The find
+// filter is page based, so the scenes are read from the pages of size count
+// that contain the range.
+func (me *contentDirectoryService) findSceneRange(ctx context.Context, sceneFilter *models.SceneFilterType, start int, count int) ([]*models.Scene, int, error) {
+	r := me.repository
+
+	page := start/count + 1
+	offset := start % count
+
+	findFilter := me.videoFindFilter(sceneFilter)
+	findFilter.Page = &page
+	findFilter.PerPage = &count
+
+	scenes, total, err := scene.QueryWithCount(ctx, r.SceneFinder, sceneFilter, &findFilter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if offset >= len(scenes) {
+		return nil, total, nil
+	}
+	scenes = scenes[offset:]
+
+	// the range continues on the next page
+	if offset > 0 && page*count < total {
+		nextPage := page + 1
+		findFilter.Page = &nextPage
+
+		next, err := scene.Query(ctx, r.SceneFinder, sceneFilter, &findFilter)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		scenes = append(scenes, next...)
+	}
+
+	if len(scenes) > count {
+		scenes = scenes[:count]
+	}
+
+	return scenes, total, nil
+}
+
 func makeBrowseResult(objs []interface{}, updateID string) (map[string]string, error) {
 	result, err := xml.Marshal(objs)
 	if err != nil {
@@ -434,6 +754,8 @@ func getRootObjects() []interface{} {
 	objs = append(objs, makeStorageFolder("tags", "tags", rootID))
 	objs = append(objs, makeStorageFolder("studios", "studios", rootID))
 	objs = append(objs, makeStorageFolder("groups", "groups", rootID))
+	objs = append(objs, makeStorageFolder("galleries", "galleries", rootID))
+	objs = append(objs, makeStorageFolder("savedfilters", "saved filters", rootID))
 	objs = append(objs, makeStorageFolder("rating", "rating", rootID))
 
 	return objs
@@ -448,20 +770,33 @@ func getSortDirection(sceneFilter *models.SceneFilterType, sort string) models.S
 	return direction
 }
 
-func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType, parentID string, host string) []interface{} {
+func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType, parentID string, client *cdsClient) []interface{} {
+	return me.getFilteredVideos(sceneFilter, me.videoFindFilter(sceneFilter), parentID, client)
+}
+
+// videoFindFilter returns the find filter for scenes using the configured
+// sort order.
+func (me *contentDirectoryService) videoFindFilter(sceneFilter *models.SceneFilterType) models.FindFilterType {
+	sort := me.VideoSortOrder
+	direction := getSortDirection(sceneFilter, sort)
+	return models.FindFilterType{
+		Sort:      &sort,
+		Direction: &direction,
+	}
+}
+
+// getFilteredVideos returns the scenes matching the filters, or page folders
+// if there are more scenes than fit on a page. The page settings of
+// findFilter are ignored.
+func (me *contentDirectoryService) getFilteredVideos(sceneFilter *models.SceneFilterType, findFilter models.FindFilterType, parentID string, client *cdsClient) []interface{} {
 	var objs []interface{}
 
 	r := me.repository
 	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
-		sort := me.VideoSortOrder
-		direction := getSortDirection(sceneFilter, sort)
-		findFilter := &models.FindFilterType{
-			PerPage:   &pageSize,
-			Sort:      &sort,
-			Direction: &direction,
-		}
+		findFilter.PerPage = &pageSize
+		findFilter.Page = nil
 
-		scenes, total, err := scene.QueryWithCount(ctx, r.SceneFinder, sceneFilter, findFilter)
+		scenes, total, err := scene.QueryWithCount(ctx, r.SceneFinder, sceneFilter, &findFilter)
 		if err != nil {
 			return err
 		}
@@ -469,6 +804,7 @@ func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType
 		if total > pageSize {
 			pager := scenePager{
 				sceneFilter: sceneFilter,
+				q:           findFilter.Q,
 				parentID:    parentID,
 			}
 
@@ -482,7 +818,7 @@ func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType
 					return err
 				}
 
-				objs = append(objs, sceneToContainer(s, parentID, host))
+				objs = append(objs, sceneToContainer(s, parentID, client))
 			}
 		}
 
@@ -494,20 +830,23 @@ func (me *contentDirectoryService) getVideos(sceneFilter *models.SceneFilterType
 	return objs
 }
 
-func (me *contentDirectoryService) getPageVideos(sceneFilter *models.SceneFilterType, parentID string, page int, host string) []interface{} {
+func (me *contentDirectoryService) getPageVideos(sceneFilter *models.SceneFilterType, parentID string, page int, client *cdsClient) []interface{} {
+	return me.getFilteredPageVideos(sceneFilter, me.videoFindFilter(sceneFilter), parentID, page, client)
+}
+
+func (me *contentDirectoryService) getFilteredPageVideos(sceneFilter *models.SceneFilterType, findFilter models.FindFilterType, parentID string, page int, client *cdsClient) []interface{} {
 	var objs []interface{}
 
 	r := me.repository
 	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
 		pager := scenePager{
 			sceneFilter: sceneFilter,
+			q:           findFilter.Q,
 			parentID:    parentID,
 		}
 
-		sort := me.VideoSortOrder
-		direction := getSortDirection(sceneFilter, sort)
 		var err error
-		objs, err = pager.getPageVideos(ctx, r.SceneFinder, r.FileGetter, page, host, sort, direction)
+		objs, err = pager.getPageVideos(ctx, r.SceneFinder, r.FileGetter, page, client, findFilter)
 		if err != nil {
 			return err
 		}
@@ -534,8 +873,8 @@ func getPageFromID(paths []string) *int {
 	return &ret
 }
 
-func (me *contentDirectoryService) getAllScenes(host string) []interface{} {
-	return me.getVideos(&models.SceneFilterType{}, "all", host)
+func (me *contentDirectoryService) getAllScenes(client *cdsClient) []interface{} {
+	return me.getVideos(&models.SceneFilterType{}, "all", client)
 }
 
 func (me *contentDirectoryService) getStudios() []interface{} {
@@ -548,7 +887,13 @@ func (me *contentDirectoryService) getStudios() []interface{} {
 			return err
 		}
 
+		restriction := models.GetContentRestriction(ctx)
 		for _, s := range studios {
+			// hidden by the content restriction
+			if restriction != nil && sliceutil.Contains(restriction.StudioIDs, s.ID) {
+				continue
+			}
+
 			objs = append(objs, makeStorageFolder("studios/"+strconv.Itoa(s.ID), s.Name, "studios"))
 		}
 
@@ -560,7 +905,7 @@ func (me *contentDirectoryService) getStudios() []interface{} {
 	return objs
 }
 
-func (me *contentDirectoryService) getStudioScenes(paths []string, host string) []interface{} {
+func (me *contentDirectoryService) getStudioScenes(paths []string, client *cdsClient) []interface{} {
 	sceneFilter := &models.SceneFilterType{
 		Studios: &models.HierarchicalMultiCriterionInput{
 			Modifier: models.CriterionModifierIncludes,
@@ -572,10 +917,10 @@ func (me *contentDirectoryService) getStudioScenes(paths []string, host string)
 
 	page := getPageFromID(paths)
 	if page != nil {
-		return me.getPageVideos(sceneFilter, parentID, *page, host)
+		return me.getPageVideos(sceneFilter, parentID, *page, client)
 	}
 
-	return me.getVideos(sceneFilter, parentID, host)
+	return me.getVideos(sceneFilter, parentID, client)
 }
 
 func (me *contentDirectoryService) getTags() []interface{} {
@@ -588,7 +933,13 @@ func (me *contentDirectoryService) getTags() []interface{} {
 			return err
 		}
 
+		restriction := models.GetContentRestriction(ctx)
 		for _, s := range tags {
+			// hidden by the content restriction
+			if restriction != nil && sliceutil.Contains(restriction.TagIDs, s.ID) {
+				continue
+			}
+
 			objs = append(objs, makeStorageFolder("tags/"+strconv.Itoa(s.ID), s.Name, "tags"))
 		}
 
@@ -600,7 +951,7 @@ func (me *contentDirectoryService) getTags() []interface{} {
 	return objs
 }
 
-func (me *contentDirectoryService) getTagScenes(paths []string, host string) []interface{} {
+func (me *contentDirectoryService) getTagScenes(paths []string, client *cdsClient) []interface{} {
 	sceneFilter := &models.SceneFilterType{
 		Tags: &models.HierarchicalMultiCriterionInput{
 			Modifier: models.CriterionModifierIncludes,
@@ -612,10 +963,10 @@ func (me *contentDirectoryService) getTagScenes(paths []string, host string) []i
 
 	page := getPageFromID(paths)
 	if page != nil {
-		return me.getPageVideos(sceneFilter, parentID, *page, host)
+		return me.getPageVideos(sceneFilter, parentID, *page, client)
 	}
 
-	return me.getVideos(sceneFilter, parentID, host)
+	return me.getVideos(sceneFilter, parentID, client)
 }
 
 func (me *contentDirectoryService) getPerformers() []interface{} {
@@ -628,7 +979,13 @@ func (me *contentDirectoryService) getPerformers() []interface{} {
 			return err
 		}
 
+		restriction := models.GetContentRestriction(ctx)
 		for _, s := range performers {
+			// hidden by the content restriction
+			if restriction != nil && sliceutil.Contains(restriction.PerformerIDs, s.ID) {
+				continue
+			}
+
 			objs = append(objs, makeStorageFolder("performers/"+strconv.Itoa(s.ID), s.Name, "performers"))
 		}
 
@@ -640,7 +997,7 @@ func (me *contentDirectoryService) getPerformers() []interface{} {
 	return objs
 }
 
-func (me *contentDirectoryService) getPerformerScenes(paths []string, host string) []interface{} {
+func (me *contentDirectoryService) getPerformerScenes(paths []string, client *cdsClient) []interface{} {
 	sceneFilter := &models.SceneFilterType{
 		Performers: &models.MultiCriterionInput{
 			Modifier: models.CriterionModifierIncludes,
@@ -652,10 +1009,10 @@ func (me *contentDirectoryService) getPerformerScenes(paths []string, host strin
 
 	page := getPageFromID(paths)
 	if page != nil {
-		return me.getPageVideos(sceneFilter, parentID, *page, host)
+		return me.getPageVideos(sceneFilter, parentID, *page, client)
 	}
 
-	return me.getVideos(sceneFilter, parentID, host)
+	return me.getVideos(sceneFilter, parentID, client)
 }
 
 func (me *contentDirectoryService) getGroups() []interface{} {
@@ -668,7 +1025,13 @@ func (me *contentDirectoryService) getGroups() []interface{} {
 			return err
 		}
 
+		restriction := models.GetContentRestriction(ctx)
 		for _, s := range groups {
+			// hidden by the content restriction
+			if restriction != nil && s.StudioID != nil && sliceutil.Contains(restriction.StudioIDs, *s.StudioID) {
+				continue
+			}
+
 			objs = append(objs, makeStorageFolder("groups/"+strconv.Itoa(s.ID), s.Name, "groups"))
 		}
 
@@ -680,7 +1043,7 @@ func (me *contentDirectoryService) getGroups() []interface{} {
 	return objs
 }
 
-func (me *contentDirectoryService) getGroupScenes(paths []string, host string) []interface{} {
+func (me *contentDirectoryService) getGroupScenes(paths []string, client *cdsClient) []interface{} {
 	sceneFilter := &models.SceneFilterType{
 		Groups: &models.MultiCriterionInput{
 			Modifier: models.CriterionModifierIncludes,
@@ -692,10 +1055,176 @@ func (me *contentDirectoryService) getGroupScenes(paths []string, host string) [
 
 	page := getPageFromID(paths)
 	if page != nil {
-		return me.getPageVideos(sceneFilter, parentID, *page, host)
+		return me.getPageVideos(sceneFilter, parentID, *page, client)
+	}
+
+	return me.getVideos(sceneFilter, parentID, client)
+}
+
+// getGalleries returns the galleries container, or the given page of it.
+// If there are more galleries than fit on a page, then page folders are
+// returned instead.
+func (me *contentDirectoryService) getGalleries(page *int) []interface{} {
+	var objs []interface{}
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		sort := "title"
+		direction := models.SortDirectionEnumAsc
+		findFilter := &models.FindFilterType{
+			PerPage:   &pageSize,
+			Page:      page,
+			Sort:      &sort,
+			Direction: &direction,
+		}
+
+		galleries, total, err := r.GalleryFinder.Query(ctx, &models.GalleryFilterType{}, findFilter)
+		if err != nil {
+			return err
+		}
+
+		if page == nil && total > pageSize {
+			objs = makePageFolders("galleries", total)
+			return nil
+		}
+
+		for _, g := range galleries {
+			objs = append(objs, makeStorageFolder("galleries/"+strconv.Itoa(g.ID), g.GetTitle(), "galleries"))
+		}
+
+		return nil
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getGalleryImages(paths []string, client *cdsClient) []interface{} {
+	imageFilter := &models.ImageFilterType{
+		Galleries: &models.MultiCriterionInput{
+			Modifier: models.CriterionModifierIncludes,
+			Value:    []string{paths[0]},
+		},
+	}
+
+	parentID := "galleries/" + strings.Join(paths, "/")
+	page := getPageFromID(paths)
+
+	var objs []interface{}
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		// images are sorted by path, the default sort of gallery images in
+		// the interface. Galleries have no other image order.
+		sort := "path"
+		direction := models.SortDirectionEnumAsc
+		findFilter := &models.FindFilterType{
+			PerPage:   &pageSize,
+			Page:      page,
+			Sort:      &sort,
+			Direction: &direction,
+		}
+
+		result, err := r.ImageFinder.Query(ctx, image.QueryOptions(imageFilter, findFilter, page == nil))
+		if err != nil {
+			return err
+		}
+
+		if page == nil && result.Count > pageSize {
+			objs = makePageFolders(parentID, result.Count)
+			return nil
+		}
+
+		images, err := result.Resolve(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, i := range images {
+			if err := i.LoadPrimaryFile(ctx, r.FileGetter); err != nil {
+				return err
+			}
+
+			objs = append(objs, imageToContainer(i, parentID, client))
+		}
+
+		return nil
+	}); err != nil {
+		logger.Error(err.Error())
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getSavedFilters() []interface{} {
+	var objs []interface{}
+
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		filters, err := r.SavedFilterFinder.FindByMode(ctx, models.FilterModeScenes)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range filters {
+			objs = append(objs, makeStorageFolder("savedfilters/"+strconv.Itoa(f.ID), f.Name, "savedfilters"))
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf("error getting saved filters: %v", err)
+	}
+
+	return objs
+}
+
+func (me *contentDirectoryService) getSavedFilterScenes(paths []string, client *cdsClient) []interface{} {
+	filterID, err := strconv.Atoi(paths[0])
+	if err != nil {
+		return nil
+	}
+
+	var savedFilter *models.SavedFilter
+	r := me.repository
+	if err := r.WithReadTxn(context.TODO(), func(ctx context.Context) error {
+		savedFilter, err = r.SavedFilterFinder.Find(ctx, filterID)
+		return err
+	}); err != nil {
+		logger.Errorf("error getting saved filter %d: %v", filterID, err)
+		return nil
+	}
+
+	if savedFilter == nil || savedFilter.Mode != models.FilterModeScenes {
+		return nil
+	}
+
+	sceneFilter, err := savedSceneFilter(savedFilter.ObjectFilter)
+	if err != nil {
+		logger.Errorf("saved filter %q: %v", savedFilter.Name, err)
+		return nil
+	}
+
+	// use the search term and sort order of the saved filter, falling back
+	// to the configured sort order
+	findFilter := me.videoFindFilter(sceneFilter)
+	if ff := savedFilter.FindFilter; ff != nil {
+		findFilter.Q = ff.Q
+		if ff.Sort != nil && *ff.Sort != "" {
+			direction := models.SortDirectionEnum(ff.GetDirection())
+			findFilter.Sort = ff.Sort
+			findFilter.Direction = &direction
+		}
+	}
+
+	parentID := "savedfilters/" + strings.Join(paths, "/")
+
+	page := getPageFromID(paths)
+	if page != nil {
+		return me.getFilteredPageVideos(sceneFilter, findFilter, parentID, *page, client)
 	}
 
-	return me.getVideos(sceneFilter, parentID, host)
+	return me.getFilteredVideos(sceneFilter, findFilter, parentID, client)
 }
 
 func (me *contentDirectoryService) getRating() []interface{} {
@@ -709,7 +1238,7 @@ func (me *contentDirectoryService) getRating() []interface{} {
 	return objs
 }
 
-func (me *contentDirectoryService) getRatingScenes(paths []string, host string) []interface{} {
+func (me *contentDirectoryService) getRatingScenes(paths []string, client *cdsClient) []interface{} {
 	r, err := strconv.Atoi(paths[0])
 	if err != nil {
 		return nil
@@ -726,10 +1255,10 @@ func (me *contentDirectoryService) getRatingScenes(paths []string, host string)
 
 	page := getPageFromID(paths)
 	if page != nil {
-		return me.getPageVideos(sceneFilter, parentID, *page, host)
+		return me.getPageVideos(sceneFilter, parentID, *page, client)
 	}
 
-	return me.getVideos(sceneFilter, parentID, host)
+	return me.getVideos(sceneFilter, parentID, client)
 }
 
 // Represents a ContentDirectory object.
@@ -27,11 +27,16 @@ package dlna
 // SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
 
 import (
+	"context"
 	"net/http"
 	"strings"
 	"testing"
 
+	"github.com/anacrolix/dms/upnpav"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestEscapeObjectID(t *testing.T) {
@@ -78,3 +83,70 @@ func TestBrowseMetadataTags(t *testing.T) {
 
 	assert.Nil(t, err)
 }
+
+func TestBrowseMetadataGalleries(t *testing.T) {
+	argsXML := `<u:Browse xmlns:u="urn:schemas-upnp-org:service:ContentDirectory:1"><ObjectID>galleries</ObjectID><BrowseFlag>BrowseMetadata</BrowseFlag><Filter>*</Filter><StartingIndex>0</StartingIndex><RequestedCount>0</RequestedCount><SortCriteria></SortCriteria></u:Browse>`
+	_, err := testHandleBrowse(argsXML)
+
+	assert.Nil(t, err)
+}
+
+func TestMakePageFolders(t *testing.T) {
+	objs := makePageFolders("galleries/1", pageSize*2+1)
+
+	assert.Len(t, objs, 3)
+	if assert.IsType(t, upnpav.Container{}, objs[2]) {
+		c := objs[2].(upnpav.Container)
+		assert.Equal(t, "galleries/1/page/3", c.ID)
+		assert.Equal(t, "galleries/1", c.ParentID)
+	}
+}
+
+type testRestrictionConfig int
+
+func (c testRestrictionConfig) GetDLNARestrictionProfile() int {
+	return int(c)
+}
+
+type testRestrictionProfileFinder struct {
+	restriction *models.ContentRestriction
+}
+
+func (f testRestrictionProfileFinder) GetContentRestriction(ctx context.Context, id int) (*models.ContentRestriction, error) {
+	return f.restriction, nil
+}
+
+func TestRestrictedFolders(t *testing.T) {
+	db := mocks.NewDatabase()
+	studioID := 2
+
+	db.Performer.On("All", mock.Anything).Return([]*models.Performer{
+		{ID: 1, Name: "visible"},
+		{ID: 2, Name: "hidden"},
+	}, nil)
+	db.Group.On("All", mock.Anything).Return([]*models.Group{
+		{ID: 1, Name: "visible"},
+		{ID: 2, Name: "hidden", StudioID: &studioID},
+	}, nil)
+
+	repo := NewRepository(db.Repository())
+	repo.RestrictionProfile = testRestrictionProfileFinder{&models.ContentRestriction{
+		ProfileID:    1,
+		PerformerIDs: []int{2},
+		StudioIDs:    []int{studioID},
+	}}
+	repo.restrictionConfig = testRestrictionConfig(1)
+
+	cds := contentDirectoryService{
+		Server: &Server{repository: repo},
+	}
+
+	for name, objs := range map[string][]interface{}{
+		"performers": cds.getPerformers(),
+		"groups":     cds.getGroups(),
+	} {
+		if assert.Len(t, objs, 1, name) {
+			assert.Equal(t, name+"/1", objs[0].(upnpav.Container).ID)
+		}
+	}
+}
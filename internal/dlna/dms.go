@@ -43,10 +43,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/anacrolix/dms/dlna"
 	"github.com/anacrolix/dms/soap"
 	"github.com/anacrolix/dms/ssdp"
 	"github.com/anacrolix/dms/upnp"
 
+	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 )
@@ -56,28 +58,57 @@ type SceneFinder interface {
 	models.SceneQueryer
 }
 
+// SceneActivityWriter records the resume point and plays of scenes.
+type SceneActivityWriter interface {
+	AddViews(ctx context.Context, sceneID int, dates []time.Time) ([]time.Time, error)
+	SaveActivity(ctx context.Context, sceneID int, resumeTime *float64, playDuration *float64) (bool, error)
+}
+
 type StudioFinder interface {
 	All(ctx context.Context) ([]*models.Studio, error)
 }
 
 type TagFinder interface {
 	All(ctx context.Context) ([]*models.Tag, error)
+	Query(ctx context.Context, tagFilter *models.TagFilterType, findFilter *models.FindFilterType) ([]*models.Tag, int, error)
+}
+
+type RestrictionProfileFinder interface {
+	GetContentRestriction(ctx context.Context, id int) (*models.ContentRestriction, error)
 }
 
 type PerformerFinder interface {
 	All(ctx context.Context) ([]*models.Performer, error)
+	Query(ctx context.Context, performerFilter *models.PerformerFilterType, findFilter *models.FindFilterType) ([]*models.Performer, int, error)
 }
 
 type GroupFinder interface {
 	All(ctx context.Context) ([]*models.Group, error)
 }
 
+type ImageFinder interface {
+	models.ImageGetter
+	models.ImageQueryer
+}
+
+type GalleryFinder interface {
+	models.GalleryGetter
+	models.GalleryQueryer
+}
+
+type SavedFilterFinder interface {
+	Find(ctx context.Context, id int) (*models.SavedFilter, error)
+	FindByMode(ctx context.Context, mode models.FilterMode) ([]*models.SavedFilter, error)
+}
+
 const (
 	serverField                 = "Linux/3.4 DLNADOC/1.50 UPnP/1.0 DMS/1.0"
 	rootDeviceType              = "urn:schemas-upnp-org:device:MediaServer:1"
 	rootDeviceModelName         = "dms 1.0xb"
 	resPath                     = "/res"
 	iconPath                    = "/icon"
+	imagePath                   = "/image"
+	imageThumbnailPath          = "/imageThumbnail"
 	rootDescPath                = "/rootDesc.xml"
 	contentDirectoryEventSubURL = "/evt/ContentDirectory"
 	serviceControlURL           = "/ctl"
@@ -273,6 +304,9 @@ type Server struct {
 
 	repository         Repository
 	sceneServer        sceneServer
+	imageServer        imageServer
+	playTracker        *playTracker
+	rendererConfig     rendererConfig
 	ipWhitelistManager *ipWhitelistManager
 	VideoSortOrder     string
 
@@ -460,6 +494,100 @@ func (me *Server) serveIcon(w http.ResponseWriter, r *http.Request) {
 	me.sceneServer.ServeScreenshot(scene, w, r)
 }
 
+// findImage returns the image with the id in the image query parameter,
+// with its primary file loaded.
+func (me *Server) findImage(r *http.Request) *models.Image {
+	imageID, err := strconv.Atoi(r.URL.Query().Get("image"))
+	if err != nil {
+		return nil
+	}
+
+	var image *models.Image
+	repo := me.repository
+	if err := repo.WithReadTxn(r.Context(), func(ctx context.Context) error {
+		image, err = repo.ImageFinder.Find(ctx, imageID)
+		if err != nil || image == nil {
+			return err
+		}
+
+		return image.LoadPrimaryFile(ctx, repo.FileGetter)
+	}); err != nil {
+		logger.Warnf("failed to execute read transaction for image id (%v): %v", imageID, err)
+		return nil
+	}
+
+	return image
+}
+
+// serveSceneTranscode streams a live transcode of the scene in the given
+// format. Renderers seek in transcoded streams using the DLNA time seek
+// header. HEAD requests are answered without starting a transcode.
+func (me *Server) serveSceneTranscode(w http.ResponseWriter, r *http.Request, scene *models.Scene, format string) {
+	streamType, ok := transcodeFormats[format]
+	if !ok {
+		http.Error(w, fmt.Sprintf("invalid transcode format: %s", format), http.StatusBadRequest)
+		return
+	}
+
+	var startTime float64
+	if seek := r.Header.Get(dlna.TimeSeekRangeDomain); seek != "" {
+		npt, err := dlna.ParseNPTRange(strings.TrimPrefix(seek, "npt="))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid time seek range: %s", seek), http.StatusBadRequest)
+			return
+		}
+		startTime = npt.Start.Seconds()
+	}
+
+	profile := me.rendererProfile(r)
+
+	w.Header().Set("transferMode.dlna.org", "Streaming")
+	w.Header().Set("contentFeatures.dlna.org", dlna.ContentFeatures{
+		SupportTimeSeek: true,
+		Transcoded:      true,
+	}.String())
+
+	if r.Method == http.MethodHead {
+		w.Header().Set("Content-Type", streamType.MimeType)
+		return
+	}
+
+	var duration float64
+	if f := scene.Files.Primary(); f != nil {
+		duration = f.Duration
+	}
+
+	me.playTracker.trackTranscode(r, scene.ID, duration, func() {
+		me.sceneServer.StreamSceneTranscode(scene, ffmpeg.TranscodeOptions{
+			StreamType:   streamType,
+			StartTime:    startTime,
+			ClientCodecs: profile.getVideoCodecs(),
+		}, w, r)
+	})
+}
+
+func (me *Server) serveImage(w http.ResponseWriter, r *http.Request) {
+	image := me.findImage(r)
+	if image == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("transferMode.dlna.org", "Interactive")
+	me.imageServer.ServeImage(image, w, r)
+}
+
+func (me *Server) serveImageThumbnail(w http.ResponseWriter, r *http.Request) {
+	image := me.findImage(r)
+	if image == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("transferMode.dlna.org", "Interactive")
+	me.imageServer.ServeThumbnail(image, w, r)
+}
+
 func (me *Server) contentDirectoryInitialEvent(ctx context.Context, urls []*url.URL, sid string) {
 	body := xmlMarshalOrPanic(upnp.PropertySet{
 		Properties: []upnp.Property{
@@ -589,6 +717,8 @@ func (me *Server) initMux(mux *http.ServeMux) {
 	})
 	mux.HandleFunc(contentDirectoryEventSubURL, me.contentDirectoryEventSubHandler)
 	mux.HandleFunc(iconPath, me.serveIcon)
+	mux.HandleFunc(imagePath, me.serveImage)
+	mux.HandleFunc(imageThumbnailPath, me.serveImageThumbnail)
 	mux.HandleFunc(resPath, func(w http.ResponseWriter, r *http.Request) {
 		sceneId := r.URL.Query().Get("scene")
 		var scene *models.Scene
@@ -599,7 +729,11 @@ func (me *Server) initMux(mux *http.ServeMux) {
 				return nil
 			}
 			scene, _ = repo.SceneFinder.Find(ctx, sceneIdInt)
-			return nil
+			if scene == nil {
+				return nil
+			}
+
+			return scene.LoadPrimaryFile(ctx, repo.FileGetter)
 		})
 		if err != nil {
 			logger.Warnf("failed to execute read transaction for scene id (%v): %v", sceneId, err)
@@ -609,9 +743,22 @@ func (me *Server) initMux(mux *http.ServeMux) {
 			return
 		}
 
+		if format := r.URL.Query().Get("transcode"); format != "" {
+			me.serveSceneTranscode(w, r, scene, format)
+			return
+		}
+
 		w.Header().Set("transferMode.dlna.org", "Streaming")
 		w.Header().Set("contentFeatures.dlna.org", "DLNA.ORG_OP=01;DLNA.ORG_CI=0;DLNA.ORG_FLAGS=01500000000000000000000000000000")
-		me.sceneServer.StreamSceneDirect(scene, w, r)
+
+		var fileSize int64
+		if f := scene.Files.Primary(); f != nil {
+			fileSize = f.Size
+		}
+
+		me.playTracker.trackStream(w, r, scene.ID, fileSize, func(w http.ResponseWriter) {
+			me.sceneServer.StreamSceneDirect(scene, w, r)
+		})
 	})
 	mux.HandleFunc(rootDescPath, func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("content-type", `text/xml; charset="utf-8"`)
@@ -727,7 +874,8 @@ func didl_lite(chardata string) string {
 		` xmlns:dc="http://purl.org/dc/elements/1.1/"` +
 		` xmlns:upnp="urn:schemas-upnp-org:metadata-1-0/upnp/"` +
 		` xmlns="urn:schemas-upnp-org:metadata-1-0/DIDL-Lite/"` +
-		` xmlns:dlna="urn:schemas-dlna-org:metadata-1-0/">` +
+		` xmlns:dlna="urn:schemas-dlna-org:metadata-1-0/"` +
+		` xmlns:sec="http://www.sec.co.kr/">` +
 		chardata +
 		`</DIDL-Lite>`
 }
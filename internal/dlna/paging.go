@@ -10,8 +10,22 @@ import (
 	"github.com/stashapp/stash/pkg/scene"
 )
 
+// makePageFolders returns a folder for each page of a container with
+// total items. The page folders are numbered from 1.
+func makePageFolders(parentID string, total int) []interface{} {
+	var objs []interface{}
+
+	pages := int(math.Ceil(float64(total) / float64(pageSize)))
+	for page := 1; page <= pages; page++ {
+		objs = append(objs, makeStorageFolder(parentID+"/page/"+strconv.Itoa(page), fmt.Sprintf("Page %d", page), parentID))
+	}
+
+	return objs
+}
+
 type scenePager struct {
 	sceneFilter *models.SceneFilterType
+	q           *string
 	parentID    string
 }
 
@@ -28,6 +42,7 @@ func (p *scenePager) getPages(ctx context.Context, r models.SceneQueryer, total
 	singlePageSize := 1
 	sort := "title"
 	findFilter := &models.FindFilterType{
+		Q:       p.q,
 		PerPage: &singlePageSize,
 		Sort:    &sort,
 	}
@@ -59,17 +74,13 @@ func (p *scenePager) getPages(ctx context.Context, r models.SceneQueryer, total
 	return objs, nil
 }
 
-func (p *scenePager) getPageVideos(ctx context.Context, r SceneFinder, f models.FileGetter, page int, host string, sort string, direction models.SortDirectionEnum) ([]interface{}, error) {
+func (p *scenePager) getPageVideos(ctx context.Context, r SceneFinder, f models.FileGetter, page int, client *cdsClient, findFilter models.FindFilterType) ([]interface{}, error) {
 	var objs []interface{}
 
-	findFilter := &models.FindFilterType{
-		PerPage:   &pageSize,
-		Page:      &page,
-		Sort:      &sort,
-		Direction: &direction,
-	}
+	findFilter.PerPage = &pageSize
+	findFilter.Page = &page
 
-	scenes, err := scene.Query(ctx, r, p.sceneFilter, findFilter)
+	scenes, err := scene.Query(ctx, r, p.sceneFilter, &findFilter)
 	if err != nil {
 		return nil, err
 	}
@@ -79,7 +90,7 @@ func (p *scenePager) getPageVideos(ctx context.Context, r SceneFinder, f models.
 			return nil, err
 		}
 
-		objs = append(objs, sceneToContainer(s, p.parentID, host))
+		objs = append(objs, sceneToContainer(s, p.parentID, client))
 	}
 
 	return objs, nil
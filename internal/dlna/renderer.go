@@ -0,0 +1,236 @@
+package dlna
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// RendererProfile is a renderer profile from the configuration or one of
+// the built-in profiles.
+type RendererProfile models.DLNARendererProfile
+
+const (
+	transcodeFormatMP4  = "mp4"
+	transcodeFormatWEBM = "webm"
+	transcodeFormatMKV  = "mkv"
+
+	genericRendererProfileName = "generic"
+)
+
+type rendererConfig interface {
+	GetDLNARendererProfiles() []*models.DLNARendererProfile
+}
+
+var transcodeFormats = map[string]ffmpeg.StreamFormat{
+	transcodeFormatMP4:  ffmpeg.StreamTypeMP4,
+	transcodeFormatWEBM: ffmpeg.StreamTypeWEBM,
+	transcodeFormatMKV:  ffmpeg.StreamTypeMKV,
+}
+
+// headers used by renderers to identify themselves
+var rendererHeaders = []string{
+	"User-Agent",
+	"X-AV-Client-Info",
+	"X-AV-Physical-Unit-Info",
+	"FriendlyName.DLNA.ORG",
+}
+
+var builtinRendererProfiles = []*RendererProfile{
+	{
+		Name:        "samsung",
+		UserAgent:   `(?i)samsung|SEC_HHP|tizen`,
+		VideoCodecs: []string{"h264", "hevc", "mpeg4", "mpeg2video", "vp8", "vp9", "wmv3", "vc1"},
+		AudioCodecs: []string{"aac", "mp3", "ac3", "eac3", "opus", "vorbis", "flac", "wmav2"},
+		Containers:  []string{"mp4", "m4v", "mov", "matroska", "webm", "avi", "mpegts", "wmv"},
+	},
+	{
+		Name:        "lg",
+		UserAgent:   `(?i)LGE_DLNA|webos|\bLG\b`,
+		VideoCodecs: []string{"h264", "hevc", "mpeg4", "mpeg2video", "vp9", "av1"},
+		AudioCodecs: []string{"aac", "mp3", "ac3", "eac3", "opus", "vorbis", "flac"},
+		Containers:  []string{"mp4", "m4v", "mov", "matroska", "webm", "avi", "mpegts"},
+	},
+	{
+		Name:        "sony",
+		UserAgent:   `(?i)sony|bravia`,
+		VideoCodecs: []string{"h264", "hevc", "mpeg4", "mpeg2video"},
+		AudioCodecs: []string{"aac", "mp3", "ac3", "eac3"},
+		Containers:  []string{"mp4", "m4v", "mov", "matroska", "mpegts"},
+	},
+	{
+		// Kodi plays everything
+		Name:      "kodi",
+		UserAgent: `(?i)kodi|xbmc`,
+	},
+}
+
+// genericRendererProfile is used for renderers that do not match any other
+// profile. It allows the codecs and containers supported by most renderers.
+var genericRendererProfile = &RendererProfile{
+	Name:        genericRendererProfileName,
+	VideoCodecs: []string{"h264", "hevc", "mpeg4", "mpeg2video"},
+	AudioCodecs: []string{"aac", "mp3", "ac3", "eac3", "mp2"},
+	Containers:  []string{"mp4", "m4v", "mov", "matroska", "avi", "mpegts"},
+}
+
+// ValidateRendererProfiles returns an error if any of the profiles are
+// invalid.
+func ValidateRendererProfiles(profiles []*models.DLNARendererProfile) error {
+	for _, p := range profiles {
+		if p.Name == "" {
+			return errors.New("renderer profile name is required")
+		}
+
+		if p.UserAgent == "" && !strings.EqualFold(p.Name, genericRendererProfileName) {
+			return fmt.Errorf("renderer profile %q: user agent is required", p.Name)
+		}
+
+		if _, err := regexp.Compile(p.UserAgent); err != nil {
+			return fmt.Errorf("renderer profile %q: invalid user agent: %w", p.Name, err)
+		}
+
+		if p.TranscodeFormat != "" {
+			if _, ok := transcodeFormats[p.TranscodeFormat]; !ok {
+				return fmt.Errorf("renderer profile %q: invalid transcode format %q", p.Name, p.TranscodeFormat)
+			}
+		}
+	}
+
+	return nil
+}
+
+// matchRendererProfile returns the profile of the renderer that made the
+// request. Configured profiles take precedence over the built-in profiles.
+// The generic profile is returned if no profile matches.
+func matchRendererProfile(configured []*RendererProfile, r *http.Request) *RendererProfile {
+	var values []string
+	for _, h := range rendererHeaders {
+		values = append(values, r.Header.Values(h)...)
+	}
+	identity := strings.Join(values, "\n")
+
+	generic := genericRendererProfile
+	replaced := make(map[string]bool)
+
+	for _, p := range configured {
+		name := strings.ToLower(p.Name)
+		replaced[name] = true
+
+		if name == genericRendererProfileName {
+			generic = p
+			continue
+		}
+
+		if p.matches(identity) {
+			return p
+		}
+	}
+
+	for _, p := range builtinRendererProfiles {
+		if !replaced[p.Name] && p.matches(identity) {
+			return p
+		}
+	}
+
+	return generic
+}
+
+// rendererProfile returns the profile of the renderer that made the request.
+func (me *Server) rendererProfile(r *http.Request) *RendererProfile {
+	var configured []*RendererProfile
+	if me.rendererConfig != nil {
+		for _, p := range me.rendererConfig.GetDLNARendererProfiles() {
+			configured = append(configured, (*RendererProfile)(p))
+		}
+	}
+
+	return matchRendererProfile(configured, r)
+}
+
+// userAgentRegexps caches the compiled user agent expressions of the
+// profiles by expression, so that they are not compiled for each request.
+// Expressions that fail to compile are stored as nil.
+var userAgentRegexps sync.Map
+
+func init() {
+	for _, p := range builtinRendererProfiles {
+		userAgentRegexps.Store(p.UserAgent, regexp.MustCompile(p.UserAgent))
+	}
+}
+
+func userAgentRegexp(expr string) *regexp.Regexp {
+	if re, ok := userAgentRegexps.Load(expr); ok {
+		return re.(*regexp.Regexp)
+	}
+
+	// invalid expressions are rejected when the profiles are saved
+	re, _ := regexp.Compile(expr)
+	userAgentRegexps.Store(expr, re)
+
+	return re
+}
+
+func (p *RendererProfile) matches(identity string) bool {
+	if p.UserAgent == "" || identity == "" {
+		return false
+	}
+
+	re := userAgentRegexp(p.UserAgent)
+	if re == nil {
+		return false
+	}
+
+	return re.MatchString(identity)
+}
+
+// canPlay returns true if the renderer can play the file without
+// transcoding. A nil profile can play all files.
+func (p *RendererProfile) canPlay(f *models.VideoFile) bool {
+	if p == nil {
+		return true
+	}
+
+	supports := func(values []string, v string) bool {
+		if len(values) == 0 || v == "" {
+			return true
+		}
+
+		for _, s := range values {
+			if strings.EqualFold(s, v) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return supports(p.Containers, f.Format) &&
+		supports(p.VideoCodecs, f.VideoCodec) &&
+		supports(p.AudioCodecs, f.AudioCodec)
+}
+
+// getTranscodeFormat returns the name of the format of transcoded streams.
+func (p *RendererProfile) getTranscodeFormat() string {
+	if p == nil || p.TranscodeFormat == "" {
+		return transcodeFormatMP4
+	}
+
+	return p.TranscodeFormat
+}
+
+// getVideoCodecs returns the video codecs that the renderer can play, for
+// use when choosing the codec of transcoded streams.
+func (p *RendererProfile) getVideoCodecs() []string {
+	if p == nil {
+		return nil
+	}
+
+	return p.VideoCodecs
+}
@@ -0,0 +1,73 @@
+package dlna
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestMatchRendererProfile(t *testing.T) {
+	custom := &RendererProfile{
+		Name:      "custom",
+		UserAgent: "MyPlayer",
+	}
+	samsung := &RendererProfile{
+		Name:      "Samsung",
+		UserAgent: "SAMSUNG-CUSTOM",
+	}
+
+	tests := []struct {
+		name       string
+		configured []*RendererProfile
+		header     string
+		value      string
+		want       string
+	}{
+		{"samsung", nil, "User-Agent", "SEC_HHP_[TV] Samsung Q70 Series/1.0", "samsung"},
+		{"lg", nil, "User-Agent", "Linux/2.6.35 UPnP/1.0 DLNADOC/1.50 LGE_DLNA_SDK/1.6.0", "lg"},
+		{"sony client info", nil, "X-AV-Client-Info", `av=5.0; cn="Sony Corporation"; mn="BRAVIA KDL-50W800C"`, "sony"},
+		{"kodi", nil, "User-Agent", "Kodi/20.2 (X11; Linux x86_64)", "kodi"},
+		{"unknown", nil, "User-Agent", "SomePlayer/1.0", genericRendererProfileName},
+		{"configured", []*RendererProfile{custom}, "User-Agent", "MyPlayer/2.0", "custom"},
+		{"replaced builtin", []*RendererProfile{samsung}, "User-Agent", "SEC_HHP_[TV] Samsung Q70 Series/1.0", genericRendererProfileName},
+		{"replacement matches", []*RendererProfile{samsung}, "User-Agent", "SAMSUNG-CUSTOM", "Samsung"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/ctl", nil)
+			r.Header.Set(tt.header, tt.value)
+
+			if got := matchRendererProfile(tt.configured, r); got.Name != tt.want {
+				t.Errorf("matchRendererProfile() = %v, want %v", got.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestRendererProfileCanPlay(t *testing.T) {
+	profile := &RendererProfile{
+		VideoCodecs: []string{"h264", "HEVC"},
+		Containers:  []string{"mp4"},
+	}
+
+	tests := []struct {
+		name    string
+		profile *RendererProfile
+		file    models.VideoFile
+		want    bool
+	}{
+		{"supported", profile, models.VideoFile{Format: "mp4", VideoCodec: "h264", AudioCodec: "aac"}, true},
+		{"case insensitive", profile, models.VideoFile{Format: "mp4", VideoCodec: "hevc"}, true},
+		{"unsupported codec", profile, models.VideoFile{Format: "mp4", VideoCodec: "vp9"}, false},
+		{"unsupported container", profile, models.VideoFile{Format: "matroska", VideoCodec: "h264"}, false},
+		{"nil profile", nil, models.VideoFile{Format: "wmv", VideoCodec: "wmv3"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.canPlay(&tt.file); got != tt.want {
+				t.Errorf("RendererProfile.canPlay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
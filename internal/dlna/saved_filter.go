@@ -0,0 +1,128 @@
+package dlna
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// savedSceneFilter converts the object filter of a saved scene filter to a
+// scene filter. Saved filters store criteria in the format used by the UI,
+// where the value of a criterion may be an object containing the selected
+// items and their labels.
+func savedSceneFilter(objectFilter map[string]interface{}) (*models.SceneFilterType, error) {
+	fieldTypes := jsonFieldTypes(reflect.TypeOf(models.SceneFilterType{}))
+
+	converted := make(map[string]interface{})
+	for field, v := range objectFilter {
+		t, ok := fieldTypes[field]
+		if !ok {
+			// ignore unknown criteria rather than failing the whole filter
+			continue
+		}
+
+		criterion, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		converted[field] = convertSavedCriterion(t, criterion)
+	}
+
+	data, err := json.Marshal(converted)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret models.SceneFilterType
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, fmt.Errorf("converting saved filter: %w", err)
+	}
+
+	return &ret, nil
+}
+
+// jsonFieldTypes returns the types of the fields of a struct, keyed by their
+// json name. Pointer types are dereferenced.
+func jsonFieldTypes(t reflect.Type) map[string]reflect.Type {
+	ret := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		ret[name] = ft
+	}
+
+	return ret
+}
+
+func convertSavedCriterion(t reflect.Type, criterion map[string]interface{}) interface{} {
+	value := criterion["value"]
+
+	// some filter fields are plain values rather than criterion inputs
+	switch t.Kind() {
+	case reflect.Bool:
+		switch v := value.(type) {
+		case bool:
+			return v
+		case string:
+			b, _ := strconv.ParseBool(v)
+			return b
+		}
+		return nil
+	case reflect.String:
+		return value
+	}
+
+	ret := map[string]interface{}{
+		"modifier": criterion["modifier"],
+	}
+
+	valueMap, ok := value.(map[string]interface{})
+	if !ok {
+		if value != nil {
+			ret["value"] = value
+		}
+		return ret
+	}
+
+	// selected items are stored with their labels, only the ids are used
+	for k, v := range valueMap {
+		switch k {
+		case "items":
+			ret["value"] = savedItemIDs(v)
+		case "excluded":
+			ret["excludes"] = savedItemIDs(v)
+		default:
+			ret[k] = v
+		}
+	}
+
+	return ret
+}
+
+func savedItemIDs(v interface{}) []string {
+	items, _ := v.([]interface{})
+
+	ret := []string{}
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			if id, ok := m["id"].(string); ok {
+				ret = append(ret, id)
+			}
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,80 @@
+package dlna
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavedSceneFilter(t *testing.T) {
+	depth := -1
+	objectFilter := map[string]interface{}{
+		"title": map[string]interface{}{
+			"modifier": "INCLUDES",
+			"value":    "foo",
+		},
+		"tags": map[string]interface{}{
+			"modifier": "INCLUDES_ALL",
+			"value": map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"id": "1", "label": "tag 1"},
+					map[string]interface{}{"id": "2", "label": "tag 2"},
+				},
+				"excluded": []interface{}{
+					map[string]interface{}{"id": "3", "label": "tag 3"},
+				},
+				"depth": -1,
+			},
+		},
+		"rating100": map[string]interface{}{
+			"modifier": "BETWEEN",
+			"value": map[string]interface{}{
+				"value":  60,
+				"value2": 80,
+			},
+		},
+		"organized": map[string]interface{}{
+			"modifier": "EQUALS",
+			"value":    "true",
+		},
+		"is_missing": map[string]interface{}{
+			"modifier": "EQUALS",
+			"value":    "performers",
+		},
+		"unknown": map[string]interface{}{
+			"modifier": "EQUALS",
+			"value":    "foo",
+		},
+	}
+
+	got, err := savedSceneFilter(objectFilter)
+	if err != nil {
+		t.Fatalf("savedSceneFilter() error = %v", err)
+	}
+
+	organized := true
+	isMissing := "performers"
+	value2 := 80
+	want := &models.SceneFilterType{
+		Title: &models.StringCriterionInput{
+			Value:    "foo",
+			Modifier: models.CriterionModifierIncludes,
+		},
+		Tags: &models.HierarchicalMultiCriterionInput{
+			Value:    []string{"1", "2"},
+			Excludes: []string{"3"},
+			Depth:    &depth,
+			Modifier: models.CriterionModifierIncludesAll,
+		},
+		Rating100: &models.IntCriterionInput{
+			Value:    60,
+			Value2:   &value2,
+			Modifier: models.CriterionModifierBetween,
+		},
+		Organized: &organized,
+		IsMissing: &isMissing,
+	}
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,417 @@
+package dlna
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// searchCapabilities are the properties supported in search criteria.
+const searchCapabilities = "dc:title,upnp:artist,upnp:genre,upnp:class"
+
+var errInvalidSearchCriteria = errors.New("invalid search criteria")
+
+// searchFilter is the scene filter for a UPnP search expression. If none is
+// true, then the expression cannot match any scene, such as when searching
+// for audio items.
+type searchFilter struct {
+	filter *models.SceneFilterType
+	none   bool
+
+	// exclusions are the performer and tag names that matching scenes must
+	// not have. They must be resolved before the filter is used.
+	exclusions []*nameExclusion
+}
+
+// nameExclusion excludes scenes with any performer or tag with a matching
+// name. Filtering on the names of related performers or tags matches scenes
+// with any performer or tag that matches, so a negated name criterion would
+// match scenes with any other performer or tag. Instead, the ids of the
+// performers or tags with the name are excluded.
+type nameExclusion struct {
+	// name matches the names of the excluded performers or tags
+	name *models.StringCriterionInput
+
+	// one of performers or tags is set, and is populated by resolve
+	performers *models.MultiCriterionInput
+	tags       *models.HierarchicalMultiCriterionInput
+}
+
+// resolve sets the ids of the performers or tags to exclude.
+func (e *nameExclusion) resolve(ctx context.Context, r Repository) error {
+	perPage := models.PerPageAll
+	findFilter := &models.FindFilterType{PerPage: &perPage}
+
+	if e.performers != nil {
+		performers, _, err := r.PerformerFinder.Query(ctx, &models.PerformerFilterType{Name: e.name}, findFilter)
+		if err != nil {
+			return fmt.Errorf("finding excluded performers: %w", err)
+		}
+
+		for _, p := range performers {
+			e.performers.Value = append(e.performers.Value, strconv.Itoa(p.ID))
+		}
+	}
+
+	if e.tags != nil {
+		tags, _, err := r.TagFinder.Query(ctx, &models.TagFilterType{Name: e.name}, findFilter)
+		if err != nil {
+			return fmt.Errorf("finding excluded tags: %w", err)
+		}
+
+		for _, t := range tags {
+			e.tags.Value = append(e.tags.Value, strconv.Itoa(t.ID))
+		}
+	}
+
+	return nil
+}
+
+// resolveExclusions resolves the exclusions of the filter.
+func (f *searchFilter) resolveExclusions(ctx context.Context, r Repository) error {
+	for _, e := range f.exclusions {
+		if err := e.resolve(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// searchParser parses UPnP ContentDirectory search criteria, as defined in
+// section 2.5.5 of the ContentDirectory:1 specification, into a scene
+// filter. Unknown properties match all scenes.
+type searchParser struct {
+	tokens []string
+	pos    int
+}
+
+// parseSearchCriteria returns the scene filter for the search criteria.
+func parseSearchCriteria(criteria string) (*searchFilter, error) {
+	criteria = strings.TrimSpace(criteria)
+	if criteria == "" || criteria == "*" {
+		return &searchFilter{}, nil
+	}
+
+	tokens, err := tokenizeSearchCriteria(criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &searchParser{tokens: tokens}
+	ret, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected %q", errInvalidSearchCriteria, p.tokens[p.pos])
+	}
+
+	return ret, nil
+}
+
+func tokenizeSearchCriteria(criteria string) ([]string, error) {
+	var tokens []string
+	runes := []rune(criteria)
+
+	for i := 0; i < len(runes); {
+		c := runes[i]
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case c == '"':
+			// quoted values escape quotes and backslashes with a backslash
+			var sb strings.Builder
+			sb.WriteRune('"')
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					sb.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, fmt.Errorf("%w: unterminated string", errInvalidSearchCriteria)
+			}
+			tokens = append(tokens, sb.String())
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' && runes[i] != '"' {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		}
+	}
+
+	return tokens, nil
+}
+
+func (p *searchParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *searchParser) next() (string, error) {
+	if p.pos >= len(p.tokens) {
+		return "", fmt.Errorf("%w: unexpected end of criteria", errInvalidSearchCriteria)
+	}
+	ret := p.tokens[p.pos]
+	p.pos++
+	return ret, nil
+}
+
+func (p *searchParser) parseOr() (*searchFilter, error) {
+	ret, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for strings.EqualFold(p.peek(), "or") {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		ret, err = orSearchFilters(ret, right)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+func (p *searchParser) parseAnd() (*searchFilter, error) {
+	ret, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+
+	for strings.EqualFold(p.peek(), "and") {
+		p.pos++
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		ret, err = andSearchFilters(ret, right)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+func (p *searchParser) parseTerm() (*searchFilter, error) {
+	if p.peek() == "(" {
+		p.pos++
+		ret, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if t, err := p.next(); err != nil || t != ")" {
+			return nil, fmt.Errorf("%w: missing closing parenthesis", errInvalidSearchCriteria)
+		}
+
+		return ret, nil
+	}
+
+	property, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	op, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	value, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.EqualFold(op, "exists") {
+		// all scenes have the supported properties
+		return &searchFilter{}, nil
+	}
+
+	if !strings.HasPrefix(value, `"`) {
+		return nil, fmt.Errorf("%w: expected quoted value, got %q", errInvalidSearchCriteria, value)
+	}
+	value = value[1:]
+
+	return relationalSearchFilter(property, op, value)
+}
+
+func relationalSearchFilter(property, op, value string) (*searchFilter, error) {
+	if property == "upnp:class" {
+		return classSearchFilter(op, value), nil
+	}
+
+	var modifier models.CriterionModifier
+	// positiveModifier is the modifier without negation
+	var positiveModifier models.CriterionModifier
+	switch strings.ToLower(op) {
+	case "=":
+		modifier = models.CriterionModifierEquals
+	case "!=":
+		modifier = models.CriterionModifierNotEquals
+		positiveModifier = models.CriterionModifierEquals
+	case "contains":
+		modifier = models.CriterionModifierIncludes
+	case "doesnotcontain":
+		modifier = models.CriterionModifierExcludes
+		positiveModifier = models.CriterionModifierIncludes
+	default:
+		// comparisons are not supported for string properties
+		return &searchFilter{}, nil
+	}
+
+	criterion := &models.StringCriterionInput{
+		Value:    value,
+		Modifier: modifier,
+	}
+
+	if positiveModifier != "" {
+		if ret := exclusionSearchFilter(property, &models.StringCriterionInput{
+			Value:    value,
+			Modifier: positiveModifier,
+		}); ret != nil {
+			return ret, nil
+		}
+	}
+
+	switch property {
+	case "dc:title":
+		return &searchFilter{filter: &models.SceneFilterType{
+			Title: criterion,
+		}}, nil
+	case "upnp:artist", "dc:creator":
+		return &searchFilter{filter: &models.SceneFilterType{
+			PerformersFilter: &models.PerformerFilterType{
+				Name: criterion,
+			},
+		}}, nil
+	case "upnp:genre":
+		return &searchFilter{filter: &models.SceneFilterType{
+			TagsFilter: &models.TagFilterType{
+				Name: criterion,
+			},
+		}}, nil
+	}
+
+	return &searchFilter{}, nil
+}
+
+// exclusionSearchFilter returns a filter excluding scenes with a performer or
+// tag name matching name, or nil if property is not a performer or tag name.
+func exclusionSearchFilter(property string, name *models.StringCriterionInput) *searchFilter {
+	e := &nameExclusion{name: name}
+	filter := &models.SceneFilterType{}
+
+	switch property {
+	case "upnp:artist", "dc:creator":
+		e.performers = &models.MultiCriterionInput{Modifier: models.CriterionModifierExcludes}
+		filter.Performers = e.performers
+	case "upnp:genre":
+		e.tags = &models.HierarchicalMultiCriterionInput{Modifier: models.CriterionModifierExcludes}
+		filter.Tags = e.tags
+	default:
+		return nil
+	}
+
+	return &searchFilter{
+		filter:     filter,
+		exclusions: []*nameExclusion{e},
+	}
+}
+
+// classSearchFilter returns a filter matching all scenes if scenes are of
+// the class, or none otherwise.
+func classSearchFilter(op, value string) *searchFilter {
+	const videoClass = "object.item.videoItem"
+
+	var matches bool
+	switch strings.ToLower(op) {
+	case "derivedfrom":
+		// videoItem is derived from object.item and object
+		matches = strings.HasPrefix(value, videoClass) || strings.HasPrefix(videoClass, value)
+	case "=":
+		matches = value == videoClass
+	case "!=":
+		matches = value != videoClass
+	default:
+		matches = true
+	}
+
+	return &searchFilter{none: !matches}
+}
+
+func isSubFilterSet(f *models.SceneFilterType) bool {
+	return f.And != nil || f.Or != nil || f.Not != nil
+}
+
+func andSearchFilters(a, b *searchFilter) (*searchFilter, error) {
+	switch {
+	case a.none || b.none:
+		return &searchFilter{none: true}, nil
+	case a.filter == nil:
+		return b, nil
+	case b.filter == nil:
+		return a, nil
+	case !isSubFilterSet(a.filter):
+		a.filter.And = b.filter
+		a.exclusions = append(a.exclusions, b.exclusions...)
+		return a, nil
+	case !isSubFilterSet(b.filter):
+		b.filter.And = a.filter
+		b.exclusions = append(b.exclusions, a.exclusions...)
+		return b, nil
+	}
+
+	return nil, fmt.Errorf("%w: too complex", errInvalidSearchCriteria)
+}
+
+func orSearchFilters(a, b *searchFilter) (*searchFilter, error) {
+	switch {
+	case a.none:
+		return b, nil
+	case b.none:
+		return a, nil
+	case a.filter == nil || b.filter == nil:
+		// one side matches all scenes
+		return &searchFilter{}, nil
+	case !isSubFilterSet(a.filter):
+		a.filter.Or = b.filter
+		a.exclusions = append(a.exclusions, b.exclusions...)
+		return a, nil
+	case !isSubFilterSet(b.filter):
+		b.filter.Or = a.filter
+		b.exclusions = append(b.exclusions, a.exclusions...)
+		return b, nil
+	}
+
+	return nil, fmt.Errorf("%w: too complex", errInvalidSearchCriteria)
+}
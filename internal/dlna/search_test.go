@@ -0,0 +1,153 @@
+package dlna
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseSearchCriteria(t *testing.T) {
+	title := func(v string, m models.CriterionModifier) *models.SceneFilterType {
+		return &models.SceneFilterType{
+			Title: &models.StringCriterionInput{Value: v, Modifier: m},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		criteria string
+		want     *searchFilter
+		wantErr  bool
+	}{
+		{"all", "*", &searchFilter{}, false},
+		{"empty", "", &searchFilter{}, false},
+		{
+			"title",
+			`dc:title contains "foo"`,
+			&searchFilter{filter: title("foo", models.CriterionModifierIncludes)},
+			false,
+		},
+		{
+			"escaped quote",
+			`dc:title = "a \"b\""`,
+			&searchFilter{filter: title(`a "b"`, models.CriterionModifierEquals)},
+			false,
+		},
+		{
+			"video class and title",
+			`upnp:class derivedfrom "object.item.videoItem" and dc:title contains "foo"`,
+			&searchFilter{filter: title("foo", models.CriterionModifierIncludes)},
+			false,
+		},
+		{
+			"image class",
+			`upnp:class derivedfrom "object.item.imageItem" and dc:title contains "foo"`,
+			&searchFilter{none: true},
+			false,
+		},
+		{
+			"classes or",
+			`(upnp:class derivedfrom "object.item.audioItem" or upnp:class derivedfrom "object.item") and dc:title doesNotContain "foo"`,
+			&searchFilter{filter: title("foo", models.CriterionModifierExcludes)},
+			false,
+		},
+		{
+			"artist or genre",
+			`upnp:artist contains "foo" or upnp:genre = "bar"`,
+			&searchFilter{filter: &models.SceneFilterType{
+				PerformersFilter: &models.PerformerFilterType{
+					Name: &models.StringCriterionInput{Value: "foo", Modifier: models.CriterionModifierIncludes},
+				},
+				OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+					Or: &models.SceneFilterType{
+						TagsFilter: &models.TagFilterType{
+							Name: &models.StringCriterionInput{Value: "bar", Modifier: models.CriterionModifierEquals},
+						},
+					},
+				},
+			}},
+			false,
+		},
+		{
+			"title not equals",
+			`dc:title != "foo"`,
+			&searchFilter{filter: title("foo", models.CriterionModifierNotEquals)},
+			false,
+		},
+		{"exists", `@refID exists false`, &searchFilter{}, false},
+		{"unterminated", `dc:title contains "foo`, nil, true},
+		{"missing value", `dc:title contains`, nil, true},
+		{"unbalanced", `(dc:title contains "foo"`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSearchCriteria(tt.criteria)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseSearchCriteria() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, errInvalidSearchCriteria))
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSearchExclusions(t *testing.T) {
+	sf, err := parseSearchCriteria(`upnp:artist != "foo" and upnp:genre doesNotContain "bar"`)
+	if err != nil {
+		t.Fatalf("parseSearchCriteria() error = %v", err)
+	}
+
+	assert := assert.New(t)
+	if !assert.Len(sf.exclusions, 2) {
+		return
+	}
+
+	performers := &mocks.PerformerReaderWriter{}
+	performers.On("Query", mock.Anything, &models.PerformerFilterType{
+		Name: &models.StringCriterionInput{Value: "foo", Modifier: models.CriterionModifierEquals},
+	}, mock.Anything).Return([]*models.Performer{{ID: 1}, {ID: 2}}, 2, nil)
+
+	tags := &mocks.TagReaderWriter{}
+	tags.On("Query", mock.Anything, &models.TagFilterType{
+		Name: &models.StringCriterionInput{Value: "bar", Modifier: models.CriterionModifierIncludes},
+	}, mock.Anything).Return([]*models.Tag{{ID: 3}}, 1, nil)
+
+	r := Repository{
+		PerformerFinder: performers,
+		TagFinder:       tags,
+	}
+
+	if err := sf.resolveExclusions(context.Background(), r); err != nil {
+		t.Fatalf("resolveExclusions() error = %v", err)
+	}
+
+	// scenes with any of the matching performers or tags are excluded
+	want := &models.SceneFilterType{
+		Performers: &models.MultiCriterionInput{
+			Value:    []string{"1", "2"},
+			Modifier: models.CriterionModifierExcludes,
+		},
+		OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+			And: &models.SceneFilterType{
+				Tags: &models.HierarchicalMultiCriterionInput{
+					Value:    []string{"3"},
+					Modifier: models.CriterionModifierExcludes,
+				},
+			},
+		},
+	}
+	assert.Equal(want, sf.filter)
+
+	performers.AssertExpectations(t)
+	tags.AssertExpectations(t)
+}
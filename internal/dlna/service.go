@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/txn"
@@ -18,11 +19,21 @@ type Repository struct {
 	TxnManager models.TxnManager
 
 	SceneFinder     SceneFinder
+	SceneActivity   SceneActivityWriter
 	FileGetter      models.FileGetter
 	StudioFinder    StudioFinder
 	TagFinder       TagFinder
 	PerformerFinder PerformerFinder
 	GroupFinder     GroupFinder
+	ImageFinder     ImageFinder
+	GalleryFinder   GalleryFinder
+
+	SavedFilterFinder SavedFilterFinder
+
+	RestrictionProfile RestrictionProfileFinder
+
+	// set by NewService
+	restrictionConfig restrictionConfig
 }
 
 func NewRepository(repo models.Repository) Repository {
@@ -30,15 +41,66 @@ func NewRepository(repo models.Repository) Repository {
 		TxnManager:      repo.TxnManager,
 		FileGetter:      repo.File,
 		SceneFinder:     repo.Scene,
+		SceneActivity:   repo.Scene,
 		StudioFinder:    repo.Studio,
 		TagFinder:       repo.Tag,
 		PerformerFinder: repo.Performer,
 		GroupFinder:     repo.Group,
+		ImageFinder:     repo.Image,
+		GalleryFinder:   repo.Gallery,
+
+		SavedFilterFinder: repo.SavedFilter,
+
+		RestrictionProfile: repo.RestrictionProfile,
 	}
 }
 
+// WithReadTxn runs fn in a read transaction. Content hidden by the configured
+// restriction profile is excluded from queries run by fn.
 func (r *Repository) WithReadTxn(ctx context.Context, fn txn.TxnFunc) error {
-	return txn.WithReadTxn(ctx, r.TxnManager, fn)
+	return txn.WithReadTxn(ctx, r.TxnManager, func(ctx context.Context) error {
+		ctx, err := r.withContentRestriction(ctx)
+		if err != nil {
+			return err
+		}
+
+		return fn(ctx)
+	})
+}
+
+// WithTxn runs fn in a write transaction. Content hidden by the configured
+// restriction profile is excluded from queries run by fn.
+func (r *Repository) WithTxn(ctx context.Context, fn txn.TxnFunc) error {
+	return txn.WithTxn(ctx, r.TxnManager, func(ctx context.Context) error {
+		ctx, err := r.withContentRestriction(ctx)
+		if err != nil {
+			return err
+		}
+
+		return fn(ctx)
+	})
+}
+
+func (r *Repository) withContentRestriction(ctx context.Context) (context.Context, error) {
+	if r.restrictionConfig == nil || r.RestrictionProfile == nil {
+		return ctx, nil
+	}
+
+	profileID := r.restrictionConfig.GetDLNARestrictionProfile()
+	if profileID == 0 {
+		return ctx, nil
+	}
+
+	restriction, err := r.RestrictionProfile.GetContentRestriction(ctx, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("getting content restriction: %w", err)
+	}
+
+	if restriction == nil {
+		return ctx, nil
+	}
+
+	return models.WithContentRestriction(ctx, restriction), nil
 }
 
 type Status struct {
@@ -68,10 +130,23 @@ type dmsConfig struct {
 
 type sceneServer interface {
 	StreamSceneDirect(scene *models.Scene, w http.ResponseWriter, r *http.Request)
+	StreamSceneTranscode(scene *models.Scene, options ffmpeg.TranscodeOptions, w http.ResponseWriter, r *http.Request)
 	ServeScreenshot(scene *models.Scene, w http.ResponseWriter, r *http.Request)
 }
 
+type imageServer interface {
+	ServeImage(image *models.Image, w http.ResponseWriter, r *http.Request)
+	ServeThumbnail(image *models.Image, w http.ResponseWriter, r *http.Request)
+}
+
+type restrictionConfig interface {
+	GetDLNARestrictionProfile() int
+}
+
 type Config interface {
+	restrictionConfig
+	activityConfig
+	rendererConfig
 	GetDLNAInterfaces() []string
 	GetDLNAServerName() string
 	GetDLNADefaultIPWhitelist() []string
@@ -83,6 +158,7 @@ type Service struct {
 	repository     Repository
 	config         Config
 	sceneServer    sceneServer
+	imageServer    imageServer
 	ipWhitelistMgr *ipWhitelistManager
 
 	server  *Server
@@ -154,6 +230,9 @@ func (s *Service) init() error {
 	s.server = &Server{
 		repository:         s.repository,
 		sceneServer:        s.sceneServer,
+		imageServer:        s.imageServer,
+		playTracker:        newPlayTracker(s.repository, s.config),
+		rendererConfig:     s.config,
 		ipWhitelistManager: s.ipWhitelistMgr,
 		Interfaces:         interfaces,
 		HTTPConn: func() net.Listener {
@@ -215,10 +294,13 @@ func (s *Service) init() error {
 // }
 
 // NewService initialises and returns a new DLNA service.
-func NewService(repo Repository, cfg Config, sceneServer sceneServer) *Service {
+func NewService(repo Repository, cfg Config, sceneServer sceneServer, imageServer imageServer) *Service {
+	repo.restrictionConfig = cfg
+
 	ret := &Service{
 		repository:  repo,
 		sceneServer: sceneServer,
+		imageServer: imageServer,
 		config:      cfg,
 		ipWhitelistMgr: &ipWhitelistManager{
 			config: cfg,
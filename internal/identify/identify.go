@@ -36,10 +36,13 @@ type SceneUpdatePostHookExecutor interface {
 }
 
 type ScraperSource struct {
-	Name       string
-	Options    *MetadataOptions
-	Scraper    SceneScraper
-	RemoteSite string
+	Name    string
+	Options *MetadataOptions
+	Scraper SceneScraper
+	// ImageScraper is used to identify images. Sources without an image
+	// scraper are skipped when identifying images.
+	ImageScraper ImageScraper
+	RemoteSite   string
 }
 
 type SceneIdentifier struct {
@@ -125,11 +128,15 @@ func (t *SceneIdentifier) scrapeScene(ctx context.Context, scene *models.Scene)
 	return nil, nil
 }
 
-// Returns a MetadataOptions object with any default options overwritten by source specific options
 func (t *SceneIdentifier) getOptions(source ScraperSource) MetadataOptions {
+	return getOptions(t.DefaultOptions, source)
+}
+
+// Returns a MetadataOptions object with any default options overwritten by source specific options
+func getOptions(defaultOptions *MetadataOptions, source ScraperSource) MetadataOptions {
 	var options MetadataOptions
-	if t.DefaultOptions != nil {
-		options = *t.DefaultOptions
+	if defaultOptions != nil {
+		options = *defaultOptions
 	}
 	if source.Options == nil {
 		return options
@@ -0,0 +1,335 @@
+package identify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type ImageScraper interface {
+	ScrapeImages(ctx context.Context, imageID int) ([]*scraper.ScrapedImage, error)
+}
+
+type ImageUpdatePostHookExecutor interface {
+	ExecuteImageUpdatePostHooks(ctx context.Context, input models.ImageUpdateInput, inputFields []string)
+}
+
+type ImageReaderUpdater interface {
+	models.ImageUpdater
+	models.PerformerIDLoader
+	models.TagIDLoader
+	models.URLLoader
+}
+
+type ImageIdentifier struct {
+	TxnManager         txn.Manager
+	ImageReaderUpdater ImageReaderUpdater
+	StudioReaderWriter models.StudioReaderWriter
+	PerformerCreator   PerformerCreator
+	TagFinderCreator   models.TagFinderCreator
+
+	DefaultOptions              *MetadataOptions
+	Sources                     []ScraperSource
+	ImageUpdatePostHookExecutor ImageUpdatePostHookExecutor
+}
+
+type imageScrapeResult struct {
+	result *scraper.ScrapedImage
+	source ScraperSource
+}
+
+func (t *ImageIdentifier) Identify(ctx context.Context, i *models.Image) error {
+	result, err := t.scrapeImage(ctx, i)
+	var multipleMatchErr *MultipleMatchesFoundError
+	if err != nil {
+		if !errors.As(err, &multipleMatchErr) {
+			return err
+		}
+	}
+
+	if result == nil {
+		if multipleMatchErr != nil {
+			logger.Debugf("Identify skipped because multiple results returned for %s", i.DisplayName())
+
+			// find if the image should be tagged for multiple results
+			options := getOptions(t.DefaultOptions, multipleMatchErr.Source)
+			if options.SkipMultipleMatchTag != nil && len(*options.SkipMultipleMatchTag) > 0 {
+				return t.addTagToImage(ctx, i, *options.SkipMultipleMatchTag)
+			}
+		} else {
+			logger.Debugf("Unable to identify %s", i.DisplayName())
+		}
+		return nil
+	}
+
+	// results were found, modify the image
+	if err := t.modifyImage(ctx, i, result); err != nil {
+		return fmt.Errorf("error modifying image: %v", err)
+	}
+
+	return nil
+}
+
+func (t *ImageIdentifier) scrapeImage(ctx context.Context, i *models.Image) (*imageScrapeResult, error) {
+	// iterate through the input sources
+	for _, source := range t.Sources {
+		if source.ImageScraper == nil {
+			// source does not support images
+			continue
+		}
+
+		results, err := source.ImageScraper.ScrapeImages(ctx, i.ID)
+		if err != nil {
+			logger.Errorf("error scraping from %s: %v", source.Name, err)
+			continue
+		}
+
+		if len(results) > 0 {
+			options := getOptions(t.DefaultOptions, source)
+			if len(results) > 1 && utils.IsTrue(options.SkipMultipleMatches) {
+				return nil, &MultipleMatchesFoundError{
+					Source: source,
+				}
+			}
+
+			// if results were found then return
+			return &imageScrapeResult{
+				result: results[0],
+				source: source,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t *ImageIdentifier) getImagePartial(ctx context.Context, i *models.Image, result *imageScrapeResult) (*models.ImagePartial, error) {
+	allOptions := []MetadataOptions{}
+	if result.source.Options != nil {
+		allOptions = append(allOptions, *result.source.Options)
+	}
+	if t.DefaultOptions != nil {
+		allOptions = append(allOptions, *t.DefaultOptions)
+	}
+
+	fieldOptions := getFieldOptions(allOptions)
+	options := getOptions(t.DefaultOptions, result.source)
+
+	scraped := result.result
+	endpoint := result.source.RemoteSite
+
+	ret := getImagePartial(i, scraped, fieldOptions, utils.IsTrue(options.SetOrganized))
+
+	studioID, err := getStudioID(ctx, t.StudioReaderWriter, endpoint, i.StudioID, scraped.Studio, fieldOptions["studio"])
+	if err != nil {
+		return nil, fmt.Errorf("error getting studio: %w", err)
+	}
+
+	if studioID != nil {
+		ret.StudioID = models.NewOptionalInt(*studioID)
+	}
+
+	includeMalePerformers := true
+	if options.IncludeMalePerformers != nil {
+		includeMalePerformers = *options.IncludeMalePerformers
+	}
+
+	addSkipSingleNamePerformerTag := false
+	performerIDs, err := getPerformerIDs(ctx, t.PerformerCreator, endpoint, i.PerformerIDs.List(), scraped.Performers, fieldOptions["performers"], !includeMalePerformers, utils.IsTrue(options.SkipSingleNamePerformers))
+	if err != nil {
+		if errors.Is(err, ErrSkipSingleNamePerformer) {
+			addSkipSingleNamePerformerTag = true
+		} else {
+			return nil, err
+		}
+	}
+	if performerIDs != nil {
+		ret.PerformerIDs = &models.UpdateIDs{
+			IDs:  performerIDs,
+			Mode: models.RelationshipUpdateModeSet,
+		}
+	}
+
+	tagIDs, err := getTagIDs(ctx, t.TagFinderCreator, i.TagIDs.List(), scraped.Tags, fieldOptions["tags"])
+	if err != nil {
+		return nil, err
+	}
+	if addSkipSingleNamePerformerTag && options.SkipSingleNamePerformerTag != nil {
+		tagID, err := strconv.ParseInt(*options.SkipSingleNamePerformerTag, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("error converting tag ID %s: %w", *options.SkipSingleNamePerformerTag, err)
+		}
+
+		tagIDs = sliceutil.AppendUnique(tagIDs, int(tagID))
+	}
+	if tagIDs != nil {
+		ret.TagIDs = &models.UpdateIDs{
+			IDs:  tagIDs,
+			Mode: models.RelationshipUpdateModeSet,
+		}
+	}
+
+	return &ret, nil
+}
+
+func (t *ImageIdentifier) modifyImage(ctx context.Context, i *models.Image, result *imageScrapeResult) error {
+	var partial *models.ImagePartial
+	if err := txn.WithTxn(ctx, t.TxnManager, func(ctx context.Context) error {
+		// load image relationships
+		if err := i.LoadURLs(ctx, t.ImageReaderUpdater); err != nil {
+			return err
+		}
+		if err := i.LoadPerformerIDs(ctx, t.ImageReaderUpdater); err != nil {
+			return err
+		}
+		if err := i.LoadTagIDs(ctx, t.ImageReaderUpdater); err != nil {
+			return err
+		}
+
+		var err error
+		partial, err = t.getImagePartial(ctx, i, result)
+		if err != nil {
+			return err
+		}
+
+		// don't update anything if nothing was set
+		if imagePartialIsEmpty(*partial) {
+			logger.Debugf("Nothing to set for %s", i.DisplayName())
+			partial = nil
+			return nil
+		}
+
+		partial.UpdatedAt = models.NewOptionalTime(time.Now())
+
+		if _, err := t.ImageReaderUpdater.UpdatePartial(ctx, i.ID, *partial); err != nil {
+			return fmt.Errorf("error updating image: %w", err)
+		}
+
+		as := ""
+		if partial.Title.Ptr() != nil {
+			as = fmt.Sprintf(" as %s", partial.Title.Value)
+		}
+		logger.Infof("Successfully identified %s%s using %s", i.DisplayName(), as, result.source.Name)
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// fire post-update hooks
+	if partial != nil {
+		updateInput := partial.UpdateInput(i.ID)
+		fields := utils.NotNilFields(updateInput, "json")
+		t.ImageUpdatePostHookExecutor.ExecuteImageUpdatePostHooks(ctx, updateInput, fields)
+	}
+
+	return nil
+}
+
+func (t *ImageIdentifier) addTagToImage(ctx context.Context, i *models.Image, tagToAdd string) error {
+	return txn.WithTxn(ctx, t.TxnManager, func(ctx context.Context) error {
+		tagID, err := strconv.Atoi(tagToAdd)
+		if err != nil {
+			return fmt.Errorf("error converting tag ID %s: %w", tagToAdd, err)
+		}
+
+		if err := i.LoadTagIDs(ctx, t.ImageReaderUpdater); err != nil {
+			return err
+		}
+
+		if sliceutil.Contains(i.TagIDs.List(), tagID) {
+			// skip if the image was already tagged
+			return nil
+		}
+
+		if err := image.AddTag(ctx, t.ImageReaderUpdater, i, tagID); err != nil {
+			return err
+		}
+
+		ret, err := t.TagFinderCreator.Find(ctx, tagID)
+		if err != nil {
+			logger.Infof("Added tag id %s to skipped image %s", tagToAdd, i.DisplayName())
+		} else {
+			logger.Infof("Added tag %s to skipped image %s", ret.Name, i.DisplayName())
+		}
+
+		return nil
+	})
+}
+
+func imagePartialIsEmpty(p models.ImagePartial) bool {
+	return !p.Title.Set && !p.Code.Set && !p.Details.Set && !p.Photographer.Set &&
+		!p.Date.Set && p.URLs == nil && !p.Organized.Set && !p.StudioID.Set &&
+		p.PerformerIDs == nil && p.TagIDs == nil
+}
+
+func getImagePartial(i *models.Image, scraped *scraper.ScrapedImage, fieldOptions map[string]*FieldOptions, setOrganized bool) models.ImagePartial {
+	partial := models.ImagePartial{}
+
+	if scraped.Title != nil && (i.Title != *scraped.Title) {
+		if shouldSetSingleValueField(fieldOptions["title"], i.Title != "") {
+			partial.Title = models.NewOptionalString(*scraped.Title)
+		}
+	}
+	if scraped.Code != nil && (i.Code != *scraped.Code) {
+		if shouldSetSingleValueField(fieldOptions["code"], i.Code != "") {
+			partial.Code = models.NewOptionalString(*scraped.Code)
+		}
+	}
+	if scraped.Details != nil && (i.Details != *scraped.Details) {
+		if shouldSetSingleValueField(fieldOptions["details"], i.Details != "") {
+			partial.Details = models.NewOptionalString(*scraped.Details)
+		}
+	}
+	if scraped.Photographer != nil && (i.Photographer != *scraped.Photographer) {
+		if shouldSetSingleValueField(fieldOptions["photographer"], i.Photographer != "") {
+			partial.Photographer = models.NewOptionalString(*scraped.Photographer)
+		}
+	}
+	if scraped.Date != nil && (i.Date == nil || i.Date.String() != *scraped.Date) {
+		if shouldSetSingleValueField(fieldOptions["date"], i.Date != nil) {
+			d, err := models.ParseDate(*scraped.Date)
+			if err == nil {
+				partial.Date = models.NewOptionalDate(d)
+			}
+		}
+	}
+	if len(scraped.URLs) > 0 && shouldSetSingleValueField(fieldOptions["url"], false) {
+		switch getFieldStrategy(fieldOptions["url"]) {
+		case FieldStrategyOverwrite:
+			// only overwrite if not equal
+			if len(sliceutil.Exclude(scraped.URLs, i.URLs.List())) != 0 {
+				partial.URLs = &models.UpdateStrings{
+					Values: scraped.URLs,
+					Mode:   models.RelationshipUpdateModeSet,
+				}
+			}
+		case FieldStrategyMerge:
+			// if merge, add if not already present
+			urls := sliceutil.AppendUniques(i.URLs.List(), scraped.URLs)
+
+			if len(urls) != len(i.URLs.List()) {
+				partial.URLs = &models.UpdateStrings{
+					Values: urls,
+					Mode:   models.RelationshipUpdateModeSet,
+				}
+			}
+		}
+	}
+
+	if setOrganized && !i.Organized {
+		partial.Organized = models.NewOptionalBool(true)
+	}
+
+	return partial
+}
@@ -0,0 +1,163 @@
+package identify
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_getImagePartial(t *testing.T) {
+	var (
+		originalTitle        = "originalTitle"
+		originalPhotographer = "originalPhotographer"
+		originalURL          = "originalURL"
+	)
+
+	var (
+		scrapedTitle        = "scrapedTitle"
+		scrapedPhotographer = "scrapedPhotographer"
+		scrapedDate         = "2002-02-02"
+		scrapedURL          = "scrapedURL"
+	)
+
+	scrapedDateObj, _ := models.ParseDate(scrapedDate)
+
+	originalImage := &models.Image{
+		Title:        originalTitle,
+		Photographer: originalPhotographer,
+		URLs:         models.NewRelatedStrings([]string{originalURL}),
+	}
+
+	organisedImage := *originalImage
+	organisedImage.Organized = true
+
+	scrapedImage := &scraper.ScrapedImage{
+		Title:        &scrapedTitle,
+		Photographer: &scrapedPhotographer,
+		Date:         &scrapedDate,
+		URLs:         []string{scrapedURL},
+	}
+
+	scrapedUnchangedImage := &scraper.ScrapedImage{
+		Title:        &originalTitle,
+		Photographer: &originalPhotographer,
+		URLs:         []string{originalURL},
+	}
+
+	makeFieldOptions := func(input *FieldOptions) map[string]*FieldOptions {
+		return map[string]*FieldOptions{
+			"title":        input,
+			"photographer": input,
+			"date":         input,
+			"url":          input,
+		}
+	}
+
+	overwriteAll := makeFieldOptions(&FieldOptions{
+		Strategy: FieldStrategyOverwrite,
+	})
+	ignoreAll := makeFieldOptions(&FieldOptions{
+		Strategy: FieldStrategyIgnore,
+	})
+	mergeAll := makeFieldOptions(&FieldOptions{
+		Strategy: FieldStrategyMerge,
+	})
+
+	type args struct {
+		image        *models.Image
+		scraped      *scraper.ScrapedImage
+		fieldOptions map[string]*FieldOptions
+		setOrganized bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want models.ImagePartial
+	}{
+		{
+			"overwrite all",
+			args{
+				originalImage,
+				scrapedImage,
+				overwriteAll,
+				false,
+			},
+			models.ImagePartial{
+				Title:        models.NewOptionalString(scrapedTitle),
+				Photographer: models.NewOptionalString(scrapedPhotographer),
+				Date:         models.NewOptionalDate(scrapedDateObj),
+				URLs: &models.UpdateStrings{
+					Values: []string{scrapedURL},
+					Mode:   models.RelationshipUpdateModeSet,
+				},
+			},
+		},
+		{
+			"ignore all",
+			args{
+				originalImage,
+				scrapedImage,
+				ignoreAll,
+				false,
+			},
+			models.ImagePartial{},
+		},
+		{
+			"merge (existing values)",
+			args{
+				originalImage,
+				scrapedImage,
+				mergeAll,
+				false,
+			},
+			models.ImagePartial{
+				Date: models.NewOptionalDate(scrapedDateObj),
+				URLs: &models.UpdateStrings{
+					Values: []string{originalURL, scrapedURL},
+					Mode:   models.RelationshipUpdateModeSet,
+				},
+			},
+		},
+		{
+			"unchanged",
+			args{
+				originalImage,
+				scrapedUnchangedImage,
+				overwriteAll,
+				false,
+			},
+			models.ImagePartial{},
+		},
+		{
+			"set organized",
+			args{
+				originalImage,
+				scrapedUnchangedImage,
+				overwriteAll,
+				true,
+			},
+			models.ImagePartial{
+				Organized: models.NewOptionalBool(true),
+			},
+		},
+		{
+			"set organized unchanged",
+			args{
+				&organisedImage,
+				scrapedUnchangedImage,
+				overwriteAll,
+				true,
+			},
+			models.ImagePartial{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getImagePartial(tt.args.image, tt.args.scraped, tt.args.fieldOptions, tt.args.setOrganized)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
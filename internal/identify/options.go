@@ -21,8 +21,10 @@ type Options struct {
 	Options *MetadataOptions `json:"options"`
 	// scene ids to identify
 	SceneIDs []string `json:"sceneIDs"`
-	// paths of scenes to identify - ignored if scene ids are set
+	// paths of scenes and images to identify - ignored if scene or image ids are set
 	Paths []string `json:"paths"`
+	// image ids to identify
+	ImageIDs []string `json:"imageIDs"`
 }
 
 type MetadataOptions struct {
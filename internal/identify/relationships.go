@@ -0,0 +1,148 @@
+package identify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// getStudioID returns the id of the scraped studio, creating it if needed.
+// Returns nil if the studio should not be set or is unchanged.
+func getStudioID(ctx context.Context, w models.StudioReaderWriter, endpoint string, existingID *int, scraped *models.ScrapedStudio, fieldStrategy *FieldOptions) (*int, error) {
+	createMissing := fieldStrategy != nil && utils.IsTrue(fieldStrategy.CreateMissing)
+
+	if scraped == nil || !shouldSetSingleValueField(fieldStrategy, existingID != nil) {
+		return nil, nil
+	}
+
+	if scraped.StoredID != nil {
+		// existing studio, just set it
+		studioID, err := strconv.Atoi(*scraped.StoredID)
+		if err != nil {
+			return nil, fmt.Errorf("error converting studio ID %s: %w", *scraped.StoredID, err)
+		}
+
+		// only return value if different to current
+		if existingID == nil || *existingID != studioID {
+			return &studioID, nil
+		}
+	} else if createMissing {
+		return createMissingStudio(ctx, endpoint, w, scraped)
+	}
+
+	return nil, nil
+}
+
+// getPerformerIDs returns the performer ids to set from the scraped
+// performers, creating missing performers if needed. Returns nil if the
+// performers should not be set or are unchanged.
+func getPerformerIDs(ctx context.Context, w PerformerCreator, endpoint string, originalPerformerIDs []int, scraped []*models.ScrapedPerformer, fieldStrategy *FieldOptions, ignoreMale bool, skipSingleNamePerformers bool) ([]int, error) {
+	// just check if ignored
+	if len(scraped) == 0 || !shouldSetSingleValueField(fieldStrategy, false) {
+		return nil, nil
+	}
+
+	createMissing := fieldStrategy != nil && utils.IsTrue(fieldStrategy.CreateMissing)
+	strategy := FieldStrategyMerge
+	if fieldStrategy != nil {
+		strategy = fieldStrategy.Strategy
+	}
+
+	var performerIDs []int
+
+	if strategy == FieldStrategyMerge {
+		// add to existing
+		performerIDs = originalPerformerIDs
+	}
+
+	singleNamePerformerSkipped := false
+
+	for _, p := range scraped {
+		if ignoreMale && p.Gender != nil && strings.EqualFold(*p.Gender, models.GenderEnumMale.String()) {
+			continue
+		}
+
+		performerID, err := getPerformerID(ctx, endpoint, w, p, createMissing, skipSingleNamePerformers)
+		if err != nil {
+			if errors.Is(err, ErrSkipSingleNamePerformer) {
+				singleNamePerformerSkipped = true
+				continue
+			}
+			return nil, err
+		}
+
+		if performerID != nil {
+			performerIDs = sliceutil.AppendUnique(performerIDs, *performerID)
+		}
+	}
+
+	// don't return if nothing was added
+	if sliceutil.SliceSame(originalPerformerIDs, performerIDs) {
+		if singleNamePerformerSkipped {
+			return nil, ErrSkipSingleNamePerformer
+		}
+		return nil, nil
+	}
+
+	if singleNamePerformerSkipped {
+		return performerIDs, ErrSkipSingleNamePerformer
+	}
+	return performerIDs, nil
+}
+
+// getTagIDs returns the tag ids to set from the scraped tags, creating missing
+// tags if needed. Returns nil if the tags should not be set or are unchanged.
+func getTagIDs(ctx context.Context, w models.TagCreator, originalTagIDs []int, scraped []*models.ScrapedTag, fieldStrategy *FieldOptions) ([]int, error) {
+	// just check if ignored
+	if len(scraped) == 0 || !shouldSetSingleValueField(fieldStrategy, false) {
+		return nil, nil
+	}
+
+	createMissing := fieldStrategy != nil && utils.IsTrue(fieldStrategy.CreateMissing)
+	strategy := FieldStrategyMerge
+	if fieldStrategy != nil {
+		strategy = fieldStrategy.Strategy
+	}
+
+	var tagIDs []int
+
+	if strategy == FieldStrategyMerge {
+		// add to existing
+		tagIDs = originalTagIDs
+	}
+
+	for _, t := range scraped {
+		if t.StoredID != nil {
+			// existing tag, just add it
+			tagID, err := strconv.ParseInt(*t.StoredID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error converting tag ID %s: %w", *t.StoredID, err)
+			}
+
+			tagIDs = sliceutil.AppendUnique(tagIDs, int(tagID))
+		} else if createMissing {
+			newTag := models.NewTag()
+			newTag.Name = t.Name
+
+			err := w.Create(ctx, &newTag)
+			if err != nil {
+				return nil, fmt.Errorf("error creating tag: %w", err)
+			}
+
+			tagIDs = append(tagIDs, newTag.ID)
+		}
+	}
+
+	// don't return if nothing was added
+	if sliceutil.SliceSame(originalTagIDs, tagIDs) {
+		return nil, nil
+	}
+
+	return tagIDs, nil
+}
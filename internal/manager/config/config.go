@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"sync"
 	// "github.com/sasha-s/go-deadlock" // if you have deadlock issues
@@ -69,6 +70,7 @@ const (
 
 	MaxTranscodeSize          = "max_transcode_size"
 	MaxStreamingTranscodeSize = "max_streaming_transcode_size"
+	TranscodeVideoCodec       = "transcode_video_codec"
 
 	// ffmpeg extra args options
 	TranscodeInputArgs      = "ffmpeg.transcode.input_args"
@@ -79,6 +81,13 @@ const (
 	ParallelTasks        = "parallel_tasks"
 	parallelTasksDefault = 1
 
+	// maximum number of queued jobs of each class that may run concurrently
+	IOJobSlots      = "job_slots.io"
+	CPUJobSlots     = "job_slots.cpu"
+	NetworkJobSlots = "job_slots.network"
+	GeneralJobSlots = "job_slots.general"
+	jobSlotsDefault = 1
+
 	PreviewPreset                 = "preview_preset"
 	TranscodeHardwareAcceleration = "ffmpeg.hardware_acceleration"
 
@@ -134,6 +143,10 @@ const (
 	ScraperCDPPath            = "scraper_cdp_path"
 	ScraperExcludeTagPatterns = "scraper_exclude_tag_patterns"
 
+	// time in minutes that scraper responses are cached for
+	ScraperCacheTTL        = "scraper_cache_ttl"
+	scraperCacheTTLDefault = 10
+
 	// stash-box options
 	StashBoxes = "stash_boxes"
 
@@ -145,6 +158,10 @@ const (
 	PluginsSettingPrefix = PluginsSetting + "."
 	DisabledPlugins      = "plugins.disabled"
 
+	// total time allowed for the pre-hooks of a single operation, in seconds
+	PluginsPreHookTimeout        = "plugins.pre_hook_timeout"
+	pluginsPreHookTimeoutDefault = 10
+
 	sourceDefaultPath = "community"
 	sourceDefaultName = "Community (stable)"
 
@@ -235,6 +252,26 @@ const (
 	sslCertPath = "ssl_cert_path"
 	sslKeyPath  = "ssl_key_path"
 
+	// Restriction profiles
+	RestrictionProfile       = "restriction_profile"
+	APIKeyRestrictionProfile = "api_key_restriction_profile"
+
+	// External authentication
+	OIDCIssuer               = "oidc.issuer"
+	OIDCClientID             = "oidc.client_id"
+	OIDCClientSecret         = "oidc.client_secret"
+	OIDCRedirectURL          = "oidc.redirect_url"
+	OIDCScopes               = "oidc.scopes"
+	OIDCUsernameClaim        = "oidc.username_claim"
+	oidcUsernameClaimDefault = "preferred_username"
+
+	TrustedHeader        = "trusted_header.header"
+	TrustedHeaderProxies = "trusted_header.proxies"
+
+	ExternalAuthCreateUsers        = "external_auth.create_users"
+	ExternalAuthDefaultRole        = "external_auth.default_role"
+	externalAuthDefaultRoleDefault = "VIEWER"
+
 	// DLNA options
 	DLNAServerName         = "dlna.server_name"
 	DLNADefaultEnabled     = "dlna.default_enabled"
@@ -247,6 +284,13 @@ const (
 	DLNAPort        = "dlna.port"
 	DLNAPortDefault = 1338
 
+	DLNARestrictionProfile = "dlna.restriction_profile"
+
+	DLNAMinimumPlayPercent        = "dlna.minimum_play_percent"
+	dlnaMinimumPlayPercentDefault = 50
+
+	DLNARendererProfiles = "dlna.renderer_profiles"
+
 	// Logging options
 	LogFile          = "logfile"
 	LogOut           = "logout"
@@ -836,6 +880,12 @@ func (i *Config) GetScraperCertCheck() bool {
 	return i.getBoolDefault(ScraperCertCheck, true)
 }
 
+// GetScraperCacheTTL returns the time that scraper responses are cached
+// for. Responses are not cached if zero.
+func (i *Config) GetScraperCacheTTL() time.Duration {
+	return time.Duration(i.getInt(ScraperCacheTTL)) * time.Minute
+}
+
 func (i *Config) GetScraperExcludeTagPatterns() []string {
 	return i.getStringSlice(ScraperExcludeTagPatterns)
 }
@@ -900,6 +950,13 @@ func (i *Config) SetPluginConfiguration(pluginID string, v map[string]interface{
 	i.set(key, v)
 }
 
+// GetPluginsPreHookTimeout returns the maximum time allowed for plugin
+// pre-hooks to complete before the operation is rejected. A zero value
+// means no timeout.
+func (i *Config) GetPluginsPreHookTimeout() time.Duration {
+	return time.Duration(i.getInt(PluginsPreHookTimeout)) * time.Second
+}
+
 func (i *Config) GetDisabledPlugins() []string {
 	return i.getStringSlice(DisabledPlugins)
 }
@@ -954,6 +1011,30 @@ func (i *Config) GetParallelTasksWithAutoDetection() int {
 	return parallelTasks
 }
 
+// GetIOJobSlots returns the number of IO-bound jobs, such as scanning, that
+// may run concurrently.
+func (i *Config) GetIOJobSlots() int {
+	return i.getInt(IOJobSlots)
+}
+
+// GetCPUJobSlots returns the number of CPU-bound jobs, such as generating,
+// that may run concurrently.
+func (i *Config) GetCPUJobSlots() int {
+	return i.getInt(CPUJobSlots)
+}
+
+// GetNetworkJobSlots returns the number of network-bound jobs, such as
+// identifying and stash-box tagging, that may run concurrently.
+func (i *Config) GetNetworkJobSlots() int {
+	return i.getInt(NetworkJobSlots)
+}
+
+// GetGeneralJobSlots returns the number of other jobs, such as plugin tasks,
+// that may run concurrently.
+func (i *Config) GetGeneralJobSlots() int {
+	return i.getInt(GeneralJobSlots)
+}
+
 func (i *Config) GetPreviewAudio() bool {
 	return i.getBool(PreviewAudio)
 }
@@ -1021,6 +1102,17 @@ func (i *Config) GetMaxStreamingTranscodeSize() models.StreamingResolutionEnum {
 	return models.StreamingResolutionEnum(ret)
 }
 
+// GetTranscodeVideoCodec returns the video codec of generated transcodes.
+// Defaults to H264.
+func (i *Config) GetTranscodeVideoCodec() models.TranscodeVideoCodec {
+	ret := models.TranscodeVideoCodec(i.getString(TranscodeVideoCodec))
+	if !ret.IsValid() {
+		return models.TranscodeVideoCodecH264
+	}
+
+	return ret
+}
+
 func (i *Config) GetTranscodeInputArgs() []string {
 	return i.getStringSlice(TranscodeInputArgs)
 }
@@ -1055,6 +1147,28 @@ func (i *Config) GetAPIKey() string {
 	return i.getString(ApiKey)
 }
 
+// GetAPIKeyRestrictionProfile returns the ID of the restriction profile used
+// to hide content from requests authenticated with the configured API key.
+// Returns 0 if content is not restricted.
+func (i *Config) GetAPIKeyRestrictionProfile() int {
+	return i.getInt(APIKeyRestrictionProfile)
+}
+
+// GetRestrictionProfile returns the ID of the restriction profile applied to
+// all requests when authentication is not required. Returns 0 if content is
+// not restricted.
+func (i *Config) GetRestrictionProfile() int {
+	return i.getInt(RestrictionProfile)
+}
+
+// SetRestrictionProfile sets the ID of the restriction profile applied to all
+// requests when authentication is not required, and writes the
+// configuration.
+func (i *Config) SetRestrictionProfile(id int) error {
+	i.SetInt(RestrictionProfile, id)
+	return i.Write()
+}
+
 func (i *Config) GetUsername() string {
 	return i.getString(Username)
 }
@@ -1078,6 +1192,18 @@ func (i *Config) HasCredentials() bool {
 	return username != "" && pwHash != ""
 }
 
+// AuthenticationRequired returns true if requests must be authenticated,
+// either because credentials are configured or because single sign-on is
+// configured.
+func (i *Config) AuthenticationRequired() bool {
+	if i.HasCredentials() {
+		return true
+	}
+
+	oidcEnabled := i.GetOIDCIssuer() != "" && i.GetOIDCClientID() != ""
+	return oidcEnabled || i.GetTrustedHeader() != ""
+}
+
 func hashPassword(password string) string {
 	hash, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
 
@@ -1100,9 +1226,12 @@ func (i *Config) ValidateCredentials(username string, password string) bool {
 var stashBoxRe = regexp.MustCompile("^http.*graphql$")
 
 type StashBoxInput struct {
-	Endpoint string `json:"endpoint"`
-	APIKey   string `json:"api_key"`
-	Name     string `json:"name"`
+	Endpoint              string `json:"endpoint"`
+	APIKey                string `json:"api_key"`
+	Name                  string `json:"name"`
+	MaxRequestsPerMinute  int    `json:"max_requests_per_minute"`
+	MaxConcurrentRequests int    `json:"max_concurrent_requests"`
+	MaxRetries            int    `json:"max_retries"`
 }
 
 func (i *Config) ValidateStashBoxes(boxes []*StashBoxInput) error {
@@ -1125,6 +1254,10 @@ func (i *Config) ValidateStashBoxes(boxes []*StashBoxInput) error {
 		if isMulti && box.Name == "" {
 			return &StashBoxError{msg: "name cannot be blank"}
 		}
+
+		if box.MaxRequestsPerMinute < 0 || box.MaxConcurrentRequests < 0 || box.MaxRetries < 0 {
+			return &StashBoxError{msg: "rate limits cannot be negative"}
+		}
 	}
 
 	return nil
@@ -1145,6 +1278,81 @@ func (i *Config) GetMaxSessionAge() int {
 	return ret
 }
 
+// GetOIDCIssuer returns the URL of the OpenID Connect provider used to log
+// in. OpenID Connect login is disabled if empty.
+func (i *Config) GetOIDCIssuer() string {
+	return i.getString(OIDCIssuer)
+}
+
+func (i *Config) GetOIDCClientID() string {
+	return i.getString(OIDCClientID)
+}
+
+func (i *Config) GetOIDCClientSecret() string {
+	return i.getString(OIDCClientSecret)
+}
+
+// GetOIDCRedirectURL returns the URL that the OpenID Connect provider
+// redirects to after logging in. If empty, the URL is derived from the login
+// request.
+func (i *Config) GetOIDCRedirectURL() string {
+	return i.getString(OIDCRedirectURL)
+}
+
+// GetOIDCScopes returns the scopes requested from the OpenID Connect
+// provider, in addition to the openid scope.
+func (i *Config) GetOIDCScopes() []string {
+	i.RLock()
+	defer i.RUnlock()
+
+	v := i.forKey(OIDCScopes)
+	if v.Exists(OIDCScopes) {
+		return v.Strings(OIDCScopes)
+	}
+
+	return []string{"profile", "email"}
+}
+
+// GetOIDCUsernameClaim returns the ID token claim that is mapped to the
+// username of the local user.
+func (i *Config) GetOIDCUsernameClaim() string {
+	if ret := i.getString(OIDCUsernameClaim); ret != "" {
+		return ret
+	}
+
+	return oidcUsernameClaimDefault
+}
+
+// GetTrustedHeader returns the name of the request header containing the
+// username of a user authenticated by a reverse proxy. Header authentication
+// is disabled if empty.
+func (i *Config) GetTrustedHeader() string {
+	return i.getString(TrustedHeader)
+}
+
+// GetTrustedHeaderProxies returns the IP addresses or CIDR ranges of the
+// reverse proxies that are trusted to set the trusted header.
+func (i *Config) GetTrustedHeaderProxies() []string {
+	return i.getStringSlice(TrustedHeaderProxies)
+}
+
+// GetExternalAuthCreateUsers returns true if local users should be created
+// for externally authenticated users that do not have a local user.
+func (i *Config) GetExternalAuthCreateUsers() bool {
+	return i.getBool(ExternalAuthCreateUsers)
+}
+
+// GetExternalAuthDefaultRole returns the role of local users created for
+// externally authenticated users.
+func (i *Config) GetExternalAuthDefaultRole() models.UserRole {
+	ret := models.UserRole(strings.ToUpper(i.getString(ExternalAuthDefaultRole)))
+	if !ret.IsValid() {
+		ret = models.UserRole(externalAuthDefaultRoleDefault)
+	}
+
+	return ret
+}
+
 // GetCustomServedFolders gets the map of custom paths to their applicable
 // filesystem locations
 func (i *Config) GetCustomServedFolders() utils.URLMap {
@@ -1589,6 +1797,30 @@ func (i *Config) GetVideoSortOrder() string {
 	return ret
 }
 
+// GetDLNARestrictionProfile returns the ID of the restriction profile used
+// to hide content from DLNA clients. Returns 0 if content is not restricted.
+func (i *Config) GetDLNARestrictionProfile() int {
+	return i.getInt(DLNARestrictionProfile)
+}
+
+// GetDLNAMinimumPlayPercent returns the percentage of a scene that must be
+// streamed to a DLNA client before its play count is incremented. Returns 0
+// if plays are not counted.
+func (i *Config) GetDLNAMinimumPlayPercent() int {
+	return i.getInt(DLNAMinimumPlayPercent)
+}
+
+// GetDLNARendererProfiles returns the configured DLNA renderer profiles.
+// These take precedence over the built-in profiles.
+func (i *Config) GetDLNARendererProfiles() []*models.DLNARendererProfile {
+	var ret []*models.DLNARendererProfile
+	if err := i.unmarshalKey(DLNARendererProfiles, &ret); err != nil {
+		logger.Warnf("error in unmarshalkey: %v", err)
+	}
+
+	return ret
+}
+
 // GetLogFile returns the filename of the file to output logs to.
 // An empty string means that file logging will be disabled.
 func (i *Config) GetLogFile() string {
@@ -1762,6 +1994,11 @@ func (i *Config) setDefaultValues() {
 	i.setDefault(Port, portDefault)
 
 	i.setDefault(ParallelTasks, parallelTasksDefault)
+	i.setDefault(IOJobSlots, jobSlotsDefault)
+	i.setDefault(CPUJobSlots, jobSlotsDefault)
+	i.setDefault(NetworkJobSlots, jobSlotsDefault)
+	i.setDefault(GeneralJobSlots, jobSlotsDefault)
+	i.setDefault(PluginsPreHookTimeout, pluginsPreHookTimeoutDefault)
 	i.setDefault(SequentialScanning, SequentialScanningDefault)
 	i.setDefault(PreviewSegmentDuration, previewSegmentDurationDefault)
 	i.setDefault(PreviewSegments, previewSegmentsDefault)
@@ -1770,6 +2007,9 @@ func (i *Config) setDefaultValues() {
 	i.setDefault(PreviewAudio, previewAudioDefault)
 	i.setDefault(SoundOnPreview, false)
 
+	i.setDefault(DLNAMinimumPlayPercent, dlnaMinimumPlayPercentDefault)
+	i.setDefault(ScraperCacheTTL, scraperCacheTTLDefault)
+
 	i.setDefault(ThemeColor, DefaultThemeColor)
 
 	i.setDefault(WriteImageThumbnails, writeImageThumbnailsDefault)
@@ -0,0 +1,123 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/stashapp/stash/pkg/ffmpeg"
+	"github.com/stashapp/stash/pkg/file/video"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	// embeddedTracksCacheSize is the number of files whose embedded tracks
+	// are cached. The tracks of a file are small, so this is generous to
+	// avoid probing files when browsing scenes.
+	embeddedTracksCacheSize = 1000
+
+	// embeddedSubtitleCacheSize is the number of extracted subtitle tracks
+	// that are cached. A subtitle track is typically only requested while
+	// the scene is being played.
+	embeddedSubtitleCacheSize = 20
+)
+
+// embeddedTracksKey identifies the contents of a file, so that cached tracks
+// are not used once the file is changed.
+type embeddedTracksKey struct {
+	fileID  models.FileID
+	modTime time.Time
+	size    int64
+}
+
+func newEmbeddedTracksKey(f *models.VideoFile) embeddedTracksKey {
+	return embeddedTracksKey{
+		fileID: f.ID,
+		// remove the monotonic clock reading and location so that equal times
+		// are equal keys
+		modTime: f.ModTime.UTC().Round(0),
+		size:    f.Size,
+	}
+}
+
+type embeddedSubtitleKey struct {
+	embeddedTracksKey
+	track int
+}
+
+// EmbeddedTracks are the embedded audio and subtitle tracks of a video file.
+type EmbeddedTracks struct {
+	Audio    []ffmpeg.MediaTrack
+	Subtitle []ffmpeg.MediaTrack
+}
+
+var (
+	embeddedTracksCache   *lru.Cache[embeddedTracksKey, *EmbeddedTracks]
+	embeddedSubtitleCache *lru.Cache[embeddedSubtitleKey, []byte]
+)
+
+func init() {
+	embeddedTracksCache, _ = lru.New[embeddedTracksKey, *EmbeddedTracks](embeddedTracksCacheSize)
+	embeddedSubtitleCache, _ = lru.New[embeddedSubtitleKey, []byte](embeddedSubtitleCacheSize)
+}
+
+// GetEmbeddedTracks returns the embedded tracks of the video file. The file is
+// probed the first time its tracks are requested, and the result is cached
+// until the file is changed.
+func GetEmbeddedTracks(f *models.VideoFile) (*EmbeddedTracks, error) {
+	key := newEmbeddedTracksKey(f)
+	if ret, ok := embeddedTracksCache.Get(key); ok {
+		return ret, nil
+	}
+
+	ffprobe := GetInstance().FFProbe
+	if ffprobe == "" {
+		return nil, fmt.Errorf("ffprobe not available")
+	}
+
+	probeResult, err := ffprobe.NewVideoFile(f.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &EmbeddedTracks{
+		Audio:    probeResult.AudioTracks,
+		Subtitle: probeResult.SubtitleTracks,
+	}
+	embeddedTracksCache.Add(key, ret)
+
+	return ret, nil
+}
+
+// ExtractEmbeddedSubtitle returns the embedded subtitle track of the video
+// file with the given index, converted to WebVTT. Recently extracted tracks
+// are cached.
+func ExtractEmbeddedSubtitle(ctx context.Context, f *models.VideoFile, track int) ([]byte, error) {
+	key := embeddedSubtitleKey{
+		embeddedTracksKey: newEmbeddedTracksKey(f),
+		track:             track,
+	}
+	if ret, ok := embeddedSubtitleCache.Get(key); ok {
+		return ret, nil
+	}
+
+	tracks, err := GetEmbeddedTracks(f)
+	if err != nil {
+		return nil, err
+	}
+
+	encoder := GetInstance().FFMpeg
+	if encoder == nil {
+		return nil, fmt.Errorf("ffmpeg not available")
+	}
+
+	ret, err := video.ExtractSubtitleTrack(ctx, encoder, f.Path, tracks.Subtitle, track)
+	if err != nil {
+		return nil, err
+	}
+
+	embeddedSubtitleCache.Add(key, ret)
+
+	return ret, nil
+}
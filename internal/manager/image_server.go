@@ -0,0 +1,116 @@
+package manager
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"os/exec"
+
+	"github.com/stashapp/stash/internal/static"
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// ImageServer serves image files and thumbnails. The primary file of the
+// image must be loaded.
+type ImageServer struct{}
+
+// ServeImage serves the primary file of the image, including images
+// inside zip files.
+func (s *ImageServer) ServeImage(img *models.Image, w http.ResponseWriter, r *http.Request) {
+	const useDefault = false
+	s.serveImage(w, r, img, useDefault)
+}
+
+// ServeThumbnail serves the thumbnail of the image, generating it if it
+// has not been generated. The image itself is served if a thumbnail cannot
+// be generated.
+func (s *ImageServer) ServeThumbnail(img *models.Image, w http.ResponseWriter, r *http.Request) {
+	mgr := GetInstance()
+	filepath := mgr.Paths.Generated.GetThumbnailPath(img.Checksum, models.DefaultGthumbWidth)
+
+	// if the thumbnail doesn't exist, encode on the fly
+	exists, _ := fsutil.FileExists(filepath)
+	if exists {
+		utils.ServeStaticFile(w, r, filepath)
+	} else {
+		const useDefault = true
+
+		f := img.Files.Primary()
+		if f == nil {
+			s.serveImage(w, r, img, useDefault)
+			return
+		}
+
+		// use the image thumbnail generate wait group to limit the number of concurrent thumbnail generation tasks
+		wg := &mgr.ImageThumbnailGenerateWaitGroup
+		wg.Add()
+		defer wg.Done()
+
+		clipPreviewOptions := image.ClipPreviewOptions{
+			InputArgs:  mgr.Config.GetTranscodeInputArgs(),
+			OutputArgs: mgr.Config.GetTranscodeOutputArgs(),
+			Preset:     mgr.Config.GetPreviewPreset().String(),
+		}
+
+		encoder := image.NewThumbnailEncoder(mgr.FFMpeg, mgr.FFProbe, clipPreviewOptions)
+		data, err := encoder.GetThumbnail(f, models.DefaultGthumbWidth)
+		if err != nil {
+			// don't log for unsupported image format
+			// don't log for file not found - can optionally be logged in serveImage
+			if !errors.Is(err, image.ErrNotSupportedForThumbnail) && !errors.Is(err, fs.ErrNotExist) {
+				logger.Errorf("error generating thumbnail for %s: %v", f.Base().Path, err)
+
+				var exitErr *exec.ExitError
+				if errors.As(err, &exitErr) {
+					logger.Errorf("stderr: %s", string(exitErr.Stderr))
+				}
+			}
+
+			// backwards compatibility - fallback to original image instead
+			s.serveImage(w, r, img, useDefault)
+			return
+		}
+
+		// write the generated thumbnail to disk if enabled
+		if mgr.Config.IsWriteImageThumbnails() {
+			logger.Debugf("writing thumbnail to disk: %s", img.Path)
+			if err := fsutil.WriteFile(filepath, data); err == nil {
+				utils.ServeStaticFile(w, r, filepath)
+				return
+			}
+			logger.Errorf("error writing thumbnail for image %s: %v", img.Path, err)
+		}
+		utils.ServeStaticContent(w, r, data)
+	}
+}
+
+func (s *ImageServer) serveImage(w http.ResponseWriter, r *http.Request, i *models.Image, useDefault bool) {
+	if i.Files.Primary() != nil {
+		err := i.Files.Primary().Base().Serve(&file.OsFS{}, w, r)
+		if err == nil {
+			return
+		}
+
+		if !useDefault {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		// only log in debug since it can get noisy
+		logger.Debugf("Error serving %s: %v", i.DisplayName(), err)
+	}
+
+	if !useDefault {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	// fallback to default image
+	image := static.ReadAll(static.DefaultImageImage)
+	utils.ServeImage(w, r, image)
+}
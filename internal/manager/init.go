@@ -73,7 +73,7 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 	}
 
 	dlnaRepository := dlna.NewRepository(repo)
-	dlnaService := dlna.NewService(dlnaRepository, cfg, sceneServer)
+	dlnaService := dlna.NewService(dlnaRepository, cfg, sceneServer, &ImageServer{})
 
 	mgr := &Manager{
 		Config: cfg,
@@ -103,6 +103,9 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		scanSubs: &subscriptionManager{},
 	}
 
+	mgr.jobHooks = newJobCompleteHooks(mgr.JobManager, pluginCache)
+	mgr.Scheduler = newScheduler(repo, db, mgr.JobManager, mgr.queueScheduledTask)
+
 	if !cfg.IsNewSystem() {
 		logger.Infof("using config file: %s", cfg.GetConfigFile())
 
@@ -123,8 +126,9 @@ func Initialize(cfg *config.Config, l *log.Logger) (*Manager, error) {
 		}
 
 		// create temporary session store - this will be re-initialised
-		// after config is complete
-		mgr.SessionStore = session.NewStore(cfg)
+		// after config is complete. Local user accounts are not available
+		// until the database is initialised.
+		mgr.SessionStore = session.NewStore(cfg, session.Repository{})
 
 		logger.Warnf("config file %snot found. Assuming new system...", cfgFile)
 	}
@@ -148,6 +152,7 @@ func formatDuration(t time.Duration) string {
 
 func initJobManager(cfg *config.Config) *job.Manager {
 	ret := job.NewManager()
+	setJobSlots(ret, cfg)
 
 	// desktop notifications
 	ctx := context.Background()
@@ -183,7 +188,7 @@ func initJobManager(cfg *config.Config) *job.Manager {
 func (s *Manager) postInit(ctx context.Context) error {
 	s.RefreshConfig()
 
-	s.SessionStore = session.NewStore(s.Config)
+	s.SessionStore = session.NewStore(s.Config, session.NewRepository(s.Repository))
 	s.PluginCache.RegisterSessionStore(s.SessionStore)
 
 	s.RefreshPluginCache()
@@ -241,6 +246,9 @@ func (s *Manager) postInit(ctx context.Context) error {
 	s.RefreshFFMpeg(ctx)
 	s.RefreshStreamManager()
 
+	// the scheduler outlives the context of the caller
+	s.Scheduler.Start(context.Background())
+
 	return nil
 }
 
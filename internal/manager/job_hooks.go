@@ -0,0 +1,94 @@
+package manager
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+)
+
+const (
+	JobTypeScan     = "scan"
+	JobTypeClean    = "clean"
+	JobTypeGenerate = "generate"
+)
+
+// JobCompleteHookInput is the input passed to Job.Complete.Post hooks.
+type JobCompleteHookInput struct {
+	JobID       int         `json:"job_id"`
+	Type        string      `json:"type"`
+	Description string      `json:"description"`
+	Status      job.Status  `json:"status"`
+	Error       *string     `json:"error"`
+	StartTime   *time.Time  `json:"start_time"`
+	EndTime     *time.Time  `json:"end_time"`
+	Input       interface{} `json:"input"`
+}
+
+type jobCompleteHookExecutor interface {
+	ExecutePostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string)
+}
+
+// jobCompleteHooks executes the Job.Complete.Post plugin hooks when jobs
+// added using add are removed from the job queue.
+type jobCompleteHooks struct {
+	jobManager   *job.Manager
+	hookExecutor jobCompleteHookExecutor
+
+	mutex sync.Mutex
+	// jobs maps job ID to the hook input for jobs that have not yet completed
+	jobs map[int]JobCompleteHookInput
+}
+
+func newJobCompleteHooks(jobManager *job.Manager, hookExecutor jobCompleteHookExecutor) *jobCompleteHooks {
+	ret := &jobCompleteHooks{
+		jobManager:   jobManager,
+		hookExecutor: hookExecutor,
+		jobs:         make(map[int]JobCompleteHookInput),
+	}
+
+	// the listener is called for every removed job, unlike subscriptions
+	// which drop notifications when the subscriber falls behind
+	jobManager.OnJobRemoved(context.Background(), ret.jobRemoved)
+
+	return ret
+}
+
+// add queues a job, and registers it so that the hooks are executed when it
+// completes. input is the input used to create the job.
+func (h *jobCompleteHooks) add(ctx context.Context, jobType string, input interface{}, description string, e job.JobExec, options job.Options) int {
+	// hold the lock while adding so that the job cannot be removed before it
+	// is registered
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	id := h.jobManager.AddWithOptions(ctx, description, e, options)
+	h.jobs[id] = JobCompleteHookInput{
+		JobID:       id,
+		Type:        jobType,
+		Description: description,
+		Input:       input,
+	}
+
+	return id
+}
+
+func (h *jobCompleteHooks) jobRemoved(j job.Job) {
+	h.mutex.Lock()
+	input, found := h.jobs[j.ID]
+	delete(h.jobs, j.ID)
+	h.mutex.Unlock()
+
+	if !found {
+		return
+	}
+
+	input.Status = j.Status
+	input.Error = j.Error
+	input.StartTime = j.StartTime
+	input.EndTime = j.EndTime
+
+	h.hookExecutor.ExecutePostHooks(context.Background(), j.ID, hook.JobCompletePost, input, nil)
+}
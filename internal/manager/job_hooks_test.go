@@ -0,0 +1,80 @@
+package manager
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingHookExecutor records the inputs of executed post-hooks.
+type recordingHookExecutor struct {
+	mutex  sync.Mutex
+	inputs map[int]JobCompleteHookInput
+}
+
+func (e *recordingHookExecutor) ExecutePostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if hookType == hook.JobCompletePost {
+		e.inputs[id] = input.(JobCompleteHookInput)
+	}
+}
+
+func (e *recordingHookExecutor) count() int {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return len(e.inputs)
+}
+
+func TestJobCompleteHooks(t *testing.T) {
+	m := job.NewManager()
+	defer m.Stop()
+
+	e := &recordingHookExecutor{inputs: make(map[int]JobCompleteHookInput)}
+	h := newJobCompleteHooks(m, e)
+
+	// an unread subscription must not cause hooks to be missed
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.Subscribe(ctx)
+
+	exec := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
+		return nil
+	})
+
+	const numJobs = 150
+	for i := 0; i < numJobs; i++ {
+		h.add(context.Background(), JobTypeScan, "input", "test job", exec, job.Options{})
+	}
+
+	// jobs not added using the hooks do not execute them
+	m.Add(context.Background(), "other job", exec)
+
+	deadline := time.Now().Add(time.Second)
+	for e.count() < numJobs && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// wait for the other job to be handled
+	time.Sleep(50 * time.Millisecond)
+
+	assert := assert.New(t)
+	if !assert.Equal(numJobs, e.count()) {
+		return
+	}
+
+	for id, input := range e.inputs {
+		assert.Equal(id, input.JobID)
+		assert.Equal(JobTypeScan, input.Type)
+		assert.Equal("input", input.Input)
+		assert.Equal(job.StatusFinished, input.Status)
+		assert.NotNil(input.EndTime)
+	}
+}
@@ -45,6 +45,7 @@ type Manager struct {
 	StreamManager *ffmpeg.StreamManager
 
 	JobManager      *job.Manager
+	Scheduler       *Scheduler
 	ReadLockManager *fsutil.ReadLockManager
 
 	DownloadStore *DownloadStore
@@ -66,6 +67,7 @@ type Manager struct {
 	GalleryService GalleryService
 
 	scanSubs *subscriptionManager
+	jobHooks *jobCompleteHooks
 }
 
 var instance *Manager
@@ -115,6 +117,15 @@ func (s *Manager) RefreshConfig() {
 
 		s.ImageThumbnailGenerateWaitGroup.Size = cfg.GetParallelTasksWithAutoDetection()
 	}
+
+	setJobSlots(s.JobManager, cfg)
+}
+
+func setJobSlots(jobManager *job.Manager, cfg *config.Config) {
+	jobManager.SetSlots(job.ClassIO, cfg.GetIOJobSlots())
+	jobManager.SetSlots(job.ClassCPU, cfg.GetCPUJobSlots())
+	jobManager.SetSlots(job.ClassNetwork, cfg.GetNetworkJobSlots())
+	jobManager.SetSlots(job.ClassGeneral, cfg.GetGeneralJobSlots())
 }
 
 // RefreshPluginCache refreshes the plugin cache.
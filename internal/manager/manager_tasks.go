@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/internal/manager/task"
 	"github.com/stashapp/stash/pkg/file"
 	file_image "github.com/stashapp/stash/pkg/file/image"
 	"github.com/stashapp/stash/pkg/file/video"
@@ -118,6 +119,7 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		},
 		FingerprintCalculator: &fingerprintCalculator{s.Config},
 		FS:                    &file.OsFS{},
+		PostHooks:             s.PluginCache,
 	}
 
 	scanJob := ScanJob{
@@ -126,7 +128,7 @@ func (s *Manager) Scan(ctx context.Context, input ScanMetadataInput) (int, error
 		subscriptions: s.scanSubs,
 	}
 
-	return s.JobManager.Add(ctx, "Scanning...", &scanJob), nil
+	return s.jobHooks.add(ctx, JobTypeScan, input, "Scanning...", &scanJob, job.Options{Class: job.ClassIO}), nil
 }
 
 func (s *Manager) Import(ctx context.Context) (int, error) {
@@ -205,7 +207,7 @@ func (s *Manager) Generate(ctx context.Context, input GenerateMetadataInput) (in
 		input:      input,
 	}
 
-	return s.JobManager.Add(ctx, "Generating...", j), nil
+	return s.jobHooks.add(ctx, JobTypeGenerate, input, "Generating...", j, job.Options{Class: job.ClassCPU}), nil
 }
 
 func (s *Manager) GenerateDefaultScreenshot(ctx context.Context, sceneId string) int {
@@ -258,7 +260,7 @@ func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, at *fl
 		return nil
 	})
 
-	return s.JobManager.Add(ctx, fmt.Sprintf("Generating screenshot for scene id %s", sceneId), j)
+	return s.JobManager.AddWithOptions(ctx, fmt.Sprintf("Generating screenshot for scene id %s", sceneId), j, job.Options{Class: job.ClassCPU})
 }
 
 type AutoTagMetadataInput struct {
@@ -278,7 +280,7 @@ func (s *Manager) AutoTag(ctx context.Context, input AutoTagMetadataInput) int {
 		input:      input,
 	}
 
-	return s.JobManager.Add(ctx, "Auto-tagging...", &j)
+	return s.JobManager.AddWithOptions(ctx, "Auto-tagging...", &j, job.Options{Class: job.ClassIO})
 }
 
 type CleanMetadataInput struct {
@@ -294,6 +296,7 @@ func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 		Handlers: []file.CleanHandler{
 			&cleanHandler{},
 		},
+		PostHooks: s.PluginCache,
 	}
 
 	j := cleanJob{
@@ -305,7 +308,9 @@ func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 		scanSubs:     s.scanSubs,
 	}
 
-	return s.JobManager.Add(ctx, "Cleaning...", &j)
+	// clean deletes files, folders and their objects, so must not run
+	// alongside jobs that create or use them
+	return s.jobHooks.add(ctx, JobTypeClean, input, "Cleaning...", &j, job.Options{Class: job.ClassExclusive})
 }
 
 func (s *Manager) OptimiseDatabase(ctx context.Context) int {
@@ -316,6 +321,20 @@ func (s *Manager) OptimiseDatabase(ctx context.Context) int {
 	return s.JobManager.Add(ctx, "Optimising database...", &j)
 }
 
+func (s *Manager) CleanGenerated(ctx context.Context, input task.CleanGeneratedOptions) int {
+	j := &task.CleanGeneratedJob{
+		Options:                  input,
+		Paths:                    s.Paths,
+		BlobsStorageType:         s.Config.GetBlobsStorage(),
+		VideoFileNamingAlgorithm: s.Config.GetVideoFileNamingAlgorithm(),
+		Repository:               s.Repository,
+		BlobCleaner:              s.Repository.Blob,
+	}
+
+	// must not delete files that are being generated
+	return s.JobManager.AddWithOptions(ctx, "Cleaning generated files...", j, job.Options{Class: job.ClassExclusive})
+}
+
 func (s *Manager) MigrateHash(ctx context.Context) int {
 	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) error {
 		fileNamingAlgo := config.GetInstance().GetVideoFileNamingAlgorithm()
@@ -518,7 +537,7 @@ func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, box *models.Sta
 		return nil
 	})
 
-	return s.JobManager.Add(ctx, "Batch stash-box performer tag...", j)
+	return s.JobManager.AddWithOptions(ctx, "Batch stash-box performer tag...", j, job.Options{Class: job.ClassNetwork})
 }
 
 func (s *Manager) StashBoxBatchStudioTag(ctx context.Context, box *models.StashBox, input StashBoxBatchTagInput) int {
@@ -639,5 +658,5 @@ func (s *Manager) StashBoxBatchStudioTag(ctx context.Context, box *models.StashB
 		return nil
 	})
 
-	return s.JobManager.Add(ctx, "Batch stash-box studio tag...", j)
+	return s.JobManager.AddWithOptions(ctx, "Batch stash-box studio tag...", j, job.Options{Class: job.ClassNetwork})
 }
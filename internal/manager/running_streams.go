@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"os"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
 
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/internal/static"
@@ -11,6 +15,7 @@ import (
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/txn"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -28,6 +33,70 @@ func KillRunningStreams(scene *models.Scene, fileNamingAlgo models.HashAlgorithm
 	instance.ReadLockManager.Cancel(transcodePath)
 }
 
+// transcodeCodecCacheSize is the number of generated transcodes whose video
+// codec is cached.
+const transcodeCodecCacheSize = 1000
+
+// transcodeCodecKey identifies the contents of a generated transcode, so that
+// the cached codec is not used once the transcode is regenerated.
+type transcodeCodecKey struct {
+	path    string
+	modTime time.Time
+	size    int64
+}
+
+var transcodeCodecCache *lru.Cache[transcodeCodecKey, string]
+
+func init() {
+	transcodeCodecCache, _ = lru.New[transcodeCodecKey, string](transcodeCodecCacheSize)
+}
+
+// getTranscodeCodec returns the video codec of the generated transcode at
+// path. The transcode is probed the first time its codec is requested.
+func getTranscodeCodec(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	key := transcodeCodecKey{
+		path:    path,
+		modTime: info.ModTime().UTC().Round(0),
+		size:    info.Size(),
+	}
+	if ret, ok := transcodeCodecCache.Get(key); ok {
+		return ret, nil
+	}
+
+	ffprobe := GetInstance().FFProbe
+	if ffprobe == "" {
+		return "", errors.New("ffprobe not available")
+	}
+
+	probeResult, err := ffprobe.NewVideoFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	transcodeCodecCache.Add(key, probeResult.VideoCodec)
+
+	return probeResult.VideoCodec, nil
+}
+
+// clientSupportsTranscode returns true if the client of the request can decode
+// the generated transcode at path. H.264 transcodes are supported by all
+// clients, while HEVC and AV1 transcodes are only supported by clients that
+// have indicated that they can decode them.
+func clientSupportsTranscode(path string, r *http.Request) bool {
+	codec, err := getTranscodeCodec(path)
+	if err != nil {
+		logger.Warnf("[transcode] error getting codec of %s: %v", path, err)
+		return true
+	}
+
+	return codec == ffmpeg.H264 || sliceutil.Contains(ffmpeg.ClientVideoCodecs(r), codec)
+}
+
 type SceneCoverGetter interface {
 	GetCover(ctx context.Context, sceneID int) ([]byte, error)
 }
@@ -47,6 +116,17 @@ func (s *SceneServer) StreamSceneDirect(scene *models.Scene, w http.ResponseWrit
 	sceneHash := scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm())
 
 	filepath := GetInstance().Paths.Scene.GetStreamPath(scene.Path, sceneHash)
+
+	// generated transcodes may use a codec that the client cannot decode
+	if filepath != scene.Path && !clientSupportsTranscode(filepath, r) {
+		logger.Debugf("[transcode] client does not support the transcode of scene %d, streaming as H.264", scene.ID)
+		s.StreamSceneTranscode(scene, ffmpeg.TranscodeOptions{
+			StreamType:   ffmpeg.StreamTypeMP4,
+			ClientCodecs: ffmpeg.ClientVideoCodecs(r),
+		}, w, r)
+		return
+	}
+
 	streamRequestCtx := ffmpeg.NewStreamRequestContext(w, r)
 
 	// #2579 - hijacking and closing the connection here causes video playback to fail in Safari
@@ -56,6 +136,27 @@ func (s *SceneServer) StreamSceneDirect(scene *models.Scene, w http.ResponseWrit
 	http.ServeFile(w, r, filepath)
 }
 
+// StreamSceneTranscode streams a live transcode of the primary file of the
+// scene. The video file of the options is set to the primary file.
+func (s *SceneServer) StreamSceneTranscode(scene *models.Scene, options ffmpeg.TranscodeOptions, w http.ResponseWriter, r *http.Request) {
+	streamManager := GetInstance().StreamManager
+	if streamManager == nil {
+		http.Error(w, "Live transcoding disabled", http.StatusServiceUnavailable)
+		return
+	}
+
+	f := scene.Files.Primary()
+	if f == nil {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+
+	options.VideoFile = f
+
+	logger.Debugf("[transcode] streaming scene %d as %s", scene.ID, options.StreamType.MimeType)
+	streamManager.ServeTranscode(w, r, options)
+}
+
 func (s *SceneServer) ServeScreenshot(scene *models.Scene, w http.ResponseWriter, r *http.Request) {
 	var cover []byte
 	readTxnErr := txn.WithReadTxn(r.Context(), s.TxnManager, func(ctx context.Context) error {
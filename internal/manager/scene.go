@@ -3,17 +3,52 @@ package manager
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 )
 
 type SceneStreamEndpoint struct {
-	URL      string  `json:"url"`
-	MimeType *string `json:"mime_type"`
-	Label    *string `json:"label"`
+	URL            string              `json:"url"`
+	MimeType       *string             `json:"mime_type"`
+	Label          *string             `json:"label"`
+	AudioTracks    []*SceneStreamTrack `json:"audio_tracks"`
+	SubtitleTracks []*SceneStreamTrack `json:"subtitle_tracks"`
+}
+
+// SceneStreamTrack is an embedded audio or subtitle track of a scene file.
+type SceneStreamTrack struct {
+	Index        int     `json:"index"`
+	Codec        string  `json:"codec"`
+	LanguageCode *string `json:"language_code"`
+	Title        *string `json:"title"`
+	Default      bool    `json:"default"`
+	Forced       bool    `json:"forced"`
+	URL          *string `json:"url"`
+}
+
+func newSceneStreamTrack(t ffmpeg.MediaTrack, url *string) *SceneStreamTrack {
+	ret := &SceneStreamTrack{
+		Index:   t.Index,
+		Codec:   t.Codec,
+		Default: t.Default,
+		Forced:  t.Forced,
+		URL:     url,
+	}
+	// und is used by some muxers for tracks without a language
+	if t.Language != "" && t.Language != "und" {
+		lang := t.Language
+		ret.LanguageCode = &lang
+	}
+	if t.Title != "" {
+		title := t.Title
+		ret.Title = &title
+	}
+	return ret
 }
 
 type endpointType struct {
@@ -75,7 +110,7 @@ func GetVideoFileContainer(file *models.VideoFile) (ffmpeg.Container, error) {
 	return container, nil
 }
 
-func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStreamingTranscodeSize models.StreamingResolutionEnum) ([]*SceneStreamEndpoint, error) {
+func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, captionURL string, maxStreamingTranscodeSize models.StreamingResolutionEnum) ([]*SceneStreamEndpoint, error) {
 	if scene == nil {
 		return nil, fmt.Errorf("nil scene")
 	}
@@ -144,6 +179,24 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 		}
 	}
 
+	// adaptive streams switch between the resolutions depending on bandwidth
+	makeAdaptiveStreamEndpoint := func(t endpointType) *SceneStreamEndpoint {
+		url := *directStreamURL
+		url.Path += t.extension
+
+		v := url.Query()
+		v.Set("adaptive", "true")
+		url.RawQuery = v.Encode()
+
+		label := t.label + " Adaptive"
+
+		return &SceneStreamEndpoint{
+			URL:      url.String(),
+			MimeType: &t.mimeType,
+			Label:    &label,
+		}
+	}
+
 	var endpoints []*SceneStreamEndpoint
 
 	// direct stream should only apply when the audio codec is supported
@@ -166,8 +219,12 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 
 	mp4Streams := []*SceneStreamEndpoint{}
 	webmStreams := []*SceneStreamEndpoint{}
-	hlsStreams := []*SceneStreamEndpoint{}
-	dashStreams := []*SceneStreamEndpoint{}
+	hlsStreams := []*SceneStreamEndpoint{
+		makeAdaptiveStreamEndpoint(hlsEndpointType),
+	}
+	dashStreams := []*SceneStreamEndpoint{
+		makeAdaptiveStreamEndpoint(dashEndpointType),
+	}
 
 	if includeSceneStreamPath(models.StreamingResolutionEnumOriginal) {
 		mp4Streams = append(mp4Streams, makeStreamEndpoint(mp4EndpointType, models.StreamingResolutionEnumOriginal))
@@ -216,9 +273,56 @@ func GetSceneStreamPaths(scene *models.Scene, directStreamURL *url.URL, maxStrea
 	endpoints = append(endpoints, hlsStreams...)
 	endpoints = append(endpoints, dashStreams...)
 
+	addEmbeddedTracks(pf, endpoints, captionURL)
+
 	return endpoints, nil
 }
 
+// addEmbeddedTracks adds the embedded audio and subtitle tracks of the file
+// to the stream endpoints. Audio tracks can only be selected when the file is
+// transcoded, so they are not added to the direct stream endpoint.
+func addEmbeddedTracks(pf *models.VideoFile, endpoints []*SceneStreamEndpoint, captionURL string) {
+	tracks, err := GetEmbeddedTracks(pf)
+	if err != nil {
+		logger.Warnf("error reading embedded tracks of %s: %v", pf.Path, err)
+		return
+	}
+
+	// text subtitles are served as WebVTT by the caption endpoint
+	var subtitleTracks []*SceneStreamTrack
+	for _, t := range tracks.Subtitle {
+		if !t.IsTextSubtitle() {
+			continue
+		}
+
+		trackURL := fmt.Sprintf("%s?track=%d", captionURL, t.Index)
+		subtitleTracks = append(subtitleTracks, newSceneStreamTrack(t, &trackURL))
+	}
+
+	for _, endpoint := range endpoints {
+		endpoint.SubtitleTracks = subtitleTracks
+
+		// only list audio tracks if there is a choice
+		if *endpoint.Label == directEndpointType.label || len(tracks.Audio) < 2 {
+			continue
+		}
+
+		u, err := url.Parse(endpoint.URL)
+		if err != nil {
+			continue
+		}
+
+		for _, t := range tracks.Audio {
+			v := u.Query()
+			v.Set(ffmpeg.AudioTrackParam, strconv.Itoa(t.Index))
+			u.RawQuery = v.Encode()
+
+			trackURL := u.String()
+			endpoint.AudioTracks = append(endpoint.AudioTracks, newSceneStreamTrack(t, &trackURL))
+		}
+	}
+}
+
 // HasTranscode returns true if a transcoded video exists for the provided
 // scene. It will check using the OSHash of the scene first, then fall back
 // to the checksum.
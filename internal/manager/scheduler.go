@@ -0,0 +1,612 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/internal/manager/task"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+)
+
+const (
+	// schedulerInterval is the maximum time between checks for due tasks.
+	schedulerInterval = time.Minute
+
+	// maxScheduledTaskRuns is the number of runs kept in the history of
+	// each scheduled task.
+	maxScheduledTaskRuns = 50
+
+	interruptedRunError = "interrupted by shutdown"
+)
+
+// ScheduledPluginTaskInput is the input for scheduled tasks of type PLUGIN.
+type ScheduledPluginTaskInput struct {
+	PluginID string                 `json:"plugin_id"`
+	TaskName *string                `json:"task_name"`
+	ArgsMap  map[string]interface{} `json:"args_map"`
+}
+
+type schedulerDatabase interface {
+	Ready() error
+	Version() uint
+	AppSchemaVersion() uint
+}
+
+// Scheduler queues persisted scheduled tasks on the job manager according to
+// their cron schedule, and records the history of each run.
+type Scheduler struct {
+	repository models.Repository
+	database   schedulerDatabase
+	jobManager *job.Manager
+
+	// queueJob adds the job for the provided task to the job manager,
+	// returning the job id.
+	queueJob func(ctx context.Context, t *models.ScheduledTask) (int, error)
+
+	mutex sync.Mutex
+	// in-progress runs, keyed by job id
+	runs map[int]*models.ScheduledTaskRun
+
+	wake    chan struct{}
+	started bool
+	resumed bool
+}
+
+func newScheduler(repo models.Repository, db schedulerDatabase, jobManager *job.Manager, queueJob func(ctx context.Context, t *models.ScheduledTask) (int, error)) *Scheduler {
+	return &Scheduler{
+		repository: repo,
+		database:   db,
+		jobManager: jobManager,
+		queueJob:   queueJob,
+		runs:       make(map[int]*models.ScheduledTaskRun),
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// Start starts the scheduler loop. Has no effect if the scheduler is already
+// running.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.started {
+		return
+	}
+	s.started = true
+
+	// subscriptions drop notifications when they fall behind, so completed
+	// jobs are received from a listener instead
+	removed := make(chan job.Job)
+	s.jobManager.OnJobRemoved(ctx, func(j job.Job) {
+		select {
+		case removed <- j:
+		case <-ctx.Done():
+		}
+	})
+
+	sub := s.jobManager.Subscribe(ctx)
+	go s.trackRuns(ctx, sub, removed)
+	go s.run(ctx)
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) ready() bool {
+	return s.database.Ready() == nil && s.database.Version() == s.database.AppSchemaVersion()
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	for {
+		wait := schedulerInterval
+
+		if s.ready() {
+			var resumed map[int]bool
+			if !s.resumed {
+				resumed = s.resumeInterrupted(ctx)
+				s.resumed = true
+			}
+
+			next, err := s.queueDue(ctx, time.Now(), resumed)
+			if err != nil {
+				logger.Errorf("error queueing scheduled tasks: %v", err)
+			} else if next != nil {
+				if untilNext := time.Until(*next); untilNext < wait {
+					wait = untilNext
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-time.After(wait):
+		}
+	}
+}
+
+// resumeInterrupted marks runs that were queued or running when stash was
+// last stopped as failed, and queues them again. Returns the IDs of the tasks
+// that were queued.
+func (s *Scheduler) resumeInterrupted(ctx context.Context) map[int]bool {
+	var toQueue []*models.ScheduledTask
+
+	r := s.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.ScheduledTask
+		runs, err := qb.FindUnfinishedRuns(ctx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		queued := make(map[int]bool)
+		for _, run := range runs {
+			errStr := interruptedRunError
+			run.Status = string(job.StatusFailed)
+			run.EndTime = &now
+			run.Error = &errStr
+			if err := qb.UpdateRun(ctx, run); err != nil {
+				return err
+			}
+
+			if queued[run.ScheduledTaskID] {
+				continue
+			}
+			queued[run.ScheduledTaskID] = true
+
+			t, err := qb.Find(ctx, run.ScheduledTaskID)
+			if err != nil {
+				return err
+			}
+			if t != nil {
+				toQueue = append(toQueue, t)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		logger.Errorf("error resuming interrupted scheduled tasks: %v", err)
+		return nil
+	}
+
+	ret := make(map[int]bool)
+	for _, t := range toQueue {
+		logger.Infof("Re-queueing interrupted scheduled task %q", t.Name)
+		if _, err := s.queue(ctx, t); err != nil {
+			logger.Errorf("error re-queueing scheduled task %q: %v", t.Name, err)
+			continue
+		}
+		ret[t.ID] = true
+	}
+
+	return ret
+}
+
+// queueDue queues all enabled tasks that are due at the provided time, and
+// returns the time that the next task is due. Tasks in skip are not queued,
+// so that tasks that were re-queued after being interrupted are not queued
+// twice, but their next run time is still advanced.
+func (s *Scheduler) queueDue(ctx context.Context, now time.Time, skip map[int]bool) (*time.Time, error) {
+	var (
+		due  []*models.ScheduledTask
+		next *time.Time
+	)
+
+	r := s.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.ScheduledTask
+		tasks, err := qb.All(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, t := range tasks {
+			if !t.Enabled {
+				continue
+			}
+
+			changed := false
+			if t.NextRunAt == nil {
+				changed = true
+			} else if !t.NextRunAt.After(now) {
+				if !skip[t.ID] {
+					tCopy := *t
+					due = append(due, &tCopy)
+				}
+				changed = true
+			}
+
+			if changed {
+				if err := setNextRunAt(t, now); err != nil {
+					logger.Warnf("scheduled task %q: %v", t.Name, err)
+				}
+				if err := qb.Update(ctx, t); err != nil {
+					return err
+				}
+			}
+
+			if t.NextRunAt != nil && (next == nil || t.NextRunAt.Before(*next)) {
+				next = t.NextRunAt
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, t := range due {
+		logger.Infof("Queueing scheduled task %q", t.Name)
+		if _, err := s.queue(ctx, t); err != nil {
+			logger.Errorf("error queueing scheduled task %q: %v", t.Name, err)
+		}
+	}
+
+	return next, nil
+}
+
+// setNextRunAt sets the NextRunAt field of the task based on its schedule.
+// NextRunAt is cleared if the task is disabled or the schedule is invalid.
+func setNextRunAt(t *models.ScheduledTask, from time.Time) error {
+	t.NextRunAt = nil
+	if !t.Enabled {
+		return nil
+	}
+
+	schedule, err := job.ParseSchedule(t.Schedule)
+	if err != nil {
+		return err
+	}
+
+	next := schedule.Next(from)
+	if next.IsZero() {
+		return fmt.Errorf("schedule %q never runs", t.Schedule)
+	}
+
+	t.NextRunAt = &next
+	return nil
+}
+
+// queue creates a run record for the task and adds its job to the job
+// manager.
+func (s *Scheduler) queue(ctx context.Context, t *models.ScheduledTask) (int, error) {
+	run := &models.ScheduledTaskRun{
+		ScheduledTaskID: t.ID,
+		Status:          string(job.StatusReady),
+		AddTime:         time.Now(),
+	}
+
+	r := s.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.ScheduledTask
+		if err := qb.CreateRun(ctx, run); err != nil {
+			return err
+		}
+
+		return qb.PruneRuns(ctx, t.ID, maxScheduledTaskRuns)
+	}); err != nil {
+		return 0, err
+	}
+
+	// hold the lock until the run is registered, so that job updates are not
+	// processed before the run is known
+	s.mutex.Lock()
+	jobID, err := s.queueJob(ctx, t)
+	if err == nil {
+		s.runs[jobID] = run
+	}
+	s.mutex.Unlock()
+
+	if err != nil {
+		now := time.Now()
+		errStr := err.Error()
+		run.Status = string(job.StatusFailed)
+		run.EndTime = &now
+		run.Error = &errStr
+		s.saveRun(ctx, run)
+		return 0, err
+	}
+
+	return jobID, nil
+}
+
+// trackRuns records the start and end of the runs of queued jobs. Updates
+// are processed in order by a single goroutine, so that the start of a run
+// is never saved after its end.
+func (s *Scheduler) trackRuns(ctx context.Context, sub *job.ManagerSubscription, removed <-chan job.Job) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case j, ok := <-sub.UpdatedJob:
+			if !ok {
+				return
+			}
+			s.jobUpdated(ctx, j)
+		case j := <-removed:
+			s.jobRemoved(ctx, j)
+		}
+	}
+}
+
+// startRun marks the run of the job as started. Returns a copy of the run
+// to be saved, or nil if the job has no run or the run has already started.
+func (s *Scheduler) startRun(j job.Job) *models.ScheduledTaskRun {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	run := s.runs[j.ID]
+	if run == nil || run.StartTime != nil || j.StartTime == nil {
+		return nil
+	}
+
+	run.Status = string(job.StatusRunning)
+	run.StartTime = j.StartTime
+
+	ret := *run
+	return &ret
+}
+
+// removeRun removes and returns the run of the job, or nil if the job has
+// no run.
+func (s *Scheduler) removeRun(jobID int) *models.ScheduledTaskRun {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	run := s.runs[jobID]
+	delete(s.runs, jobID)
+	return run
+}
+
+func (s *Scheduler) jobUpdated(ctx context.Context, j job.Job) {
+	if run := s.startRun(j); run != nil {
+		s.saveRun(ctx, run)
+	}
+}
+
+func (s *Scheduler) jobRemoved(ctx context.Context, j job.Job) {
+	run := s.removeRun(j.ID)
+	if run == nil {
+		return
+	}
+
+	endTime := j.EndTime
+	if endTime == nil {
+		// cancelled before starting
+		now := time.Now()
+		endTime = &now
+	}
+
+	run.Status = string(j.Status)
+	run.StartTime = j.StartTime
+	run.EndTime = endTime
+	run.Error = j.Error
+	s.saveRun(ctx, run)
+}
+
+func (s *Scheduler) saveRun(ctx context.Context, run *models.ScheduledTaskRun) {
+	r := s.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.ScheduledTask.UpdateRun(ctx, run)
+	}); err != nil {
+		logger.Errorf("error saving scheduled task run: %v", err)
+	}
+}
+
+func (s *Scheduler) validate(t *models.ScheduledTask) error {
+	if strings.TrimSpace(t.Name) == "" {
+		return errors.New("name must be non-empty")
+	}
+
+	if !t.Type.IsValid() {
+		return fmt.Errorf("invalid task type %q", t.Type)
+	}
+
+	if _, err := job.ParseSchedule(t.Schedule); err != nil {
+		return err
+	}
+
+	if _, err := decodeScheduledTaskInput(t); err != nil {
+		return fmt.Errorf("invalid input for %s task: %w", t.Type, err)
+	}
+
+	return nil
+}
+
+// Create validates and creates a new scheduled task.
+func (s *Scheduler) Create(ctx context.Context, t *models.ScheduledTask) error {
+	if err := s.validate(t); err != nil {
+		return err
+	}
+
+	if err := setNextRunAt(t, time.Now()); err != nil {
+		return err
+	}
+
+	r := s.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.ScheduledTask.Create(ctx, t)
+	}); err != nil {
+		return err
+	}
+
+	s.notify()
+	return nil
+}
+
+// Update validates and updates an existing scheduled task. The next run time
+// is recalculated from the current time if the schedule was changed or the
+// task was enabled or disabled.
+func (s *Scheduler) Update(ctx context.Context, t *models.ScheduledTask) error {
+	if err := s.validate(t); err != nil {
+		return err
+	}
+
+	t.UpdatedAt = time.Now()
+
+	r := s.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		qb := r.ScheduledTask
+		existing, err := qb.Find(ctx, t.ID)
+		if err != nil {
+			return err
+		}
+
+		if existing == nil {
+			return fmt.Errorf("scheduled task with id %d not found", t.ID)
+		}
+
+		if existing.Schedule == t.Schedule && existing.Enabled == t.Enabled && existing.NextRunAt != nil {
+			t.NextRunAt = existing.NextRunAt
+		} else if err := setNextRunAt(t, time.Now()); err != nil {
+			return err
+		}
+
+		return qb.Update(ctx, t)
+	}); err != nil {
+		return err
+	}
+
+	s.notify()
+	return nil
+}
+
+// Destroy deletes the scheduled task and its run history. Jobs that have
+// already been queued are not affected.
+func (s *Scheduler) Destroy(ctx context.Context, id int) error {
+	r := s.repository
+	if err := r.WithTxn(ctx, func(ctx context.Context) error {
+		return r.ScheduledTask.Destroy(ctx, id)
+	}); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for jobID, run := range s.runs {
+		if run.ScheduledTaskID == id {
+			delete(s.runs, jobID)
+		}
+	}
+
+	return nil
+}
+
+// RunNow queues the scheduled task immediately, regardless of its schedule.
+// Returns the job id.
+func (s *Scheduler) RunNow(ctx context.Context, id int) (int, error) {
+	var t *models.ScheduledTask
+
+	r := s.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		t, err = r.ScheduledTask.Find(ctx, id)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	if t == nil {
+		return 0, fmt.Errorf("scheduled task with id %d not found", id)
+	}
+
+	return s.queue(ctx, t)
+}
+
+func decodeInput(input map[string]interface{}, out interface{}) error {
+	d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		TagName:          "json",
+		WeaklyTypedInput: true,
+		Squash:           true,
+		ErrorUnused:      true,
+		Result:           out,
+	})
+	if err != nil {
+		return err
+	}
+
+	return d.Decode(input)
+}
+
+// decodeScheduledTaskInput decodes the input of the task into the input type
+// of the equivalent mutation.
+func decodeScheduledTaskInput(t *models.ScheduledTask) (interface{}, error) {
+	var ret interface{}
+
+	switch t.Type {
+	case models.ScheduledTaskTypeScan:
+		ret = &ScanMetadataInput{}
+	case models.ScheduledTaskTypeGenerate:
+		ret = &GenerateMetadataInput{}
+	case models.ScheduledTaskTypeAutoTag:
+		ret = &AutoTagMetadataInput{}
+	case models.ScheduledTaskTypeClean:
+		ret = &CleanMetadataInput{}
+	case models.ScheduledTaskTypeCleanGenerated:
+		ret = &task.CleanGeneratedOptions{}
+	case models.ScheduledTaskTypeIdentify:
+		ret = &identify.Options{}
+	case models.ScheduledTaskTypeOptimise:
+		return nil, nil
+	case models.ScheduledTaskTypePlugin:
+		ret = &ScheduledPluginTaskInput{}
+	default:
+		return nil, fmt.Errorf("unsupported task type %q", t.Type)
+	}
+
+	if err := decodeInput(t.Input, ret); err != nil {
+		return nil, err
+	}
+
+	if in, ok := ret.(*ScheduledPluginTaskInput); ok && in.PluginID == "" {
+		return nil, errors.New("plugin_id is required")
+	}
+
+	return ret, nil
+}
+
+// queueScheduledTask adds the job for the scheduled task to the job queue,
+// using the same code paths as the equivalent mutations.
+func (s *Manager) queueScheduledTask(ctx context.Context, t *models.ScheduledTask) (int, error) {
+	input, err := decodeScheduledTaskInput(t)
+	if err != nil {
+		return 0, err
+	}
+
+	switch in := input.(type) {
+	case *ScanMetadataInput:
+		return s.Scan(ctx, *in)
+	case *GenerateMetadataInput:
+		return s.Generate(ctx, *in)
+	case *AutoTagMetadataInput:
+		return s.AutoTag(ctx, *in), nil
+	case *CleanMetadataInput:
+		return s.Clean(ctx, *in), nil
+	case *task.CleanGeneratedOptions:
+		return s.CleanGenerated(ctx, *in), nil
+	case *identify.Options:
+		return s.Identify(ctx, *in), nil
+	case *ScheduledPluginTaskInput:
+		description := fmt.Sprintf("%s (scheduled)", t.Name)
+		return s.RunPluginTask(ctx, in.PluginID, in.TaskName, &description, plugin.OperationInput(in.ArgsMap)), nil
+	}
+
+	// optimise has no input
+	return s.OptimiseDatabase(ctx), nil
+}
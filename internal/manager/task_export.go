@@ -501,6 +501,11 @@ func (t *ExportTask) exportScene(ctx context.Context, wg *sync.WaitGroup, jobCha
 			logger.Errorf("[scenes] <%s> error loading scene relationships: %v", sceneHash, err)
 		}
 
+		if err := s.LoadCustomFields(ctx, sceneReader); err != nil {
+			logger.Errorf("[scenes] <%s> error getting scene custom fields: %v", sceneHash, err)
+			continue
+		}
+
 		newSceneJSON, err := scene.ToBasicJSON(ctx, sceneReader, s)
 		if err != nil {
 			logger.Errorf("[scenes] <%s> error getting scene JSON: %v", sceneHash, err)
@@ -663,6 +668,11 @@ func (t *ExportTask) exportImage(ctx context.Context, wg *sync.WaitGroup, jobCha
 			continue
 		}
 
+		if err := s.LoadCustomFields(ctx, r.Image); err != nil {
+			logger.Errorf("[images] <%s> error getting image custom fields: %v", imageHash, err)
+			continue
+		}
+
 		newImageJSON := image.ToBasicJSON(s)
 
 		// export files
@@ -790,6 +800,11 @@ func (t *ExportTask) exportGallery(ctx context.Context, wg *sync.WaitGroup, jobC
 			continue
 		}
 
+		if err := g.LoadCustomFields(ctx, r.Gallery); err != nil {
+			logger.Errorf("[galleries] <%s> error getting gallery custom fields: %v", g.DisplayName(), err)
+			continue
+		}
+
 		newGalleryJSON, err := gallery.ToBasicJSON(g)
 		if err != nil {
 			logger.Errorf("[galleries] <%s> error getting gallery JSON: %v", g.DisplayName(), err)
@@ -1135,6 +1150,11 @@ func (t *ExportTask) exportGroup(ctx context.Context, wg *sync.WaitGroup, jobCha
 			continue
 		}
 
+		if err := m.LoadCustomFields(ctx, r.Group); err != nil {
+			logger.Errorf("[groups] <%s> error getting group custom fields: %v", m.Name, err)
+			continue
+		}
+
 		newGroupJSON, err := group.ToJSON(ctx, groupReader, studioReader, m)
 
 		if err != nil {
@@ -7,19 +7,26 @@ import (
 	"strings"
 
 	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
+	"github.com/stashapp/stash/pkg/sliceutil"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
 var ErrInput = errors.New("invalid request input")
 
+type identifyPostHookExecutor interface {
+	identify.SceneUpdatePostHookExecutor
+	identify.ImageUpdatePostHookExecutor
+}
+
 type IdentifyJob struct {
-	postHookExecutor identify.SceneUpdatePostHookExecutor
+	postHookExecutor identifyPostHookExecutor
 	input            identify.Options
 
 	stashBoxes []*models.StashBox
@@ -34,6 +41,12 @@ func CreateIdentifyJob(input identify.Options) *IdentifyJob {
 	}
 }
 
+// Identify queues an identify job for the provided input. Returns the job id.
+func (s *Manager) Identify(ctx context.Context, input identify.Options) int {
+	j := CreateIdentifyJob(input)
+	return s.JobManager.AddWithOptions(ctx, "Identifying...", j, job.Options{Class: job.ClassNetwork})
+}
+
 func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error {
 	j.progress = progress
 
@@ -47,13 +60,13 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error
 		return err
 	}
 
-	// if scene ids provided, use those
-	// otherwise, batch query for all scenes - ordering by path
+	// if scene or image ids provided, use those
+	// otherwise, batch query for all scenes and images - ordering by path
 	// don't use a transaction to query scenes
 	r := instance.Repository
 	if err := r.WithDB(ctx, func(ctx context.Context) error {
-		if len(j.input.SceneIDs) == 0 {
-			return j.identifyAllScenes(ctx, sources)
+		if len(j.input.SceneIDs) == 0 && len(j.input.ImageIDs) == 0 {
+			return j.identifyAll(ctx, sources)
 		}
 
 		sceneIDs, err := stringslice.StringSliceToIntSlice(j.input.SceneIDs)
@@ -61,7 +74,12 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error
 			return fmt.Errorf("invalid scene IDs: %w", err)
 		}
 
-		progress.SetTotal(len(sceneIDs))
+		imageIDs, err := stringslice.StringSliceToIntSlice(j.input.ImageIDs)
+		if err != nil {
+			return fmt.Errorf("invalid image IDs: %w", err)
+		}
+
+		progress.SetTotal(len(sceneIDs) + len(imageIDs))
 		for _, id := range sceneIDs {
 			if job.IsCancelled(ctx) {
 				break
@@ -81,15 +99,34 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) error
 			j.identifyScene(ctx, scene, sources)
 		}
 
+		for _, id := range imageIDs {
+			if job.IsCancelled(ctx) {
+				break
+			}
+
+			img, err := r.Image.Find(ctx, id)
+			if err != nil {
+				return fmt.Errorf("finding image id %d: %w", id, err)
+			}
+
+			if img == nil {
+				return fmt.Errorf("image with id %d not found", id)
+			}
+
+			j.identifyImage(ctx, img, sources)
+		}
+
 		return nil
 	}); err != nil {
-		return fmt.Errorf("error encountered while identifying scenes: %w", err)
+		return fmt.Errorf("error encountered while identifying: %w", err)
 	}
 
 	return nil
 }
 
-func (j *IdentifyJob) identifyAllScenes(ctx context.Context, sources []identify.ScraperSource) error {
+// identifyAll identifies all unorganised scenes and images in the input
+// paths. Images are only identified if any of the sources support images.
+func (j *IdentifyJob) identifyAll(ctx context.Context, sources []identify.ScraperSource) error {
 	r := instance.Repository
 
 	// exclude organised
@@ -98,36 +135,76 @@ func (j *IdentifyJob) identifyAllScenes(ctx context.Context, sources []identify.
 	sceneFilter.Organized = &organised
 
 	sort := "path"
-	findFilter := &models.FindFilterType{
+	sceneFindFilter := &models.FindFilterType{
 		Sort: &sort,
 	}
 
-	// get the count
+	// get the counts
 	pp := 0
-	findFilter.PerPage = &pp
-	countResult, err := r.Scene.Query(ctx, models.SceneQueryOptions{
-		QueryOptions: models.QueryOptions{
-			FindFilter: findFilter,
-			Count:      true,
-		},
-		SceneFilter: sceneFilter,
-	})
+	sceneFindFilter.PerPage = &pp
+	sceneCount, err := r.Scene.Query(ctx, scene.QueryOptions(sceneFilter, sceneFindFilter, true))
 	if err != nil {
 		return fmt.Errorf("error getting scene count: %w", err)
 	}
 
-	j.progress.SetTotal(countResult.Count)
+	total := sceneCount.Count
+
+	var imageFilter *models.ImageFilterType
+	var imageFindFilter *models.FindFilterType
+	if hasImageSource(sources) {
+		imageFilter = image.FilterFromPaths(j.input.Paths)
+		imageFilter.Organized = &organised
+
+		imageFindFilter = &models.FindFilterType{
+			Sort:    &sort,
+			PerPage: &pp,
+		}
 
-	return scene.BatchProcess(ctx, r.Scene, sceneFilter, findFilter, func(scene *models.Scene) error {
+		imageCount, err := r.Image.Query(ctx, image.QueryOptions(imageFilter, imageFindFilter, true))
+		if err != nil {
+			return fmt.Errorf("error getting image count: %w", err)
+		}
+
+		total += imageCount.Count
+	}
+
+	j.progress.SetTotal(total)
+
+	if err := scene.BatchProcess(ctx, r.Scene, sceneFilter, sceneFindFilter, func(scene *models.Scene) error {
 		if job.IsCancelled(ctx) {
 			return nil
 		}
 
 		j.identifyScene(ctx, scene, sources)
 		return nil
+	}); err != nil {
+		return err
+	}
+
+	if imageFilter == nil {
+		return nil
+	}
+
+	return image.BatchProcess(ctx, r.Image, imageFilter, imageFindFilter, func(i *models.Image) error {
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+
+		j.identifyImage(ctx, i, sources)
+		return nil
 	})
 }
 
+func hasImageSource(sources []identify.ScraperSource) bool {
+	for _, s := range sources {
+		if s.ImageScraper != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, sources []identify.ScraperSource) {
 	if job.IsCancelled(ctx) {
 		return
@@ -158,6 +235,36 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 	j.progress.Increment()
 }
 
+func (j *IdentifyJob) identifyImage(ctx context.Context, i *models.Image, sources []identify.ScraperSource) {
+	if job.IsCancelled(ctx) {
+		return
+	}
+
+	var taskError error
+	j.progress.ExecuteTask("Identifying "+i.DisplayName(), func() {
+		r := instance.Repository
+		task := identify.ImageIdentifier{
+			TxnManager:         r.TxnManager,
+			ImageReaderUpdater: r.Image,
+			StudioReaderWriter: r.Studio,
+			PerformerCreator:   r.Performer,
+			TagFinderCreator:   r.Tag,
+
+			DefaultOptions:              j.input.Options,
+			Sources:                     sources,
+			ImageUpdatePostHookExecutor: j.postHookExecutor,
+		}
+
+		taskError = task.Identify(ctx, i)
+	})
+
+	if taskError != nil {
+		logger.Errorf("Error encountered identifying %s: %v", i.DisplayName(), taskError)
+	}
+
+	j.progress.Increment()
+}
+
 func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 	var ret []identify.ScraperSource
 	for _, source := range j.input.Sources {
@@ -184,12 +291,18 @@ func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 			if s == nil {
 				return nil, fmt.Errorf("%w: scraper with id %q", models.ErrNotFound, scraperID)
 			}
+			ss := scraperSource{
+				cache:     instance.ScraperCache,
+				scraperID: scraperID,
+			}
 			src = identify.ScraperSource{
-				Name: s.Name,
-				Scraper: scraperSource{
-					cache:     instance.ScraperCache,
-					scraperID: scraperID,
-				},
+				Name:    s.Name,
+				Scraper: ss,
+			}
+
+			// only use the scraper for images if it supports them
+			if s.Image != nil && sliceutil.Contains(s.Image.SupportedScrapes, scraper.ScrapeTypeFragment) {
+				src.ImageScraper = ss
 			}
 		}
 
@@ -289,6 +402,24 @@ func (s scraperSource) ScrapeScenes(ctx context.Context, sceneID int) ([]*scrape
 	return nil, errors.New("could not convert content to scene")
 }
 
+func (s scraperSource) ScrapeImages(ctx context.Context, imageID int) ([]*scraper.ScrapedImage, error) {
+	content, err := s.cache.ScrapeID(ctx, s.scraperID, imageID, scraper.ScrapeContentTypeImage)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't try to convert nil return value
+	if content == nil {
+		return nil, nil
+	}
+
+	if image, ok := content.(scraper.ScrapedImage); ok {
+		return []*scraper.ScrapedImage{&image}, nil
+	}
+
+	return nil, errors.New("could not convert content to image")
+}
+
 func (s scraperSource) String() string {
 	return fmt.Sprintf("scraper %s", s.scraperID)
 }
@@ -83,8 +83,9 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 		}
 	} else {
 		options := generate.TranscodeOptions{
-			Width:  w,
-			Height: h,
+			Width:      w,
+			Height:     h,
+			VideoCodec: getTranscodeVideoCodec(),
 		}
 
 		if audioCodec == ffmpeg.MissingUnsupported {
@@ -101,6 +102,28 @@ func (t *GenerateTranscodeTask) Start(ctx context.Context) {
 	}
 }
 
+// getTranscodeVideoCodec returns the software codec for the configured
+// transcode video codec, falling back to libx264 if ffmpeg does not
+// support it.
+func getTranscodeVideoCodec() ffmpeg.VideoCodec {
+	var codec ffmpeg.VideoCodec
+	switch config.GetInstance().GetTranscodeVideoCodec() {
+	case models.TranscodeVideoCodecHevc:
+		codec = ffmpeg.VideoCodecLibX265
+	case models.TranscodeVideoCodecAv1:
+		codec = ffmpeg.VideoCodecSVTAV1
+	default:
+		return ffmpeg.VideoCodecLibX264
+	}
+
+	if !instance.FFMpeg.SupportsCodec(codec) {
+		logger.Warnf("[transcode] %s is not supported by ffmpeg, using %s instead", codec, ffmpeg.VideoCodecLibX264)
+		return ffmpeg.VideoCodecLibX264
+	}
+
+	return codec
+}
+
 // return true if transcode is needed
 // used only when counting files to generate, doesn't affect the actual transcode generation
 // if container is missing from DB it is treated as non supported in order not to delay the user
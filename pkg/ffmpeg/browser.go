@@ -3,6 +3,11 @@ package ffmpeg
 import (
 	"errors"
 	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 // only support H264 by default, since Safari does not support VP8/VP9
@@ -134,3 +139,84 @@ func isValidForContainer(format Container, validContainers []Container) bool {
 	}
 	return false
 }
+
+// ClientCodecsParam is the query parameter used by clients to list the video
+// codecs that they can decode, as a comma-separated list.
+const ClientCodecsParam = "codecs"
+
+// codec identifiers used in media type codecs parameters (RFC 6381)
+var codecTags = map[string]string{
+	"avc1": H264,
+	"avc3": H264,
+	"hvc1": Hevc,
+	"hev1": Hevc,
+	"av01": Av1,
+	"vp8":  Vp8,
+	"vp08": Vp8,
+	"vp9":  Vp9,
+	"vp09": Vp9,
+}
+
+func matchClientCodec(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	switch s {
+	case H264, Hevc, Av1, Vp8, Vp9:
+		return s
+	case H265:
+		return Hevc
+	}
+
+	tag, _, _ := strings.Cut(s, ".")
+	return codecTags[tag]
+}
+
+// splitUnquoted splits s on sep, ignoring separators in quoted strings.
+func splitUnquoted(s string, sep rune) []string {
+	var ret []string
+	quoted := false
+	start := 0
+	for i, c := range s {
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case c == sep && !quoted:
+			ret = append(ret, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(ret, s[start:])
+}
+
+// ClientVideoCodecs returns the video codecs that the client has indicated
+// it can decode, either with the codecs query parameter or with the codecs
+// parameter of the media types in the Accept header.
+func ClientVideoCodecs(r *http.Request) []string {
+	var values []string
+	if v := r.URL.Query().Get(ClientCodecsParam); v != "" {
+		values = strings.Split(v, ",")
+	}
+
+	for _, accept := range r.Header.Values("Accept") {
+		for _, mediaRange := range splitUnquoted(accept, ',') {
+			_, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil {
+				continue
+			}
+
+			if codecs := params["codecs"]; codecs != "" {
+				values = append(values, strings.Split(codecs, ",")...)
+			}
+		}
+	}
+
+	var ret []string
+	for _, v := range values {
+		if codec := matchClientCodec(v); codec != "" {
+			ret = sliceutil.AppendUnique(ret, codec)
+		}
+	}
+
+	return ret
+}
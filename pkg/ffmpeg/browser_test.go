@@ -0,0 +1,45 @@
+package ffmpeg
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestClientVideoCodecs(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		accept string
+		want   []string
+	}{
+		{"none", "/stream.mp4", "*/*", nil},
+		{"query", "/stream.mp4?codecs=hevc,AV1,foo", "", []string{Hevc, Av1}},
+		{"query h265", "/stream.mp4?codecs=h265", "", []string{Hevc}},
+		{
+			"accept",
+			"/stream.mp4",
+			`video/mp4; codecs="hvc1.1.6.L93.B0, mp4a.40.2", video/webm; codecs="av01.0.05M.08", */*;q=0.8`,
+			[]string{Hevc, Av1},
+		},
+		{
+			"query and accept",
+			"/stream.mp4?codecs=av1",
+			`video/mp4; codecs="av01.0.05M.08,hev1.1.6.L93.B0"`,
+			[]string{Av1, Hevc},
+		},
+		{"invalid accept", "/stream.mp4", `video/mp4; codecs="hvc1`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+
+			if got := ClientVideoCodecs(r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ClientVideoCodecs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ var (
 	VideoCodecVP9     VideoCodec = "libvpx-vp9"
 	VideoCodecVPX     VideoCodec = "libvpx"
 	VideoCodecLibX265 VideoCodec = "libx265"
+	VideoCodecSVTAV1  VideoCodec = "libsvtav1"
 	VideoCodecCopy    VideoCodec = "copy"
 )
 
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 var (
@@ -25,15 +26,26 @@ var (
 	VideoCodecIVP9 VideoCodec = "vp9_qsv"
 	VideoCodecVVP9 VideoCodec = "vp9_vaapi"
 	VideoCodecVVPX VideoCodec = "vp8_vaapi"
+	VideoCodecN265 VideoCodec = "hevc_nvenc"
+	VideoCodecI265 VideoCodec = "hevc_qsv"
+	VideoCodecM265 VideoCodec = "hevc_videotoolbox"
+	VideoCodecV265 VideoCodec = "hevc_vaapi"
+	VideoCodecNAV1 VideoCodec = "av1_nvenc"
+	VideoCodecIAV1 VideoCodec = "av1_qsv"
+	VideoCodecVAV1 VideoCodec = "av1_vaapi"
 )
 
+// Software codecs that are not included in every ffmpeg build
+var optionalSWCodecs = []VideoCodec{
+	VideoCodecLibX265,
+	VideoCodecSVTAV1,
+}
+
 const minHeight int = 480
 
-// Tests all (given) hardware codec's
+// Tests all (given) hardware codec's, and the optional software codec's
 func (f *FFMpeg) InitHWSupport(ctx context.Context) {
-	var hwCodecSupport []VideoCodec
-
-	for _, codec := range []VideoCodec{
+	f.hwCodecSupport = f.testCodecs(ctx, []VideoCodec{
 		VideoCodecN264,
 		VideoCodecI264,
 		VideoCodecV264,
@@ -41,7 +53,45 @@ func (f *FFMpeg) InitHWSupport(ctx context.Context) {
 		VideoCodecIVP9,
 		VideoCodecVVP9,
 		VideoCodecM264,
-	} {
+		VideoCodecN265,
+		VideoCodecI265,
+		VideoCodecV265,
+		VideoCodecM265,
+		VideoCodecNAV1,
+		VideoCodecIAV1,
+		VideoCodecVAV1,
+	})
+
+	outstr := fmt.Sprintf("[InitHWSupport] Supported HW codecs [%d]:\n", len(f.hwCodecSupport))
+	for _, codec := range f.hwCodecSupport {
+		outstr += fmt.Sprintf("\t%s\n", codec)
+	}
+	logger.Info(outstr)
+
+	f.swCodecSupport = f.testCodecs(ctx, optionalSWCodecs)
+	logger.Debugf("[InitHWSupport] Supported optional SW codecs: %v", f.swCodecSupport)
+}
+
+// SupportsCodec returns true if the codec is available. Hardware codec's and
+// optional software codec's must have been detected by InitHWSupport.
+func (f *FFMpeg) SupportsCodec(codec VideoCodec) bool {
+	if sliceutil.Contains(optionalSWCodecs, codec) {
+		return sliceutil.Contains(f.swCodecSupport, codec)
+	}
+
+	switch codec {
+	case VideoCodecLibX264, VideoCodecVP9, VideoCodecCopy:
+		return true
+	}
+
+	return sliceutil.Contains(f.hwCodecSupport, codec)
+}
+
+// Returns the codec's that can encode a test video
+func (f *FFMpeg) testCodecs(ctx context.Context, codecs []VideoCodec) []VideoCodec {
+	var supported []VideoCodec
+
+	for _, codec := range codecs {
 		var args Args
 		args = append(args, "-hide_banner")
 		args = args.LogLevel(LogLevelWarning)
@@ -73,17 +123,11 @@ func (f *FFMpeg) InitHWSupport(ctx context.Context) {
 
 			logger.Debugf("[InitHWSupport] Codec %s not supported. Error output:\n%s", codec, errOutput)
 		} else {
-			hwCodecSupport = append(hwCodecSupport, codec)
+			supported = append(supported, codec)
 		}
 	}
 
-	outstr := fmt.Sprintf("[InitHWSupport] Supported HW codecs [%d]:\n", len(hwCodecSupport))
-	for _, codec := range hwCodecSupport {
-		outstr += fmt.Sprintf("\t%s\n", codec)
-	}
-	logger.Info(outstr)
-
-	f.hwCodecSupport = hwCodecSupport
+	return supported
 }
 
 func (f *FFMpeg) hwCanFullHWTranscode(ctx context.Context, codec VideoCodec, vf *models.VideoFile, reqHeight int) bool {
@@ -128,7 +172,9 @@ func (f *FFMpeg) hwCanFullHWTranscode(ctx context.Context, codec VideoCodec, vf
 // Prepend input for hardware encoding only
 func (f *FFMpeg) hwDeviceInit(args Args, toCodec VideoCodec, fullhw bool) Args {
 	switch toCodec {
-	case VideoCodecN264:
+	case VideoCodecN264,
+		VideoCodecN265,
+		VideoCodecNAV1:
 		args = append(args, "-hwaccel_device")
 		args = append(args, "0")
 		if fullhw {
@@ -140,7 +186,9 @@ func (f *FFMpeg) hwDeviceInit(args Args, toCodec VideoCodec, fullhw bool) Args {
 			args = append(args, "cuda")
 		}
 	case VideoCodecV264,
-		VideoCodecVVP9:
+		VideoCodecVVP9,
+		VideoCodecV265,
+		VideoCodecVAV1:
 		args = append(args, "-vaapi_device")
 		args = append(args, "/dev/dri/renderD128")
 		if fullhw {
@@ -150,7 +198,9 @@ func (f *FFMpeg) hwDeviceInit(args Args, toCodec VideoCodec, fullhw bool) Args {
 			args = append(args, "vaapi")
 		}
 	case VideoCodecI264,
-		VideoCodecIVP9:
+		VideoCodecIVP9,
+		VideoCodecI265,
+		VideoCodecIAV1:
 		if fullhw {
 			args = append(args, "-hwaccel")
 			args = append(args, "qsv")
@@ -162,7 +212,8 @@ func (f *FFMpeg) hwDeviceInit(args Args, toCodec VideoCodec, fullhw bool) Args {
 			args = append(args, "-filter_hw_device")
 			args = append(args, "hw")
 		}
-	case VideoCodecM264:
+	case VideoCodecM264,
+		VideoCodecM265:
 		if fullhw {
 			args = append(args, "-hwaccel")
 			args = append(args, "videotoolbox")
@@ -182,23 +233,30 @@ func (f *FFMpeg) hwFilterInit(toCodec VideoCodec, fullhw bool) VideoFilter {
 	var videoFilter VideoFilter
 	switch toCodec {
 	case VideoCodecV264,
-		VideoCodecVVP9:
+		VideoCodecVVP9,
+		VideoCodecV265,
+		VideoCodecVAV1:
 		if !fullhw {
 			videoFilter = videoFilter.Append("format=nv12")
 			videoFilter = videoFilter.Append("hwupload")
 		}
-	case VideoCodecN264:
+	case VideoCodecN264,
+		VideoCodecN265,
+		VideoCodecNAV1:
 		if !fullhw {
 			videoFilter = videoFilter.Append("format=yuv420p")
 			videoFilter = videoFilter.Append("hwupload_cuda")
 		}
 	case VideoCodecI264,
-		VideoCodecIVP9:
+		VideoCodecIVP9,
+		VideoCodecI265,
+		VideoCodecIAV1:
 		if !fullhw {
 			videoFilter = videoFilter.Append("hwupload=extra_hw_frames=64")
 			videoFilter = videoFilter.Append("format=qsv")
 		}
-	case VideoCodecM264:
+	case VideoCodecM264,
+		VideoCodecM265:
 		if !fullhw {
 			videoFilter = videoFilter.Append("format=nv12")
 			videoFilter = videoFilter.Append("hwupload")
@@ -268,15 +326,15 @@ func (f *FFMpeg) hwCodecFilter(args VideoFilter, codec VideoCodec, vf *models.Vi
 // Apply format switching if applicable
 func (f *FFMpeg) hwApplyFullHWFilter(args VideoFilter, codec VideoCodec, fullhw bool) VideoFilter {
 	switch codec {
-	case VideoCodecN264:
+	case VideoCodecN264, VideoCodecN265, VideoCodecNAV1:
 		if fullhw && f.version.Gteq(FFMpegVersion{major: 5}) { // Added in FFMpeg 5
 			args = args.Append("scale_cuda=format=yuv420p")
 		}
-	case VideoCodecV264, VideoCodecVVP9:
+	case VideoCodecV264, VideoCodecVVP9, VideoCodecV265, VideoCodecVAV1:
 		if fullhw && f.version.Gteq(FFMpegVersion{major: 3, minor: 1}) { // Added in FFMpeg 3.1
 			args = args.Append("scale_vaapi=format=nv12")
 		}
-	case VideoCodecI264, VideoCodecIVP9:
+	case VideoCodecI264, VideoCodecIVP9, VideoCodecI265, VideoCodecIAV1:
 		if fullhw && f.version.Gteq(FFMpegVersion{major: 3, minor: 3}) { // Added in FFMpeg 3.3
 			args = args.Append("scale_qsv=format=nv12")
 		}
@@ -290,31 +348,31 @@ func (f *FFMpeg) hwApplyScaleTemplate(sargs string, codec VideoCodec, match []in
 	var template string
 
 	switch codec {
-	case VideoCodecN264:
+	case VideoCodecN264, VideoCodecN265, VideoCodecNAV1:
 		template = "scale_cuda=$value"
 		if fullhw && f.version.Gteq(FFMpegVersion{major: 5}) { // Added in FFMpeg 5
 			template += ":format=yuv420p"
 		}
-	case VideoCodecV264, VideoCodecVVP9:
+	case VideoCodecV264, VideoCodecVVP9, VideoCodecV265, VideoCodecVAV1:
 		template = "scale_vaapi=$value"
 		if fullhw && f.version.Gteq(FFMpegVersion{major: 3, minor: 1}) { // Added in FFMpeg 3.1
 			template += ":format=nv12"
 		}
-	case VideoCodecI264, VideoCodecIVP9:
+	case VideoCodecI264, VideoCodecIVP9, VideoCodecI265, VideoCodecIAV1:
 		template = "scale_qsv=$value"
 		if fullhw && f.version.Gteq(FFMpegVersion{major: 3, minor: 3}) { // Added in FFMpeg 3.3
 			template += ":format=nv12"
 		}
-	case VideoCodecM264:
+	case VideoCodecM264, VideoCodecM265:
 		template = "scale_vt=$value"
 	default:
 		return VideoFilter(sargs)
 	}
 
 	// BUG: [scale_qsv]: Size values less than -1 are not acceptable.
-	isIntel := codec == VideoCodecI264 || codec == VideoCodecIVP9
+	isIntel := codec == VideoCodecI264 || codec == VideoCodecIVP9 || codec == VideoCodecI265 || codec == VideoCodecIAV1
 	// BUG: scale_vt doesn't call ff_scale_adjust_dimensions, thus cant accept negative size values
-	isApple := codec == VideoCodecM264
+	isApple := codec == VideoCodecM264 || codec == VideoCodecM265
 	return VideoFilter(templateReplaceScale(sargs, template, match, vf, isIntel || isApple))
 }
 
@@ -322,7 +380,11 @@ func (f *FFMpeg) hwApplyScaleTemplate(sargs string, codec VideoCodec, match []in
 func (f *FFMpeg) hwCodecMaxRes(codec VideoCodec) (int, int) {
 	switch codec {
 	case VideoCodecN264,
-		VideoCodecI264:
+		VideoCodecI264,
+		VideoCodecN265,
+		VideoCodecI265,
+		VideoCodecNAV1,
+		VideoCodecIAV1:
 		return 4096, 4096
 	}
 
@@ -355,8 +417,21 @@ func (f *FFMpeg) hwCodecHLSCompatible() *VideoCodec {
 	return nil
 }
 
-// Return if a hardware accelerated codec for MP4 is available
-func (f *FFMpeg) hwCodecMP4Compatible() *VideoCodec {
+// Return if a hardware accelerated codec for MP4 is available.
+// AV1 and HEVC codec's are preferred if supported by the client.
+func (f *FFMpeg) hwCodecMP4Compatible(clientCodecs []string) *VideoCodec {
+	if sliceutil.Contains(clientCodecs, Av1) {
+		if codec := f.hwCodecAV1Compatible(); codec != nil {
+			return codec
+		}
+	}
+
+	if sliceutil.Contains(clientCodecs, Hevc) {
+		if codec := f.hwCodecHEVCCompatible(); codec != nil {
+			return codec
+		}
+	}
+
 	for _, element := range f.hwCodecSupport {
 		switch element {
 		case VideoCodecN264,
@@ -368,8 +443,15 @@ func (f *FFMpeg) hwCodecMP4Compatible() *VideoCodec {
 	return nil
 }
 
-// Return if a hardware accelerated codec for WebM is available
-func (f *FFMpeg) hwCodecWEBMCompatible() *VideoCodec {
+// Return if a hardware accelerated codec for WebM is available.
+// AV1 codec's are preferred if supported by the client.
+func (f *FFMpeg) hwCodecWEBMCompatible(clientCodecs []string) *VideoCodec {
+	if sliceutil.Contains(clientCodecs, Av1) {
+		if codec := f.hwCodecAV1Compatible(); codec != nil {
+			return codec
+		}
+	}
+
 	for _, element := range f.hwCodecSupport {
 		switch element {
 		case VideoCodecIVP9,
@@ -379,3 +461,30 @@ func (f *FFMpeg) hwCodecWEBMCompatible() *VideoCodec {
 	}
 	return nil
 }
+
+// Return if a hardware accelerated HEVC codec is available
+func (f *FFMpeg) hwCodecHEVCCompatible() *VideoCodec {
+	for _, element := range f.hwCodecSupport {
+		switch element {
+		case VideoCodecN265,
+			VideoCodecI265,
+			VideoCodecV265,
+			VideoCodecM265:
+			return &element
+		}
+	}
+	return nil
+}
+
+// Return if a hardware accelerated AV1 codec is available
+func (f *FFMpeg) hwCodecAV1Compatible() *VideoCodec {
+	for _, element := range f.hwCodecSupport {
+		switch element {
+		case VideoCodecNAV1,
+			VideoCodecIAV1,
+			VideoCodecVAV1:
+			return &element
+		}
+	}
+	return nil
+}
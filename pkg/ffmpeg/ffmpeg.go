@@ -214,6 +214,7 @@ type FFMpeg struct {
 	ffmpeg         string
 	version        FFMpegVersion
 	hwCodecSupport []VideoCodec
+	swCodecSupport []VideoCodec
 }
 
 // Creates a new FFMpeg encoder
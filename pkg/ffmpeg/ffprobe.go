@@ -14,6 +14,7 @@ import (
 	stashExec "github.com/stashapp/stash/pkg/exec"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 func ValidateFFProbe(ffprobePath string) error {
@@ -109,6 +110,29 @@ type VideoFile struct {
 	FrameCount   int64
 
 	AudioCodec string
+
+	AudioTracks    []MediaTrack
+	SubtitleTracks []MediaTrack
+}
+
+// MediaTrack is an audio or subtitle stream of a file.
+type MediaTrack struct {
+	// Index is the index of the stream among the streams of the same type.
+	// It is used to select the stream with a stream specifier such as 0:a:1.
+	Index    int
+	Codec    string
+	Language string
+	Title    string
+	Default  bool
+	Forced   bool
+}
+
+// text based subtitle codecs, which can be converted to WebVTT
+var textSubtitleCodecs = []string{"subrip", "ass", "ssa", "webvtt", "mov_text", "text"}
+
+// IsTextSubtitle returns true if the track is a text based subtitle.
+func (t MediaTrack) IsTextSubtitle() bool {
+	return sliceutil.Contains(textSubtitleCodecs, t.Codec)
 }
 
 // TranscodeScale calculates the dimension scaling for a transcode, where maxSize is the maximum size of the longest dimension of the input video.
@@ -219,6 +243,9 @@ func parse(filePath string, probeJSON *FFProbeJSON) (*VideoFile, error) {
 		result.AudioStream = audioStream
 	}
 
+	result.AudioTracks = result.getTracks("audio")
+	result.SubtitleTracks = result.getTracks("subtitle")
+
 	videoStream := result.getVideoStream()
 	if videoStream != nil {
 		result.VideoStream = videoStream
@@ -279,6 +306,26 @@ func (v *VideoFile) getVideoStream() *FFProbeStream {
 	return nil
 }
 
+func (v *VideoFile) getTracks(fileType string) []MediaTrack {
+	var ret []MediaTrack
+	for _, stream := range v.JSON.Streams {
+		if stream.CodecType != fileType {
+			continue
+		}
+
+		ret = append(ret, MediaTrack{
+			Index:    len(ret),
+			Codec:    stream.CodecName,
+			Language: stream.Tags.Language,
+			Title:    stream.Tags.Title,
+			Default:  stream.Disposition.Default == 1,
+			Forced:   stream.Disposition.Forced == 1,
+		})
+	}
+
+	return ret
+}
+
 func (v *VideoFile) getStreamIndex(fileType string, probeJSON FFProbeJSON) int {
 	ret := -1
 	for i, stream := range probeJSON.Streams {
@@ -101,6 +101,17 @@ func (a Args) SkipAudio() Args {
 	return append(a, "-an")
 }
 
+// Map adds the -map argument with the given stream specifier and returns the result.
+func (a Args) Map(specifier string) Args {
+	return append(a, "-map", specifier)
+}
+
+// MapAudioTrack maps the first video stream and the audio stream at the
+// given index among the audio streams of the input, and returns the result.
+func (a Args) MapAudioTrack(track int) Args {
+	return a.Map("0:V:0").Map(fmt.Sprintf("0:a:%d", track))
+}
+
 // VideoCodec adds the given video codec and returns the result.
 func (a Args) VideoCodec(c VideoCodec) Args {
 	return append(a, c.Args()...)
@@ -2,7 +2,9 @@ package ffmpeg
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
@@ -20,6 +22,28 @@ const (
 	MimeMp4Audio  string = "audio/mp4"
 )
 
+// AudioTrackParam is the query parameter used to select the audio track of a
+// stream, as the index of the track among the audio streams of the file.
+const AudioTrackParam = "audio"
+
+var ErrInvalidAudioTrack = errors.New("invalid audio track")
+
+// ParseAudioTrack returns the audio track requested by the AudioTrackParam
+// query parameter of r, or nil if the default audio track should be used.
+func ParseAudioTrack(r *http.Request) (*int, error) {
+	v := r.URL.Query().Get(AudioTrackParam)
+	if v == "" {
+		return nil, nil
+	}
+
+	track, err := strconv.Atoi(v)
+	if err != nil || track < 0 {
+		return nil, ErrInvalidAudioTrack
+	}
+
+	return &track, nil
+}
+
 type StreamManager struct {
 	cacheDir string
 	encoder  *FFMpeg
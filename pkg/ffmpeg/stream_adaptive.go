@@ -0,0 +1,233 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
+
+	"github.com/zencoder/go-dash/v3/mpd"
+)
+
+const (
+	// nominal bits per pixel per frame, used to estimate the
+	// bandwidth of a transcoded rendition
+	adaptiveBitsPerPixel = 0.1
+
+	// frame rate assumed when the frame rate of the file is unknown
+	adaptiveDefaultFrameRate = 30
+
+	// bitrate of the audio streams, as set in the stream type args
+	hlsAudioBandwidth  = 128000
+	dashAudioBandwidth = 96000
+)
+
+// adaptiveResolutions are the scaled resolutions that may be included
+// in an adaptive manifest, in ascending order.
+var adaptiveResolutions = []models.StreamingResolutionEnum{
+	models.StreamingResolutionEnumLow,
+	models.StreamingResolutionEnumStandard,
+	models.StreamingResolutionEnumStandardHd,
+	models.StreamingResolutionEnumFullHd,
+	models.StreamingResolutionEnumFourK,
+}
+
+type rendition struct {
+	resolution models.StreamingResolutionEnum
+	width      int
+	height     int
+	bandwidth  int64
+}
+
+// scaleToMaxSize returns the dimensions of a video scaled so that its
+// smaller dimension is no larger than maxSize. A maxSize of 0 does not
+// scale the video.
+func scaleToMaxSize(width, height, maxSize int) (int, int) {
+	if maxSize == 0 {
+		return width, height
+	}
+
+	videoSize := height
+	if width < videoSize {
+		videoSize = width
+	}
+
+	if maxSize < videoSize {
+		scaleFactor := float64(maxSize) / float64(videoSize)
+		width = int(float64(width) * scaleFactor)
+		height = int(float64(height) * scaleFactor)
+	}
+
+	return width, height
+}
+
+// estimateBandwidth returns the approximate bitrate of a transcode of the
+// video file at the given dimensions.
+func estimateBandwidth(vf *models.VideoFile, width, height int) int64 {
+	frameRate := vf.FrameRate
+	if frameRate <= 0 {
+		frameRate = adaptiveDefaultFrameRate
+	}
+
+	ret := int64(float64(width*height) * frameRate * adaptiveBitsPerPixel)
+
+	// transcoding cannot add detail, so assume that a transcode is
+	// never larger than the source file
+	if vf.BitRate > 0 && (ret == 0 || vf.BitRate < ret) {
+		ret = vf.BitRate
+	}
+
+	return ret
+}
+
+// adaptiveRenditions returns the renditions of the video file to include in
+// an adaptive manifest, lowest quality first. Renditions larger than
+// maxTranscodeSize are excluded, and the original resolution is only
+// included if it is not larger than maxTranscodeSize.
+func adaptiveRenditions(vf *models.VideoFile, width, height int, maxTranscodeSize int) []rendition {
+	videoSize := height
+	if width < videoSize {
+		videoSize = width
+	}
+
+	var ret []rendition
+	add := func(resolution models.StreamingResolutionEnum, maxSize int) {
+		w, h := scaleToMaxSize(width, height, maxSize)
+		ret = append(ret, rendition{
+			resolution: resolution,
+			width:      w,
+			height:     h,
+			bandwidth:  estimateBandwidth(vf, w, h),
+		})
+	}
+
+	for _, resolution := range adaptiveResolutions {
+		size := resolution.GetMaxResolution()
+		// the original rendition is used instead of upscaling
+		if size >= videoSize || (maxTranscodeSize != 0 && size > maxTranscodeSize) {
+			break
+		}
+
+		add(resolution, size)
+	}
+
+	if maxTranscodeSize == 0 || videoSize <= maxTranscodeSize || len(ret) == 0 {
+		add(models.StreamingResolutionEnumOriginal, 0)
+	}
+
+	return ret
+}
+
+// serveHLSMasterManifest serves a HLS master playlist, with a variant for
+// each rendition of the video file. The URLs for the variants are of the
+// form {r.URL}?resolution={resolution}, which are served by serveHLSManifest.
+// The audio track of the request is passed through to the variants.
+func serveHLSMasterManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile) {
+	if sm.cacheDir == "" {
+		logger.Error("[transcode] cannot live transcode with HLS because cache dir is unset")
+		http.Error(w, "cannot live transcode with HLS because cache dir is unset", http.StatusServiceUnavailable)
+		return
+	}
+
+	baseUrl := *r.URL
+	baseUrl.RawQuery = ""
+	baseURL := baseUrl.String()
+
+	hasAudio := ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported
+
+	maxTranscodeSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
+	renditions := adaptiveRenditions(vf, vf.Width, vf.Height, maxTranscodeSize)
+
+	var buf bytes.Buffer
+
+	fmt.Fprint(&buf, "#EXTM3U\n")
+	fmt.Fprint(&buf, "#EXT-X-VERSION:3\n")
+
+	for _, rendition := range renditions {
+		bandwidth := rendition.bandwidth
+		if hasAudio {
+			bandwidth += hlsAudioBandwidth
+		}
+
+		fmt.Fprintf(&buf, "#EXT-X-STREAM-INF:BANDWIDTH=%d", bandwidth)
+		if rendition.width != 0 && rendition.height != 0 {
+			fmt.Fprintf(&buf, ",RESOLUTION=%dx%d", rendition.width, rendition.height)
+		}
+		fmt.Fprint(&buf, "\n")
+		fmt.Fprintf(&buf, "%s%s\n", baseURL, segmentURLQuery(r, rendition.resolution.String()))
+	}
+
+	w.Header().Set("Content-Type", MimeHLS)
+	utils.ServeStaticContent(w, r, buf.Bytes())
+}
+
+// serveDASHAdaptiveManifest serves a DASH manifest with a video
+// representation for each rendition of the video file. The representation
+// ID is the resolution, which is passed to the segment URLs.
+func serveDASHAdaptiveManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile) {
+	if sm.cacheDir == "" {
+		logger.Error("[transcode] cannot live transcode with DASH because cache dir is unset")
+		http.Error(w, "cannot live transcode files with DASH because cache dir is unset", http.StatusServiceUnavailable)
+		return
+	}
+
+	probeResult, err := sm.ffprobe.NewVideoFile(vf.Path)
+	if err != nil {
+		logger.Warnf("[transcode] error generating DASH manifest: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	framerate, videoWidth, videoHeight := dashVideoProperties(probeResult, vf)
+
+	maxTranscodeSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
+	renditions := adaptiveRenditions(vf, videoWidth, videoHeight, maxTranscodeSize)
+
+	mediaDuration := mpd.Duration(time.Duration(probeResult.FileDuration * float64(time.Second)))
+	m := mpd.NewMPD(mpd.DASH_PROFILE_LIVE, mediaDuration.String(), "PT4.0S")
+
+	baseUrl := r.URL.JoinPath("/")
+	baseUrl.RawQuery = ""
+	m.BaseURL = baseUrl.String()
+
+	urlQuery := segmentURLQuery(r, "$RepresentationID$")
+
+	video, _ := m.AddNewAdaptationSetVideo(MimeWebmVideo, "progressive", true, 1)
+
+	_, _ = video.SetNewSegmentTemplate(2, "init_v.webm"+urlQuery, "$Number$_v.webm"+urlQuery, 0, 1)
+	for _, rendition := range renditions {
+		_, _ = video.AddNewRepresentationVideo(rendition.bandwidth, "vp09.00.40.08", rendition.resolution.String(), framerate, int64(rendition.width), int64(rendition.height))
+	}
+
+	// audio is not affected by the resolution, so a single
+	// representation is shared by all video representations
+	if ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported {
+		audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, "und")
+		audioQuery := segmentURLQuery(r, "")
+		_, _ = audio.SetNewSegmentTemplate(2, "init_a.webm"+audioQuery, "$Number$_a.webm"+audioQuery, 0, 1)
+		_, _ = audio.AddNewRepresentationAudio(48000, dashAudioBandwidth, "opus", "1")
+	}
+
+	var buf bytes.Buffer
+	_ = m.Write(&buf)
+
+	w.Header().Set("Content-Type", MimeDASH)
+	utils.ServeStaticContent(w, r, buf.Bytes())
+}
+
+// ServeAdaptiveManifest serves a manifest listing multiple renditions of the
+// video file, so that the client can switch between them as the available
+// bandwidth changes. Each rendition is transcoded on demand when its
+// segments are requested.
+func (sm *StreamManager) ServeAdaptiveManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *models.VideoFile) {
+	if streamType.ServeAdaptiveManifest == nil {
+		streamType.ServeManifest(sm, w, r, vf, "")
+		return
+	}
+
+	streamType.ServeAdaptiveManifest(sm, w, r, vf)
+}
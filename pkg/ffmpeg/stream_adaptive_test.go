@@ -0,0 +1,128 @@
+package ffmpeg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestAdaptiveRenditions(t *testing.T) {
+	type size struct {
+		resolution models.StreamingResolutionEnum
+		width      int
+		height     int
+	}
+
+	tests := []struct {
+		name             string
+		width            int
+		height           int
+		maxTranscodeSize int
+		want             []size
+	}{
+		{
+			"1080p unlimited",
+			1920,
+			1080,
+			0,
+			[]size{
+				{models.StreamingResolutionEnumLow, 426, 240},
+				{models.StreamingResolutionEnumStandard, 853, 480},
+				{models.StreamingResolutionEnumStandardHd, 1280, 720},
+				{models.StreamingResolutionEnumOriginal, 1920, 1080},
+			},
+		},
+		{
+			"1080p limited to 720p",
+			1920,
+			1080,
+			720,
+			[]size{
+				{models.StreamingResolutionEnumLow, 426, 240},
+				{models.StreamingResolutionEnumStandard, 853, 480},
+				{models.StreamingResolutionEnumStandardHd, 1280, 720},
+			},
+		},
+		{
+			"portrait 720p",
+			720,
+			1280,
+			0,
+			[]size{
+				{models.StreamingResolutionEnumLow, 240, 426},
+				{models.StreamingResolutionEnumStandard, 480, 853},
+				{models.StreamingResolutionEnumOriginal, 720, 1280},
+			},
+		},
+		{
+			"smaller than lowest",
+			320,
+			180,
+			0,
+			[]size{
+				{models.StreamingResolutionEnumOriginal, 320, 180},
+			},
+		},
+		{
+			"smaller than lowest and limited",
+			320,
+			180,
+			240,
+			[]size{
+				{models.StreamingResolutionEnumOriginal, 320, 180},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vf := &models.VideoFile{
+				Width:     tt.width,
+				Height:    tt.height,
+				FrameRate: 30,
+			}
+
+			renditions := adaptiveRenditions(vf, tt.width, tt.height, tt.maxTranscodeSize)
+
+			var got []size
+			for i, r := range renditions {
+				got = append(got, size{r.resolution, r.width, r.height})
+
+				if i > 0 && r.bandwidth <= renditions[i-1].bandwidth {
+					t.Errorf("adaptiveRenditions() bandwidth of %s = %d, not greater than %s", r.resolution, r.bandwidth, renditions[i-1].resolution)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("adaptiveRenditions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimateBandwidth(t *testing.T) {
+	tests := []struct {
+		name    string
+		bitRate int64
+		width   int
+		height  int
+		want    int64
+	}{
+		{"no source bitrate", 0, 1280, 720, 2764800},
+		{"below source bitrate", 8000000, 1280, 720, 2764800},
+		{"limited to source bitrate", 1000000, 1280, 720, 1000000},
+		{"unknown dimensions", 1000000, 0, 0, 1000000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vf := &models.VideoFile{
+				BitRate:   tt.bitRate,
+				FrameRate: 30,
+			}
+
+			if got := estimateBandwidth(vf, tt.width, tt.height); got != tt.want {
+				t.Errorf("estimateBandwidth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -51,15 +51,18 @@ type StreamType struct {
 	Name          string
 	SegmentType   *SegmentType
 	ServeManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile, resolution string)
-	Args          func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) Args
+	// nil if the stream type does not support adaptive streaming
+	ServeAdaptiveManifest func(sm *StreamManager, w http.ResponseWriter, r *http.Request, vf *models.VideoFile)
+	Args                  func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioTrack *int, outputDir string) Args
 }
 
 var (
 	StreamTypeHLS = &StreamType{
-		Name:          "hls",
-		SegmentType:   SegmentTypeTS,
-		ServeManifest: serveHLSManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Name:                  "hls",
+		SegmentType:           SegmentTypeTS,
+		ServeManifest:         serveHLSManifest,
+		ServeAdaptiveManifest: serveHLSMasterManifest,
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioTrack *int, outputDir string) (args Args) {
 			args = CodecInit(codec)
 			args = append(args,
 				"-flags", "+cgop",
@@ -69,6 +72,9 @@ var (
 			if videoOnly {
 				args = append(args, "-an")
 			} else {
+				if audioTrack != nil {
+					args = args.MapAudioTrack(*audioTrack)
+				}
 				args = append(args,
 					"-c:a", "aac",
 					"-ac", "2",
@@ -94,11 +100,14 @@ var (
 		Name:          "hls-copy",
 		SegmentType:   SegmentTypeTS,
 		ServeManifest: serveHLSManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioTrack *int, outputDir string) (args Args) {
 			args = CodecInit(codec)
 			if videoOnly {
 				args = append(args, "-an")
 			} else {
+				if audioTrack != nil {
+					args = args.MapAudioTrack(*audioTrack)
+				}
 				args = append(args,
 					"-c:a", "aac",
 					"-ac", "2",
@@ -121,10 +130,11 @@ var (
 		},
 	}
 	StreamTypeDASHVideo = &StreamType{
-		Name:          "dash-v",
-		SegmentType:   SegmentTypeWEBMVideo,
-		ServeManifest: serveDASHManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Name:                  "dash-v",
+		SegmentType:           SegmentTypeWEBMVideo,
+		ServeManifest:         serveDASHManifest,
+		ServeAdaptiveManifest: serveDASHAdaptiveManifest,
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioTrack *int, outputDir string) (args Args) {
 			// only generate the actual init segment (init_v.webm)
 			// when generating the first segment
 			init := ".init"
@@ -154,20 +164,24 @@ var (
 		Name:          "dash-a",
 		SegmentType:   SegmentTypeWEBMAudio,
 		ServeManifest: serveDASHManifest,
-		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, outputDir string) (args Args) {
+		Args: func(codec VideoCodec, segment int, videoFilter VideoFilter, videoOnly bool, audioTrack *int, outputDir string) (args Args) {
 			// only generate the actual init segment (init_a.webm)
 			// when generating the first segment
 			init := ".init"
 			if segment == 0 {
 				init = "init"
 			}
+			track := 0
+			if audioTrack != nil {
+				track = *audioTrack
+			}
 			args = append(args,
 				"-c:a", "libopus",
 				"-b:a", "96000",
 				"-ar", "48000",
 				"-copyts",
 				"-avoid_negative_ts", "disabled",
-				"-map", "0:a:0",
+				"-map", fmt.Sprintf("0:a:%d", track),
 				"-f", "webm_chunk",
 				"-chunk_start_index", fmt.Sprint(segment),
 				"-audio_chunk_duration", fmt.Sprint(segmentLength*1000),
@@ -255,6 +269,9 @@ type StreamOptions struct {
 	Resolution string
 	Hash       string
 	Segment    string
+	// index of the audio track to include, among the audio streams of the file.
+	// The default audio stream is used if nil.
+	AudioTrack *int
 }
 
 type transcodeProcess struct {
@@ -282,6 +299,7 @@ type runningStream struct {
 	streamType       *StreamType
 	vf               *models.VideoFile
 	maxTranscodeSize int
+	audioTrack       *int
 	outputDir        string
 
 	waitingSegments []*waitingSegment
@@ -294,12 +312,35 @@ func (t StreamType) String() string {
 	return t.Name
 }
 
-func (t StreamType) FileDir(hash string, maxTranscodeSize int) string {
-	if maxTranscodeSize == 0 {
-		return fmt.Sprintf("%s_%s", hash, t)
-	} else {
-		return fmt.Sprintf("%s_%s_%d", hash, t, maxTranscodeSize)
+func (t StreamType) FileDir(hash string, maxTranscodeSize int, audioTrack *int) string {
+	dir := fmt.Sprintf("%s_%s", hash, t)
+	if maxTranscodeSize != 0 {
+		dir += fmt.Sprintf("_%d", maxTranscodeSize)
+	}
+	if audioTrack != nil {
+		dir += fmt.Sprintf("_a%d", *audioTrack)
+	}
+	return dir
+}
+
+// segmentURLQuery returns the query string to add to the segment URLs of a
+// manifest. The audio track of the manifest request is passed through to
+// the segments, so that they are transcoded with the same audio track.
+func segmentURLQuery(r *http.Request, resolution string) string {
+	var params []string
+	if resolution != "" {
+		params = append(params, "resolution="+resolution)
+	}
+	if audioTrack := r.URL.Query().Get(AudioTrackParam); audioTrack != "" {
+		if _, err := strconv.Atoi(audioTrack); err == nil {
+			params = append(params, AudioTrackParam+"="+audioTrack)
+		}
+	}
+
+	if len(params) == 0 {
+		return ""
 	}
+	return "?" + strings.Join(params, "&")
 }
 
 func HLSGetCodec(sm *StreamManager, name string) (codec VideoCodec) {
@@ -311,7 +352,7 @@ func HLSGetCodec(sm *StreamManager, name string) (codec VideoCodec) {
 		}
 	case "dash-v":
 		codec = VideoCodecVP9
-		if hwcodec := sm.encoder.hwCodecWEBMCompatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
+		if hwcodec := sm.encoder.hwCodecWEBMCompatible(nil); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
 			codec = *hwcodec
 		}
 	case "hls-copy":
@@ -344,7 +385,7 @@ func (s *runningStream) makeStreamArgs(sm *StreamManager, segment int) Args {
 
 	videoFilter := sm.encoder.hwMaxResFilter(codec, s.vf, s.maxTranscodeSize, fullhw)
 
-	args = append(args, s.streamType.Args(codec, segment, videoFilter, videoOnly, s.outputDir)...)
+	args = append(args, s.streamType.Args(codec, segment, videoFilter, videoOnly, s.audioTrack, s.outputDir)...)
 
 	args = append(args, extraOutputArgs...)
 
@@ -425,10 +466,7 @@ func serveHLSManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request,
 	baseUrl.RawQuery = ""
 	baseURL := baseUrl.String()
 
-	var urlQuery string
-	if resolution != "" {
-		urlQuery = fmt.Sprintf("?resolution=%s", resolution)
-	}
+	urlQuery := segmentURLQuery(r, resolution)
 
 	var buf bytes.Buffer
 
@@ -476,56 +514,14 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	var framerate string
-	var videoWidth int
-	var videoHeight int
-	videoStream := probeResult.VideoStream
-	if videoStream != nil {
-		framerate = videoStream.AvgFrameRate
-		videoWidth = videoStream.Width
-		videoHeight = videoStream.Height
-	} else {
-		// extract the framerate fraction from the file framerate
-		// framerates 0.1% below round numbers are common,
-		// attempt to infer when this is the case
-		fileFramerate := vf.FrameRate
-		rate1001, off1001 := math.Modf(fileFramerate * 1.001)
-		var numerator int
-		var denominator int
-		switch {
-		case off1001 < 0.005:
-			numerator = int(rate1001) * 1000
-			denominator = 1001
-		case off1001 > 0.995:
-			numerator = (int(rate1001) + 1) * 1000
-			denominator = 1001
-		default:
-			numerator = int(fileFramerate * 1000)
-			denominator = 1000
-		}
-		framerate = fmt.Sprintf("%d/%d", numerator, denominator)
-		videoHeight = vf.Height
-		videoWidth = vf.Width
-	}
+	framerate, videoWidth, videoHeight := dashVideoProperties(probeResult, vf)
 
-	var urlQuery string
+	urlQuery := segmentURLQuery(r, resolution)
 	maxTranscodeSize := sm.config.GetMaxStreamingTranscodeSize().GetMaxResolution()
 	if resolution != "" {
 		maxTranscodeSize = models.StreamingResolutionEnum(resolution).GetMaxResolution()
-		urlQuery = fmt.Sprintf("?resolution=%s", resolution)
-	}
-	if maxTranscodeSize != 0 {
-		videoSize := videoHeight
-		if videoWidth < videoSize {
-			videoSize = videoWidth
-		}
-
-		if maxTranscodeSize < videoSize {
-			scaleFactor := float64(maxTranscodeSize) / float64(videoSize)
-			videoWidth = int(float64(videoWidth) * scaleFactor)
-			videoHeight = int(float64(videoHeight) * scaleFactor)
-		}
 	}
+	videoWidth, videoHeight = scaleToMaxSize(videoWidth, videoHeight, maxTranscodeSize)
 
 	mediaDuration := mpd.Duration(time.Duration(probeResult.FileDuration * float64(time.Second)))
 	m := mpd.NewMPD(mpd.DASH_PROFILE_LIVE, mediaDuration.String(), "PT4.0S")
@@ -537,12 +533,12 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 	video, _ := m.AddNewAdaptationSetVideo(MimeWebmVideo, "progressive", true, 1)
 
 	_, _ = video.SetNewSegmentTemplate(2, "init_v.webm"+urlQuery, "$Number$_v.webm"+urlQuery, 0, 1)
-	_, _ = video.AddNewRepresentationVideo(200000, "vp09.00.40.08", "0", framerate, int64(videoWidth), int64(videoHeight))
+	_, _ = video.AddNewRepresentationVideo(estimateBandwidth(vf, videoWidth, videoHeight), "vp09.00.40.08", "0", framerate, int64(videoWidth), int64(videoHeight))
 
 	if ProbeAudioCodec(vf.AudioCodec) != MissingUnsupported {
 		audio, _ := m.AddNewAdaptationSetAudio(MimeWebmAudio, true, 1, "und")
 		_, _ = audio.SetNewSegmentTemplate(2, "init_a.webm"+urlQuery, "$Number$_a.webm"+urlQuery, 0, 1)
-		_, _ = audio.AddNewRepresentationAudio(48000, 96000, "opus", "1")
+		_, _ = audio.AddNewRepresentationAudio(48000, dashAudioBandwidth, "opus", "1")
 	}
 
 	var buf bytes.Buffer
@@ -552,6 +548,38 @@ func serveDASHManifest(sm *StreamManager, w http.ResponseWriter, r *http.Request
 	utils.ServeStaticContent(w, r, buf.Bytes())
 }
 
+// dashVideoProperties returns the framerate fraction and dimensions of the
+// video stream, falling back to the stored file properties if the probe
+// result has no video stream.
+func dashVideoProperties(probeResult *VideoFile, vf *models.VideoFile) (framerate string, videoWidth int, videoHeight int) {
+	videoStream := probeResult.VideoStream
+	if videoStream != nil {
+		return videoStream.AvgFrameRate, videoStream.Width, videoStream.Height
+	}
+
+	// extract the framerate fraction from the file framerate
+	// framerates 0.1% below round numbers are common,
+	// attempt to infer when this is the case
+	fileFramerate := vf.FrameRate
+	rate1001, off1001 := math.Modf(fileFramerate * 1.001)
+	var numerator int
+	var denominator int
+	switch {
+	case off1001 < 0.005:
+		numerator = int(rate1001) * 1000
+		denominator = 1001
+	case off1001 > 0.995:
+		numerator = (int(rate1001) + 1) * 1000
+		denominator = 1001
+	default:
+		numerator = int(fileFramerate * 1000)
+		denominator = 1000
+	}
+	framerate = fmt.Sprintf("%d/%d", numerator, denominator)
+
+	return framerate, vf.Width, vf.Height
+}
+
 func (sm *StreamManager) ServeManifest(w http.ResponseWriter, r *http.Request, streamType *StreamType, vf *models.VideoFile, resolution string) {
 	streamType.ServeManifest(sm, w, r, vf, resolution)
 }
@@ -598,7 +626,7 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 		maxTranscodeSize = models.StreamingResolutionEnum(options.Resolution).GetMaxResolution()
 	}
 
-	dir := options.StreamType.FileDir(options.Hash, maxTranscodeSize)
+	dir := options.StreamType.FileDir(options.Hash, maxTranscodeSize, options.AudioTrack)
 	outputDir := filepath.Join(sm.cacheDir, dir)
 
 	name := streamType.SegmentType.MakeFilename(segment)
@@ -613,6 +641,7 @@ func (sm *StreamManager) ServeSegment(w http.ResponseWriter, r *http.Request, op
 			streamType:       options.StreamType,
 			vf:               options.VideoFile,
 			maxTranscodeSize: maxTranscodeSize,
+			audioTrack:       options.AudioTrack,
 			outputDir:        outputDir,
 
 			// initialize to cap 10 to avoid reallocations
@@ -11,6 +11,7 @@ import (
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
 )
 
 type StreamFormat struct {
@@ -39,6 +40,19 @@ func CodecInit(codec VideoCodec) (args Args) {
 			"-crf", "30",
 			"-b:v", "0",
 		)
+	case VideoCodecLibX265:
+		args = append(args,
+			"-pix_fmt", "yuv420p",
+			"-preset", "veryfast",
+			"-crf", "28",
+			"-tag:v", "hvc1",
+		)
+	case VideoCodecSVTAV1:
+		args = append(args,
+			"-pix_fmt", "yuv420p",
+			"-preset", "10",
+			"-crf", "35",
+		)
 	// HW Codecs
 	case VideoCodecN264:
 		args = append(args,
@@ -76,6 +90,39 @@ func CodecInit(codec VideoCodec) (args Args) {
 		args = append(args,
 			"-qp", "20",
 		)
+	// the hvc1 tag is required for playback in Safari
+	case VideoCodecN265:
+		args = append(args,
+			"-rc", "vbr",
+			"-cq", "20",
+			"-tag:v", "hvc1",
+		)
+	case VideoCodecI265:
+		args = append(args,
+			"-global_quality", "20",
+			"-preset", "faster",
+			"-tag:v", "hvc1",
+		)
+	case VideoCodecV265:
+		args = append(args,
+			"-qp", "20",
+			"-tag:v", "hvc1",
+		)
+	case VideoCodecM265:
+		args = append(args,
+			"-realtime", "1",
+			"-tag:v", "hvc1",
+		)
+	case VideoCodecNAV1:
+		args = append(args,
+			"-rc", "vbr",
+			"-cq", "30",
+		)
+	case VideoCodecIAV1:
+		args = append(args,
+			"-global_quality", "20",
+			"-preset", "faster",
+		)
 	}
 
 	return args
@@ -136,6 +183,11 @@ type TranscodeOptions struct {
 	VideoFile  *models.VideoFile
 	Resolution string
 	StartTime  float64
+	// video codecs that the client can decode, as returned by ClientVideoCodecs
+	ClientCodecs []string
+	// index of the audio track to include, among the audio streams of the file.
+	// The default audio stream is used if nil.
+	AudioTrack *int
 }
 
 func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int) (codec VideoCodec) {
@@ -149,21 +201,29 @@ func (o TranscodeOptions) FileGetCodec(sm *StreamManager, maxTranscodeSize int)
 		}
 	}
 
+	clientSupports := func(videoCodec string) bool {
+		return sliceutil.Contains(o.ClientCodecs, videoCodec)
+	}
+
 	switch o.StreamType.MimeType {
 	case MimeMp4Video:
-		if !needsResize && o.VideoFile.VideoCodec == H264 {
+		videoCodec := o.VideoFile.VideoCodec
+		if !needsResize && (videoCodec == H264 || ((videoCodec == Hevc || videoCodec == Av1) && clientSupports(videoCodec))) {
 			return VideoCodecCopy
 		}
+		// HEVC and AV1 are only used with hardware encoding,
+		// software encoding is too slow for live transcoding
 		codec = VideoCodecLibX264
-		if hwcodec := sm.encoder.hwCodecMP4Compatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
+		if hwcodec := sm.encoder.hwCodecMP4Compatible(o.ClientCodecs); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
 			codec = *hwcodec
 		}
 	case MimeWebmVideo:
-		if !needsResize && (o.VideoFile.VideoCodec == Vp8 || o.VideoFile.VideoCodec == Vp9) {
+		videoCodec := o.VideoFile.VideoCodec
+		if !needsResize && (videoCodec == Vp8 || videoCodec == Vp9 || (videoCodec == Av1 && clientSupports(videoCodec))) {
 			return VideoCodecCopy
 		}
 		codec = VideoCodecVP9
-		if hwcodec := sm.encoder.hwCodecWEBMCompatible(); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
+		if hwcodec := sm.encoder.hwCodecWEBMCompatible(o.ClientCodecs); hwcodec != nil && sm.config.GetTranscodeHardwareAcceleration() {
 			codec = *hwcodec
 		}
 	case MimeMkvVideo:
@@ -202,6 +262,15 @@ func (o TranscodeOptions) makeStreamArgs(sm *StreamManager) Args {
 
 	args = append(args, o.StreamType.Args(codec, videoFilter, videoOnly)...)
 
+	if o.AudioTrack != nil && !videoOnly {
+		args = args.MapAudioTrack(*o.AudioTrack)
+	}
+
+	// copied HEVC streams need the hvc1 tag to play in Safari
+	if codec == VideoCodecCopy && o.StreamType.MimeType == MimeMp4Video && o.VideoFile.VideoCodec == Hevc {
+		args = append(args, "-tag:v", "hvc1")
+	}
+
 	args = append(args, extraOutputArgs...)
 
 	args = args.Output("pipe:")
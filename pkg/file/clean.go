@@ -11,6 +11,7 @@ import (
 	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
 )
 
 // Cleaner scans through stored file and folder instances and removes those that are no longer present on disk.
@@ -19,6 +20,9 @@ type Cleaner struct {
 	Repository Repository
 
 	Handlers []CleanHandler
+
+	// PostHooks registers the plugin hooks for deleted folders.
+	PostHooks PostHookRegisterer
 }
 
 type cleanJob struct {
@@ -419,7 +423,15 @@ func (j *cleanJob) deleteFolder(ctx context.Context, folderID models.FolderID, f
 			return err
 		}
 
-		return r.Folder.Destroy(ctx, folderID)
+		if err := r.Folder.Destroy(ctx, folderID); err != nil {
+			return err
+		}
+
+		registerPostHooks(ctx, j.PostHooks, int(folderID), hook.FolderDestroyPost, FolderInput{
+			Path: fn,
+		})
+
+		return nil
 	}); err != nil {
 		logger.Errorf("Error deleting folder %q from database: %s", fn, err.Error())
 		return
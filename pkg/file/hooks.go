@@ -0,0 +1,58 @@
+package file
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
+)
+
+// PostHookRegisterer registers plugin post-hooks to be executed once the
+// current transaction is committed.
+type PostHookRegisterer interface {
+	RegisterPostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string)
+}
+
+// types for file and folder hooks
+type FileCreateInput struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	// Fingerprints maps fingerprint type to value
+	Fingerprints map[string]interface{} `json:"fingerprints"`
+}
+
+type FileFingerprintChangeInput struct {
+	Path            string                 `json:"path"`
+	OldFingerprints map[string]interface{} `json:"old_fingerprints"`
+	NewFingerprints map[string]interface{} `json:"new_fingerprints"`
+}
+
+type FolderInput struct {
+	Path string `json:"path"`
+}
+
+// MoveInput is used for file and folder move hooks.
+type MoveInput struct {
+	OldPath string `json:"old_path"`
+	NewPath string `json:"new_path"`
+}
+
+// fingerprintMap returns a map of fingerprint type to value, for use in hook
+// inputs.
+func fingerprintMap(fp models.Fingerprints) map[string]interface{} {
+	ret := make(map[string]interface{})
+	for _, f := range fp {
+		ret[f.Type] = f.Fingerprint
+	}
+
+	return ret
+}
+
+// registerPostHooks registers the post-hooks using h, if it is set.
+func registerPostHooks(ctx context.Context, h PostHookRegisterer, id int, hookType hook.TriggerEnum, input interface{}) {
+	if h == nil {
+		return
+	}
+
+	h.RegisterPostHooks(ctx, id, hookType, input, nil)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/remeh/sizedwaitgroup"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/hook"
 	"github.com/stashapp/stash/pkg/txn"
 	"github.com/stashapp/stash/pkg/utils"
 )
@@ -57,6 +58,10 @@ type Scanner struct {
 
 	// FileDecorators are applied to files as they are scanned.
 	FileDecorators []Decorator
+
+	// PostHooks registers the plugin hooks for created and moved files and
+	// folders, and for files with changed fingerprints.
+	PostHooks PostHookRegisterer
 }
 
 // FingerprintCalculator calculates a fingerprint for the provided file.
@@ -560,6 +565,10 @@ func (s *scanJob) onNewFolder(ctx context.Context, file scanFile) (*models.Folde
 		return nil, fmt.Errorf("creating folder %q: %w", file.Path, err)
 	}
 
+	registerPostHooks(ctx, s.PostHooks, int(toCreate.ID), hook.FolderCreatePost, FolderInput{
+		Path: toCreate.Path,
+	})
+
 	return toCreate, nil
 }
 
@@ -580,7 +589,8 @@ func (s *scanJob) handleFolderRename(ctx context.Context, file scanFile) (*model
 	}
 
 	// if the folder was moved, update the existing folder
-	logger.Infof("%s moved to %s. Updating path...", renamedFrom.Path, file.Path)
+	oldPath := renamedFrom.Path
+	logger.Infof("%s moved to %s. Updating path...", oldPath, file.Path)
 	renamedFrom.Path = file.Path
 
 	// update the parent folder ID
@@ -601,6 +611,11 @@ func (s *scanJob) handleFolderRename(ctx context.Context, file scanFile) (*model
 		return nil, fmt.Errorf("correcting sub folder hierarchy for %q: %w", renamedFrom.Path, err)
 	}
 
+	registerPostHooks(ctx, s.PostHooks, int(renamedFrom.ID), hook.FolderMovePost, MoveInput{
+		OldPath: oldPath,
+		NewPath: renamedFrom.Path,
+	})
+
 	return renamedFrom, nil
 }
 
@@ -756,6 +771,13 @@ func (s *scanJob) onNewFile(ctx context.Context, f scanFile) (models.File, error
 			return fmt.Errorf("creating file %q: %w", path, err)
 		}
 
+		base := file.Base()
+		registerPostHooks(ctx, s.PostHooks, int(base.ID), hook.FileCreatePost, FileCreateInput{
+			Path:         base.Path,
+			Size:         base.Size,
+			Fingerprints: fingerprintMap(base.Fingerprints),
+		})
+
 		if err := s.fireHandlers(ctx, file, nil); err != nil {
 			return err
 		}
@@ -920,6 +942,11 @@ func (s *scanJob) handleRename(ctx context.Context, f models.File, fp []models.F
 			return fmt.Errorf("updating file for rename %q: %w", newPath, err)
 		}
 
+		registerPostHooks(ctx, s.PostHooks, int(updatedBase.ID), hook.FileMovePost, MoveInput{
+			OldPath: oldPath,
+			NewPath: newPath,
+		})
+
 		if s.isZipFile(updatedBase.Basename) {
 			if err := transferZipHierarchy(ctx, s.Repository.Folder, s.Repository.File, updatedBase.ID, oldPath, newPath); err != nil {
 				return fmt.Errorf("moving zip hierarchy for renamed zip file %q: %w", newPath, err)
@@ -1033,6 +1060,8 @@ func (s *scanJob) onExistingFile(ctx context.Context, f scanFile, existing model
 	}
 
 	oldBase := *base
+	// SetFingerprints overwrites existing entries in place
+	oldFingerprints := append(models.Fingerprints{}, base.Fingerprints...)
 
 	if !updated && forceRescan {
 		logger.Infof("rescanning %s", path)
@@ -1065,6 +1094,15 @@ func (s *scanJob) onExistingFile(ctx context.Context, f scanFile, existing model
 			return fmt.Errorf("updating file %q: %w", path, err)
 		}
 
+		newFingerprints := existing.Base().Fingerprints
+		if fingerprintsChanged(oldFingerprints, newFingerprints) {
+			registerPostHooks(ctx, s.PostHooks, int(base.ID), hook.FileFingerprintChangePost, FileFingerprintChangeInput{
+				Path:            path,
+				OldFingerprints: fingerprintMap(oldFingerprints),
+				NewFingerprints: fingerprintMap(newFingerprints),
+			})
+		}
+
 		if err := s.fireHandlers(ctx, existing, &oldBase); err != nil {
 			return err
 		}
@@ -1077,6 +1115,23 @@ func (s *scanJob) onExistingFile(ctx context.Context, f scanFile, existing model
 	return existing, nil
 }
 
+// fingerprintsChanged returns true if old and new have different fingerprint
+// types, or if any fingerprint has a different value in new.
+func fingerprintsChanged(old models.Fingerprints, new models.Fingerprints) bool {
+	if len(old) != len(new) {
+		return true
+	}
+
+	for _, o := range old {
+		n := new.For(o.Type)
+		if n == nil || n.Fingerprint != o.Fingerprint {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *scanJob) removeOutdatedFingerprints(existing models.File, fp models.Fingerprints) {
 	// HACK - if no MD5 fingerprint was returned, and the oshash is changed
 	// then remove the MD5 fingerprint
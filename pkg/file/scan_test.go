@@ -0,0 +1,35 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestFingerprintsChanged(t *testing.T) {
+	oshash := models.Fingerprint{Type: models.FingerprintTypeOshash, Fingerprint: "oshash"}
+	md5 := models.Fingerprint{Type: models.FingerprintTypeMD5, Fingerprint: "md5"}
+	changedOshash := models.Fingerprint{Type: models.FingerprintTypeOshash, Fingerprint: "changed"}
+
+	testCases := []struct {
+		name string
+		old  models.Fingerprints
+		new  models.Fingerprints
+		want bool
+	}{
+		{"unchanged", models.Fingerprints{oshash, md5}, models.Fingerprints{md5, oshash}, false},
+		{"changed value", models.Fingerprints{oshash, md5}, models.Fingerprints{changedOshash, md5}, true},
+		{"added type", models.Fingerprints{oshash}, models.Fingerprints{oshash, md5}, true},
+		{"removed type", models.Fingerprints{oshash, md5}, models.Fingerprints{changedOshash}, true},
+		{"replaced type", models.Fingerprints{oshash}, models.Fingerprints{md5}, true},
+		{"none", nil, nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := fingerprintsChanged(tc.old, tc.new); got != tc.want {
+				t.Errorf("fingerprintsChanged() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/asticode/go-astisub"
+	"github.com/stashapp/stash/pkg/ffmpeg"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/txn"
@@ -41,6 +42,28 @@ func ReadSubs(path string) (*astisub.Subtitles, error) {
 	return astisub.OpenFile(path)
 }
 
+var ErrInvalidSubtitleTrack = errors.New("invalid subtitle track")
+
+// ExtractSubtitleTrack extracts the embedded subtitle stream of the video file
+// at path with the given index among its subtitle streams, converted to
+// WebVTT. subtitleTracks are the subtitle streams of the file. Only text based
+// subtitles can be extracted.
+func ExtractSubtitleTrack(ctx context.Context, encoder *ffmpeg.FFMpeg, path string, subtitleTracks []ffmpeg.MediaTrack, track int) ([]byte, error) {
+	if track < 0 || track >= len(subtitleTracks) || !subtitleTracks[track].IsTextSubtitle() {
+		return nil, ErrInvalidSubtitleTrack
+	}
+
+	var args ffmpeg.Args
+	args = append(args, "-hide_banner")
+	args = args.LogLevel(ffmpeg.LogLevelError)
+	args = args.Input(path)
+	args = args.Map(fmt.Sprintf("0:s:%d", track))
+	args = args.Format(ffmpeg.FormatWebVTT)
+	args = args.Output("pipe:")
+
+	return encoder.GenerateOutput(ctx, args, nil)
+}
+
 // IsValidLanguage checks whether the given string is a valid
 // ISO 639 language code
 func IsValidLanguage(lang string) bool {
@@ -40,6 +40,10 @@ func ToBasicJSON(gallery *models.Gallery) (*jsonschema.Gallery, error) {
 
 	newGalleryJSON.Organized = gallery.Organized
 
+	if gallery.CustomFields.Loaded() {
+		newGalleryJSON.CustomFields = gallery.CustomFields.List()
+	}
+
 	return &newGalleryJSON, nil
 }
 
@@ -76,6 +76,9 @@ func (i *Importer) galleryJSONToGallery(galleryJSON jsonschema.Gallery) models.G
 	} else if galleryJSON.URL != "" {
 		newGallery.URLs = models.NewRelatedStrings([]string{galleryJSON.URL})
 	}
+	if len(galleryJSON.CustomFields) > 0 {
+		newGallery.CustomFields = models.NewRelatedCustomFields(galleryJSON.CustomFields)
+	}
 	if galleryJSON.Date != "" {
 		d, err := models.ParseDate(galleryJSON.Date)
 		if err == nil {
@@ -38,6 +38,10 @@ func ToJSON(ctx context.Context, reader ImageGetter, studioReader models.StudioG
 		newMovieJSON.Duration = *movie.Duration
 	}
 
+	if movie.CustomFields.Loaded() {
+		newMovieJSON.CustomFields = movie.CustomFields.List()
+	}
+
 	if movie.StudioID != nil {
 		studio, err := studioReader.Find(ctx, *movie.StudioID)
 		if err != nil {
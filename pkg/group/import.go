@@ -133,7 +133,8 @@ func (i *Importer) groupJSONToGroup(groupJSON jsonschema.Group) models.Group {
 		CreatedAt: groupJSON.CreatedAt.GetTime(),
 		UpdatedAt: groupJSON.UpdatedAt.GetTime(),
 
-		TagIDs: models.NewRelatedIDs([]int{}),
+		TagIDs:       models.NewRelatedIDs([]int{}),
+		CustomFields: models.NewRelatedCustomFields(groupJSON.CustomFields),
 	}
 
 	if len(groupJSON.URLs) > 0 {
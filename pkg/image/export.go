@@ -37,6 +37,10 @@ func ToBasicJSON(image *models.Image) *jsonschema.Image {
 		newImageJSON.Files = append(newImageJSON.Files, f.Base().Path)
 	}
 
+	if image.CustomFields.Loaded() {
+		newImageJSON.CustomFields = image.CustomFields.List()
+	}
+
 	return &newImageJSON
 }
 
@@ -93,6 +93,9 @@ func (i *Importer) imageJSONToImage(imageJSON jsonschema.Image) models.Image {
 	} else if imageJSON.URL != "" {
 		newImage.URLs = models.NewRelatedStrings([]string{imageJSON.URL})
 	}
+	if len(imageJSON.CustomFields) > 0 {
+		newImage.CustomFields = models.NewRelatedCustomFields(imageJSON.CustomFields)
+	}
 
 	if imageJSON.Date != "" {
 		d, err := models.ParseDate(imageJSON.Date)
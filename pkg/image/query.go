@@ -2,8 +2,12 @@ package image
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
 	"strconv"
+	"strings"
 
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -33,6 +37,73 @@ func Query(ctx context.Context, qb models.ImageQueryer, imageFilter *models.Imag
 	return images, nil
 }
 
+func BatchProcess(ctx context.Context, reader models.ImageQueryer, imageFilter *models.ImageFilterType, findFilter *models.FindFilterType, fn func(image *models.Image) error) error {
+	const batchSize = 1000
+
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+
+	page := 1
+	perPage := batchSize
+	findFilter.Page = &page
+	findFilter.PerPage = &perPage
+
+	for more := true; more; {
+		if job.IsCancelled(ctx) {
+			return nil
+		}
+
+		images, err := Query(ctx, reader, imageFilter, findFilter)
+		if err != nil {
+			return fmt.Errorf("error querying for images: %w", err)
+		}
+
+		for _, image := range images {
+			if err := fn(image); err != nil {
+				return err
+			}
+		}
+
+		if len(images) != batchSize {
+			more = false
+		} else {
+			*findFilter.Page++
+		}
+	}
+
+	return nil
+}
+
+// FilterFromPaths creates an ImageFilterType that filters using the provided
+// paths.
+func FilterFromPaths(paths []string) *models.ImageFilterType {
+	ret := &models.ImageFilterType{}
+	or := ret
+	sep := string(filepath.Separator)
+
+	for _, p := range paths {
+		if !strings.HasSuffix(p, sep) {
+			p += sep
+		}
+
+		if ret.Path == nil {
+			or = ret
+		} else {
+			newOr := &models.ImageFilterType{}
+			or.Or = newOr
+			or = newOr
+		}
+
+		or.Path = &models.StringCriterionInput{
+			Modifier: models.CriterionModifierEquals,
+			Value:    p + "%",
+		}
+	}
+
+	return ret
+}
+
 func CountByPerformerID(ctx context.Context, r models.ImageQueryer, id int) (int, error) {
 	filter := &models.ImageFilterType{
 		Performers: &models.MultiCriterionInput{
@@ -0,0 +1,233 @@
+package job
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScheduleSearch is the furthest into the future that Schedule.Next will
+// search for a matching time before giving up.
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// Schedule is a parsed cron expression.
+// It supports the standard five field format (minute, hour, day of month,
+// month, day of week), as well as the @yearly, @monthly, @weekly, @daily and
+// @hourly descriptors.
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// true if either day field was a wildcard. Used to determine if the
+	// day of month and day of week fields should be OR-ed together.
+	domStar bool
+	dowStar bool
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 7 is accepted as an alias for sunday
+	dowField = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+var scheduleDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a cron expression into a Schedule.
+func ParseSchedule(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if d, ok := scheduleDescriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, found %d in %q", ErrInvalidSchedule, len(fields), expr)
+	}
+
+	var (
+		ret Schedule
+		err error
+	)
+
+	if ret.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if ret.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if ret.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if ret.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if ret.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+
+	// treat 7 as sunday
+	if ret.dow&(1<<7) != 0 {
+		ret.dow |= 1
+	}
+
+	ret.domStar = fields[2] == "*" || fields[2] == "?"
+	ret.dowStar = fields[4] == "*" || fields[4] == "?"
+
+	return &ret, nil
+}
+
+func (f cronField) parse(s string) (uint64, error) {
+	var ret uint64
+	for _, part := range strings.Split(s, ",") {
+		bits, err := f.parseRange(part)
+		if err != nil {
+			return 0, err
+		}
+		ret |= bits
+	}
+
+	return ret, nil
+}
+
+func (f cronField) parseRange(s string) (uint64, error) {
+	rangeStr, stepStr, hasStep := strings.Cut(s, "/")
+
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepStr)
+		if err != nil || step <= 0 {
+			return 0, fmt.Errorf("%w: invalid step %q in %s field", ErrInvalidSchedule, stepStr, f.name)
+		}
+	}
+
+	var start, end int
+	switch {
+	case rangeStr == "*" || rangeStr == "?":
+		start = f.min
+		end = f.max
+	case strings.Contains(rangeStr, "-"):
+		startStr, endStr, _ := strings.Cut(rangeStr, "-")
+		var err error
+		if start, err = f.parseValue(startStr); err != nil {
+			return 0, err
+		}
+		if end, err = f.parseValue(endStr); err != nil {
+			return 0, err
+		}
+	default:
+		var err error
+		if start, err = f.parseValue(rangeStr); err != nil {
+			return 0, err
+		}
+		end = start
+		// a/n is shorthand for a-max/n
+		if hasStep {
+			end = f.max
+		}
+	}
+
+	if start > end {
+		return 0, fmt.Errorf("%w: invalid range %q in %s field", ErrInvalidSchedule, rangeStr, f.name)
+	}
+
+	var ret uint64
+	for i := start; i <= end; i += step {
+		ret |= 1 << uint(i)
+	}
+
+	return ret, nil
+}
+
+func (f cronField) parseValue(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid value %q in %s field", ErrInvalidSchedule, s, f.name)
+	}
+
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%w: value %d out of range [%d-%d] in %s field", ErrInvalidSchedule, v, f.min, f.max, f.name)
+	}
+
+	return v, nil
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	// if both fields are restricted, then either may match
+	if !s.domStar && !s.dowStar {
+		return domMatch || dowMatch
+	}
+
+	return domMatch && dowMatch
+}
+
+// Next returns the first time after t that matches the schedule.
+// Returns the zero time if no matching time could be found.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,141 @@
+package job
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseScheduleInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"empty", ""},
+		{"too few fields", "* * * *"},
+		{"too many fields", "* * * * * *"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "0 24 * * *"},
+		{"day of month zero", "0 0 0 * *"},
+		{"invalid month name", "0 0 1 foo *"},
+		{"reversed range", "0 5-1 * * *"},
+		{"zero step", "*/0 * * * *"},
+		{"unknown descriptor", "@fortnightly"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseSchedule(tt.expr)
+			assert.ErrorIs(t, err, ErrInvalidSchedule)
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// Wednesday
+	base := time.Date(2024, time.January, 10, 10, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{
+			"every minute",
+			"* * * * *",
+			base,
+			time.Date(2024, time.January, 10, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			"nightly at 3am",
+			"0 3 * * *",
+			base,
+			time.Date(2024, time.January, 11, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			"every 15 minutes",
+			"*/15 * * * *",
+			base,
+			time.Date(2024, time.January, 10, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			"hour range",
+			"0 1-4 * * *",
+			base,
+			time.Date(2024, time.January, 11, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			"list",
+			"5,40 * * * *",
+			base,
+			time.Date(2024, time.January, 10, 10, 40, 0, 0, time.UTC),
+		},
+		{
+			"weekday names",
+			"0 0 * * sat,sun",
+			base,
+			time.Date(2024, time.January, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"sunday as 7",
+			"0 0 * * 7",
+			base,
+			time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"month rollover",
+			"0 0 1 * *",
+			base,
+			time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"year rollover",
+			"@yearly",
+			base,
+			time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"leap day",
+			"0 0 29 2 *",
+			time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"day of month or day of week",
+			"0 0 12 * mon",
+			base,
+			time.Date(2024, time.January, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			"exact minute is not repeated",
+			"30 10 * * *",
+			time.Date(2024, time.January, 10, 10, 30, 0, 0, time.UTC),
+			time.Date(2024, time.January, 11, 10, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseSchedule(tt.expr)
+			if err != nil {
+				t.Errorf("ParseSchedule(%q) error = %v", tt.expr, err)
+				return
+			}
+
+			got := s.Next(tt.from)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScheduleNextImpossible(t *testing.T) {
+	s, err := ParseSchedule("0 0 31 2 *")
+	if err != nil {
+		t.Errorf("ParseSchedule error = %v", err)
+		return
+	}
+
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
@@ -34,6 +34,9 @@ type Status string
 const (
 	// StatusReady means that the Job is not yet started.
 	StatusReady Status = "READY"
+	// StatusPaused means that the Job is queued, but will not be started
+	// until it is resumed.
+	StatusPaused Status = "PAUSED"
 	// StatusRunning means that the job is currently running.
 	StatusRunning Status = "RUNNING"
 	// StatusStopping means that the job is cancelled but is still running.
@@ -46,10 +49,58 @@ const (
 	StatusFailed Status = "FAILED"
 )
 
+// Class is the class of resource that a Job primarily uses. The Manager
+// limits the number of jobs of each class that may run concurrently.
+type Class string
+
+const (
+	// ClassExclusive jobs are run on their own. No other queued jobs are
+	// started while an exclusive job is running, and an exclusive job is not
+	// started until all other queued jobs have finished.
+	ClassExclusive Class = "EXCLUSIVE"
+	// ClassIO jobs are limited by disk access, such as scanning.
+	ClassIO Class = "IO"
+	// ClassCPU jobs are limited by processing power, such as generation.
+	ClassCPU Class = "CPU"
+	// ClassNetwork jobs are limited by remote services, such as scraping.
+	ClassNetwork Class = "NETWORK"
+	// ClassGeneral is for jobs that do not fit into any other class, such as
+	// plugin tasks.
+	ClassGeneral Class = "GENERAL"
+)
+
+var AllClasses = []Class{
+	ClassExclusive,
+	ClassIO,
+	ClassCPU,
+	ClassNetwork,
+	ClassGeneral,
+}
+
+func (c Class) IsValid() bool {
+	switch c {
+	case ClassExclusive, ClassIO, ClassCPU, ClassNetwork, ClassGeneral:
+		return true
+	}
+	return false
+}
+
+// Options are the options used when adding a job to the Manager.
+type Options struct {
+	// Class determines which concurrency limit applies to the job.
+	// Defaults to ClassExclusive.
+	Class Class
+	// Jobs with a higher priority are started before jobs with a lower
+	// priority. Jobs with equal priority are started in queue order.
+	Priority int
+}
+
 // Job represents the status of a queued or running job.
 type Job struct {
-	ID     int
-	Status Status
+	ID       int
+	Status   Status
+	Class    Class
+	Priority int
 	// details of the current operations of the job
 	Details     []string
 	Description string
@@ -79,7 +130,7 @@ func (j *Job) TimeElapsed() time.Duration {
 }
 
 func (j *Job) cancel() {
-	if j.Status == StatusReady {
+	if j.Status == StatusReady || j.Status == StatusPaused {
 		j.Status = StatusCancelled
 	} else if j.Status == StatusRunning {
 		j.Status = StatusStopping
@@ -2,7 +2,9 @@ package job
 
 import (
 	"context"
+	"errors"
 	"runtime/debug"
+	"sort"
 	"sync"
 	"time"
 
@@ -13,29 +15,51 @@ import (
 const maxGraveyardSize = 10
 const defaultThrottleLimit = 100 * time.Millisecond
 
-// Manager maintains a queue of jobs. Jobs are executed one at a time.
+// defaultSlots is the number of jobs of each class that may run concurrently
+// if not otherwise configured.
+const defaultSlots = 1
+
+var (
+	ErrJobNotFound  = errors.New("job not found")
+	ErrJobNotQueued = errors.New("job is not queued")
+)
+
+// Manager maintains a queue of jobs. Jobs are executed in priority order.
+// Jobs of different classes may run concurrently, up to the number of slots
+// configured for each class. Exclusive jobs are always run on their own.
 type Manager struct {
 	queue     []*Job
 	graveyard []*Job
 
-	mutex    sync.Mutex
-	notEmpty *sync.Cond
-	stop     chan struct{}
+	mutex   sync.Mutex
+	changed *sync.Cond
+	stop    chan struct{}
 
 	lastID int
 
+	// number of dispatched jobs running for each class
+	running map[Class]int
+	slots   map[Class]int
+
 	subscriptions       []*ManagerSubscription
+	removedListeners    []*removedListener
 	updateThrottleLimit time.Duration
 }
 
+type removedListener struct {
+	fn func(j Job)
+}
+
 // NewManager initialises and returns a new Manager.
 func NewManager() *Manager {
 	ret := &Manager{
 		stop:                make(chan struct{}),
+		running:             make(map[Class]int),
+		slots:               make(map[Class]int),
 		updateThrottleLimit: defaultThrottleLimit,
 	}
 
-	ret.notEmpty = sync.NewCond(&ret.mutex)
+	ret.changed = sync.NewCond(&ret.mutex)
 
 	go ret.dispatcher()
 
@@ -46,59 +70,104 @@ func NewManager() *Manager {
 // more Jobs will be processed.
 func (m *Manager) Stop() {
 	m.CancelAll()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	close(m.stop)
+	m.changed.Broadcast()
 }
 
-// Add queues a job.
-func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
+// SetSlots sets the maximum number of jobs of the given class that may run
+// concurrently. Values less than 1 are treated as 1. Has no effect for
+// exclusive jobs.
+func (m *Manager) SetSlots(class Class, slots int) {
+	if slots < 1 {
+		slots = 1
+	}
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	t := time.Now()
+	m.slots[class] = slots
+	m.changed.Broadcast()
+}
 
-	j := Job{
-		ID:          m.nextID(),
-		Status:      StatusReady,
-		Description: description,
-		AddTime:     t,
-		exec:        e,
-		outerCtx:    ctx,
+func (m *Manager) getSlots(class Class) int {
+	// assumes lock held
+	if class == ClassExclusive {
+		return 1
 	}
 
-	m.queue = append(m.queue, &j)
-
-	if len(m.queue) == 1 {
-		// notify that there is now a job in the queue
-		m.notEmpty.Broadcast()
+	if s, ok := m.slots[class]; ok {
+		return s
 	}
 
-	m.notifyNewJob(&j)
+	return defaultSlots
+}
+
+// Add queues a job as an exclusive job with the default priority.
+func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
+	return m.AddWithOptions(ctx, description, e, Options{})
+}
+
+// AddWithOptions queues a job using the provided options.
+func (m *Manager) AddWithOptions(ctx context.Context, description string, e JobExec, options Options) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	j := m.newJob(ctx, description, e, options)
+
+	m.queue = append(m.queue, j)
+
+	// notify that the queue has changed
+	m.changed.Broadcast()
+
+	m.notifyNewJob(j)
 
 	return j.ID
 }
 
 // Start adds a job and starts it immediately, concurrently with any other
-// jobs.
+// jobs. Jobs started this way do not count towards the class limits.
 func (m *Manager) Start(ctx context.Context, description string, e JobExec) int {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	t := time.Now()
+	j := m.newJob(ctx, description, e, Options{})
+
+	m.queue = append(m.queue, j)
+
+	done := m.dispatch(ctx, j)
+	go func() {
+		<-done
 
-	j := Job{
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		m.removeJob(j)
+	}()
+
+	return j.ID
+}
+
+func (m *Manager) newJob(ctx context.Context, description string, e JobExec, options Options) *Job {
+	// assumes lock held
+	class := options.Class
+	if !class.IsValid() {
+		class = ClassExclusive
+	}
+
+	return &Job{
 		ID:          m.nextID(),
 		Status:      StatusReady,
+		Class:       class,
+		Priority:    options.Priority,
 		Description: description,
-		AddTime:     t,
+		AddTime:     time.Now(),
 		exec:        e,
 		outerCtx:    ctx,
 	}
-
-	m.queue = append(m.queue, &j)
-
-	m.dispatch(ctx, &j)
-
-	return j.ID
 }
 
 func (m *Manager) notifyNewJob(j *Job) {
@@ -117,50 +186,112 @@ func (m *Manager) nextID() int {
 	return m.lastID
 }
 
-func (m *Manager) getReadyJob() *Job {
+// pendingJobs returns the queued jobs in the order that they should be
+// considered for starting.
+func (m *Manager) pendingJobs() []*Job {
 	// assumes lock held
+	var ret []*Job
 	for _, j := range m.queue {
-		if j.Status == StatusReady {
-			return j
+		if j.Status == StatusReady || j.Status == StatusPaused {
+			ret = append(ret, j)
 		}
 	}
 
-	return nil
+	sort.SliceStable(ret, func(i, k int) bool {
+		return ret[i].Priority > ret[k].Priority
+	})
+
+	return ret
+}
+
+func (m *Manager) totalRunning() int {
+	// assumes lock held
+	ret := 0
+	for _, n := range m.running {
+		ret += n
+	}
+	return ret
+}
+
+// getStartableJobs returns the jobs that may be started given the current
+// running jobs and slots.
+func (m *Manager) getStartableJobs() []*Job {
+	// assumes lock held
+	if m.running[ClassExclusive] > 0 {
+		return nil
+	}
+
+	var ret []*Job
+	running := make(map[Class]int)
+	for c, n := range m.running {
+		running[c] = n
+	}
+	total := m.totalRunning()
+
+	for _, j := range m.pendingJobs() {
+		if j.Status == StatusPaused {
+			continue
+		}
+
+		if j.Class == ClassExclusive {
+			// exclusive jobs wait for all other jobs to finish, and block
+			// lower priority jobs from starting in the meantime
+			if total == 0 {
+				ret = append(ret, j)
+			}
+			break
+		}
+
+		if running[j.Class] < m.getSlots(j.Class) {
+			ret = append(ret, j)
+			running[j.Class]++
+			total++
+		}
+	}
+
+	return ret
 }
 
 func (m *Manager) dispatcher() {
 	m.mutex.Lock()
+	defer m.mutex.Unlock()
 
 	for {
-		// wait until we have something to process
-		j := m.getReadyJob()
-
-		for j == nil {
-			m.notEmpty.Wait()
-
-			// it's possible that we have been stopped - check here
-			select {
-			case <-m.stop:
-				m.mutex.Unlock()
-				return
-			default:
-				// keep going
-				j = m.getReadyJob()
-			}
+		// it's possible that we have been stopped - check here
+		select {
+		case <-m.stop:
+			return
+		default:
 		}
 
-		done := m.dispatch(j.outerCtx, j)
+		for _, j := range m.getStartableJobs() {
+			m.dispatchQueued(j)
+		}
 
-		// unlock the mutex and wait for the job to finish
-		m.mutex.Unlock()
+		// wait until the queue or running jobs change
+		m.changed.Wait()
+	}
+}
+
+func (m *Manager) dispatchQueued(j *Job) {
+	// assumes lock held
+	m.running[j.Class]++
+	done := m.dispatch(j.outerCtx, j)
+
+	go func() {
 		<-done
+
 		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		m.running[j.Class]--
 
 		// remove the job from the queue
 		m.removeJob(j)
 
-		// process next job
-	}
+		// process next jobs
+		m.changed.Broadcast()
+	}()
 }
 
 func (m *Manager) newProgress(j *Job) *Progress {
@@ -250,6 +381,10 @@ func (m *Manager) removeJob(job *Job) {
 		default:
 		}
 	}
+
+	for _, l := range m.removedListeners {
+		go l.fn(*job)
+	}
 }
 
 func (m *Manager) getJob(list []*Job, id int) (index int, job *Job) {
@@ -301,6 +436,104 @@ func (m *Manager) CancelAll() {
 	}
 }
 
+// PauseJob prevents a queued job from being started until ResumeJob is
+// called. Returns an error if the job is not waiting in the queue.
+func (m *Manager) PauseJob(id int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j == nil {
+		return ErrJobNotFound
+	}
+
+	if j.Status != StatusReady && j.Status != StatusPaused {
+		return ErrJobNotQueued
+	}
+
+	j.Status = StatusPaused
+	m.notifyJobUpdate(j)
+
+	return nil
+}
+
+// ResumeJob allows a paused job to be started. Returns an error if the job is
+// not waiting in the queue.
+func (m *Manager) ResumeJob(id int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j == nil {
+		return ErrJobNotFound
+	}
+
+	if j.Status != StatusReady && j.Status != StatusPaused {
+		return ErrJobNotQueued
+	}
+
+	j.Status = StatusReady
+	m.notifyJobUpdate(j)
+	m.changed.Broadcast()
+
+	return nil
+}
+
+// SetJobPriority changes the priority of a queued job. Returns an error if the
+// job is not waiting in the queue.
+func (m *Manager) SetJobPriority(id int, priority int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j == nil {
+		return ErrJobNotFound
+	}
+
+	if j.Status != StatusReady && j.Status != StatusPaused {
+		return ErrJobNotQueued
+	}
+
+	j.Priority = priority
+	m.notifyJobUpdate(j)
+	m.changed.Broadcast()
+
+	return nil
+}
+
+// MoveJob moves a queued job to the provided position in the queue, where 0
+// is the front of the queue. Positions outside of the queue are clamped.
+// Queue order only applies between jobs of the same priority.
+func (m *Manager) MoveJob(id int, position int) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	index, j := m.getJob(m.queue, id)
+	if j == nil {
+		return ErrJobNotFound
+	}
+
+	if j.Status != StatusReady && j.Status != StatusPaused {
+		return ErrJobNotQueued
+	}
+
+	m.queue = append(m.queue[:index], m.queue[index+1:]...)
+
+	if position < 0 {
+		position = 0
+	}
+	if position > len(m.queue) {
+		position = len(m.queue)
+	}
+
+	m.queue = append(m.queue[:position], append([]*Job{j}, m.queue[position:]...)...)
+
+	m.notifyJobUpdate(j)
+	m.changed.Broadcast()
+
+	return nil
+}
+
 // GetJob returns a copy of the Job for the provided id. Returns nil if the job
 // does not exist.
 func (m *Manager) GetJob(id int) *Job {
@@ -361,6 +594,32 @@ func (m *Manager) Subscribe(ctx context.Context) *ManagerSubscription {
 	return ret
 }
 
+// OnJobRemoved calls fn with a copy of each job that is removed from the
+// queue once it has finished or been cancelled, until the context is
+// cancelled. Unlike subscriptions, which drop notifications when their
+// channels are full, fn is called for every removed job. fn is called in its
+// own goroutine.
+func (m *Manager) OnJobRemoved(ctx context.Context, fn func(j Job)) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	l := &removedListener{fn: fn}
+	m.removedListeners = append(m.removedListeners, l)
+
+	go func() {
+		<-ctx.Done()
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+
+		for i, ll := range m.removedListeners {
+			if ll == l {
+				m.removedListeners = append(m.removedListeners[:i], m.removedListeners[i+1:]...)
+				break
+			}
+		}
+	}()
+}
+
 func (m *Manager) notifyJobUpdate(j *Job) {
 	// don't update if job is finished or cancelled - these are handled
 	// by removeJob
@@ -343,3 +343,210 @@ func TestSubscribe(t *testing.T) {
 
 	cancel()
 }
+
+func TestOnJobRemoved(t *testing.T) {
+	m := NewManager()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// an unread subscription drops notifications once its buffer is full
+	m.Subscribe(ctx)
+
+	removed := make(chan Job)
+	m.OnJobRemoved(ctx, func(j Job) {
+		removed <- j
+	})
+
+	const numJobs = 150
+	for i := 0; i < numJobs; i++ {
+		m.Add(context.Background(), "test job", newTestExec(nil))
+	}
+
+	seen := make(map[int]bool)
+	for len(seen) < numJobs {
+		select {
+		case j := <-removed:
+			assert.Equal(t, StatusFinished, j.Status)
+			seen[j.ID] = true
+		case <-time.After(time.Second):
+			t.Fatalf("received %d of %d removed jobs", len(seen), numJobs)
+		}
+	}
+}
+
+func TestAddClassesConcurrent(t *testing.T) {
+	m := NewManager()
+
+	ioExec := newTestExec(make(chan struct{}))
+	ioID := m.AddWithOptions(context.Background(), "io", ioExec, Options{Class: ClassIO})
+
+	io2Exec := newTestExec(make(chan struct{}))
+	io2ID := m.AddWithOptions(context.Background(), "io 2", io2Exec, Options{Class: ClassIO})
+
+	cpuExec := newTestExec(make(chan struct{}))
+	cpuID := m.AddWithOptions(context.Background(), "cpu", cpuExec, Options{Class: ClassCPU})
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	// expect io and cpu jobs to run concurrently, second io job to wait
+	assert.Equal(StatusRunning, m.GetJob(ioID).Status)
+	assert.Equal(StatusReady, m.GetJob(io2ID).Status)
+	assert.Equal(StatusRunning, m.GetJob(cpuID).Status)
+
+	// increase io slots - second io job should start
+	m.SetSlots(ClassIO, 2)
+	time.Sleep(sleepTime)
+
+	assert.Equal(StatusRunning, m.GetJob(io2ID).Status)
+
+	close(ioExec.finish)
+	close(io2Exec.finish)
+	close(cpuExec.finish)
+}
+
+func TestExclusive(t *testing.T) {
+	m := NewManager()
+
+	ioExec := newTestExec(make(chan struct{}))
+	ioID := m.AddWithOptions(context.Background(), "io", ioExec, Options{Class: ClassIO})
+
+	exclusiveExec := newTestExec(make(chan struct{}))
+	exclusiveID := m.Add(context.Background(), "exclusive", exclusiveExec)
+
+	cpuExec := newTestExec(make(chan struct{}))
+	cpuID := m.AddWithOptions(context.Background(), "cpu", cpuExec, Options{Class: ClassCPU})
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	// exclusive job must wait for io job, and blocks the cpu job
+	assert.Equal(StatusRunning, m.GetJob(ioID).Status)
+	assert.Equal(StatusReady, m.GetJob(exclusiveID).Status)
+	assert.Equal(StatusReady, m.GetJob(cpuID).Status)
+
+	close(ioExec.finish)
+	time.Sleep(sleepTime)
+
+	assert.Equal(StatusRunning, m.GetJob(exclusiveID).Status)
+	assert.Equal(StatusReady, m.GetJob(cpuID).Status)
+
+	close(exclusiveExec.finish)
+	time.Sleep(sleepTime)
+
+	assert.Equal(StatusRunning, m.GetJob(cpuID).Status)
+
+	close(cpuExec.finish)
+}
+
+func TestPriority(t *testing.T) {
+	m := NewManager()
+
+	firstExec := newTestExec(make(chan struct{}))
+	m.Add(context.Background(), "first", firstExec)
+
+	lowExec := newTestExec(make(chan struct{}))
+	lowID := m.Add(context.Background(), "low", lowExec)
+
+	highExec := newTestExec(make(chan struct{}))
+	highID := m.AddWithOptions(context.Background(), "high", highExec, Options{Priority: 1})
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	close(firstExec.finish)
+	time.Sleep(sleepTime)
+
+	// expect high priority job to be started first
+	assert := assert.New(t)
+	assert.Equal(StatusRunning, m.GetJob(highID).Status)
+	assert.Equal(StatusReady, m.GetJob(lowID).Status)
+
+	close(highExec.finish)
+	close(lowExec.finish)
+}
+
+func TestPauseResume(t *testing.T) {
+	m := NewManager()
+
+	firstExec := newTestExec(make(chan struct{}))
+	firstID := m.Add(context.Background(), "first", firstExec)
+
+	pausedExec := newTestExec(make(chan struct{}))
+	pausedID := m.Add(context.Background(), "paused", pausedExec)
+
+	otherExec := newTestExec(make(chan struct{}))
+	otherID := m.Add(context.Background(), "other", otherExec)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	// cannot pause a running job
+	assert.ErrorIs(m.PauseJob(firstID), ErrJobNotQueued)
+	assert.ErrorIs(m.PauseJob(-1), ErrJobNotFound)
+
+	assert.Nil(m.PauseJob(pausedID))
+	assert.Equal(StatusPaused, m.GetJob(pausedID).Status)
+
+	close(firstExec.finish)
+	time.Sleep(sleepTime)
+
+	// paused job should be skipped
+	assert.Equal(StatusPaused, m.GetJob(pausedID).Status)
+	assert.Equal(StatusRunning, m.GetJob(otherID).Status)
+
+	assert.Nil(m.ResumeJob(pausedID))
+	assert.Equal(StatusReady, m.GetJob(pausedID).Status)
+
+	close(otherExec.finish)
+	time.Sleep(sleepTime)
+
+	assert.Equal(StatusRunning, m.GetJob(pausedID).Status)
+
+	close(pausedExec.finish)
+}
+
+func TestMoveJob(t *testing.T) {
+	m := NewManager()
+
+	firstExec := newTestExec(make(chan struct{}))
+	firstID := m.Add(context.Background(), "first", firstExec)
+
+	secondExec := newTestExec(make(chan struct{}))
+	secondID := m.Add(context.Background(), "second", secondExec)
+
+	thirdExec := newTestExec(make(chan struct{}))
+	thirdID := m.Add(context.Background(), "third", thirdExec)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+
+	assert.ErrorIs(m.MoveJob(firstID, 2), ErrJobNotQueued)
+
+	// move third job ahead of second
+	assert.Nil(m.MoveJob(thirdID, 0))
+
+	queue := m.GetQueue()
+	assert.Len(queue, 3)
+	assert.Equal(thirdID, queue[0].ID)
+	assert.Equal(firstID, queue[1].ID)
+	assert.Equal(secondID, queue[2].ID)
+
+	close(firstExec.finish)
+	time.Sleep(sleepTime)
+
+	assert.Equal(StatusRunning, m.GetJob(thirdID).Status)
+	assert.Equal(StatusReady, m.GetJob(secondID).Status)
+
+	close(thirdExec.finish)
+	close(secondExec.finish)
+}
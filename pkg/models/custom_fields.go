@@ -0,0 +1,197 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxCustomFieldNameLength is the maximum length of a custom field name.
+const MaxCustomFieldNameLength = 64
+
+type CustomFieldType string
+
+const (
+	CustomFieldTypeString  CustomFieldType = "STRING"
+	CustomFieldTypeInt     CustomFieldType = "INT"
+	CustomFieldTypeFloat   CustomFieldType = "FLOAT"
+	CustomFieldTypeDate    CustomFieldType = "DATE"
+	CustomFieldTypeBoolean CustomFieldType = "BOOLEAN"
+)
+
+var AllCustomFieldType = []CustomFieldType{
+	CustomFieldTypeString,
+	CustomFieldTypeInt,
+	CustomFieldTypeFloat,
+	CustomFieldTypeDate,
+	CustomFieldTypeBoolean,
+}
+
+func (e CustomFieldType) IsValid() bool {
+	switch e {
+	case CustomFieldTypeString, CustomFieldTypeInt, CustomFieldTypeFloat, CustomFieldTypeDate, CustomFieldTypeBoolean:
+		return true
+	}
+	return false
+}
+
+func (e CustomFieldType) String() string {
+	return string(e)
+}
+
+func (e *CustomFieldType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CustomFieldType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CustomFieldType", str)
+	}
+	return nil
+}
+
+func (e CustomFieldType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// CustomField is a user-defined field of an object.
+// Value is a string for string and date fields, an int for int fields,
+// a float64 for float fields and a bool for boolean fields. Dates are in
+// the format YYYY-MM-DD.
+type CustomField struct {
+	Field string          `json:"field"`
+	Type  CustomFieldType `json:"type"`
+	Value interface{}     `json:"value"`
+}
+
+var ErrCustomFieldValue = errors.New("invalid custom field value")
+
+// Normalise returns the field with a trimmed name and the value converted to
+// the type of the field. Returns an error if the name is invalid or the value
+// cannot be converted.
+func (f CustomField) Normalise() (CustomField, error) {
+	ret := f
+	ret.Field = strings.TrimSpace(f.Field)
+
+	if ret.Field == "" {
+		return ret, errors.New("custom field name must not be empty")
+	}
+	if len(ret.Field) > MaxCustomFieldNameLength {
+		return ret, fmt.Errorf("custom field name %q is longer than %d characters", ret.Field, MaxCustomFieldNameLength)
+	}
+
+	v, err := customFieldValue(f.Type, f.Value)
+	if err != nil {
+		return ret, fmt.Errorf("custom field %q: %w", ret.Field, err)
+	}
+
+	ret.Value = v
+	return ret, nil
+}
+
+// NormaliseCustomFields normalises all fields, returning an error if any
+// field is invalid or if a field name is used more than once.
+func NormaliseCustomFields(fields []CustomField) ([]CustomField, error) {
+	ret := make([]CustomField, len(fields))
+	seen := make(map[string]bool)
+	for i, f := range fields {
+		v, err := f.Normalise()
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[v.Field] {
+			return nil, fmt.Errorf("custom field %q set more than once", v.Field)
+		}
+		seen[v.Field] = true
+
+		ret[i] = v
+	}
+
+	return ret, nil
+}
+
+func customFieldValue(t CustomFieldType, v interface{}) (interface{}, error) {
+	switch t {
+	case CustomFieldTypeString:
+		if s, ok := v.(string); ok {
+			return s, nil
+		}
+	case CustomFieldTypeDate:
+		if s, ok := v.(string); ok {
+			d, err := ParseDate(s)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrCustomFieldValue, err)
+			}
+			return d.String(), nil
+		}
+	case CustomFieldTypeInt:
+		switch vv := v.(type) {
+		case int:
+			return vv, nil
+		case int64:
+			return int(vv), nil
+		case json.Number:
+			if i, err := vv.Int64(); err == nil {
+				return int(i), nil
+			}
+		case float64:
+			// numbers decoded from json are floats
+			if vv == math.Trunc(vv) {
+				return int(vv), nil
+			}
+		}
+	case CustomFieldTypeFloat:
+		if f, ok := customFieldNumber(v); ok {
+			return f, nil
+		}
+	case CustomFieldTypeBoolean:
+		switch vv := v.(type) {
+		case bool:
+			return vv, nil
+		case int64:
+			// booleans are stored as integers
+			return vv != 0, nil
+		}
+	default:
+		return nil, fmt.Errorf("invalid custom field type %q", t)
+	}
+
+	return nil, fmt.Errorf("%w: %v is not a valid %s value", ErrCustomFieldValue, v, strings.ToLower(t.String()))
+}
+
+func customFieldNumber(v interface{}) (float64, bool) {
+	switch vv := v.(type) {
+	case int:
+		return float64(vv), true
+	case int64:
+		return float64(vv), true
+	case float64:
+		return vv, true
+	case json.Number:
+		f, err := vv.Float64()
+		return f, err == nil
+	}
+
+	return 0, false
+}
+
+// UpdateCustomFields describes a change to the custom fields of an object.
+// Fields are added or replaced by name in add mode, and removed by name in
+// remove mode.
+type UpdateCustomFields struct {
+	CustomFields []CustomField          `json:"custom_fields"`
+	Mode         RelationshipUpdateMode `json:"mode"`
+}
+
+type CustomFieldCriterionInput struct {
+	Field    string            `json:"field"`
+	Value    []interface{}     `json:"value"`
+	Modifier CriterionModifier `json:"modifier"`
+}
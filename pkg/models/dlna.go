@@ -0,0 +1,20 @@
+package models
+
+// DLNARendererProfile describes the files that a DLNA renderer can play.
+// Scenes that the renderer cannot play are advertised as a live transcode
+// instead of the original file.
+type DLNARendererProfile struct {
+	// Name of the profile. A configured profile replaces the built-in profile
+	// of the same name.
+	Name string `json:"name"`
+	// Regular expression matched against the user agent and device
+	// description headers of requests from the renderer.
+	UserAgent string `json:"userAgent"`
+	// Video codecs, audio codecs and containers that the renderer can play,
+	// as reported by ffprobe. Empty to allow all.
+	VideoCodecs []string `json:"videoCodecs"`
+	AudioCodecs []string `json:"audioCodecs"`
+	Containers  []string `json:"containers"`
+	// Format of transcoded streams. One of mp4, webm or mkv. Defaults to mp4.
+	TranscodeFormat string `json:"transcodeFormat"`
+}
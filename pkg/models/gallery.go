@@ -63,24 +63,27 @@ type GalleryFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type GalleryUpdateInput struct {
-	ClientMutationID *string  `json:"clientMutationId"`
-	ID               string   `json:"id"`
-	Title            *string  `json:"title"`
-	Code             *string  `json:"code"`
-	Urls             []string `json:"urls"`
-	Date             *string  `json:"date"`
-	Details          *string  `json:"details"`
-	Photographer     *string  `json:"photographer"`
-	Rating100        *int     `json:"rating100"`
-	Organized        *bool    `json:"organized"`
-	SceneIds         []string `json:"scene_ids"`
-	StudioID         *string  `json:"studio_id"`
-	TagIds           []string `json:"tag_ids"`
-	PerformerIds     []string `json:"performer_ids"`
-	PrimaryFileID    *string  `json:"primary_file_id"`
+	ClientMutationID *string       `json:"clientMutationId"`
+	ID               string        `json:"id"`
+	Title            *string       `json:"title"`
+	Code             *string       `json:"code"`
+	Urls             []string      `json:"urls"`
+	Date             *string       `json:"date"`
+	Details          *string       `json:"details"`
+	Photographer     *string       `json:"photographer"`
+	Rating100        *int          `json:"rating100"`
+	Organized        *bool         `json:"organized"`
+	SceneIds         []string      `json:"scene_ids"`
+	StudioID         *string       `json:"studio_id"`
+	TagIds           []string      `json:"tag_ids"`
+	PerformerIds     []string      `json:"performer_ids"`
+	PrimaryFileID    *string       `json:"primary_file_id"`
+	CustomFields     []CustomField `json:"custom_fields"`
 
 	// deprecated
 	URL *string `json:"url"`
@@ -92,3 +92,47 @@ func (e *PreviewPreset) UnmarshalGQL(v interface{}) error {
 func (e PreviewPreset) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
+
+// TranscodeVideoCodec is the video codec of generated transcodes.
+type TranscodeVideoCodec string
+
+const (
+	TranscodeVideoCodecH264 TranscodeVideoCodec = "H264"
+	TranscodeVideoCodecHevc TranscodeVideoCodec = "HEVC"
+	TranscodeVideoCodecAv1  TranscodeVideoCodec = "AV1"
+)
+
+var AllTranscodeVideoCodec = []TranscodeVideoCodec{
+	TranscodeVideoCodecH264,
+	TranscodeVideoCodecHevc,
+	TranscodeVideoCodecAv1,
+}
+
+func (e TranscodeVideoCodec) IsValid() bool {
+	switch e {
+	case TranscodeVideoCodecH264, TranscodeVideoCodecHevc, TranscodeVideoCodecAv1:
+		return true
+	}
+	return false
+}
+
+func (e TranscodeVideoCodec) String() string {
+	return string(e)
+}
+
+func (e *TranscodeVideoCodec) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TranscodeVideoCodec(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TranscodeVideoCodec", str)
+	}
+	return nil
+}
+
+func (e TranscodeVideoCodec) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
@@ -61,6 +61,28 @@ type ImageFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
+}
+
+type ImageUpdateInput struct {
+	ClientMutationID *string       `json:"clientMutationId"`
+	ID               string        `json:"id"`
+	Title            *string       `json:"title"`
+	Code             *string       `json:"code"`
+	Rating100        *int          `json:"rating100"`
+	Organized        *bool         `json:"organized"`
+	URL              *string       `json:"url"`
+	Urls             []string      `json:"urls"`
+	Date             *string       `json:"date"`
+	Details          *string       `json:"details"`
+	Photographer     *string       `json:"photographer"`
+	StudioID         *string       `json:"studio_id"`
+	PerformerIds     []string      `json:"performer_ids"`
+	TagIds           []string      `json:"tag_ids"`
+	GalleryIds       []string      `json:"gallery_ids"`
+	PrimaryFileID    *string       `json:"primary_file_id"`
+	CustomFields     []CustomField `json:"custom_fields"`
 }
 
 type ImageDestroyInput struct {
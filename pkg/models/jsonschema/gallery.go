@@ -7,6 +7,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/json"
 )
 
@@ -18,22 +19,23 @@ type GalleryChapter struct {
 }
 
 type Gallery struct {
-	ZipFiles     []string         `json:"zip_files,omitempty"`
-	FolderPath   string           `json:"folder_path,omitempty"`
-	Title        string           `json:"title,omitempty"`
-	Code         string           `json:"code,omitempty"`
-	URLs         []string         `json:"urls,omitempty"`
-	Date         string           `json:"date,omitempty"`
-	Details      string           `json:"details,omitempty"`
-	Photographer string           `json:"photographer,omitempty"`
-	Rating       int              `json:"rating,omitempty"`
-	Organized    bool             `json:"organized,omitempty"`
-	Chapters     []GalleryChapter `json:"chapters,omitempty"`
-	Studio       string           `json:"studio,omitempty"`
-	Performers   []string         `json:"performers,omitempty"`
-	Tags         []string         `json:"tags,omitempty"`
-	CreatedAt    json.JSONTime    `json:"created_at,omitempty"`
-	UpdatedAt    json.JSONTime    `json:"updated_at,omitempty"`
+	ZipFiles     []string             `json:"zip_files,omitempty"`
+	FolderPath   string               `json:"folder_path,omitempty"`
+	Title        string               `json:"title,omitempty"`
+	Code         string               `json:"code,omitempty"`
+	URLs         []string             `json:"urls,omitempty"`
+	Date         string               `json:"date,omitempty"`
+	Details      string               `json:"details,omitempty"`
+	Photographer string               `json:"photographer,omitempty"`
+	Rating       int                  `json:"rating,omitempty"`
+	Organized    bool                 `json:"organized,omitempty"`
+	Chapters     []GalleryChapter     `json:"chapters,omitempty"`
+	Studio       string               `json:"studio,omitempty"`
+	Performers   []string             `json:"performers,omitempty"`
+	Tags         []string             `json:"tags,omitempty"`
+	CreatedAt    json.JSONTime        `json:"created_at,omitempty"`
+	UpdatedAt    json.JSONTime        `json:"updated_at,omitempty"`
+	CustomFields []models.CustomField `json:"custom_fields,omitempty"`
 
 	// deprecated - for import only
 	URL string `json:"url,omitempty"`
@@ -6,6 +6,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/json"
 )
 
@@ -18,18 +19,19 @@ type Image struct {
 	// deprecated - for import only
 	URL string `json:"url,omitempty"`
 
-	URLs         []string      `json:"urls,omitempty"`
-	Date         string        `json:"date,omitempty"`
-	Details      string        `json:"details,omitempty"`
-	Photographer string        `json:"photographer,omitempty"`
-	Organized    bool          `json:"organized,omitempty"`
-	OCounter     int           `json:"o_counter,omitempty"`
-	Galleries    []GalleryRef  `json:"galleries,omitempty"`
-	Performers   []string      `json:"performers,omitempty"`
-	Tags         []string      `json:"tags,omitempty"`
-	Files        []string      `json:"files,omitempty"`
-	CreatedAt    json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt    json.JSONTime `json:"updated_at,omitempty"`
+	URLs         []string             `json:"urls,omitempty"`
+	Date         string               `json:"date,omitempty"`
+	Details      string               `json:"details,omitempty"`
+	Photographer string               `json:"photographer,omitempty"`
+	Organized    bool                 `json:"organized,omitempty"`
+	OCounter     int                  `json:"o_counter,omitempty"`
+	Galleries    []GalleryRef         `json:"galleries,omitempty"`
+	Performers   []string             `json:"performers,omitempty"`
+	Tags         []string             `json:"tags,omitempty"`
+	Files        []string             `json:"files,omitempty"`
+	CreatedAt    json.JSONTime        `json:"created_at,omitempty"`
+	UpdatedAt    json.JSONTime        `json:"updated_at,omitempty"`
+	CustomFields []models.CustomField `json:"custom_fields,omitempty"`
 }
 
 func (s Image) Filename(basename string, hash string) string {
@@ -77,8 +77,9 @@ type Scene struct {
 	PlayHistory []json.JSONTime `json:"play_history,omitempty"`
 	OHistory    []json.JSONTime `json:"o_history,omitempty"`
 
-	PlayDuration float64          `json:"play_duration,omitempty"`
-	StashIDs     []models.StashID `json:"stash_ids,omitempty"`
+	PlayDuration float64              `json:"play_duration,omitempty"`
+	StashIDs     []models.StashID     `json:"stash_ids,omitempty"`
+	CustomFields []models.CustomField `json:"custom_fields,omitempty"`
 }
 
 func (s Scene) Filename(id int, basename string, hash string) string {
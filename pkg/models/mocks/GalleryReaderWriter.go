@@ -395,6 +395,29 @@ func (_m *GalleryReaderWriter) FindUserGalleryByTitle(ctx context.Context, title
 	return r0, r1
 }
 
+// GetCustomFields provides a mock function with given fields: ctx, relatedID
+func (_m *GalleryReaderWriter) GetCustomFields(ctx context.Context, relatedID int) ([]models.CustomField, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []models.CustomField
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.CustomField); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CustomField)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFiles provides a mock function with given fields: ctx, relatedID
 func (_m *GalleryReaderWriter) GetFiles(ctx context.Context, relatedID int) ([]models.File, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -289,6 +289,29 @@ func (_m *GroupReaderWriter) GetBackImage(ctx context.Context, groupID int) ([]b
 	return r0, r1
 }
 
+// GetCustomFields provides a mock function with given fields: ctx, relatedID
+func (_m *GroupReaderWriter) GetCustomFields(ctx context.Context, relatedID int) ([]models.CustomField, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []models.CustomField
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.CustomField); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CustomField)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFrontImage provides a mock function with given fields: ctx, groupID
 func (_m *GroupReaderWriter) GetFrontImage(ctx context.Context, groupID int) ([]byte, error) {
 	ret := _m.Called(ctx, groupID)
@@ -314,6 +314,29 @@ func (_m *PerformerReaderWriter) GetAliases(ctx context.Context, relatedID int)
 	return r0, r1
 }
 
+// GetCustomFields provides a mock function with given fields: ctx, relatedID
+func (_m *PerformerReaderWriter) GetCustomFields(ctx context.Context, relatedID int) ([]models.CustomField, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []models.CustomField
+	if rf, ok := ret.Get(0).(func(context.Context, int) []models.CustomField); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.CustomField)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImage provides a mock function with given fields: ctx, performerID
 func (_m *PerformerReaderWriter) GetImage(ctx context.Context, performerID int) ([]byte, error) {
 	ret := _m.Called(ctx, performerID)
@@ -427,6 +450,20 @@ func (_m *PerformerReaderWriter) HasImage(ctx context.Context, performerID int)
 	return r0, r1
 }
 
+// Merge provides a mock function with given fields: ctx, source, destination
+func (_m *PerformerReaderWriter) Merge(ctx context.Context, source []int, destination int) error {
+	ret := _m.Called(ctx, source, destination)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int, int) error); ok {
+		r0 = rf(ctx, source, destination)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Query provides a mock function with given fields: ctx, performerFilter, findFilter
 func (_m *PerformerReaderWriter) Query(ctx context.Context, performerFilter *models.PerformerFilterType, findFilter *models.FindFilterType) ([]*models.Performer, int, error) {
 	ret := _m.Called(ctx, performerFilter, findFilter)
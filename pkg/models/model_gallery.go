@@ -36,6 +36,8 @@ type Gallery struct {
 	SceneIDs     RelatedIDs     `json:"scene_ids"`
 	TagIDs       RelatedIDs     `json:"tag_ids"`
 	PerformerIDs RelatedIDs     `json:"performer_ids"`
+
+	CustomFields RelatedCustomFields `json:"custom_fields"`
 }
 
 func NewGallery() Gallery {
@@ -70,6 +72,7 @@ type GalleryPartial struct {
 	TagIDs        *UpdateIDs
 	PerformerIDs  *UpdateIDs
 	PrimaryFileID *FileID
+	CustomFields  *UpdateCustomFields
 }
 
 func NewGalleryPartial() GalleryPartial {
@@ -133,6 +136,12 @@ func (g *Gallery) LoadTagIDs(ctx context.Context, l TagIDLoader) error {
 	})
 }
 
+func (g *Gallery) LoadCustomFields(ctx context.Context, l CustomFieldsLoader) error {
+	return g.CustomFields.load(func() ([]CustomField, error) {
+		return l.GetCustomFields(ctx, g.ID)
+	})
+}
+
 func (g Gallery) PrimaryChecksum() string {
 	// renamed from Checksum to prevent gqlgen from using it in the resolver
 	if p := g.Files.Primary(); p != nil {
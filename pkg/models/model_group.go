@@ -21,6 +21,8 @@ type Group struct {
 
 	URLs   RelatedStrings `json:"urls"`
 	TagIDs RelatedIDs     `json:"tag_ids"`
+
+	CustomFields RelatedCustomFields `json:"custom_fields"`
 }
 
 func NewGroup() Group {
@@ -43,20 +45,27 @@ func (m *Group) LoadTagIDs(ctx context.Context, l TagIDLoader) error {
 	})
 }
 
+func (m *Group) LoadCustomFields(ctx context.Context, l CustomFieldsLoader) error {
+	return m.CustomFields.load(func() ([]CustomField, error) {
+		return l.GetCustomFields(ctx, m.ID)
+	})
+}
+
 type GroupPartial struct {
 	Name     OptionalString
 	Aliases  OptionalString
 	Duration OptionalInt
 	Date     OptionalDate
 	// Rating expressed in 1-100 scale
-	Rating    OptionalInt
-	StudioID  OptionalInt
-	Director  OptionalString
-	Synopsis  OptionalString
-	URLs      *UpdateStrings
-	TagIDs    *UpdateIDs
-	CreatedAt OptionalTime
-	UpdatedAt OptionalTime
+	Rating       OptionalInt
+	StudioID     OptionalInt
+	Director     OptionalString
+	Synopsis     OptionalString
+	URLs         *UpdateStrings
+	TagIDs       *UpdateIDs
+	CreatedAt    OptionalTime
+	UpdatedAt    OptionalTime
+	CustomFields *UpdateCustomFields
 }
 
 func NewGroupPartial() GroupPartial {
@@ -37,6 +37,8 @@ type Image struct {
 	GalleryIDs   RelatedIDs `json:"gallery_ids"`
 	TagIDs       RelatedIDs `json:"tag_ids"`
 	PerformerIDs RelatedIDs `json:"performer_ids"`
+
+	CustomFields RelatedCustomFields `json:"custom_fields"`
 }
 
 func NewImage() Image {
@@ -66,6 +68,7 @@ type ImagePartial struct {
 	TagIDs        *UpdateIDs
 	PerformerIDs  *UpdateIDs
 	PrimaryFileID *FileID
+	CustomFields  *UpdateCustomFields
 }
 
 func NewImagePartial() ImagePartial {
@@ -75,6 +78,32 @@ func NewImagePartial() ImagePartial {
 	}
 }
 
+// UpdateInput constructs an ImageUpdateInput using the populated fields in the ImagePartial object.
+func (s ImagePartial) UpdateInput(id int) ImageUpdateInput {
+	var dateStr *string
+	if s.Date.Set {
+		d := s.Date.Value
+		v := d.String()
+		dateStr = &v
+	}
+
+	return ImageUpdateInput{
+		ID:           strconv.Itoa(id),
+		Title:        s.Title.Ptr(),
+		Code:         s.Code.Ptr(),
+		Rating100:    s.Rating.Ptr(),
+		Organized:    s.Organized.Ptr(),
+		Urls:         s.URLs.Strings(),
+		Date:         dateStr,
+		Details:      s.Details.Ptr(),
+		Photographer: s.Photographer.Ptr(),
+		StudioID:     s.StudioID.StringPtr(),
+		PerformerIds: s.PerformerIDs.IDStrings(),
+		TagIds:       s.TagIDs.IDStrings(),
+		GalleryIds:   s.GalleryIDs.IDStrings(),
+	}
+}
+
 func (i *Image) LoadURLs(ctx context.Context, l URLLoader) error {
 	return i.URLs.load(func() ([]string, error) {
 		return l.GetURLs(ctx, i.ID)
@@ -124,6 +153,12 @@ func (i *Image) LoadTagIDs(ctx context.Context, l TagIDLoader) error {
 	})
 }
 
+func (i *Image) LoadCustomFields(ctx context.Context, l CustomFieldsLoader) error {
+	return i.CustomFields.load(func() ([]CustomField, error) {
+		return l.GetCustomFields(ctx, i.ID)
+	})
+}
+
 // GetTitle returns the title of the image. If the Title field is empty,
 // then the base filename is returned.
 func (i Image) GetTitle() string {
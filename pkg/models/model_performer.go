@@ -37,6 +37,8 @@ type Performer struct {
 	URLs     RelatedStrings  `json:"urls"`
 	TagIDs   RelatedIDs      `json:"tag_ids"`
 	StashIDs RelatedStashIDs `json:"stash_ids"`
+
+	CustomFields RelatedCustomFields `json:"custom_fields"`
 }
 
 func NewPerformer() Performer {
@@ -77,9 +79,10 @@ type PerformerPartial struct {
 	Weight        OptionalInt
 	IgnoreAutoTag OptionalBool
 
-	Aliases  *UpdateStrings
-	TagIDs   *UpdateIDs
-	StashIDs *UpdateStashIDs
+	Aliases      *UpdateStrings
+	TagIDs       *UpdateIDs
+	StashIDs     *UpdateStashIDs
+	CustomFields *UpdateCustomFields
 }
 
 func NewPerformerPartial() PerformerPartial {
@@ -107,6 +110,12 @@ func (s *Performer) LoadTagIDs(ctx context.Context, l TagIDLoader) error {
 	})
 }
 
+func (s *Performer) LoadCustomFields(ctx context.Context, l CustomFieldsLoader) error {
+	return s.CustomFields.load(func() ([]CustomField, error) {
+		return l.GetCustomFields(ctx, s.ID)
+	})
+}
+
 func (s *Performer) LoadStashIDs(ctx context.Context, l StashIDLoader) error {
 	return s.StashIDs.load(func() ([]StashID, error) {
 		return l.GetStashIDs(ctx, s.ID)
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// RestrictionProfile describes content that is hidden from users or sessions
+// that the profile is applied to.
+type RestrictionProfile struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	// PinHash is the bcrypt hash of the PIN required to leave the profile
+	PinHash string `json:"-"`
+	// MaxRating hides content rated higher than the value, if set
+	MaxRating *int `json:"max_rating"`
+	// TagIDs hides content tagged with the tags or any of their child tags
+	TagIDs       []int     `json:"tag_ids"`
+	StudioIDs    []int     `json:"studio_ids"`
+	PerformerIDs []int     `json:"performer_ids"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func NewRestrictionProfile() RestrictionProfile {
+	currentTime := time.Now()
+	return RestrictionProfile{
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// ContentRestriction is the resolved form of a restriction profile, used to
+// exclude hidden content from queries.
+type ContentRestriction struct {
+	ProfileID int
+	// Locked is true if the restriction is applied to the user account, and
+	// so cannot be left using the PIN.
+	Locked bool
+
+	MaxRating *int
+	// TagIDs includes the child tags of the tags in the profile
+	TagIDs       []int
+	StudioIDs    []int
+	PerformerIDs []int
+}
+
+type contentRestrictionKey struct{}
+
+// WithContentRestriction returns a context that hides the content excluded by
+// the provided restriction.
+func WithContentRestriction(ctx context.Context, r *ContentRestriction) context.Context {
+	return context.WithValue(ctx, contentRestrictionKey{}, r)
+}
+
+// GetContentRestriction returns the content restriction set in the context,
+// or nil if content is not restricted.
+func GetContentRestriction(ctx context.Context) *ContentRestriction {
+	ret, _ := ctx.Value(contentRestrictionKey{}).(*ContentRestriction)
+	return ret
+}
@@ -43,6 +43,8 @@ type Scene struct {
 	PerformerIDs RelatedIDs      `json:"performer_ids"`
 	Groups       RelatedGroups   `json:"groups"`
 	StashIDs     RelatedStashIDs `json:"stash_ids"`
+
+	CustomFields RelatedCustomFields `json:"custom_fields"`
 }
 
 func NewScene() Scene {
@@ -77,6 +79,7 @@ type ScenePartial struct {
 	GroupIDs      *UpdateGroupIDs
 	StashIDs      *UpdateStashIDs
 	PrimaryFileID *FileID
+	CustomFields  *UpdateCustomFields
 }
 
 func NewScenePartial() ScenePartial {
@@ -139,6 +142,12 @@ func (s *Scene) LoadTagIDs(ctx context.Context, l TagIDLoader) error {
 	})
 }
 
+func (s *Scene) LoadCustomFields(ctx context.Context, l CustomFieldsLoader) error {
+	return s.CustomFields.load(func() ([]CustomField, error) {
+		return l.GetCustomFields(ctx, s.ID)
+	})
+}
+
 func (s *Scene) LoadGroups(ctx context.Context, l SceneGroupLoader) error {
 	return s.Groups.load(func() ([]GroupsScenes, error) {
 		return l.GetGroups(ctx, s.ID)
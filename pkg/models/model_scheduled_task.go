@@ -0,0 +1,106 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type ScheduledTaskType string
+
+const (
+	ScheduledTaskTypeScan           ScheduledTaskType = "SCAN"
+	ScheduledTaskTypeGenerate       ScheduledTaskType = "GENERATE"
+	ScheduledTaskTypeAutoTag        ScheduledTaskType = "AUTO_TAG"
+	ScheduledTaskTypeClean          ScheduledTaskType = "CLEAN"
+	ScheduledTaskTypeCleanGenerated ScheduledTaskType = "CLEAN_GENERATED"
+	ScheduledTaskTypeIdentify       ScheduledTaskType = "IDENTIFY"
+	ScheduledTaskTypeOptimise       ScheduledTaskType = "OPTIMISE"
+	ScheduledTaskTypePlugin         ScheduledTaskType = "PLUGIN"
+)
+
+var AllScheduledTaskType = []ScheduledTaskType{
+	ScheduledTaskTypeScan,
+	ScheduledTaskTypeGenerate,
+	ScheduledTaskTypeAutoTag,
+	ScheduledTaskTypeClean,
+	ScheduledTaskTypeCleanGenerated,
+	ScheduledTaskTypeIdentify,
+	ScheduledTaskTypeOptimise,
+	ScheduledTaskTypePlugin,
+}
+
+func (e ScheduledTaskType) IsValid() bool {
+	switch e {
+	case ScheduledTaskTypeScan, ScheduledTaskTypeGenerate, ScheduledTaskTypeAutoTag, ScheduledTaskTypeClean, ScheduledTaskTypeCleanGenerated, ScheduledTaskTypeIdentify, ScheduledTaskTypeOptimise, ScheduledTaskTypePlugin:
+		return true
+	}
+	return false
+}
+
+func (e ScheduledTaskType) String() string {
+	return string(e)
+}
+
+func (e *ScheduledTaskType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ScheduledTaskType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ScheduledTaskType", str)
+	}
+	return nil
+}
+
+func (e ScheduledTaskType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// ScheduledTask is a persisted job definition that is queued on a
+// recurring schedule.
+type ScheduledTask struct {
+	ID   int               `json:"id"`
+	Name string            `json:"name"`
+	Type ScheduledTaskType `json:"type"`
+	// Schedule is a cron expression
+	Schedule string `json:"schedule"`
+	// Input is the job-specific input, as would be passed to the
+	// equivalent mutation.
+	Input   map[string]interface{} `json:"input"`
+	Enabled bool                   `json:"enabled"`
+	// NextRunAt is the next time the task is due to be queued.
+	// Nil if the task is disabled.
+	NextRunAt *time.Time `json:"next_run_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func NewScheduledTask() ScheduledTask {
+	currentTime := time.Now()
+	return ScheduledTask{
+		Enabled:   true,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// ScheduledTaskRun records a single execution of a ScheduledTask.
+type ScheduledTaskRun struct {
+	ID              int `json:"id"`
+	ScheduledTaskID int `json:"scheduled_task_id"`
+	// Status uses the same values as job.Status
+	Status    string     `json:"status"`
+	AddTime   time.Time  `json:"add_time"`
+	StartTime *time.Time `json:"start_time"`
+	EndTime   *time.Time `json:"end_time"`
+	Error     *string    `json:"error"`
+}
+
+// Finished returns true if the run has completed, successfully or otherwise.
+func (r ScheduledTaskRun) Finished() bool {
+	return r.EndTime != nil
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type SearchResultType string
+
+const (
+	SearchResultTypeScene       SearchResultType = "SCENE"
+	SearchResultTypeImage       SearchResultType = "IMAGE"
+	SearchResultTypeGallery     SearchResultType = "GALLERY"
+	SearchResultTypePerformer   SearchResultType = "PERFORMER"
+	SearchResultTypeStudio      SearchResultType = "STUDIO"
+	SearchResultTypeGroup       SearchResultType = "GROUP"
+	SearchResultTypeTag         SearchResultType = "TAG"
+	SearchResultTypeSceneMarker SearchResultType = "SCENE_MARKER"
+)
+
+var AllSearchResultType = []SearchResultType{
+	SearchResultTypeScene,
+	SearchResultTypeImage,
+	SearchResultTypeGallery,
+	SearchResultTypePerformer,
+	SearchResultTypeStudio,
+	SearchResultTypeGroup,
+	SearchResultTypeTag,
+	SearchResultTypeSceneMarker,
+}
+
+func (e SearchResultType) IsValid() bool {
+	switch e {
+	case SearchResultTypeScene, SearchResultTypeImage, SearchResultTypeGallery, SearchResultTypePerformer, SearchResultTypeStudio, SearchResultTypeGroup, SearchResultTypeTag, SearchResultTypeSceneMarker:
+		return true
+	}
+	return false
+}
+
+func (e SearchResultType) String() string {
+	return string(e)
+}
+
+func (e *SearchResultType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchResultType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchResultType", str)
+	}
+	return nil
+}
+
+func (e SearchResultType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// SearchResult is an object matching a global search query.
+type SearchResult struct {
+	Type SearchResultType `json:"type"`
+	ID   int              `json:"id"`
+	// Score is the relevance of the object to the query. Higher scores are
+	// more relevant.
+	Score float64 `json:"score"`
+}
+
+// SearchResultItem is an object that may be returned by a global search.
+type SearchResultItem interface {
+	IsSearchResultItem()
+}
+
+func (Scene) IsSearchResultItem()       {}
+func (Image) IsSearchResultItem()       {}
+func (Gallery) IsSearchResultItem()     {}
+func (Performer) IsSearchResultItem()   {}
+func (Studio) IsSearchResultItem()      {}
+func (Group) IsSearchResultItem()       {}
+func (Tag) IsSearchResultItem()         {}
+func (SceneMarker) IsSearchResultItem() {}
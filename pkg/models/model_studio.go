@@ -21,6 +21,8 @@ type Studio struct {
 	Aliases  RelatedStrings  `json:"aliases"`
 	TagIDs   RelatedIDs      `json:"tag_ids"`
 	StashIDs RelatedStashIDs `json:"stash_ids"`
+
+	CustomFields RelatedCustomFields `json:"custom_fields"`
 }
 
 func NewStudio() Studio {
@@ -45,9 +47,10 @@ type StudioPartial struct {
 	UpdatedAt     OptionalTime
 	IgnoreAutoTag OptionalBool
 
-	Aliases  *UpdateStrings
-	TagIDs   *UpdateIDs
-	StashIDs *UpdateStashIDs
+	Aliases      *UpdateStrings
+	TagIDs       *UpdateIDs
+	StashIDs     *UpdateStashIDs
+	CustomFields *UpdateCustomFields
 }
 
 func NewStudioPartial() StudioPartial {
@@ -69,6 +72,12 @@ func (s *Studio) LoadTagIDs(ctx context.Context, l TagIDLoader) error {
 	})
 }
 
+func (s *Studio) LoadCustomFields(ctx context.Context, l CustomFieldsLoader) error {
+	return s.CustomFields.load(func() ([]CustomField, error) {
+		return l.GetCustomFields(ctx, s.ID)
+	})
+}
+
 func (s *Studio) LoadStashIDs(ctx context.Context, l StashIDLoader) error {
 	return s.StashIDs.load(func() ([]StashID, error) {
 		return l.GetStashIDs(ctx, s.ID)
@@ -17,6 +17,8 @@ type Tag struct {
 	Aliases   RelatedStrings `json:"aliases"`
 	ParentIDs RelatedIDs     `json:"parent_ids"`
 	ChildIDs  RelatedIDs     `json:"tag_ids"`
+
+	CustomFields RelatedCustomFields `json:"custom_fields"`
 }
 
 func NewTag() Tag {
@@ -33,6 +35,12 @@ func (s *Tag) LoadAliases(ctx context.Context, l AliasLoader) error {
 	})
 }
 
+func (s *Tag) LoadCustomFields(ctx context.Context, l CustomFieldsLoader) error {
+	return s.CustomFields.load(func() ([]CustomField, error) {
+		return l.GetCustomFields(ctx, s.ID)
+	})
+}
+
 func (s *Tag) LoadParentIDs(ctx context.Context, l TagRelationLoader) error {
 	return s.ParentIDs.load(func() ([]int, error) {
 		return l.GetParentIDs(ctx, s.ID)
@@ -53,9 +61,10 @@ type TagPartial struct {
 	CreatedAt     OptionalTime
 	UpdatedAt     OptionalTime
 
-	Aliases   *UpdateStrings
-	ParentIDs *UpdateIDs
-	ChildIDs  *UpdateIDs
+	Aliases      *UpdateStrings
+	ParentIDs    *UpdateIDs
+	ChildIDs     *UpdateIDs
+	CustomFields *UpdateCustomFields
 }
 
 func NewTagPartial() TagPartial {
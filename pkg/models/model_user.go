@@ -0,0 +1,119 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type UserRole string
+
+const (
+	// UserRoleAdmin users may perform any operation, including managing
+	// users and changing the system configuration.
+	UserRoleAdmin UserRole = "ADMIN"
+	// UserRoleEditor users may view and modify library content.
+	UserRoleEditor UserRole = "EDITOR"
+	// UserRoleViewer users may view library content, and may only modify
+	// their own ratings and activity.
+	UserRoleViewer UserRole = "VIEWER"
+)
+
+var AllUserRole = []UserRole{
+	UserRoleAdmin,
+	UserRoleEditor,
+	UserRoleViewer,
+}
+
+func (e UserRole) IsValid() bool {
+	switch e {
+	case UserRoleAdmin, UserRoleEditor, UserRoleViewer:
+		return true
+	}
+	return false
+}
+
+// CanModify returns true if the role permits modifying library content.
+func (e UserRole) CanModify() bool {
+	return e == UserRoleAdmin || e == UserRoleEditor
+}
+
+func (e UserRole) String() string {
+	return string(e)
+}
+
+func (e *UserRole) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UserRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UserRole", str)
+	}
+	return nil
+}
+
+func (e UserRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// User is a local user account.
+type User struct {
+	ID       int      `json:"id"`
+	Username string   `json:"username"`
+	Role     UserRole `json:"role"`
+	// PasswordHash is the bcrypt hash of the user's password
+	PasswordHash string `json:"-"`
+	// APIKeyHash is the hash of the user's API key, as returned by
+	// session.HashAPIKey. It is empty if the user has no API key.
+	APIKeyHash string `json:"-"`
+	// ExternalIssuer and ExternalSubject identify the external identity of
+	// a user authenticated by single sign-on. They are empty for users that
+	// were not created by single sign-on.
+	ExternalIssuer  string `json:"-"`
+	ExternalSubject string `json:"-"`
+	// RestrictionProfileID is the restriction profile applied to all
+	// requests made by the user, if set
+	RestrictionProfileID *int      `json:"restriction_profile_id"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+func NewUser() User {
+	currentTime := time.Now()
+	return User{
+		Role:      UserRoleViewer,
+		CreatedAt: currentTime,
+		UpdatedAt: currentTime,
+	}
+}
+
+// SceneUserData is the rating and playback state of a scene for a single
+// user.
+type SceneUserData struct {
+	SceneID      int     `json:"scene_id"`
+	UserID       int     `json:"user_id"`
+	Rating       *int    `json:"rating"`
+	ResumeTime   float64 `json:"resume_time"`
+	PlayDuration float64 `json:"play_duration"`
+}
+
+type userIDKey struct{}
+
+// WithUserID returns a context in which scenes are filtered and sorted by the
+// ratings, resume points and play and o history of the local user account with
+// the provided ID.
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// GetUserID returns the ID of the local user account set in the context. It
+// returns false if the current user is the configured user.
+func GetUserID(ctx context.Context) (int, bool) {
+	ret, ok := ctx.Value(userIDKey{}).(int)
+	return ret, ok
+}
@@ -57,6 +57,10 @@ type URLLoader interface {
 	GetURLs(ctx context.Context, relatedID int) ([]string, error)
 }
 
+type CustomFieldsLoader interface {
+	GetCustomFields(ctx context.Context, relatedID int) ([]CustomField, error)
+}
+
 // RelatedIDs represents a list of related IDs.
 // TODO - this can be made generic
 type RelatedIDs struct {
@@ -484,3 +488,53 @@ func (r *RelatedStrings) load(fn func() ([]string, error)) error {
 
 	return nil
 }
+
+// RelatedCustomFields represents the custom fields of an object.
+type RelatedCustomFields struct {
+	list []CustomField
+}
+
+// NewRelatedCustomFields returns a loaded RelatedCustomFields object with the provided fields.
+// Loaded will return true when called on the returned object if the provided slice is not nil.
+func NewRelatedCustomFields(list []CustomField) RelatedCustomFields {
+	return RelatedCustomFields{
+		list: list,
+	}
+}
+
+// Loaded returns true if the custom fields have been loaded.
+func (r RelatedCustomFields) Loaded() bool {
+	return r.list != nil
+}
+
+func (r RelatedCustomFields) mustLoaded() {
+	if !r.Loaded() {
+		panic("list has not been loaded")
+	}
+}
+
+// List returns the custom fields. Panics if the relationship has not been loaded.
+func (r RelatedCustomFields) List() []CustomField {
+	r.mustLoaded()
+
+	return r.list
+}
+
+func (r *RelatedCustomFields) load(fn func() ([]CustomField, error)) error {
+	if r.Loaded() {
+		return nil
+	}
+
+	list, err := fn()
+	if err != nil {
+		return err
+	}
+
+	if list == nil {
+		list = []CustomField{}
+	}
+
+	r.list = list
+
+	return nil
+}
@@ -27,6 +27,12 @@ type Repository struct {
 	Studio         StudioReaderWriter
 	Tag            TagReaderWriter
 	SavedFilter    SavedFilterReaderWriter
+	Search         SearchReader
+	ScheduledTask  ScheduledTaskReaderWriter
+	User           UserReaderWriter
+	SceneUserData  SceneUserDataRepository
+
+	RestrictionProfile RestrictionProfileReaderWriter
 }
 
 func (r *Repository) WithTxn(ctx context.Context, fn txn.TxnFunc) error {
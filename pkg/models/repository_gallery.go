@@ -70,6 +70,7 @@ type GalleryReader interface {
 	PerformerIDLoader
 	TagIDLoader
 	FileLoader
+	CustomFieldsLoader
 
 	All(ctx context.Context) ([]*Gallery, error)
 }
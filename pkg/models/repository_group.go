@@ -66,6 +66,7 @@ type GroupReader interface {
 	GroupCounter
 	URLLoader
 	TagIDLoader
+	CustomFieldsLoader
 
 	All(ctx context.Context) ([]*Group, error)
 	GetFrontImage(ctx context.Context, groupID int) ([]byte, error)
@@ -70,6 +70,7 @@ type ImageReader interface {
 	PerformerIDLoader
 	TagIDLoader
 	FileLoader
+	CustomFieldsLoader
 
 	All(ctx context.Context) ([]*Image, error)
 	Size(ctx context.Context) (float64, error)
@@ -79,6 +79,7 @@ type PerformerReader interface {
 	StashIDLoader
 	TagIDLoader
 	URLLoader
+	CustomFieldsLoader
 
 	All(ctx context.Context) ([]*Performer, error)
 	GetImage(ctx context.Context, performerID int) ([]byte, error)
@@ -90,6 +91,8 @@ type PerformerWriter interface {
 	PerformerCreator
 	PerformerUpdater
 	PerformerDestroyer
+
+	Merge(ctx context.Context, source []int, destination int) error
 }
 
 // PerformerReaderWriter provides all performer methods.
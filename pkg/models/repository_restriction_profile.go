@@ -0,0 +1,26 @@
+package models
+
+import "context"
+
+// RestrictionProfileReader provides methods to find restriction profiles.
+type RestrictionProfileReader interface {
+	All(ctx context.Context) ([]*RestrictionProfile, error)
+	Find(ctx context.Context, id int) (*RestrictionProfile, error)
+	FindByName(ctx context.Context, name string) (*RestrictionProfile, error)
+	// GetContentRestriction returns the content restriction for the profile,
+	// or nil if the profile does not exist.
+	GetContentRestriction(ctx context.Context, id int) (*ContentRestriction, error)
+}
+
+// RestrictionProfileWriter provides methods to modify restriction profiles.
+type RestrictionProfileWriter interface {
+	Create(ctx context.Context, newProfile *RestrictionProfile) error
+	Update(ctx context.Context, updatedProfile *RestrictionProfile) error
+	Destroy(ctx context.Context, id int) error
+}
+
+// RestrictionProfileReaderWriter provides all restriction profile methods.
+type RestrictionProfileReaderWriter interface {
+	RestrictionProfileReader
+	RestrictionProfileWriter
+}
@@ -102,6 +102,7 @@ type SceneReader interface {
 	SceneGroupLoader
 	StashIDLoader
 	VideoFileLoader
+	CustomFieldsLoader
 
 	All(ctx context.Context) ([]*Scene, error)
 	Wall(ctx context.Context, q *string) ([]*Scene, error)
@@ -124,6 +125,30 @@ type ViewHistoryWriter interface {
 	DeleteAllViews(ctx context.Context, id int) (int, error)
 }
 
+// SceneActivityWriter provides methods to modify the play and o history and
+// the resume point of scenes.
+type SceneActivityWriter interface {
+	OHistoryWriter
+	ViewHistoryWriter
+	SaveActivity(ctx context.Context, sceneID int, resumeTime *float64, playDuration *float64) (bool, error)
+}
+
+// SceneActivityReader provides methods to read the play and o history of
+// scenes.
+type SceneActivityReader interface {
+	ViewDateReader
+	ODateReader
+
+	PlayDuration(ctx context.Context) (float64, error)
+}
+
+// SceneActivityReaderWriter provides methods to read and modify the play and
+// o history of scenes.
+type SceneActivityReaderWriter interface {
+	SceneActivityReader
+	SceneActivityWriter
+}
+
 // SceneWriter provides all methods to modify scenes.
 type SceneWriter interface {
 	SceneCreator
@@ -134,9 +159,7 @@ type SceneWriter interface {
 	AddGalleryIDs(ctx context.Context, sceneID int, galleryIDs []int) error
 	AssignFiles(ctx context.Context, sceneID int, fileID []FileID) error
 
-	OHistoryWriter
-	ViewHistoryWriter
-	SaveActivity(ctx context.Context, sceneID int, resumeTime *float64, playDuration *float64) (bool, error)
+	SceneActivityWriter
 }
 
 // SceneReaderWriter provides all scene methods.
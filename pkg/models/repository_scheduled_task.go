@@ -0,0 +1,31 @@
+package models
+
+import "context"
+
+type ScheduledTaskReader interface {
+	All(ctx context.Context) ([]*ScheduledTask, error)
+	Find(ctx context.Context, id int) (*ScheduledTask, error)
+	FindMany(ctx context.Context, ids []int, ignoreNotFound bool) ([]*ScheduledTask, error)
+
+	// FindRuns returns the most recent runs for the given task, newest first.
+	// If limit is <= 0, then all runs are returned.
+	FindRuns(ctx context.Context, taskID int, limit int) ([]*ScheduledTaskRun, error)
+	// FindUnfinishedRuns returns all runs that have not been completed.
+	FindUnfinishedRuns(ctx context.Context) ([]*ScheduledTaskRun, error)
+}
+
+type ScheduledTaskWriter interface {
+	Create(ctx context.Context, obj *ScheduledTask) error
+	Update(ctx context.Context, obj *ScheduledTask) error
+	Destroy(ctx context.Context, id int) error
+
+	CreateRun(ctx context.Context, run *ScheduledTaskRun) error
+	UpdateRun(ctx context.Context, run *ScheduledTaskRun) error
+	// PruneRuns deletes all but the most recent keep runs for the given task.
+	PruneRuns(ctx context.Context, taskID int, keep int) error
+}
+
+type ScheduledTaskReaderWriter interface {
+	ScheduledTaskReader
+	ScheduledTaskWriter
+}
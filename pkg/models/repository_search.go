@@ -0,0 +1,20 @@
+package models
+
+import "context"
+
+const (
+	// DefaultSearchLimit is the number of search results returned if no
+	// limit is provided.
+	DefaultSearchLimit = 20
+	// MaxSearchLimit is the maximum number of search results returned.
+	MaxSearchLimit = 100
+)
+
+// SearchReader provides methods to search across object types.
+type SearchReader interface {
+	// Search returns up to limit objects of the provided types matching the
+	// search query, ordered by descending relevance. All types are searched
+	// if types is empty. A limit that is not positive is replaced with
+	// DefaultSearchLimit, and limit is capped to MaxSearchLimit.
+	Search(ctx context.Context, q string, types []SearchResultType, limit int) ([]*SearchResult, error)
+}
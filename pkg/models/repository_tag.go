@@ -87,6 +87,7 @@ type TagReader interface {
 
 	AliasLoader
 	TagRelationLoader
+	CustomFieldsLoader
 
 	All(ctx context.Context) ([]*Tag, error)
 	GetImage(ctx context.Context, tagID int) ([]byte, error)
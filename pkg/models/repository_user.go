@@ -0,0 +1,58 @@
+package models
+
+import "context"
+
+// UserReader provides methods to find users.
+type UserReader interface {
+	All(ctx context.Context) ([]*User, error)
+	Count(ctx context.Context) (int, error)
+	Find(ctx context.Context, id int) (*User, error)
+	FindByUsername(ctx context.Context, username string) (*User, error)
+	FindByAPIKeyHash(ctx context.Context, apiKeyHash string) (*User, error)
+	FindByExternalIdentity(ctx context.Context, issuer string, subject string) (*User, error)
+}
+
+// UserWriter provides methods to modify users.
+type UserWriter interface {
+	Create(ctx context.Context, newUser *User) error
+	Update(ctx context.Context, updatedUser *User) error
+	Destroy(ctx context.Context, id int) error
+}
+
+// UserReaderWriter provides all user methods.
+type UserReaderWriter interface {
+	UserReader
+	UserWriter
+}
+
+// SceneUserDataReader provides methods to read the scene data of a single
+// user.
+type SceneUserDataReader interface {
+	SceneActivityReader
+
+	GetUserData(ctx context.Context, sceneID int) (*SceneUserData, error)
+	// GetManyUserData returns the user data for the provided scenes. The
+	// entry for a scene is nil if the user has no data for it.
+	GetManyUserData(ctx context.Context, sceneIDs []int) ([]*SceneUserData, error)
+}
+
+// SceneUserDataWriter provides methods to modify the scene data of a single
+// user.
+type SceneUserDataWriter interface {
+	SceneActivityWriter
+
+	UpdateRating(ctx context.Context, sceneID int, rating *int) error
+}
+
+// SceneUserDataReaderWriter provides all methods for the scene data of a
+// single user.
+type SceneUserDataReaderWriter interface {
+	SceneUserDataReader
+	SceneUserDataWriter
+}
+
+// SceneUserDataRepository provides access to the scene data of individual
+// users.
+type SceneUserDataRepository interface {
+	ForUser(userID int) SceneUserDataReaderWriter
+}
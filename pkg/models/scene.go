@@ -115,6 +115,8 @@ type SceneFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type SceneQueryOptions struct {
@@ -168,7 +170,8 @@ type SceneCreateInput struct {
 	// The first id will be assigned as primary.
 	// Files will be reassigned from existing scenes if applicable.
 	// Files must not already be primary for another scene.
-	FileIds []string `json:"file_ids"`
+	FileIds      []string      `json:"file_ids"`
+	CustomFields []CustomField `json:"custom_fields"`
 }
 
 type SceneUpdateInput struct {
@@ -191,12 +194,13 @@ type SceneUpdateInput struct {
 	Groups           []SceneGroupInput `json:"groups"`
 	TagIds           []string          `json:"tag_ids"`
 	// This should be a URL or a base64 encoded data URL
-	CoverImage    *string   `json:"cover_image"`
-	StashIds      []StashID `json:"stash_ids"`
-	ResumeTime    *float64  `json:"resume_time"`
-	PlayDuration  *float64  `json:"play_duration"`
-	PlayCount     *int      `json:"play_count"`
-	PrimaryFileID *string   `json:"primary_file_id"`
+	CoverImage    *string       `json:"cover_image"`
+	StashIds      []StashID     `json:"stash_ids"`
+	ResumeTime    *float64      `json:"resume_time"`
+	PlayDuration  *float64      `json:"play_duration"`
+	PlayCount     *int          `json:"play_count"`
+	PrimaryFileID *string       `json:"primary_file_id"`
+	CustomFields  []CustomField `json:"custom_fields"`
 }
 
 type SceneDestroyInput struct {
@@ -10,4 +10,9 @@ type StashBox struct {
 	Endpoint string `json:"endpoint"`
 	APIKey   string `json:"api_key"`
 	Name     string `json:"name"`
+	// Limits of requests to the stash-box server. Zero disables the limit.
+	MaxRequestsPerMinute  int `json:"max_requests_per_minute"`
+	MaxConcurrentRequests int `json:"max_concurrent_requests"`
+	// Maximum number of times requests failing with a 429 or 5xx status are retried.
+	MaxRetries int `json:"max_retries"`
 }
@@ -44,6 +44,8 @@ type StudioFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom fields
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type StudioCreateInput struct {
@@ -51,14 +53,15 @@ type StudioCreateInput struct {
 	URL      *string `json:"url"`
 	ParentID *string `json:"parent_id"`
 	// This should be a URL or a base64 encoded data URL
-	Image         *string   `json:"image"`
-	StashIds      []StashID `json:"stash_ids"`
-	Rating100     *int      `json:"rating100"`
-	Favorite      *bool     `json:"favorite"`
-	Details       *string   `json:"details"`
-	Aliases       []string  `json:"aliases"`
-	TagIds        []string  `json:"tag_ids"`
-	IgnoreAutoTag *bool     `json:"ignore_auto_tag"`
+	Image         *string       `json:"image"`
+	StashIds      []StashID     `json:"stash_ids"`
+	Rating100     *int          `json:"rating100"`
+	Favorite      *bool         `json:"favorite"`
+	Details       *string       `json:"details"`
+	Aliases       []string      `json:"aliases"`
+	TagIds        []string      `json:"tag_ids"`
+	IgnoreAutoTag *bool         `json:"ignore_auto_tag"`
+	CustomFields  []CustomField `json:"custom_fields"`
 }
 
 type StudioUpdateInput struct {
@@ -67,12 +70,13 @@ type StudioUpdateInput struct {
 	URL      *string `json:"url"`
 	ParentID *string `json:"parent_id"`
 	// This should be a URL or a base64 encoded data URL
-	Image         *string   `json:"image"`
-	StashIds      []StashID `json:"stash_ids"`
-	Rating100     *int      `json:"rating100"`
-	Favorite      *bool     `json:"favorite"`
-	Details       *string   `json:"details"`
-	Aliases       []string  `json:"aliases"`
-	TagIds        []string  `json:"tag_ids"`
-	IgnoreAutoTag *bool     `json:"ignore_auto_tag"`
+	Image         *string       `json:"image"`
+	StashIds      []StashID     `json:"stash_ids"`
+	Rating100     *int          `json:"rating100"`
+	Favorite      *bool         `json:"favorite"`
+	Details       *string       `json:"details"`
+	Aliases       []string      `json:"aliases"`
+	TagIds        []string      `json:"tag_ids"`
+	IgnoreAutoTag *bool         `json:"ignore_auto_tag"`
+	CustomFields  []CustomField `json:"custom_fields"`
 }
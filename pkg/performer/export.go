@@ -17,6 +17,7 @@ type ImageAliasStashIDGetter interface {
 	models.AliasLoader
 	models.StashIDLoader
 	models.URLLoader
+	models.CustomFieldsLoader
 }
 
 // ToJSON converts a Performer object into its JSON equivalent.
@@ -87,6 +88,11 @@ func ToJSON(ctx context.Context, reader ImageAliasStashIDGetter, performer *mode
 
 	newPerformerJSON.StashIDs = performer.StashIDs.List()
 
+	if err := performer.LoadCustomFields(ctx, reader); err != nil {
+		return nil, fmt.Errorf("loading performer custom fields: %w", err)
+	}
+	newPerformerJSON.CustomFields = performer.CustomFields.List()
+
 	image, err := reader.GetImage(ctx, performer.ID)
 	if err != nil {
 		logger.Errorf("Error getting performer image: %v", err)
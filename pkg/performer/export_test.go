@@ -62,6 +62,11 @@ var stashIDs = []models.StashID{
 	stashID,
 }
 
+var customFields = []models.CustomField{
+	{Field: "string", Type: models.CustomFieldTypeString, Value: "value"},
+	{Field: "int", Type: models.CustomFieldTypeInt, Value: 1},
+}
+
 const image = "aW1hZ2VCeXRlcw=="
 
 var birthDate, _ = models.ParseDate("2001-01-01")
@@ -103,18 +108,20 @@ func createFullPerformer(id int, name string) *models.Performer {
 		IgnoreAutoTag:  autoTagIgnored,
 		TagIDs:         models.NewRelatedIDs([]int{}),
 		StashIDs:       models.NewRelatedStashIDs(stashIDs),
+		CustomFields:   models.NewRelatedCustomFields(customFields),
 	}
 }
 
 func createEmptyPerformer(id int) models.Performer {
 	return models.Performer{
-		ID:        id,
-		CreatedAt: createTime,
-		UpdatedAt: updateTime,
-		Aliases:   models.NewRelatedStrings([]string{}),
-		URLs:      models.NewRelatedStrings([]string{}),
-		TagIDs:    models.NewRelatedIDs([]int{}),
-		StashIDs:  models.NewRelatedStashIDs([]models.StashID{}),
+		ID:           id,
+		CreatedAt:    createTime,
+		UpdatedAt:    updateTime,
+		Aliases:      models.NewRelatedStrings([]string{}),
+		URLs:         models.NewRelatedStrings([]string{}),
+		TagIDs:       models.NewRelatedIDs([]int{}),
+		StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+		CustomFields: models.NewRelatedCustomFields([]models.CustomField{}),
 	}
 }
 
@@ -151,15 +158,17 @@ func createFullJSONPerformer(name string, image string) *jsonschema.Performer {
 		HairColor:     hairColor,
 		Weight:        weight,
 		StashIDs:      stashIDs,
+		CustomFields:  customFields,
 		IgnoreAutoTag: autoTagIgnored,
 	}
 }
 
 func createEmptyJSONPerformer() *jsonschema.Performer {
 	return &jsonschema.Performer{
-		Aliases:  []string{},
-		URLs:     []string{},
-		StashIDs: []models.StashID{},
+		Aliases:      []string{},
+		URLs:         []string{},
+		StashIDs:     []models.StashID{},
+		CustomFields: []models.CustomField{},
 		CreatedAt: json.JSONTime{
 			Time: createTime,
 		},
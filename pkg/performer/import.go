@@ -206,6 +206,8 @@ func performerJSONToPerformer(performerJSON jsonschema.Performer) models.Perform
 
 		TagIDs:   models.NewRelatedIDs([]int{}),
 		StashIDs: models.NewRelatedStashIDs(performerJSON.StashIDs),
+
+		CustomFields: models.NewRelatedCustomFields(performerJSON.CustomFields),
 	}
 
 	if len(performerJSON.URLs) > 0 {
@@ -0,0 +1,186 @@
+package performer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+type MergeRepository interface {
+	models.PerformerReader
+	UpdatePartial(ctx context.Context, id int, updatedPerformer models.PerformerPartial) (*models.Performer, error)
+	UpdateImage(ctx context.Context, performerID int, image []byte) error
+	Merge(ctx context.Context, source []int, destination int) error
+}
+
+// Merge merges the source performers into the destination performer.
+//
+// Scene, image and gallery associations are moved to the destination. Group
+// associations and o-counters are derived from scenes and images, so they
+// follow. The names and aliases of the source performers are added as aliases,
+// and their URLs, tags and stash IDs are added to the destination, unless the
+// corresponding field is set in values. Other fields in values overwrite the
+// destination. If the destination has no image, the image of the first source
+// performer with an image is used. The source performers are destroyed.
+func Merge(ctx context.Context, sourceIDs []int, destinationID int, values models.PerformerPartial, r MergeRepository) error {
+	// ensure source ids are unique
+	sourceIDs = sliceutil.AppendUniques(nil, sourceIDs)
+
+	// ensure destination is not in source list
+	if sliceutil.Contains(sourceIDs, destinationID) {
+		return errors.New("destination performer cannot be in source list")
+	}
+
+	dest, err := r.Find(ctx, destinationID)
+	if err != nil {
+		return fmt.Errorf("finding destination performer ID %d: %w", destinationID, err)
+	}
+	if dest == nil {
+		return &NotFoundError{destinationID}
+	}
+
+	sources, err := r.FindMany(ctx, sourceIDs)
+	if err != nil {
+		return fmt.Errorf("finding source performers: %w", err)
+	}
+
+	if err := loadMergeRelationships(ctx, dest, r); err != nil {
+		return err
+	}
+
+	var (
+		aliases  []string
+		urls     []string
+		tagIDs   []int
+		stashIDs = dest.StashIDs.List()
+	)
+
+	for _, src := range sources {
+		if err := loadMergeRelationships(ctx, src, r); err != nil {
+			return err
+		}
+
+		aliases = append(aliases, src.Name)
+		aliases = append(aliases, src.Aliases.List()...)
+		urls = sliceutil.AppendUniques(urls, src.URLs.List())
+		tagIDs = sliceutil.AppendUniques(tagIDs, src.TagIDs.List())
+		stashIDs = sliceutil.AppendUniques(stashIDs, src.StashIDs.List())
+	}
+
+	if values.Aliases == nil {
+		name := dest.Name
+		if values.Name.Set {
+			name = values.Name.Value
+		}
+
+		values.Aliases = &models.UpdateStrings{
+			Values: mergeAliases(name, dest.Aliases.List(), aliases),
+			Mode:   models.RelationshipUpdateModeAdd,
+		}
+	}
+	if values.URLs == nil {
+		values.URLs = &models.UpdateStrings{
+			Values: urls,
+			Mode:   models.RelationshipUpdateModeAdd,
+		}
+	}
+	if values.TagIDs == nil {
+		values.TagIDs = &models.UpdateIDs{
+			IDs:  tagIDs,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+	}
+	if values.StashIDs == nil {
+		values.StashIDs = &models.UpdateStashIDs{
+			StashIDs: stashIDs,
+			Mode:     models.RelationshipUpdateModeSet,
+		}
+	}
+
+	if err := mergeImage(ctx, destinationID, sources, r); err != nil {
+		return err
+	}
+
+	// move associations and destroy the sources before validating, so that
+	// the destination may take the name of a source performer
+	if err := r.Merge(ctx, sourceIDs, destinationID); err != nil {
+		return fmt.Errorf("merging performers: %w", err)
+	}
+
+	if err := ValidateUpdate(ctx, destinationID, values, r); err != nil {
+		return err
+	}
+
+	if _, err := r.UpdatePartial(ctx, destinationID, values); err != nil {
+		return fmt.Errorf("updating performer: %w", err)
+	}
+
+	return nil
+}
+
+func loadMergeRelationships(ctx context.Context, p *models.Performer, r models.PerformerReader) error {
+	if err := p.LoadRelationships(ctx, r); err != nil {
+		return fmt.Errorf("loading performer relationships from %d: %w", p.ID, err)
+	}
+
+	if err := p.LoadURLs(ctx, r); err != nil {
+		return fmt.Errorf("loading performer URLs from %d: %w", p.ID, err)
+	}
+
+	return nil
+}
+
+func mergeImage(ctx context.Context, destinationID int, sources []*models.Performer, r MergeRepository) error {
+	hasImage, err := r.HasImage(ctx, destinationID)
+	if err != nil {
+		return fmt.Errorf("checking performer image: %w", err)
+	}
+
+	if hasImage {
+		return nil
+	}
+
+	for _, src := range sources {
+		image, err := r.GetImage(ctx, src.ID)
+		if err != nil {
+			return fmt.Errorf("getting image for performer %d: %w", src.ID, err)
+		}
+
+		if len(image) > 0 {
+			if err := r.UpdateImage(ctx, destinationID, image); err != nil {
+				return fmt.Errorf("updating performer image: %w", err)
+			}
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// mergeAliases returns the aliases in toAdd that do not match, case
+// insensitively, the name, an existing alias or an earlier alias in toAdd.
+func mergeAliases(name string, existing []string, toAdd []string) []string {
+	seen := map[string]bool{
+		strings.ToLower(name): true,
+	}
+	for _, a := range existing {
+		seen[strings.ToLower(a)] = true
+	}
+
+	var ret []string
+	for _, a := range toAdd {
+		aL := strings.ToLower(a)
+		if a == "" || seen[aL] {
+			continue
+		}
+
+		seen[aL] = true
+		ret = append(ret, a)
+	}
+
+	return ret
+}
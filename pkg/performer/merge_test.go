@@ -0,0 +1,99 @@
+package performer
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMerge(t *testing.T) {
+	const (
+		destID = 1
+		srcID  = 2
+	)
+
+	db := mocks.NewDatabase()
+
+	dest := &models.Performer{
+		ID:   destID,
+		Name: "dest",
+	}
+	src := &models.Performer{
+		ID:   srcID,
+		Name: "src",
+	}
+
+	stashID1 := models.StashID{StashID: "stash1", Endpoint: "endpoint"}
+	stashID2 := models.StashID{StashID: "stash2", Endpoint: "endpoint"}
+	image := []byte("image")
+
+	db.Performer.On("Find", testCtx, destID).Return(dest, nil)
+	db.Performer.On("FindMany", testCtx, []int{srcID}).Return([]*models.Performer{src}, nil)
+
+	db.Performer.On("GetAliases", testCtx, destID).Return([]string{"dest alias"}, nil).Once()
+	db.Performer.On("GetURLs", testCtx, destID).Return([]string{"url1"}, nil).Once()
+	db.Performer.On("GetTagIDs", testCtx, destID).Return([]int{1}, nil).Once()
+	db.Performer.On("GetStashIDs", testCtx, destID).Return([]models.StashID{stashID1}, nil).Once()
+
+	db.Performer.On("GetAliases", testCtx, srcID).Return([]string{"DEST ALIAS", "src alias", "Dest"}, nil).Once()
+	db.Performer.On("GetURLs", testCtx, srcID).Return([]string{"url1", "url2"}, nil).Once()
+	db.Performer.On("GetTagIDs", testCtx, srcID).Return([]int{1, 2}, nil).Once()
+	db.Performer.On("GetStashIDs", testCtx, srcID).Return([]models.StashID{stashID1, stashID2}, nil).Once()
+
+	db.Performer.On("HasImage", testCtx, destID).Return(false, nil).Once()
+	db.Performer.On("GetImage", testCtx, srcID).Return(image, nil).Once()
+	db.Performer.On("UpdateImage", testCtx, destID, image).Return(nil).Once()
+
+	db.Performer.On("Merge", testCtx, []int{srcID}, destID).Return(nil).Once()
+
+	db.Performer.On("UpdatePartial", testCtx, destID, mock.MatchedBy(func(p models.PerformerPartial) bool {
+		return assert.Equal(t, models.NewOptionalBool(true), p.Favorite) &&
+			assert.Equal(t, &models.UpdateStrings{
+				Values: []string{"src", "src alias"},
+				Mode:   models.RelationshipUpdateModeAdd,
+			}, p.Aliases) &&
+			assert.Equal(t, &models.UpdateStrings{
+				Values: []string{"url1", "url2"},
+				Mode:   models.RelationshipUpdateModeAdd,
+			}, p.URLs) &&
+			assert.Equal(t, &models.UpdateIDs{
+				IDs:  []int{1, 2},
+				Mode: models.RelationshipUpdateModeAdd,
+			}, p.TagIDs) &&
+			assert.Equal(t, &models.UpdateStashIDs{
+				StashIDs: []models.StashID{stashID1, stashID2},
+				Mode:     models.RelationshipUpdateModeSet,
+			}, p.StashIDs)
+	})).Return(dest, nil).Once()
+
+	values := models.NewPerformerPartial()
+	values.Favorite = models.NewOptionalBool(true)
+
+	err := Merge(testCtx, []int{srcID, srcID}, destID, values, db.Performer)
+	assert.Nil(t, err)
+
+	db.AssertExpectations(t)
+}
+
+func TestMergeDestinationInSource(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	err := Merge(testCtx, []int{1, 2}, 2, models.NewPerformerPartial(), db.Performer)
+	assert.NotNil(t, err)
+
+	db.AssertExpectations(t)
+}
+
+func TestMergeDestinationNotFound(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	db.Performer.On("Find", testCtx, 1).Return(nil, nil).Once()
+
+	err := Merge(testCtx, []int{2}, 1, models.NewPerformerPartial(), db.Performer)
+	assert.Equal(t, &NotFoundError{1}, err)
+
+	db.AssertExpectations(t)
+}
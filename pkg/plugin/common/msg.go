@@ -118,3 +118,12 @@ type HookContext struct {
 	Input       interface{} `json:"input"`
 	InputFields []string    `json:"inputFields,omitempty"`
 }
+
+// PreHookOutput is the structure expected in the Output field of a
+// PluginOutput returned by a plugin task triggered by a pre-hook. Pre-hooks
+// reject the operation by returning an error in the PluginOutput.
+type PreHookOutput struct {
+	// Input, if set, replaces the input to the operation. It must contain all
+	// fields to be passed to the operation, not just the modified fields.
+	Input map[string]interface{} `json:"input"`
+}
@@ -38,16 +38,69 @@ const (
 
 	PerformerCreatePost  TriggerEnum = "Performer.Create.Post"
 	PerformerUpdatePost  TriggerEnum = "Performer.Update.Post"
+	PerformerMergePost   TriggerEnum = "Performer.Merge.Post"
 	PerformerDestroyPost TriggerEnum = "Performer.Destroy.Post"
 
 	StudioCreatePost  TriggerEnum = "Studio.Create.Post"
 	StudioUpdatePost  TriggerEnum = "Studio.Update.Post"
+	StudioMergePost   TriggerEnum = "Studio.Merge.Post"
 	StudioDestroyPost TriggerEnum = "Studio.Destroy.Post"
 
 	TagCreatePost  TriggerEnum = "Tag.Create.Post"
 	TagUpdatePost  TriggerEnum = "Tag.Update.Post"
 	TagMergePost   TriggerEnum = "Tag.Merge.Post"
 	TagDestroyPost TriggerEnum = "Tag.Destroy.Post"
+
+	FileCreatePost            TriggerEnum = "File.Create.Post"
+	FileMovePost              TriggerEnum = "File.Move.Post"
+	FileFingerprintChangePost TriggerEnum = "File.FingerprintChange.Post"
+
+	FolderCreatePost  TriggerEnum = "Folder.Create.Post"
+	FolderMovePost    TriggerEnum = "Folder.Move.Post"
+	FolderDestroyPost TriggerEnum = "Folder.Destroy.Post"
+
+	JobCompletePost TriggerEnum = "Job.Complete.Post"
+
+	// Pre hooks are executed before the operation is performed, and may
+	// reject or modify the operation input.
+
+	SceneMarkerCreatePre  TriggerEnum = "SceneMarker.Create.Pre"
+	SceneMarkerUpdatePre  TriggerEnum = "SceneMarker.Update.Pre"
+	SceneMarkerDestroyPre TriggerEnum = "SceneMarker.Destroy.Pre"
+
+	SceneCreatePre  TriggerEnum = "Scene.Create.Pre"
+	SceneUpdatePre  TriggerEnum = "Scene.Update.Pre"
+	SceneDestroyPre TriggerEnum = "Scene.Destroy.Pre"
+
+	ImageUpdatePre  TriggerEnum = "Image.Update.Pre"
+	ImageDestroyPre TriggerEnum = "Image.Destroy.Pre"
+
+	GalleryCreatePre  TriggerEnum = "Gallery.Create.Pre"
+	GalleryUpdatePre  TriggerEnum = "Gallery.Update.Pre"
+	GalleryDestroyPre TriggerEnum = "Gallery.Destroy.Pre"
+
+	GalleryChapterCreatePre  TriggerEnum = "GalleryChapter.Create.Pre"
+	GalleryChapterUpdatePre  TriggerEnum = "GalleryChapter.Update.Pre"
+	GalleryChapterDestroyPre TriggerEnum = "GalleryChapter.Destroy.Pre"
+
+	GroupCreatePre  TriggerEnum = "Group.Create.Pre"
+	GroupUpdatePre  TriggerEnum = "Group.Update.Pre"
+	GroupDestroyPre TriggerEnum = "Group.Destroy.Pre"
+
+	PerformerCreatePre  TriggerEnum = "Performer.Create.Pre"
+	PerformerUpdatePre  TriggerEnum = "Performer.Update.Pre"
+	PerformerMergePre   TriggerEnum = "Performer.Merge.Pre"
+	PerformerDestroyPre TriggerEnum = "Performer.Destroy.Pre"
+
+	StudioCreatePre  TriggerEnum = "Studio.Create.Pre"
+	StudioUpdatePre  TriggerEnum = "Studio.Update.Pre"
+	StudioMergePre   TriggerEnum = "Studio.Merge.Pre"
+	StudioDestroyPre TriggerEnum = "Studio.Destroy.Pre"
+
+	TagCreatePre  TriggerEnum = "Tag.Create.Pre"
+	TagUpdatePre  TriggerEnum = "Tag.Update.Pre"
+	TagMergePre   TriggerEnum = "Tag.Merge.Pre"
+	TagDestroyPre TriggerEnum = "Tag.Destroy.Pre"
 )
 
 var AllHookTriggerEnum = []TriggerEnum{
@@ -77,16 +130,66 @@ var AllHookTriggerEnum = []TriggerEnum{
 
 	PerformerCreatePost,
 	PerformerUpdatePost,
+	PerformerMergePost,
 	PerformerDestroyPost,
 
 	StudioCreatePost,
 	StudioUpdatePost,
+	StudioMergePost,
 	StudioDestroyPost,
 
 	TagCreatePost,
 	TagUpdatePost,
 	TagMergePost,
 	TagDestroyPost,
+
+	FileCreatePost,
+	FileMovePost,
+	FileFingerprintChangePost,
+
+	FolderCreatePost,
+	FolderMovePost,
+	FolderDestroyPost,
+
+	JobCompletePost,
+
+	SceneMarkerCreatePre,
+	SceneMarkerUpdatePre,
+	SceneMarkerDestroyPre,
+
+	SceneCreatePre,
+	SceneUpdatePre,
+	SceneDestroyPre,
+
+	ImageUpdatePre,
+	ImageDestroyPre,
+
+	GalleryCreatePre,
+	GalleryUpdatePre,
+	GalleryDestroyPre,
+
+	GalleryChapterCreatePre,
+	GalleryChapterUpdatePre,
+	GalleryChapterDestroyPre,
+
+	GroupCreatePre,
+	GroupUpdatePre,
+	GroupDestroyPre,
+
+	PerformerCreatePre,
+	PerformerUpdatePre,
+	PerformerMergePre,
+	PerformerDestroyPre,
+
+	StudioCreatePre,
+	StudioUpdatePre,
+	StudioMergePre,
+	StudioDestroyPre,
+
+	TagCreatePre,
+	TagUpdatePre,
+	TagMergePre,
+	TagDestroyPre,
 }
 
 func (e TriggerEnum) IsValid() bool {
@@ -118,15 +221,65 @@ func (e TriggerEnum) IsValid() bool {
 
 		PerformerCreatePost,
 		PerformerUpdatePost,
+		PerformerMergePost,
 		PerformerDestroyPost,
 
 		StudioCreatePost,
 		StudioUpdatePost,
+		StudioMergePost,
 		StudioDestroyPost,
 
 		TagCreatePost,
 		TagUpdatePost,
-		TagDestroyPost:
+		TagDestroyPost,
+
+		FileCreatePost,
+		FileMovePost,
+		FileFingerprintChangePost,
+
+		FolderCreatePost,
+		FolderMovePost,
+		FolderDestroyPost,
+
+		JobCompletePost,
+
+		SceneMarkerCreatePre,
+		SceneMarkerUpdatePre,
+		SceneMarkerDestroyPre,
+
+		SceneCreatePre,
+		SceneUpdatePre,
+		SceneDestroyPre,
+
+		ImageUpdatePre,
+		ImageDestroyPre,
+
+		GalleryCreatePre,
+		GalleryUpdatePre,
+		GalleryDestroyPre,
+
+		GalleryChapterCreatePre,
+		GalleryChapterUpdatePre,
+		GalleryChapterDestroyPre,
+
+		GroupCreatePre,
+		GroupUpdatePre,
+		GroupDestroyPre,
+
+		PerformerCreatePre,
+		PerformerUpdatePre,
+		PerformerMergePre,
+		PerformerDestroyPre,
+
+		StudioCreatePre,
+		StudioUpdatePre,
+		StudioMergePre,
+		StudioDestroyPre,
+
+		TagCreatePre,
+		TagUpdatePre,
+		TagMergePre,
+		TagDestroyPre:
 		return true
 	}
 	return false
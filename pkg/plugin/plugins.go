@@ -9,6 +9,7 @@ package plugin
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/logger"
@@ -96,6 +98,7 @@ type ServerConfig interface {
 	GetPluginsPath() string
 	GetDisabledPlugins() []string
 	GetPythonPath() string
+	GetPluginsPreHookTimeout() time.Duration
 }
 
 // Cache stores plugin details.
@@ -368,7 +371,13 @@ func (c Cache) ExecutePostHooks(ctx context.Context, id int, hookType hook.Trigg
 	}
 }
 
-func (c Cache) RegisterPostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) {
+// RegisterPostHooks executes the post-hooks once the transaction in ctx is
+// committed. It does nothing if c is nil.
+func (c *Cache) RegisterPostHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input interface{}, inputFields []string) {
+	if c == nil {
+		return
+	}
+
 	txn.AddPostCommitHook(ctx, func(ctx context.Context) {
 		c.ExecutePostHooks(ctx, id, hookType, input, inputFields)
 	})
@@ -383,6 +392,15 @@ func (c Cache) ExecuteSceneUpdatePostHooks(ctx context.Context, input models.Sce
 	c.ExecutePostHooks(ctx, id, hook.SceneUpdatePost, input, inputFields)
 }
 
+func (c Cache) ExecuteImageUpdatePostHooks(ctx context.Context, input models.ImageUpdateInput, inputFields []string) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		logger.Errorf("error converting id in ImageUpdatePostHooks: %v", err)
+		return
+	}
+	c.ExecutePostHooks(ctx, id, hook.ImageUpdatePost, input, inputFields)
+}
+
 // maxCyclicLoopDepth is the maximum number of identical plugin hook calls that
 // can be made before a cyclic loop is detected. It is set to an arbitrary value
 // that should not be hit under normal circumstances.
@@ -401,30 +419,11 @@ func (c Cache) executePostHooks(ctx context.Context, hookType hook.TriggerEnum,
 		}
 
 		for _, h := range hooks {
-			newCtx := session.AddVisitedPluginHook(ctx, p.id, hookType)
-			serverConnection := c.makeServerConnection(newCtx)
-
-			pluginInput := buildPluginInput(&p, &h.OperationConfig, serverConnection, nil)
-			addHookContext(pluginInput.Args, hookContext)
-
-			pt := pluginTask{
-				plugin:       &p,
-				operation:    &h.OperationConfig,
-				input:        pluginInput,
-				gqlHandler:   c.gqlHandler,
-				serverConfig: c.config,
-			}
-
-			task := pt.createTask()
-			if err := task.Start(); err != nil {
-				return err
-			}
-
-			if err := waitForTask(ctx, task); err != nil {
+			output, err := c.executeHook(ctx, &p, h, hookType, hookContext)
+			if err != nil {
 				return err
 			}
 
-			output := task.GetResult()
 			if output == nil {
 				logger.Debugf("%s [%s]: returned no result", hookType.String(), p.Name)
 			} else {
@@ -440,6 +439,150 @@ func (c Cache) executePostHooks(ctx context.Context, hookType hook.TriggerEnum,
 	return nil
 }
 
+func (c Cache) executeHook(ctx context.Context, p *Config, h *HookConfig, hookType hook.TriggerEnum, hookContext common.HookContext) (*common.PluginOutput, error) {
+	newCtx := session.AddVisitedPluginHook(ctx, p.id, hookType)
+	serverConnection := c.makeServerConnection(newCtx)
+
+	pluginInput := buildPluginInput(p, &h.OperationConfig, serverConnection, nil)
+	addHookContext(pluginInput.Args, hookContext)
+
+	pt := pluginTask{
+		plugin:       p,
+		operation:    &h.OperationConfig,
+		input:        pluginInput,
+		gqlHandler:   c.gqlHandler,
+		serverConfig: c.config,
+	}
+
+	task := pt.createTask()
+	if err := task.Start(); err != nil {
+		return nil, err
+	}
+
+	if err := waitForTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	return task.GetResult(), nil
+}
+
+// ErrPreHookTimeout is returned when the pre-hooks for an operation do not
+// complete within the configured timeout.
+var ErrPreHookTimeout = errors.New("pre-hook timed out")
+
+// PreHookRejectedError is returned when a pre-hook rejects an operation.
+type PreHookRejectedError struct {
+	PluginName string
+	HookType   hook.TriggerEnum
+	Message    string
+}
+
+func (e *PreHookRejectedError) Error() string {
+	return fmt.Sprintf("%s rejected by plugin %s: %s", e.HookType, e.PluginName, e.Message)
+}
+
+// ExecutePreHooks synchronously executes the pre-hooks of the given type.
+// Returns the replacement input for the operation, or nil if no hook replaced
+// the input.
+//
+// Hooks are executed in turn, each receiving the input as modified by the
+// previous hook. A hook may replace the input by returning a PreHookOutput.
+// If a hook returns an error, then the operation is rejected and a
+// PreHookRejectedError is returned.
+//
+// All hooks for the operation must complete within the configured pre-hook
+// timeout, otherwise ErrPreHookTimeout is returned.
+func (c Cache) ExecutePreHooks(ctx context.Context, id int, hookType hook.TriggerEnum, input map[string]interface{}) (map[string]interface{}, error) {
+	if timeout := c.config.GetPluginsPreHookTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	visitedPluginHookCounts := getVisitedPluginHookCounts(ctx)
+	replaced := false
+
+	for _, p := range c.enabledPlugins() {
+		hooks := p.getHooks(hookType)
+		if len(hooks) > 0 && visitedPluginHookCounts.For(p.id, hookType) >= maxCyclicLoopDepth {
+			logger.Debugf("cyclic loop detected: plugin ID '%s' hook %s, not re-triggering", p.id, hookType)
+			continue
+		}
+
+		for _, h := range hooks {
+			output, err := c.executeHook(ctx, &p, h, hookType, common.HookContext{
+				ID:          id,
+				Type:        hookType.String(),
+				Input:       input,
+				InputFields: getInputFields(input),
+			})
+
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, fmt.Errorf("%s [%s]: %w", hookType.String(), p.Name, ErrPreHookTimeout)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s [%s]: %w", hookType.String(), p.Name, err)
+			}
+
+			if output == nil {
+				logger.Debugf("%s [%s]: returned no result", hookType.String(), p.Name)
+				continue
+			}
+
+			if output.Error != nil {
+				return nil, &PreHookRejectedError{
+					PluginName: p.Name,
+					HookType:   hookType,
+					Message:    *output.Error,
+				}
+			}
+
+			if newInput := decodePreHookOutput(output.Output); newInput != nil {
+				logger.Debugf("%s [%s]: replaced input", hookType.String(), p.Name)
+				input = newInput
+				replaced = true
+			}
+		}
+	}
+
+	if !replaced {
+		return nil, nil
+	}
+
+	return input, nil
+}
+
+func getInputFields(input map[string]interface{}) []string {
+	var ret []string
+	for k := range input {
+		ret = append(ret, k)
+	}
+
+	return ret
+}
+
+// decodePreHookOutput returns the replacement input from the output of a
+// pre-hook task, or nil if the output does not contain one.
+func decodePreHookOutput(output interface{}) map[string]interface{} {
+	if output == nil {
+		return nil
+	}
+
+	// round-trip through JSON, since the output structure depends on the
+	// plugin interface
+	data, err := json.Marshal(output)
+	if err != nil {
+		return nil
+	}
+
+	var ret common.PreHookOutput
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil
+	}
+
+	return ret.Input
+}
+
 type visitedPluginHookCount struct {
 	session.VisitedPluginHook
 	Count int
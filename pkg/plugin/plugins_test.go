@@ -0,0 +1,176 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/plugin/hook"
+	"github.com/stashapp/stash/pkg/session"
+)
+
+type testServerConfig struct {
+	disabledPlugins []string
+	preHookTimeout  time.Duration
+}
+
+func (c *testServerConfig) GetHost() string                         { return "localhost" }
+func (c *testServerConfig) GetPort() int                            { return 9999 }
+func (c *testServerConfig) GetConfigPathAbs() string                { return "" }
+func (c *testServerConfig) HasTLSConfig() bool                      { return false }
+func (c *testServerConfig) GetPluginsPath() string                  { return "" }
+func (c *testServerConfig) GetDisabledPlugins() []string            { return c.disabledPlugins }
+func (c *testServerConfig) GetPythonPath() string                   { return "" }
+func (c *testServerConfig) GetPluginsPreHookTimeout() time.Duration { return c.preHookTimeout }
+
+type testSessionConfig struct {
+	session.SessionConfig
+}
+
+func (c *testSessionConfig) GetSessionStoreKey() []byte { return []byte("test-session-store-key") }
+func (c *testSessionConfig) GetMaxSessionAge() int      { return 60 }
+
+// appendTitleScript replaces the title of the hook input with the title
+// suffixed by the plugin id, rejects the title "reject", does not replace the
+// title "keep" and never completes for the title "timeout".
+const appendTitleScript = `
+function main() {
+	var hookInput = input.Args.hookContext.input;
+	var title = hookInput.title;
+
+	if (title === "reject") {
+		return { Error: "title not allowed" };
+	}
+	if (title === "keep") {
+		return {};
+	}
+	if (title === "timeout") {
+		while (true) {}
+	}
+
+	return { Output: { input: { id: hookInput.id, title: title + "-" + input.Args.suffix } } };
+}
+
+main();
+`
+
+func writeTestPlugin(t *testing.T, dir string, id string, triggeredBy []hook.TriggerEnum) *Config {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, id+".js"), []byte(appendTitleScript), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	yml := "name: " + id + "\ninterface: js\nexec:\n  - " + id + ".js\nhooks:\n  - name: append title\n    defaultArgs:\n      suffix: " + id + "\n    triggeredBy:\n"
+	for _, tt := range triggeredBy {
+		yml += "      - " + tt.String() + "\n"
+	}
+
+	path := filepath.Join(dir, id+".yml")
+	if err := os.WriteFile(path, []byte(yml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ret, err := loadPluginFromYAMLFile(path)
+	if err != nil {
+		t.Fatalf("loading plugin %s: %v", id, err)
+	}
+
+	return ret
+}
+
+func TestExecutePreHooks(t *testing.T) {
+	dir := t.TempDir()
+
+	a := writeTestPlugin(t, dir, "a", []hook.TriggerEnum{hook.SceneUpdatePre, hook.SceneDestroyPre})
+	b := writeTestPlugin(t, dir, "b", []hook.TriggerEnum{hook.SceneUpdatePre})
+
+	sessionStore := session.NewStore(&testSessionConfig{}, session.Repository{})
+
+	testCases := []struct {
+		name            string
+		hookType        hook.TriggerEnum
+		title           string
+		disabledPlugins []string
+		preHookTimeout  time.Duration
+		want            interface{}
+		wantErr         error
+	}{
+		{"replaced by each hook in turn", hook.SceneUpdatePre, "title", nil, 0, "title-a-b", nil},
+		{"disabled plugin", hook.SceneUpdatePre, "title", []string{"a"}, 0, "title-b", nil},
+		{"not replaced", hook.SceneDestroyPre, "keep", nil, 0, nil, nil},
+		{"no hooks", hook.TagUpdatePre, "title", nil, 0, nil, nil},
+		{"rejected", hook.SceneUpdatePre, "reject", nil, 0, nil, &PreHookRejectedError{}},
+		{"timeout", hook.SceneDestroyPre, "timeout", nil, 100 * time.Millisecond, nil, ErrPreHookTimeout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := Cache{
+				config: &testServerConfig{
+					disabledPlugins: tc.disabledPlugins,
+					preHookTimeout:  tc.preHookTimeout,
+				},
+				plugins:      []Config{*a, *b},
+				sessionStore: sessionStore,
+			}
+
+			input := map[string]interface{}{
+				"id":    "1",
+				"title": tc.title,
+			}
+
+			got, err := c.ExecutePreHooks(context.Background(), 1, tc.hookType, input)
+
+			var rejected *PreHookRejectedError
+			switch {
+			case tc.wantErr == nil:
+				if err != nil {
+					t.Fatalf("ExecutePreHooks() error = %v", err)
+				}
+			case errors.As(tc.wantErr, &rejected):
+				if !errors.As(err, &rejected) {
+					t.Fatalf("ExecutePreHooks() error = %v, want PreHookRejectedError", err)
+				}
+				if rejected.PluginName != "a" || rejected.Message != "title not allowed" {
+					t.Errorf("ExecutePreHooks() error = %v", rejected)
+				}
+				return
+			default:
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("ExecutePreHooks() error = %v, want %v", err, tc.wantErr)
+				}
+				return
+			}
+
+			if tc.want == nil {
+				if got != nil {
+					t.Errorf("ExecutePreHooks() = %v, want nil", got)
+				}
+				return
+			}
+
+			if got["title"] != tc.want {
+				t.Errorf("ExecutePreHooks() title = %v, want %v", got["title"], tc.want)
+			}
+			if got["id"] != "1" {
+				t.Errorf("ExecutePreHooks() id = %v, want 1", got["id"])
+			}
+
+			// the original input is not modified
+			if input["title"] != tc.title {
+				t.Errorf("ExecutePreHooks() modified input title to %v", input["title"])
+			}
+		})
+	}
+}
+
+func TestRegisterPostHooksNilCache(t *testing.T) {
+	var c *Cache
+
+	// must not panic when plugins are not configured
+	c.RegisterPostHooks(context.Background(), 1, hook.FileCreatePost, nil, nil)
+}
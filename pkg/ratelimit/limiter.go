@@ -0,0 +1,108 @@
+// Package ratelimit provides rate limiting, concurrency limiting and retries
+// for outgoing HTTP requests.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter limits the rate of requests and the number of requests in flight.
+// A nil Limiter does not limit requests.
+type Limiter struct {
+	interval time.Duration
+	slots    chan struct{}
+
+	mutex sync.Mutex
+	next  time.Time
+}
+
+// NewLimiter returns a Limiter allowing requestsPerMinute requests per
+// minute, with at most maxConcurrent requests in flight. Zero or negative
+// values disable the corresponding limit. Returns nil if both limits are
+// disabled.
+func NewLimiter(requestsPerMinute int, maxConcurrent int) *Limiter {
+	if requestsPerMinute <= 0 && maxConcurrent <= 0 {
+		return nil
+	}
+
+	ret := &Limiter{}
+	if requestsPerMinute > 0 {
+		ret.interval = time.Minute / time.Duration(requestsPerMinute)
+	}
+	if maxConcurrent > 0 {
+		ret.slots = make(chan struct{}, maxConcurrent)
+	}
+
+	return ret
+}
+
+// Wait blocks until a request may be made, or the context is cancelled. On
+// success, the returned function must be called once the request is
+// complete to release its slot.
+func (l *Limiter) Wait(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() {
+			if l.slots != nil {
+				<-l.slots
+			}
+		})
+	}
+
+	if err := sleep(ctx, l.reserve()); err != nil {
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// reserve reserves the next available time to make a request, and returns
+// the time until then.
+func (l *Limiter) reserve() time.Duration {
+	if l.interval <= 0 {
+		return 0
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	start := l.next
+	if start.Before(now) {
+		start = now
+	}
+	l.next = start.Add(l.interval)
+
+	return start.Sub(now)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
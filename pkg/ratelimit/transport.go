@@ -0,0 +1,264 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	// initialBackoff is the delay before the first retry of a request. The
+	// delay doubles with each subsequent retry.
+	initialBackoff = time.Second
+	maxBackoff     = time.Minute
+
+	// maxRetryAfter is the longest Retry-After delay that will be waited for.
+	// Responses asking for a longer delay are returned without retrying.
+	maxRetryAfter = 5 * time.Minute
+
+	// maxErrorBodySize is the maximum size of the body of an error response
+	// that is read before the limiters of the request are released.
+	maxErrorBodySize = 1024 * 1024
+)
+
+// Transport is a http.RoundTripper that limits the requests made using the
+// Base transport, and retries requests that fail with a 429 or 5xx status.
+// Only requests that are safe to repeat are retried - see MarkIdempotent.
+type Transport struct {
+	// Base is the transport used to make requests. If nil,
+	// http.DefaultTransport is used.
+	Base http.RoundTripper
+
+	// Limiter limits all requests made using the transport.
+	Limiter *Limiter
+
+	// DomainLimiters limits requests to specific domains, keyed by lower
+	// case domain name. Requests to subdomains use the limiter of the parent
+	// domain if the subdomain has no limiter of its own.
+	DomainLimiters map[string]*Limiter
+
+	// MaxRetries is the maximum number of times a failed request is retried.
+	MaxRetries int
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	r := req
+
+	for attempt := 0; ; attempt++ {
+		resp, err := t.roundTrip(r)
+		if err != nil || attempt >= t.MaxRetries || !isIdempotent(req) || !shouldRetry(resp.StatusCode) {
+			return resp, err
+		}
+
+		delay, ok := retryDelay(resp, attempt)
+		if !ok {
+			return resp, nil
+		}
+
+		// the request body must be read again for the retry
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, nil
+		}
+
+		r = req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, nil
+			}
+			r.Body = body
+		}
+
+		// drain the body so that the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		logger.Debugf("[ratelimit] %s returned %d, retrying in %v", req.URL.Redacted(), resp.StatusCode, delay)
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Wait blocks until a request to host may be made, for use by requests that
+// are not made using the transport. On success, the returned function must be
+// called once the request is complete.
+func (t *Transport) Wait(ctx context.Context, host string) (func(), error) {
+	release, err := t.Limiter.Wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseDomain, err := t.domainLimiter(host).Wait(ctx)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	return func() {
+		releaseDomain()
+		release()
+	}, nil
+}
+
+// roundTrip waits for the limiters of the request, then makes the request.
+// The limiters are released when the response body is closed. Error
+// responses are read immediately and their limiters released, since callers
+// often return without reading or closing them.
+func (t *Transport) roundTrip(req *http.Request) (*http.Response, error) {
+	releaseAll, err := t.Wait(req.Context(), req.URL.Hostname())
+	if err != nil {
+		return nil, err
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		releaseAll()
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		resp.Body.Close()
+		releaseAll()
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+
+	resp.Body = &releaseBody{ReadCloser: resp.Body, release: releaseAll}
+	return resp, nil
+}
+
+func (t *Transport) domainLimiter(host string) *Limiter {
+	host = strings.ToLower(host)
+	for host != "" {
+		if l, ok := t.DomainLimiters[host]; ok {
+			return l
+		}
+
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			break
+		}
+		host = parent
+	}
+
+	return nil
+}
+
+// idempotencyKeyHeader marks a request as idempotent. As with http.Transport,
+// the header is not sent if it has no values.
+const idempotencyKeyHeader = "X-Idempotency-Key"
+
+// MarkIdempotent marks req as safe to retry, for requests such as queries that
+// use a method that is not idempotent.
+func MarkIdempotent(req *http.Request) {
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+
+	if _, ok := req.Header[idempotencyKeyHeader]; !ok {
+		req.Header[idempotencyKeyHeader] = nil
+	}
+}
+
+// isIdempotent returns true if req may be sent more than once. Requests using
+// methods that are not idempotent, such as POST, may have been acted on by the
+// server even if they failed, so are only idempotent if marked as such using
+// an Idempotency-Key or X-Idempotency-Key header.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	if _, ok := req.Header["Idempotency-Key"]; ok {
+		return true
+	}
+	_, ok := req.Header[idempotencyKeyHeader]
+	return ok
+}
+
+func shouldRetry(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// retryDelay returns the time to wait before retrying the request. The
+// Retry-After header is used if present, otherwise the delay increases
+// exponentially with each attempt. Returns false if the server asked for a
+// delay longer than maxRetryAfter.
+func retryDelay(resp *http.Response, attempt int) (time.Duration, bool) {
+	if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+		if d > maxRetryAfter {
+			return 0, false
+		}
+		return d, true
+	}
+
+	d := initialBackoff << attempt
+	if d > maxBackoff || d <= 0 {
+		d = maxBackoff
+	}
+
+	return d, true
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or a HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(secs)*time.Second, 0), true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+
+	return 0, false
+}
+
+// releaseBody releases the limiters of a request when the response body is
+// closed.
+type releaseBody struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (b *releaseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
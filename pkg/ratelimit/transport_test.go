@@ -0,0 +1,245 @@
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTransportRetry(t *testing.T) {
+	var requests int32
+	var lastBody string
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lastBody = string(body)
+
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	client := &http.Client{
+		Transport: &Transport{
+			Limiter:    NewLimiter(0, 1),
+			MaxRetries: 3,
+		},
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL, bytes.NewBufferString("query"))
+	MarkIdempotent(req)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+	if lastBody != "query" {
+		t.Errorf("body of retried request = %q, want %q", lastBody, "query")
+	}
+}
+
+func TestTransportNotIdempotent(t *testing.T) {
+	var requests int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		if _, ok := r.Header[idempotencyKeyHeader]; ok {
+			t.Errorf("idempotency header was sent")
+		}
+
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{
+		Transport: &Transport{
+			MaxRetries: 3,
+		},
+	}
+
+	tests := []struct {
+		name       string
+		idempotent bool
+		want       int32
+	}{
+		{"not marked", false, 1},
+		{"marked", true, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&requests, 0)
+
+			req, _ := http.NewRequest(http.MethodPost, ts.URL, bytes.NewBufferString("mutation"))
+			if tt.idempotent {
+				MarkIdempotent(req)
+			}
+
+			resp, err := client.Do(req)
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+			resp.Body.Close()
+
+			if got := atomic.LoadInt32(&requests); got != tt.want {
+				t.Errorf("requests = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTransportMaxRetries(t *testing.T) {
+	var requests int32
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{
+		Transport: &Transport{
+			MaxRetries: 1,
+		},
+	}
+
+	resp, err := client.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, want 2", requests)
+	}
+}
+
+func TestTransportErrorResponseReleased(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	client := &http.Client{
+		Transport: &Transport{
+			Limiter:    NewLimiter(0, 1),
+			MaxRetries: 1,
+		},
+	}
+
+	// the body of the error response is never read or closed
+	resp, err := client.Get(ts.URL + "/missing")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("request after error response did not get a slot: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != "ok" {
+		t.Errorf("body = %q, want %q", body, "ok")
+	}
+}
+
+func TestDomainLimiter(t *testing.T) {
+	example := NewLimiter(60, 0)
+	sub := NewLimiter(0, 1)
+
+	tr := &Transport{
+		DomainLimiters: map[string]*Limiter{
+			"example.com":     example,
+			"api.example.com": sub,
+		},
+	}
+
+	tests := []struct {
+		host string
+		want *Limiter
+	}{
+		{"example.com", example},
+		{"www.Example.com", example},
+		{"api.example.com", sub},
+		{"v1.api.example.com", sub},
+		{"example.org", nil},
+		{"localhost", nil},
+	}
+
+	for _, tt := range tests {
+		if got := tr.domainLimiter(tt.host); got != tt.want {
+			t.Errorf("domainLimiter(%q) = %p, want %p", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	newResp := func(retryAfter string) *http.Response {
+		ret := &http.Response{Header: http.Header{}}
+		if retryAfter != "" {
+			ret.Header.Set("Retry-After", retryAfter)
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name       string
+		retryAfter string
+		attempt    int
+		want       time.Duration
+		wantOK     bool
+	}{
+		{"first attempt", "", 0, initialBackoff, true},
+		{"exponential", "", 3, 8 * initialBackoff, true},
+		{"capped", "", 20, maxBackoff, true},
+		{"retry after seconds", "30", 0, 30 * time.Second, true},
+		{"retry after too long", "3600", 0, 0, false},
+		{"invalid retry after", "soon", 1, 2 * initialBackoff, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := retryDelay(newResp(tt.retryAfter), tt.attempt)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("retryDelay() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -74,6 +74,10 @@ func ToBasicJSON(ctx context.Context, reader ExportGetter, scene *models.Scene)
 
 	newSceneJSON.StashIDs = ret
 
+	if scene.CustomFields.Loaded() {
+		newSceneJSON.CustomFields = scene.CustomFields.List()
+	}
+
 	dates, err := reader.GetViewDates(ctx, scene.ID)
 	if err != nil {
 		return nil, fmt.Errorf("error getting view dates: %v", err)
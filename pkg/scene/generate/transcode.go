@@ -12,6 +12,49 @@ import (
 type TranscodeOptions struct {
 	Width  int
 	Height int
+
+	// VideoCodec is the software codec used to encode the video.
+	// Defaults to libx264.
+	VideoCodec ffmpeg.VideoCodec
+}
+
+// videoArgs returns the video codec and codec arguments for the transcode.
+func (o TranscodeOptions) videoArgs() (ffmpeg.VideoCodec, ffmpeg.Args) {
+	var videoArgs ffmpeg.Args
+	if o.Width != 0 && o.Height != 0 {
+		var videoFilter ffmpeg.VideoFilter
+		videoFilter = videoFilter.ScaleDimensions(o.Width, o.Height)
+		videoArgs = videoArgs.VideoFilter(videoFilter)
+	}
+
+	switch o.VideoCodec {
+	case ffmpeg.VideoCodecLibX265:
+		videoArgs = append(videoArgs,
+			"-pix_fmt", "yuv420p",
+			"-preset", "fast",
+			"-crf", "26",
+			// required for playback in Safari
+			"-tag:v", "hvc1",
+		)
+		return ffmpeg.VideoCodecLibX265, videoArgs
+	case ffmpeg.VideoCodecSVTAV1:
+		videoArgs = append(videoArgs,
+			"-pix_fmt", "yuv420p",
+			"-preset", "8",
+			"-crf", "32",
+		)
+		return ffmpeg.VideoCodecSVTAV1, videoArgs
+	}
+
+	videoArgs = append(videoArgs,
+		"-pix_fmt", "yuv420p",
+		"-profile:v", "high",
+		"-level", "4.2",
+		"-preset", "superfast",
+		"-crf", "23",
+	)
+
+	return ffmpeg.VideoCodecLibX264, videoArgs
 }
 
 func (g Generator) Transcode(ctx context.Context, input string, hash string, options TranscodeOptions) error {
@@ -66,24 +109,11 @@ func (g Generator) makeTranscode(lockCtx *fsutil.LockContext, hash string, gener
 
 func (g Generator) transcode(input string, options TranscodeOptions) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
-		var videoArgs ffmpeg.Args
-		if options.Width != 0 && options.Height != 0 {
-			var videoFilter ffmpeg.VideoFilter
-			videoFilter = videoFilter.ScaleDimensions(options.Width, options.Height)
-			videoArgs = videoArgs.VideoFilter(videoFilter)
-		}
-
-		videoArgs = append(videoArgs,
-			"-pix_fmt", "yuv420p",
-			"-profile:v", "high",
-			"-level", "4.2",
-			"-preset", "superfast",
-			"-crf", "23",
-		)
+		videoCodec, videoArgs := options.videoArgs()
 
 		args := transcoder.Transcode(input, transcoder.TranscodeOptions{
 			OutputPath: tmpFn,
-			VideoCodec: ffmpeg.VideoCodecLibX264,
+			VideoCodec: videoCodec,
 			VideoArgs:  videoArgs,
 			AudioCodec: ffmpeg.AudioCodecAAC,
 
@@ -97,27 +127,14 @@ func (g Generator) transcode(input string, options TranscodeOptions) generateFn
 
 func (g Generator) transcodeVideo(input string, options TranscodeOptions) generateFn {
 	return func(lockCtx *fsutil.LockContext, tmpFn string) error {
-		var videoArgs ffmpeg.Args
-		if options.Width != 0 && options.Height != 0 {
-			var videoFilter ffmpeg.VideoFilter
-			videoFilter = videoFilter.ScaleDimensions(options.Width, options.Height)
-			videoArgs = videoArgs.VideoFilter(videoFilter)
-		}
-
-		videoArgs = append(videoArgs,
-			"-pix_fmt", "yuv420p",
-			"-profile:v", "high",
-			"-level", "4.2",
-			"-preset", "superfast",
-			"-crf", "23",
-		)
+		videoCodec, videoArgs := options.videoArgs()
 
 		var audioArgs ffmpeg.Args
 		audioArgs = audioArgs.SkipAudio()
 
 		args := transcoder.Transcode(input, transcoder.TranscodeOptions{
 			OutputPath: tmpFn,
-			VideoCodec: ffmpeg.VideoCodecLibX264,
+			VideoCodec: videoCodec,
 			VideoArgs:  videoArgs,
 			AudioArgs:  audioArgs,
 
@@ -91,6 +91,7 @@ func (i *Importer) sceneJSONToScene(sceneJSON jsonschema.Scene) models.Scene {
 		GalleryIDs:   models.NewRelatedIDs([]int{}),
 		Groups:       models.NewRelatedGroups([]models.GroupsScenes{}),
 		StashIDs:     models.NewRelatedStashIDs(sceneJSON.StashIDs),
+		CustomFields: models.NewRelatedCustomFields(sceneJSON.CustomFields),
 	}
 
 	if len(sceneJSON.URLs) > 0 {
@@ -0,0 +1,590 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"golang.org/x/net/html/charset"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	apiContentTypeJSON = "application/json"
+	apiContentTypeForm = "application/x-www-form-urlencoded"
+
+	defaultAPIPageParam = "page"
+	defaultAPIMaxPages  = 5
+)
+
+// apiRequestConfig describes the request made by an api scraper. The url,
+// headers, body and graphql variables may contain placeholders such as
+// {title} and {url}, which are replaced with the values of the object being
+// scraped.
+type apiRequestConfig struct {
+	// HTTP method. Defaults to POST if the request has a body, otherwise GET.
+	Method string `yaml:"method"`
+	// URL of the request. Defaults to the queryURL of the scraper, or the
+	// scraped URL for URL scrapes.
+	URL     string    `yaml:"url"`
+	Headers []*header `yaml:"headers"`
+	// Content type of the body. Defaults to application/json.
+	ContentType string `yaml:"contentType"`
+	// Template of the request body. Placeholder values are escaped for the
+	// content type.
+	Body string `yaml:"body"`
+	// GraphQL query, sent as a json body. Cannot be used with body.
+	GraphQL    *apiGraphQLConfig    `yaml:"graphql"`
+	Pagination *apiPaginationConfig `yaml:"pagination"`
+}
+
+func (c apiRequestConfig) validate() error {
+	switch strings.ToUpper(c.Method) {
+	case "", http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch:
+	default:
+		return fmt.Errorf("request: unsupported method %s", c.Method)
+	}
+
+	if c.GraphQL != nil {
+		if c.Body != "" {
+			return errors.New("request: body and graphql cannot both be set")
+		}
+		if strings.TrimSpace(c.GraphQL.Query) == "" {
+			return errors.New("request: graphql query must not be empty")
+		}
+	}
+
+	if c.Pagination != nil && c.Pagination.MaxPages < 0 {
+		return errors.New("request: pagination maxPages must not be negative")
+	}
+
+	return nil
+}
+
+type apiGraphQLConfig struct {
+	Query     string                 `yaml:"query"`
+	Variables map[string]interface{} `yaml:"variables"`
+}
+
+// apiPaginationConfig configures the requesting of multiple pages of search
+// results. Pages are requested until a page has no results, or the maximum
+// number of pages is reached.
+type apiPaginationConfig struct {
+	// Name of the placeholder set to the current page. Defaults to page.
+	Param string `yaml:"param"`
+	// Value of the first page. Defaults to 1.
+	Start *int `yaml:"start"`
+	// Amount the page is increased by for each request. Defaults to 1. Use
+	// the page size for offset based pagination.
+	Step int `yaml:"step"`
+	// Selector of the next page in the response, such as a cursor. If set,
+	// the page is set to the selected value rather than increased by step,
+	// and pagination stops when the value is empty.
+	NextPage string `yaml:"nextPage"`
+	// Maximum number of pages to request. Defaults to 5.
+	MaxPages int `yaml:"maxPages"`
+}
+
+func (c apiPaginationConfig) param() string {
+	if c.Param == "" {
+		return defaultAPIPageParam
+	}
+	return c.Param
+}
+
+func (c apiPaginationConfig) start() int {
+	if c.Start == nil {
+		return 1
+	}
+	return *c.Start
+}
+
+func (c apiPaginationConfig) step() int {
+	if c.Step == 0 {
+		return 1
+	}
+	return c.Step
+}
+
+func (c apiPaginationConfig) maxPages() int {
+	if c.MaxPages == 0 {
+		return defaultAPIMaxPages
+	}
+	return c.MaxPages
+}
+
+// apiRequest is a request built from an apiRequestConfig.
+type apiRequest struct {
+	method      string
+	url         string
+	contentType string
+	headers     []*header
+	body        []byte
+}
+
+// cacheKey returns the key of the request in the response cache. The
+// method and body are included for requests that are not simple GETs.
+func (r apiRequest) cacheKey() string {
+	if r.method == http.MethodGet && len(r.body) == 0 {
+		return r.url
+	}
+
+	h := sha256.Sum256(r.body)
+	return r.method + " " + r.url + " " + hex.EncodeToString(h[:8])
+}
+
+type apiScraper struct {
+	scraper      scraperTypeConfig
+	config       config
+	globalConfig GlobalConfig
+	client       *http.Client
+}
+
+func newAPIScraper(scraper scraperTypeConfig, client *http.Client, config config, globalConfig GlobalConfig) *apiScraper {
+	return &apiScraper{
+		scraper:      scraper,
+		config:       config,
+		client:       client,
+		globalConfig: globalConfig,
+	}
+}
+
+// getMappedScraper returns the json scraper used to map the responses.
+func (s *apiScraper) getMappedScraper() (*mappedScraper, error) {
+	ret := s.config.JsonScrapers[s.scraper.Scraper]
+	if ret == nil {
+		return nil, fmt.Errorf("%w: json scraper with name %s", ErrNotFound, s.scraper.Scraper)
+	}
+
+	return ret, nil
+}
+
+func (s *apiScraper) requestConfig() apiRequestConfig {
+	if s.scraper.Request == nil {
+		return apiRequestConfig{}
+	}
+	return *s.scraper.Request
+}
+
+// buildRequest builds the request using the placeholder values in params.
+func (s *apiScraper) buildRequest(params queryURLParameters) (*apiRequest, error) {
+	c := s.requestConfig()
+
+	urlTemplate := c.URL
+	if urlTemplate == "" {
+		urlTemplate = s.scraper.QueryURL
+	}
+	if urlTemplate == "" {
+		if _, ok := params["url"]; !ok {
+			return nil, errors.New("api scraper request has no url")
+		}
+		urlTemplate = "{url}"
+	}
+
+	ret := &apiRequest{
+		url:         params.expand(urlTemplate, escapeURLParameter),
+		contentType: c.ContentType,
+	}
+
+	if ret.contentType == "" {
+		ret.contentType = apiContentTypeJSON
+	}
+
+	for _, h := range c.Headers {
+		ret.headers = append(ret.headers, &header{
+			Key:   h.Key,
+			Value: params.expand(h.Value, escapeHeaderParameter),
+		})
+	}
+
+	switch {
+	case c.GraphQL != nil:
+		body, err := json.Marshal(map[string]interface{}{
+			"query":     c.GraphQL.Query,
+			"variables": expandAPIVariable(c.GraphQL.Variables, params, s.pageParam()),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("encoding graphql request: %w", err)
+		}
+
+		ret.body = body
+		ret.contentType = apiContentTypeJSON
+	case c.Body != "":
+		ret.body = []byte(params.expand(c.Body, bodyParameterEscaper(ret.contentType)))
+	}
+
+	ret.method = strings.ToUpper(c.Method)
+	if ret.method == "" {
+		ret.method = http.MethodGet
+		if len(ret.body) > 0 {
+			ret.method = http.MethodPost
+		}
+	}
+
+	return ret, nil
+}
+
+func (s *apiScraper) pageParam() string {
+	if p := s.requestConfig().Pagination; p != nil {
+		return p.param()
+	}
+	return ""
+}
+
+// load makes the request built from params and returns the json response.
+func (s *apiScraper) load(ctx context.Context, params queryURLParameters) (string, error) {
+	r, err := s.buildRequest(params)
+	if err != nil {
+		return "", err
+	}
+
+	body, contentType, err := loadCached(r.cacheKey(), s.config, s.globalConfig, func() ([]byte, string, error) {
+		var reqBody io.Reader
+		if len(r.body) > 0 {
+			reqBody = bytes.NewReader(r.body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, r.method, r.url, reqBody)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if len(r.body) > 0 {
+			req.Header.Set("Content-Type", r.contentType)
+		}
+		req.Header.Set("Accept", apiContentTypeJSON)
+
+		return doRequest(s.client, req, s.config, s.globalConfig, r.headers)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	logger.Infof("loadAPI (%s %s)\n", r.method, r.url)
+
+	reader, err := charset.NewReader(bytes.NewReader(body), contentType)
+	if err != nil {
+		return "", err
+	}
+	doc, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	docStr := string(doc)
+	if !gjson.Valid(docStr) {
+		return "", errors.New("not valid json")
+	}
+
+	if s.config.DebugOptions != nil && s.config.DebugOptions.PrintHTML {
+		logger.Infof("loadAPI (%s %s) response: \n%s", r.method, r.url, docStr)
+	}
+
+	if s.requestConfig().GraphQL != nil {
+		if err := graphQLResponseError(docStr); err != nil {
+			return "", err
+		}
+	}
+
+	return docStr, nil
+}
+
+// graphQLResponseError returns an error if the response contains errors and
+// no data. Errors with partial data are logged.
+func graphQLResponseError(doc string) error {
+	errs := gjson.Get(doc, "errors.#.message")
+	if !errs.Exists() || len(errs.Array()) == 0 {
+		return nil
+	}
+
+	var messages []string
+	for _, e := range errs.Array() {
+		messages = append(messages, e.String())
+	}
+	msg := strings.Join(messages, "; ")
+
+	data := gjson.Get(doc, "data")
+	if !data.Exists() || data.Type == gjson.Null {
+		return fmt.Errorf("graphql error: %s", msg)
+	}
+
+	logger.Warnf("[scraper] graphql response has errors: %s", msg)
+	return nil
+}
+
+func (s *apiScraper) getQuery(doc string) *jsonQuery {
+	// sub-scrapers use plain GET requests
+	js := newJsonScraper(s.scraper, s.client, s.config, s.globalConfig)
+	return js.getJsonQuery(doc)
+}
+
+// paginate calls fn with the query of each page of results. fn returns
+// false if the page had no results, which stops the pagination. Only a
+// single request is made if the request has no pagination.
+func (s *apiScraper) paginate(ctx context.Context, params queryURLParameters, fn func(q *jsonQuery) (bool, error)) error {
+	p := s.requestConfig().Pagination
+	if p == nil {
+		doc, err := s.load(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		_, err = fn(s.getQuery(doc))
+		return err
+	}
+
+	page := strconv.Itoa(p.start())
+	for i := 0; i < p.maxPages(); i++ {
+		params[p.param()] = page
+
+		doc, err := s.load(ctx, params)
+		if err != nil {
+			return err
+		}
+
+		more, err := fn(s.getQuery(doc))
+		if err != nil || !more {
+			return err
+		}
+
+		if p.NextPage != "" {
+			next := gjson.Get(doc, p.NextPage)
+			if next.String() == "" {
+				return nil
+			}
+			page = next.String()
+		} else {
+			n, _ := strconv.Atoi(page)
+			page = strconv.Itoa(n + p.step())
+		}
+	}
+
+	return nil
+}
+
+func (s *apiScraper) applyReplacements(params queryURLParameters) queryURLParameters {
+	if s.scraper.QueryURLReplacements != nil {
+		params.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	return params
+}
+
+func (s *apiScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeContentType) (ScrapedContent, error) {
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.load(ctx, s.applyReplacements(queryURLParameterFromURL(url)))
+	if err != nil {
+		return nil, err
+	}
+
+	return scraper.scrapeContent(ctx, s.getQuery(doc), ty)
+}
+
+func (s *apiScraper) scrapeByName(ctx context.Context, name string, ty ScrapeContentType) ([]ScrapedContent, error) {
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	params := queryURLParameters{"name": name}
+
+	var ret []ScrapedContent
+	err = s.paginate(ctx, params, func(q *jsonQuery) (bool, error) {
+		q.setType(SearchQuery)
+
+		content, err := scraper.scrapeContents(ctx, q, ty)
+		if err != nil {
+			return false, err
+		}
+
+		ret = append(ret, content...)
+		return len(content) > 0, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *apiScraper) scrapeSceneByScene(ctx context.Context, scene *models.Scene) (*ScrapedScene, error) {
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.load(ctx, s.applyReplacements(queryURLParametersFromScene(scene)))
+	if err != nil {
+		return nil, err
+	}
+
+	return scraper.scrapeScene(ctx, s.getQuery(doc))
+}
+
+func (s *apiScraper) scrapeByFragment(ctx context.Context, input Input) (ScrapedContent, error) {
+	switch {
+	case input.Gallery != nil:
+		return nil, fmt.Errorf("%w: cannot use an api scraper as a gallery fragment scraper", ErrNotSupported)
+	case input.Performer != nil:
+		return nil, fmt.Errorf("%w: cannot use an api scraper as a performer fragment scraper", ErrNotSupported)
+	case input.Scene == nil && input.Image == nil:
+		return nil, fmt.Errorf("%w: scene input is nil", ErrNotSupported)
+	}
+
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Image != nil {
+		doc, err := s.load(ctx, s.applyReplacements(queryURLParametersFromScrapedImage(*input.Image)))
+		if err != nil {
+			return nil, err
+		}
+
+		return scraper.scrapeContent(ctx, s.getQuery(doc), ScrapeContentTypeImage)
+	}
+
+	doc, err := s.load(ctx, s.applyReplacements(queryURLParametersFromScrapedScene(*input.Scene)))
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := scraper.scrapeScene(ctx, s.getQuery(doc))
+	if err != nil || ret == nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *apiScraper) scrapeGalleryByGallery(ctx context.Context, gallery *models.Gallery) (*ScrapedGallery, error) {
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.load(ctx, s.applyReplacements(queryURLParametersFromGallery(gallery)))
+	if err != nil {
+		return nil, err
+	}
+
+	return scraper.scrapeGallery(ctx, s.getQuery(doc))
+}
+
+func (s *apiScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	scraper, err := s.getMappedScraper()
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.load(ctx, s.applyReplacements(queryURLParametersFromImage(image)))
+	if err != nil {
+		return nil, err
+	}
+
+	return scraper.scrapeImage(ctx, s.getQuery(doc))
+}
+
+// expand replaces the placeholders in s with the parameter values, escaped
+// using escape. Values are not escaped if escape is nil. Placeholders are
+// replaced in a single pass, so placeholders in the values are not expanded.
+func (p queryURLParameters) expand(s string, escape func(key, value string) string) string {
+	oldnew := make([]string, 0, len(p)*2)
+	for k, v := range p {
+		if escape != nil {
+			v = escape(k, v)
+		}
+		oldnew = append(oldnew, "{"+k+"}", v)
+	}
+
+	return strings.NewReplacer(oldnew...).Replace(s)
+}
+
+// escapeHeaderParameter removes line breaks from values placed in request
+// headers, so that the values cannot add headers to the request.
+func escapeHeaderParameter(_, value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+// escapeURLParameter query escapes values placed in the request url. The url
+// parameter is not escaped, so that {url} can be used as the request url.
+func escapeURLParameter(key, value string) string {
+	if key == "url" {
+		return value
+	}
+	return url.QueryEscape(value)
+}
+
+func bodyParameterEscaper(contentType string) func(key, value string) string {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	switch strings.TrimSpace(strings.ToLower(mediaType)) {
+	case apiContentTypeJSON:
+		return func(_, value string) string {
+			// escape as the contents of a json string
+			var b strings.Builder
+			enc := json.NewEncoder(&b)
+			enc.SetEscapeHTML(false)
+			_ = enc.Encode(value)
+			ret := strings.TrimSpace(b.String())
+			return ret[1 : len(ret)-1]
+		}
+	case apiContentTypeForm:
+		return func(_, value string) string {
+			return url.QueryEscape(value)
+		}
+	}
+
+	return nil
+}
+
+// expandAPIVariable replaces the placeholders in the string values of a
+// graphql variable. Maps decoded from yaml are converted to have string
+// keys so that they can be encoded as json. A value consisting of only the
+// page placeholder is sent as a number if the page is numeric.
+func expandAPIVariable(v interface{}, params queryURLParameters, pageParam string) interface{} {
+	switch v := v.(type) {
+	case string:
+		if pageParam != "" && v == "{"+pageParam+"}" {
+			if n, err := strconv.Atoi(params[pageParam]); err == nil {
+				return n
+			}
+		}
+		return params.expand(v, nil)
+	case map[string]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k, vv := range v {
+			ret[k] = expandAPIVariable(vv, params, pageParam)
+		}
+		return ret
+	case map[interface{}]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for k, vv := range v {
+			ret[fmt.Sprint(k)] = expandAPIVariable(vv, params, pageParam)
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, len(v))
+		for i, vv := range v {
+			ret[i] = expandAPIVariable(vv, params, pageParam)
+		}
+		return ret
+	}
+
+	return v
+}
@@ -0,0 +1,237 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestAPIScraperGraphQLPagination(t *testing.T) {
+	type request struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+
+	var requests []request
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer key" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer key")
+		}
+
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		requests = append(requests, req)
+
+		switch req.Variables["page"] {
+		case float64(1):
+			fmt.Fprint(w, `{"data": {"search": [{"name": "A"}, {"name": "B"}]}}`)
+		case float64(2):
+			fmt.Fprint(w, `{"data": {"search": [{"name": "C"}]}}`)
+		default:
+			fmt.Fprint(w, `{"data": {"search": []}}`)
+		}
+	}))
+	defer ts.Close()
+
+	yamlStr := `name: Test
+performerByName:
+  action: scrapeAPI
+  scraper: performerSearch
+  request:
+    url: ` + ts.URL + `
+    headers:
+      - Key: Authorization
+        Value: Bearer key
+    graphql:
+      query: "query($q: String!, $page: Int!) { search(q: $q, page: $page) { name } }"
+      variables:
+        q: "{name}"
+        page: "{page}"
+    pagination:
+      maxPages: 5
+jsonScrapers:
+  performerSearch:
+    performer:
+      Name: data.search.#.name
+`
+
+	c := &config{}
+	if err := yaml.Unmarshal([]byte(yamlStr), &c); err != nil {
+		t.Fatalf("Error loading yaml: %v", err)
+	}
+	if err := c.validate(); err != nil {
+		t.Fatalf("Error validating config: %v", err)
+	}
+
+	s := newGroupScraper(*c, mockGlobalConfig{})
+	ns, ok := s.(nameScraper)
+	if !ok {
+		t.Fatal("couldn't convert scraper into name scraper")
+	}
+
+	content, err := ns.viaName(context.Background(), &http.Client{}, `Jane "JD" Doe`, ScrapeContentTypePerformer)
+	if err != nil {
+		t.Fatalf("Error scraping performers: %v", err)
+	}
+
+	var names []string
+	for _, sc := range content {
+		p, ok := sc.(*models.ScrapedPerformer)
+		if !ok {
+			t.Fatalf("unexpected content type %T", sc)
+		}
+		names = append(names, *p.Name)
+	}
+
+	if fmt.Sprint(names) != "[A B C]" {
+		t.Errorf("names = %v, want [A B C]", names)
+	}
+
+	// pagination stops at the first empty page
+	if len(requests) != 3 {
+		t.Errorf("requests = %d, want 3", len(requests))
+	}
+	if len(requests) > 0 && requests[0].Variables["q"] != `Jane "JD" Doe` {
+		t.Errorf("q = %v, want %q", requests[0].Variables["q"], `Jane "JD" Doe`)
+	}
+}
+
+func TestAPIScraperBuildRequest(t *testing.T) {
+	params := queryURLParameters{
+		"title": `a "b" & c`,
+		"url":   "https://example.com/scene/1?x=y",
+	}
+
+	tests := []struct {
+		name        string
+		config      *apiRequestConfig
+		queryURL    string
+		wantMethod  string
+		wantURL     string
+		wantBody    string
+		wantErr     bool
+		paramsNoURL bool
+	}{
+		{
+			name:       "default url",
+			wantMethod: http.MethodGet,
+			wantURL:    "https://example.com/scene/1?x=y",
+		},
+		{
+			name:       "query url",
+			queryURL:   "https://api.example.com/search?q={title}",
+			wantMethod: http.MethodGet,
+			wantURL:    "https://api.example.com/search?q=a+%22b%22+%26+c",
+		},
+		{
+			name: "json body",
+			config: &apiRequestConfig{
+				URL:  "https://api.example.com/search",
+				Body: `{"title": "{title}"}`,
+			},
+			wantMethod: http.MethodPost,
+			wantURL:    "https://api.example.com/search",
+			wantBody:   `{"title": "a \"b\" & c"}`,
+		},
+		{
+			name: "form body",
+			config: &apiRequestConfig{
+				Method:      "put",
+				URL:         "https://api.example.com/search",
+				ContentType: "application/x-www-form-urlencoded",
+				Body:        "title={title}",
+			},
+			wantMethod: http.MethodPut,
+			wantURL:    "https://api.example.com/search",
+			wantBody:   "title=a+%22b%22+%26+c",
+		},
+		{
+			name:        "no url",
+			paramsNoURL: true,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAPIScraper(scraperTypeConfig{
+				Action:   scraperActionAPI,
+				QueryURL: tt.queryURL,
+				Request:  tt.config,
+			}, nil, config{}, mockGlobalConfig{})
+
+			p := queryURLParameters{}
+			for k, v := range params {
+				if k == "url" && tt.paramsNoURL {
+					continue
+				}
+				p[k] = v
+			}
+
+			got, err := s.buildRequest(p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.method != tt.wantMethod {
+				t.Errorf("method = %s, want %s", got.method, tt.wantMethod)
+			}
+			if got.url != tt.wantURL {
+				t.Errorf("url = %s, want %s", got.url, tt.wantURL)
+			}
+			if string(got.body) != tt.wantBody {
+				t.Errorf("body = %s, want %s", got.body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestAPIScraperExpandParameters(t *testing.T) {
+	// placeholders in the values must not be expanded
+	params := queryURLParameters{
+		"title": "{url}",
+		"url":   "https://example.com/{title}",
+		"name":  "a\r\nX-Injected: b",
+	}
+
+	for i := 0; i < 10; i++ {
+		if got := params.expand("{title} {url}", nil); got != "{url} https://example.com/{title}" {
+			t.Fatalf("expand() = %q", got)
+		}
+	}
+
+	s := newAPIScraper(scraperTypeConfig{
+		Action: scraperActionAPI,
+		Request: &apiRequestConfig{
+			URL: "https://api.example.com/search",
+			Headers: []*header{
+				{Key: "X-Name", Value: "{name}"},
+			},
+		},
+	}, nil, config{}, mockGlobalConfig{})
+
+	got, err := s.buildRequest(params)
+	if err != nil {
+		t.Fatalf("buildRequest() error = %v", err)
+	}
+
+	if len(got.headers) != 1 || got.headers[0].Value != "aX-Injected: b" {
+		t.Errorf("headers = %v, want line breaks removed", got.headers)
+	}
+}
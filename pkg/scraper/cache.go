@@ -40,6 +40,8 @@ type GlobalConfig interface {
 	GetScraperCertCheck() bool
 	GetPythonPath() string
 	GetProxy() string
+	GetCachePath() string
+	GetScraperCacheTTL() time.Duration
 }
 
 func isCDPPathHTTP(c GlobalConfig) bool {
@@ -77,11 +79,18 @@ type GalleryFinder interface {
 	models.URLLoader
 }
 
+type ImageFinder interface {
+	models.ImageGetter
+	models.FileLoader
+	models.URLLoader
+}
+
 type Repository struct {
 	TxnManager models.TxnManager
 
 	SceneFinder     SceneFinder
 	GalleryFinder   GalleryFinder
+	ImageFinder     ImageFinder
 	TagFinder       TagFinder
 	PerformerFinder PerformerFinder
 	GroupFinder     match.GroupNamesFinder
@@ -93,6 +102,7 @@ func NewRepository(repo models.Repository) Repository {
 		TxnManager:      repo.TxnManager,
 		SceneFinder:     repo.Scene,
 		GalleryFinder:   repo.Gallery,
+		ImageFinder:     repo.Image,
 		TagFinder:       repo.Tag,
 		PerformerFinder: repo.Performer,
 		GroupFinder:     repo.Group,
@@ -158,6 +168,9 @@ func (c *Cache) ReloadScrapers() {
 	path := c.globalConfig.GetScrapersPath()
 	scrapers := make(map[string]scraper)
 
+	// browser sessions use the driver options of the previous configs
+	closeCDPSessions()
+
 	// Add built-in scrapers
 	freeOnes := getFreeonesScraper(c.globalConfig)
 	autoTag := getAutoTagScraper(c.repository, c.globalConfig)
@@ -227,6 +240,13 @@ func (c Cache) findScraper(scraperID string) scraper {
 	return nil
 }
 
+// PurgeResponseCache removes the cached responses of the scraper with the
+// given ID, or of all scrapers if scraperID is empty. Returns the number of
+// cached responses removed.
+func (c Cache) PurgeResponseCache(scraperID string) (int, error) {
+	return newResponseCache(c.globalConfig).purge(scraperID)
+}
+
 func (c Cache) ScrapeName(ctx context.Context, id, query string, ty ScrapeContentType) ([]ScrapedContent, error) {
 	// find scraper with the provided id
 	s := c.findScraper(id)
@@ -357,6 +377,27 @@ func (c Cache) ScrapeID(ctx context.Context, scraperID string, id int, ty Scrape
 			return nil, fmt.Errorf("scraper %s: %w", scraperID, err)
 		}
 
+		if scraped != nil {
+			ret = scraped
+		}
+	case ScrapeContentTypeImage:
+		is, ok := s.(imageScraper)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot use scraper %s as an image scraper", ErrNotSupported, scraperID)
+		}
+
+		image, err := c.getImage(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("scraper %s: unable to load image id %v: %w", scraperID, id, err)
+		}
+
+		// don't assign nil concrete pointer to ret interface, otherwise nil
+		// detection is harder
+		scraped, err := is.viaImage(ctx, c.client, image)
+		if err != nil {
+			return nil, fmt.Errorf("scraper %s: %w", scraperID, err)
+		}
+
 		if scraped != nil {
 			ret = scraped
 		}
@@ -426,3 +467,34 @@ func (c Cache) getGallery(ctx context.Context, galleryID int) (*models.Gallery,
 	}
 	return ret, nil
 }
+
+func (c Cache) getImage(ctx context.Context, imageID int) (*models.Image, error) {
+	var ret *models.Image
+	r := c.repository
+	if err := r.WithReadTxn(ctx, func(ctx context.Context) error {
+		qb := r.ImageFinder
+
+		var err error
+		ret, err = qb.Find(ctx, imageID)
+		if err != nil {
+			return err
+		}
+
+		if ret == nil {
+			return fmt.Errorf("image with id %d not found", imageID)
+		}
+
+		if err := ret.LoadURLs(ctx, qb); err != nil {
+			return err
+		}
+
+		if err := ret.LoadFiles(ctx, qb); err != nil {
+			return err
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
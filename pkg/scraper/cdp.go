@@ -0,0 +1,421 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	// cdpSessionTimeout is the time after the last scrape after which the
+	// browser of a scraper session is closed.
+	cdpSessionTimeout = 10 * time.Minute
+
+	cdpDefaultActionTimeout = 10 * time.Second
+
+	// cdpMaxScrolls is the maximum number of times scrollToBottom scrolls
+	// the page, to prevent scrolling forever on infinite pages.
+	cdpMaxScrolls = 50
+
+	// cdpCaptureTimeout is the maximum time to wait for the bodies of
+	// captured responses once the page actions are complete.
+	cdpCaptureTimeout = 10 * time.Second
+
+	cdpCaptureContentType = "application/json"
+)
+
+// newCDPAllocator returns a context used to create chrome contexts, based on
+// the CDP path setting. The returned function must be called to release the
+// allocator.
+func newCDPAllocator(ctx context.Context, globalConfig GlobalConfig) (context.Context, func(), error) {
+	// if scraperCDPPath is a remote address, then allocate accordingly
+	cdpPath := globalConfig.GetScraperCDPPath()
+	if cdpPath == "" {
+		return ctx, func() {}, nil
+	}
+
+	if isCDPPathHTTP(globalConfig) || isCDPPathWS(globalConfig) {
+		remote := cdpPath
+
+		// -------------------------------------------------------------------
+		// #1023
+		// when chromium is listening over RDP it only accepts requests
+		// with host headers that are either IPs or `localhost`
+		cdpURL, err := url.Parse(remote)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse CDP Path: %v", err)
+		}
+		hostname := cdpURL.Hostname()
+		if hostname != "localhost" {
+			if net.ParseIP(hostname) == nil { // not an IP
+				addr, err := net.LookupIP(hostname)
+				if err != nil || len(addr) == 0 { // can not resolve to IP
+					return nil, nil, fmt.Errorf("CDP: hostname <%s> can not be resolved", hostname)
+				}
+				if len(addr[0]) == 0 { // nil IP
+					return nil, nil, fmt.Errorf("CDP: hostname <%s> resolved to nil", hostname)
+				}
+				// addr is a valid IP
+				// replace the host part of the cdpURL with the IP
+				cdpURL.Host = strings.Replace(cdpURL.Host, hostname, addr[0].String(), 1)
+				// use that for remote
+				remote = cdpURL.String()
+			}
+		}
+		// --------------------------------------------------------------------
+
+		// if CDPPath is http(s) then we need to get the websocket URL
+		if isCDPPathHTTP(globalConfig) {
+			var err error
+			remote, err = getRemoteCDPWSAddress(ctx, remote)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		allocCtx, cancel := chromedp.NewRemoteAllocator(ctx, remote)
+		return allocCtx, cancel, nil
+	}
+
+	// use a temporary user directory for chrome
+	dir, err := os.MkdirTemp("", "stash-chromedp")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.UserDataDir(dir),
+		chromedp.ExecPath(cdpPath),
+	)
+	if globalConfig.GetProxy() != "" {
+		url, _, _ := splitProxyAuth(globalConfig.GetProxy())
+		opts = append(opts, chromedp.ProxyServer(url))
+	}
+
+	allocCtx, cancel := chromedp.NewExecAllocator(ctx, opts...)
+	return allocCtx, func() {
+		cancel()
+		os.RemoveAll(dir)
+	}, nil
+}
+
+// cdpSession is a browser kept open for a scraper between scrapes. Each
+// scrape opens a new tab in the browser, so that cookies are shared.
+type cdpSession struct {
+	id     string
+	ctx    context.Context
+	cancel func()
+
+	// number of scrapes using the session
+	inUse int
+	timer *time.Timer
+}
+
+var (
+	cdpSessionsMutex sync.Mutex
+	cdpSessions      = make(map[string]*cdpSession)
+)
+
+// acquireCDPSession returns the browser session of the scraper, starting the
+// browser if necessary. The cookies of the scraper are set when the browser
+// is started. release must be called on the returned session once the
+// scrape is complete.
+func acquireCDPSession(id string, driverOptions scraperDriverOptions, globalConfig GlobalConfig) (*cdpSession, error) {
+	cdpSessionsMutex.Lock()
+	defer cdpSessionsMutex.Unlock()
+
+	if s := cdpSessions[id]; s != nil {
+		s.inUse++
+		s.timer.Stop()
+		return s, nil
+	}
+
+	allocCtx, cancelAlloc, err := newCDPAllocator(context.Background(), globalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+	cancel := func() {
+		cancelBrowser()
+		cancelAlloc()
+	}
+
+	// start the browser
+	if err := chromedp.Run(browserCtx, network.Enable(), setCDPCookies(driverOptions)); err != nil {
+		cancel()
+		return nil, fmt.Errorf("starting browser session: %w", err)
+	}
+
+	logger.Debugf("[scraper] started browser session for %s", id)
+
+	s := &cdpSession{
+		id:     id,
+		ctx:    browserCtx,
+		cancel: cancel,
+		inUse:  1,
+	}
+	s.timer = time.AfterFunc(cdpSessionTimeout, s.close)
+	s.timer.Stop()
+
+	cdpSessions[id] = s
+	return s, nil
+}
+
+func (s *cdpSession) release() {
+	cdpSessionsMutex.Lock()
+	s.inUse--
+	if s.inUse > 0 {
+		cdpSessionsMutex.Unlock()
+		return
+	}
+
+	if cdpSessions[s.id] != s {
+		// the session was closed while in use
+		cdpSessionsMutex.Unlock()
+		s.cancel()
+		return
+	}
+
+	s.timer.Reset(cdpSessionTimeout)
+	cdpSessionsMutex.Unlock()
+}
+
+// close closes the session if it is not in use.
+func (s *cdpSession) close() {
+	cdpSessionsMutex.Lock()
+	if s.inUse > 0 || cdpSessions[s.id] != s {
+		cdpSessionsMutex.Unlock()
+		return
+	}
+	delete(cdpSessions, s.id)
+	cdpSessionsMutex.Unlock()
+
+	logger.Debugf("[scraper] closing browser session for %s", s.id)
+	s.cancel()
+}
+
+// closeCDPSessions closes all browser sessions. Sessions in use are closed
+// once their scrapes are complete.
+func closeCDPSessions() {
+	cdpSessionsMutex.Lock()
+	var idle []*cdpSession
+	for id, s := range cdpSessions {
+		delete(cdpSessions, id)
+		s.timer.Stop()
+		if s.inUse == 0 {
+			idle = append(idle, s)
+		}
+	}
+	cdpSessionsMutex.Unlock()
+
+	for _, s := range idle {
+		s.cancel()
+	}
+}
+
+// cdpActions returns the tasks performing the actions in the scraper config.
+func cdpActions(driverOptions scraperDriverOptions) chromedp.Tasks {
+	var tasks chromedp.Tasks
+	for _, a := range driverOptions.Actions {
+		if a == nil {
+			continue
+		}
+
+		tasks = append(tasks, a.task())
+		if a.Sleep > 0 {
+			tasks = append(tasks, chromedp.Sleep(time.Duration(a.Sleep)*time.Second))
+		}
+	}
+
+	return tasks
+}
+
+func (a cdpActionOptions) timeout() time.Duration {
+	if a.Timeout > 0 {
+		return time.Duration(a.Timeout) * time.Second
+	}
+	return cdpDefaultActionTimeout
+}
+
+// withTimeout runs the actions with the timeout of the action.
+func (a cdpActionOptions) withTimeout(actions ...chromedp.Action) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, a.timeout())
+		defer cancel()
+
+		return chromedp.Tasks(actions).Do(ctx)
+	})
+}
+
+func (a cdpActionOptions) task() chromedp.Action {
+	switch {
+	case a.WaitFor != "":
+		logger.Debugf("[scraper] waiting for %s", a.WaitFor)
+		return a.withTimeout(chromedp.WaitVisible(a.WaitFor))
+	case a.ScrollToBottom:
+		return chromedp.ActionFunc(scrollToBottom)
+	case a.Click != "":
+		return a.withTimeout(chromedp.Click(a.Click))
+	case a.Fill != nil:
+		actions := []chromedp.Action{chromedp.SetValue(a.Fill.Selector, a.Fill.Value)}
+		if a.Fill.Submit {
+			actions = append(actions, chromedp.Submit(a.Fill.Selector))
+		}
+		return a.withTimeout(actions...)
+	case a.Evaluate != "":
+		return chromedp.Evaluate(a.Evaluate, nil)
+	}
+
+	return chromedp.Tasks{}
+}
+
+// scrollToBottom scrolls to the bottom of the page until the page height
+// stops changing, so that content loaded while scrolling is included.
+func scrollToBottom(ctx context.Context) error {
+	const script = `window.scrollTo(0, document.body.scrollHeight); document.body.scrollHeight`
+
+	var lastHeight int
+	for i := 0; i < cdpMaxScrolls; i++ {
+		var height int
+		if err := chromedp.Evaluate(script, &height).Do(ctx); err != nil {
+			return err
+		}
+
+		if height == lastHeight {
+			return nil
+		}
+		lastHeight = height
+
+		if err := chromedp.Sleep(time.Second).Do(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cdpCapture captures the bodies of json network responses with urls
+// matching a regular expression.
+type cdpCapture struct {
+	re *regexp.Regexp
+
+	mutex   sync.Mutex
+	wg      sync.WaitGroup
+	order   []network.RequestID
+	pending map[network.RequestID]bool
+	bodies  map[network.RequestID][]byte
+}
+
+func newCDPCapture(pattern string) (*cdpCapture, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cdpCapture{
+		re:      re,
+		pending: make(map[network.RequestID]bool),
+		bodies:  make(map[network.RequestID][]byte),
+	}, nil
+}
+
+// listen captures the matching responses of the target of ctx. Response
+// bodies are only available once loading has finished.
+func (c *cdpCapture) listen(ctx context.Context) {
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		switch ev := ev.(type) {
+		case *network.EventResponseReceived:
+			if ev.Response == nil || !strings.Contains(ev.Response.MimeType, "json") || !c.re.MatchString(ev.Response.URL) {
+				return
+			}
+
+			logger.Debugf("[scraper] capturing response from %s", ev.Response.URL)
+
+			c.mutex.Lock()
+			c.order = append(c.order, ev.RequestID)
+			c.pending[ev.RequestID] = true
+			c.mutex.Unlock()
+		case *network.EventLoadingFinished:
+			c.mutex.Lock()
+			defer c.mutex.Unlock()
+
+			if !c.pending[ev.RequestID] {
+				return
+			}
+			delete(c.pending, ev.RequestID)
+
+			// commands cannot be run in the listener, as it blocks the
+			// event loop of the target
+			id := ev.RequestID
+			c.wg.Add(1)
+			go func() {
+				defer c.wg.Done()
+
+				execCtx := cdp.WithExecutor(ctx, chromedp.FromContext(ctx).Target)
+				body, err := network.GetResponseBody(id).Do(execCtx)
+				if err != nil {
+					logger.Warnf("[scraper] error getting captured response body: %v", err)
+					return
+				}
+
+				c.mutex.Lock()
+				c.bodies[id] = body
+				c.mutex.Unlock()
+			}()
+		}
+	})
+}
+
+// result waits for the captured response bodies, and returns them as a json
+// array in the order that the responses were received.
+func (c *cdpCapture) result() ([]byte, error) {
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(cdpCaptureTimeout):
+		logger.Warnf("[scraper] timed out waiting for captured responses")
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ret := []json.RawMessage{}
+	for _, id := range c.order {
+		body, ok := c.bodies[id]
+		if !ok {
+			continue
+		}
+
+		if !json.Valid(body) {
+			logger.Warnf("[scraper] ignoring captured response that is not valid json")
+			continue
+		}
+
+		ret = append(ret, body)
+	}
+
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("no responses were captured")
+	}
+
+	return json.Marshal(ret)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"gopkg.in/yaml.v2"
@@ -45,6 +46,15 @@ type config struct {
 	// Configuration for querying a gallery by a URL
 	GalleryByURL []*scrapeByURLConfig `yaml:"galleryByURL"`
 
+	// Configuration for querying images by an Image fragment
+	ImageByFragment *scraperTypeConfig `yaml:"imageByFragment"`
+
+	// Configuration for querying images by name
+	ImageByName *scraperTypeConfig `yaml:"imageByName"`
+
+	// Configuration for querying an image by a URL
+	ImageByURL []*scrapeByURLConfig `yaml:"imageByURL"`
+
 	// Configuration for querying a movie by a URL - deprecated, use GroupByURL
 	MovieByURL []*scrapeByURLConfig `yaml:"movieByURL"`
 	GroupByURL []*scrapeByURLConfig `yaml:"groupByURL"`
@@ -63,6 +73,9 @@ type config struct {
 
 	// Scraping driver options
 	DriverOptions *scraperDriverOptions `yaml:"driver"`
+
+	// Rate limiting options
+	RateLimit *scraperRateLimit `yaml:"rateLimit"`
 }
 
 func (c config) validate() error {
@@ -88,12 +101,30 @@ func (c config) validate() error {
 		}
 	}
 
+	if c.ImageByFragment != nil {
+		if err := c.ImageByFragment.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.ImageByName != nil {
+		if err := c.ImageByName.validate(); err != nil {
+			return err
+		}
+	}
+
 	for _, s := range c.PerformerByURL {
 		if err := s.validate(); err != nil {
 			return err
 		}
 	}
 
+	for _, s := range c.ImageByURL {
+		if err := s.validate(); err != nil {
+			return err
+		}
+	}
+
 	for _, s := range c.SceneByURL {
 		if err := s.validate(); err != nil {
 			return err
@@ -110,6 +141,18 @@ func (c config) validate() error {
 		}
 	}
 
+	if c.RateLimit != nil {
+		if err := c.RateLimit.validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.DriverOptions != nil {
+		if err := c.DriverOptions.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -125,6 +168,9 @@ type scraperTypeConfig struct {
 	// for xpath name scraper only
 	QueryURL             string               `yaml:"queryURL"`
 	QueryURLReplacements queryURLReplacements `yaml:"queryURLReplace"`
+
+	// for api scraper only
+	Request *apiRequestConfig `yaml:"request"`
 }
 
 func (c scraperTypeConfig) validate() error {
@@ -136,6 +182,12 @@ func (c scraperTypeConfig) validate() error {
 		return errors.New("script is mandatory for script scraper action")
 	}
 
+	if c.Action == scraperActionAPI && c.Request != nil {
+		if err := c.Request.validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -164,6 +216,55 @@ func (c scrapeByURLConfig) matchesURL(url string) bool {
 
 type scraperDebugOptions struct {
 	PrintHTML bool `yaml:"printHTML"`
+	// Replay uses cached responses regardless of their age, so that the
+	// scraper can be developed against the same documents.
+	Replay bool `yaml:"replay"`
+}
+
+type rateLimitOptions struct {
+	RequestsPerMinute int `yaml:"requestsPerMinute"`
+	MaxConcurrent     int `yaml:"maxConcurrent"`
+}
+
+func (o rateLimitOptions) validate() error {
+	if o.RequestsPerMinute < 0 {
+		return errors.New("requestsPerMinute must not be negative")
+	}
+	if o.MaxConcurrent < 0 {
+		return errors.New("maxConcurrent must not be negative")
+	}
+
+	return nil
+}
+
+type scraperRateLimit struct {
+	// Limits applied to all requests made by the scraper
+	rateLimitOptions `yaml:",inline"`
+	// Maximum number of times requests failing with a 429 or 5xx status are retried
+	MaxRetries int `yaml:"maxRetries"`
+	// Limits applied to requests to specific domains, keyed by domain name
+	Domains map[string]*rateLimitOptions `yaml:"domains"`
+}
+
+func (c scraperRateLimit) validate() error {
+	if err := c.rateLimitOptions.validate(); err != nil {
+		return fmt.Errorf("rateLimit: %w", err)
+	}
+
+	if c.MaxRetries < 0 {
+		return errors.New("rateLimit: maxRetries must not be negative")
+	}
+
+	for domain, o := range c.Domains {
+		if o == nil {
+			continue
+		}
+		if err := o.validate(); err != nil {
+			return fmt.Errorf("rateLimit: domain %s: %w", domain, err)
+		}
+	}
+
+	return nil
 }
 
 type scraperCookies struct {
@@ -189,12 +290,94 @@ type header struct {
 	Value string `yaml:"Value"`
 }
 
+// cdpActionOptions is an action performed on the page after it is loaded
+// using CDP. Exactly one of the action fields must be set.
+type cdpActionOptions struct {
+	// Waits for the element matching the selector to be visible
+	WaitFor string `yaml:"waitFor"`
+	// Scrolls to the bottom of the page until no more content is loaded
+	ScrollToBottom bool `yaml:"scrollToBottom"`
+	// Clicks the element matching the selector
+	Click string `yaml:"click"`
+	// Sets the value of a form field
+	Fill *cdpFillOptions `yaml:"fill"`
+	// Evaluates the javascript expression in the page
+	Evaluate string `yaml:"evaluate"`
+
+	// Time in seconds to wait after the action
+	Sleep int `yaml:"sleep"`
+	// Time in seconds to wait for the element of waitFor, click or fill.
+	// Defaults to 10 seconds.
+	Timeout int `yaml:"timeout"`
+}
+
+func (o cdpActionOptions) validate() error {
+	n := 0
+	for _, set := range []bool{o.WaitFor != "", o.ScrollToBottom, o.Click != "", o.Fill != nil, o.Evaluate != ""} {
+		if set {
+			n++
+		}
+	}
+
+	if n != 1 {
+		return errors.New("exactly one of waitFor, scrollToBottom, click, fill or evaluate must be set")
+	}
+
+	if o.Fill != nil && o.Fill.Selector == "" {
+		return errors.New("fill selector must not be empty")
+	}
+
+	if o.Sleep < 0 || o.Timeout < 0 {
+		return errors.New("sleep and timeout must not be negative")
+	}
+
+	return nil
+}
+
+type cdpFillOptions struct {
+	Selector string `yaml:"selector"`
+	Value    string `yaml:"value"`
+	// Submits the form containing the field after setting the value
+	Submit bool `yaml:"submit"`
+}
+
 type scraperDriverOptions struct {
-	UseCDP  bool             `yaml:"useCDP"`
-	Sleep   int              `yaml:"sleep"`
-	Clicks  []*clickOptions  `yaml:"clicks"`
-	Cookies []*cookieOptions `yaml:"cookies"`
-	Headers []*header        `yaml:"headers"`
+	UseCDP  bool                `yaml:"useCDP"`
+	Sleep   int                 `yaml:"sleep"`
+	Clicks  []*clickOptions     `yaml:"clicks"`
+	Actions []*cdpActionOptions `yaml:"actions"`
+	Cookies []*cookieOptions    `yaml:"cookies"`
+	Headers []*header           `yaml:"headers"`
+
+	// Regular expression matching the URLs of json network responses to
+	// capture. If set, the scraped document is a json array of the captured
+	// responses rather than the page html.
+	CaptureJSON string `yaml:"captureJSON"`
+
+	// Session keeps the browser of the scraper open between scrapes, so that
+	// cookies set by the site are reused.
+	Session bool `yaml:"session"`
+}
+
+// validate checks the driver options. Chrome is not needed to validate the
+// options, it is only started when a page is scraped.
+func (o scraperDriverOptions) validate() error {
+	for i, a := range o.Actions {
+		if a == nil {
+			continue
+		}
+		if err := a.validate(); err != nil {
+			return fmt.Errorf("driver: action %d: %w", i, err)
+		}
+	}
+
+	if o.CaptureJSON != "" {
+		if _, err := regexp.Compile(o.CaptureJSON); err != nil {
+			return fmt.Errorf("driver: invalid captureJSON: %w", err)
+		}
+	}
+
+	return nil
 }
 
 func loadConfigFromYAML(id string, reader io.Reader) (*config, error) {
@@ -294,6 +477,24 @@ func (c config) spec() Scraper {
 		ret.Gallery = &gallery
 	}
 
+	image := ScraperSpec{}
+	if c.ImageByName != nil {
+		image.SupportedScrapes = append(image.SupportedScrapes, ScrapeTypeName)
+	}
+	if c.ImageByFragment != nil {
+		image.SupportedScrapes = append(image.SupportedScrapes, ScrapeTypeFragment)
+	}
+	if len(c.ImageByURL) > 0 {
+		image.SupportedScrapes = append(image.SupportedScrapes, ScrapeTypeURL)
+		for _, v := range c.ImageByURL {
+			image.Urls = append(image.Urls, v.URL...)
+		}
+	}
+
+	if len(image.SupportedScrapes) > 0 {
+		ret.Image = &image
+	}
+
 	group := ScraperSpec{}
 	if len(c.MovieByURL) > 0 || len(c.GroupByURL) > 0 {
 		group.SupportedScrapes = append(group.SupportedScrapes, ScrapeTypeURL)
@@ -318,6 +519,8 @@ func (c config) supports(ty ScrapeContentType) bool {
 		return (c.SceneByName != nil && c.SceneByQueryFragment != nil) || c.SceneByFragment != nil || len(c.SceneByURL) > 0
 	case ScrapeContentTypeGallery:
 		return c.GalleryByFragment != nil || len(c.GalleryByURL) > 0
+	case ScrapeContentTypeImage:
+		return c.ImageByName != nil || c.ImageByFragment != nil || len(c.ImageByURL) > 0
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
 		return len(c.MovieByURL) > 0 || len(c.GroupByURL) > 0
 	}
@@ -345,6 +548,12 @@ func (c config) matchesURL(url string, ty ScrapeContentType) bool {
 				return true
 			}
 		}
+	case ScrapeContentTypeImage:
+		for _, scraper := range c.ImageByURL {
+			if scraper.matchesURL(url) {
+				return true
+			}
+		}
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
 		for _, scraper := range c.MovieByURL {
 			if scraper.matchesURL(url) {
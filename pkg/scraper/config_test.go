@@ -0,0 +1,80 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigDriverActions(t *testing.T) {
+	const base = `name: Test
+sceneByURL:
+  - action: scrapeJson
+    url:
+      - example.com
+    scraper: sceneScraper
+driver:
+  useCDP: true
+`
+
+	tests := []struct {
+		name    string
+		driver  string
+		wantErr bool
+	}{
+		{
+			"valid actions",
+			`  captureJSON: /api/scenes/
+  session: true
+  actions:
+    - waitFor: //div[@id="player"]
+      timeout: 5
+    - fill:
+        selector: //input[@name="q"]
+        value: test
+        submit: true
+    - scrollToBottom: true
+      sleep: 1
+    - evaluate: document.querySelector(".modal").remove()
+`,
+			false,
+		},
+		{
+			"multiple action types",
+			`  actions:
+    - waitFor: //div
+      click: //a
+`,
+			true,
+		},
+		{
+			"empty action",
+			`  actions:
+    - sleep: 1
+`,
+			true,
+		},
+		{
+			"fill without selector",
+			`  actions:
+    - fill:
+        value: test
+`,
+			true,
+		},
+		{
+			"invalid capture pattern",
+			`  captureJSON: "("
+`,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadConfigFromYAML("test", strings.NewReader(base+tt.driver))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("loadConfigFromYAML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
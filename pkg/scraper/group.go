@@ -12,12 +12,17 @@ type group struct {
 	config config
 
 	globalConf GlobalConfig
+
+	// limits the requests made by the scraper. nil if the scraper has no
+	// rate limits.
+	limits *rateLimits
 }
 
 func newGroupScraper(c config, globalConfig GlobalConfig) scraper {
 	return group{
 		config:     c,
 		globalConf: globalConfig,
+		limits:     newRateLimits(c.RateLimit),
 	}
 }
 
@@ -35,6 +40,8 @@ func (g group) fragmentScraper(input Input) *scraperTypeConfig {
 		return g.config.GalleryByFragment
 	case input.Scene != nil:
 		return g.config.SceneByQueryFragment
+	case input.Image != nil:
+		return g.config.ImageByFragment
 	}
 
 	return nil
@@ -53,7 +60,7 @@ func (g group) viaFragment(ctx context.Context, client *http.Client, input Input
 		return nil, ErrNotSupported
 	}
 
-	s := g.config.getScraper(*stc, client, g.globalConf)
+	s := g.config.getScraper(*stc, g.limits.client(client), g.globalConf)
 	return s.scrapeByFragment(ctx, input)
 }
 
@@ -62,7 +69,7 @@ func (g group) viaScene(ctx context.Context, client *http.Client, scene *models.
 		return nil, ErrNotSupported
 	}
 
-	s := g.config.getScraper(*g.config.SceneByFragment, client, g.globalConf)
+	s := g.config.getScraper(*g.config.SceneByFragment, g.limits.client(client), g.globalConf)
 	return s.scrapeSceneByScene(ctx, scene)
 }
 
@@ -71,10 +78,19 @@ func (g group) viaGallery(ctx context.Context, client *http.Client, gallery *mod
 		return nil, ErrNotSupported
 	}
 
-	s := g.config.getScraper(*g.config.GalleryByFragment, client, g.globalConf)
+	s := g.config.getScraper(*g.config.GalleryByFragment, g.limits.client(client), g.globalConf)
 	return s.scrapeGalleryByGallery(ctx, gallery)
 }
 
+func (g group) viaImage(ctx context.Context, client *http.Client, image *models.Image) (*ScrapedImage, error) {
+	if g.config.ImageByFragment == nil {
+		return nil, ErrNotSupported
+	}
+
+	s := g.config.getScraper(*g.config.ImageByFragment, g.limits.client(client), g.globalConf)
+	return s.scrapeImageByImage(ctx, image)
+}
+
 func loadUrlCandidates(c config, ty ScrapeContentType) []*scrapeByURLConfig {
 	switch ty {
 	case ScrapeContentTypePerformer:
@@ -85,6 +101,8 @@ func loadUrlCandidates(c config, ty ScrapeContentType) []*scrapeByURLConfig {
 		return append(c.MovieByURL, c.GroupByURL...)
 	case ScrapeContentTypeGallery:
 		return c.GalleryByURL
+	case ScrapeContentTypeImage:
+		return c.ImageByURL
 	}
 
 	panic("loadUrlCandidates: unreachable")
@@ -94,7 +112,7 @@ func (g group) viaURL(ctx context.Context, client *http.Client, url string, ty S
 	candidates := loadUrlCandidates(g.config, ty)
 	for _, scraper := range candidates {
 		if scraper.matchesURL(url) {
-			s := g.config.getScraper(scraper.scraperTypeConfig, client, g.globalConf)
+			s := g.config.getScraper(scraper.scraperTypeConfig, g.limits.client(client), g.globalConf)
 			ret, err := s.scrapeByURL(ctx, url, ty)
 			if err != nil {
 				return nil, err
@@ -116,14 +134,21 @@ func (g group) viaName(ctx context.Context, client *http.Client, name string, ty
 			break
 		}
 
-		s := g.config.getScraper(*g.config.PerformerByName, client, g.globalConf)
+		s := g.config.getScraper(*g.config.PerformerByName, g.limits.client(client), g.globalConf)
 		return s.scrapeByName(ctx, name, ty)
 	case ScrapeContentTypeScene:
 		if g.config.SceneByName == nil {
 			break
 		}
 
-		s := g.config.getScraper(*g.config.SceneByName, client, g.globalConf)
+		s := g.config.getScraper(*g.config.SceneByName, g.limits.client(client), g.globalConf)
+		return s.scrapeByName(ctx, name, ty)
+	case ScrapeContentTypeImage:
+		if g.config.ImageByName == nil {
+			break
+		}
+
+		s := g.config.getScraper(*g.config.ImageByName, g.limits.client(client), g.globalConf)
 		return s.scrapeByName(ctx, name, ty)
 	}
 
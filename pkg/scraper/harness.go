@@ -0,0 +1,175 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// HarnessOptions are the options of a scrape run by RunScraperFile.
+type HarnessOptions struct {
+	// Type of the content to scrape
+	Type ScrapeContentType
+	// URL to scrape. Required for url scrapes, including those using a
+	// fixture.
+	URL string
+	// Query of a name scrape. Used if URL is not set.
+	Name string
+	// Path of a file returned as the response to every request made by the
+	// scraper, instead of making the request. The CDP driver is not used
+	// when a fixture is set. Script scrapers make their own requests, so
+	// fixtures cannot be used with them.
+	Fixture string
+}
+
+// action returns the action of the scraper config used for the scrape.
+// Returns an empty action if the config does not support the scrape.
+func (o HarnessOptions) action(c config) scraperAction {
+	if o.URL != "" {
+		for _, s := range loadUrlCandidates(c, o.Type) {
+			if s.matchesURL(o.URL) {
+				return s.Action
+			}
+		}
+
+		return ""
+	}
+
+	var byName *scraperTypeConfig
+	switch o.Type {
+	case ScrapeContentTypePerformer:
+		byName = c.PerformerByName
+	case ScrapeContentTypeScene:
+		byName = c.SceneByName
+	case ScrapeContentTypeImage:
+		byName = c.ImageByName
+	}
+
+	if byName == nil {
+		return ""
+	}
+
+	return byName.Action
+}
+
+func (o HarnessOptions) validate() error {
+	if !o.Type.IsValid() {
+		return fmt.Errorf("invalid scrape type %q", o.Type)
+	}
+
+	if o.URL == "" && o.Name == "" {
+		return errors.New("either url or name must be set")
+	}
+
+	return nil
+}
+
+// RunScraperFile loads the scraper config from the yaml file at path and runs
+// a single scrape with it, without using the scraper cache. Returns the
+// scraped content for url scrapes, and a slice of the scraped content for
+// name scrapes.
+//
+// The scraped content is not post-processed: performers, studios and tags
+// are not matched to existing objects, and images are not downloaded.
+func RunScraperFile(ctx context.Context, path string, globalConfig GlobalConfig, options HarnessOptions) (interface{}, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	c, err := loadConfigFromYAMLFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("loading scraper %s: %w", path, err)
+	}
+
+	client := newClient(globalConfig)
+
+	if options.Fixture != "" {
+		if options.action(*c) == scraperActionScript {
+			return nil, fmt.Errorf("%w: fixtures cannot be used with script scraper %s", ErrNotSupported, c.ID)
+		}
+
+		f, err := newFixtureTransport(options.Fixture)
+		if err != nil {
+			return nil, err
+		}
+		client.Transport = f
+
+		if c.DriverOptions != nil {
+			driverOptions := *c.DriverOptions
+			driverOptions.UseCDP = false
+			c.DriverOptions = &driverOptions
+		}
+	}
+
+	s := newGroupScraper(*c, globalConfig)
+	if !s.supports(options.Type) {
+		return nil, fmt.Errorf("%w: cannot use scraper %s as a %v scraper", ErrNotSupported, c.ID, options.Type)
+	}
+
+	if options.URL != "" {
+		if !s.supportsURL(options.URL, options.Type) {
+			return nil, fmt.Errorf("%w: scraper %s does not support url %s", ErrNotSupported, c.ID, options.URL)
+		}
+
+		us, ok := s.(urlScraper)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot use scraper %s as an url scraper", ErrNotSupported, c.ID)
+		}
+
+		return us.viaURL(ctx, client, options.URL, options.Type)
+	}
+
+	ns, ok := s.(nameScraper)
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot use scraper %s to scrape by name", ErrNotSupported, c.ID)
+	}
+
+	return ns.viaName(ctx, client, options.Name, options.Type)
+}
+
+// fixtureTransport responds to all requests with the contents of a file.
+type fixtureTransport struct {
+	body        []byte
+	contentType string
+}
+
+func newFixtureTransport(path string) (*fixtureTransport, error) {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading fixture: %w", err)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	return &fixtureTransport{
+		body:        body,
+		contentType: contentType,
+	}, nil
+}
+
+func (t *fixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{t.contentType}},
+		Body:          io.NopCloser(bytes.NewReader(t.body)),
+		ContentLength: int64(len(t.body)),
+		Request:       req,
+	}, nil
+}
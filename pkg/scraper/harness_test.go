@@ -0,0 +1,149 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func TestRunScraperFileFixture(t *testing.T) {
+	const scraperYAML = `name: Test
+sceneByURL:
+  - action: scrapeXPath
+    url:
+      - example.com/scene/
+    scraper: sceneScraper
+xPathScrapers:
+  sceneScraper:
+    scene:
+      Title: //h1
+      Performers:
+        Name: //span[@class="performer"]
+driver:
+  useCDP: true
+`
+
+	const fixtureHTML = `<html><body>
+<h1>Scene Title</h1>
+<span class="performer">Jane Doe</span>
+<span class="performer">John Doe</span>
+</body></html>`
+
+	dir := t.TempDir()
+	scraperPath := filepath.Join(dir, "test.yml")
+	fixturePath := filepath.Join(dir, "scene.html")
+
+	if err := os.WriteFile(scraperPath, []byte(scraperYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fixturePath, []byte(fixtureHTML), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ret, err := RunScraperFile(context.Background(), scraperPath, mockGlobalConfig{}, HarnessOptions{
+		Type:    ScrapeContentTypeScene,
+		URL:     "https://example.com/scene/1",
+		Fixture: fixturePath,
+	})
+	if err != nil {
+		t.Fatalf("RunScraperFile: %v", err)
+	}
+
+	scene, ok := ret.(*ScrapedScene)
+	if !ok {
+		t.Fatalf("unexpected content type %T", ret)
+	}
+
+	if scene.Title == nil || *scene.Title != "Scene Title" {
+		t.Errorf("Title = %v, want %q", scene.Title, "Scene Title")
+	}
+	if len(scene.Performers) != 2 || *scene.Performers[1].Name != "John Doe" {
+		t.Errorf("unexpected performers %v", scene.Performers)
+	}
+
+	// urls not matching the scraper are rejected
+	_, err = RunScraperFile(context.Background(), scraperPath, mockGlobalConfig{}, HarnessOptions{
+		Type:    ScrapeContentTypeScene,
+		URL:     "https://example.org/scene/1",
+		Fixture: fixturePath,
+	})
+	if !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+}
+
+func TestRunScraperFileFixtureStash(t *testing.T) {
+	const scraperYAML = `name: Test
+performerByName:
+  action: stash
+stashServer:
+  url: http://stash.invalid
+`
+
+	const fixtureJSON = `{"data": {"findPerformers": {"count": 1, "performers": [{"id": "1", "name": "Jane Doe"}]}}}`
+
+	dir := t.TempDir()
+	scraperPath := filepath.Join(dir, "test.yml")
+	fixturePath := filepath.Join(dir, "performers.json")
+
+	if err := os.WriteFile(scraperPath, []byte(scraperYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fixturePath, []byte(fixtureJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ret, err := RunScraperFile(context.Background(), scraperPath, mockGlobalConfig{}, HarnessOptions{
+		Type:    ScrapeContentTypePerformer,
+		Name:    "Jane",
+		Fixture: fixturePath,
+	})
+	if err != nil {
+		t.Fatalf("RunScraperFile: %v", err)
+	}
+
+	performers, ok := ret.([]ScrapedContent)
+	if !ok || len(performers) != 1 {
+		t.Fatalf("unexpected content %v", ret)
+	}
+
+	performer, ok := performers[0].(*models.ScrapedPerformer)
+	if !ok || performer.Name == nil || *performer.Name != "Jane Doe" {
+		t.Errorf("unexpected performer %v", performers[0])
+	}
+}
+
+func TestRunScraperFileFixtureScript(t *testing.T) {
+	const scraperYAML = `name: Test
+performerByName:
+  action: script
+  script:
+    - python
+    - test.py
+`
+
+	dir := t.TempDir()
+	scraperPath := filepath.Join(dir, "test.yml")
+	fixturePath := filepath.Join(dir, "performers.json")
+
+	if err := os.WriteFile(scraperPath, []byte(scraperYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(fixturePath, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// script scrapers make their own requests
+	_, err := RunScraperFile(context.Background(), scraperPath, mockGlobalConfig{}, HarnessOptions{
+		Type:    ScrapeContentTypePerformer,
+		Name:    "Jane",
+		Fixture: fixturePath,
+	})
+	if !errors.Is(err, ErrNotSupported) {
+		t.Errorf("expected ErrNotSupported, got %v", err)
+	}
+}
@@ -11,6 +11,33 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
+type ScrapedImage struct {
+	Title        *string                    `json:"title"`
+	Code         *string                    `json:"code"`
+	Details      *string                    `json:"details"`
+	Photographer *string                    `json:"photographer"`
+	URLs         []string                   `json:"urls"`
+	Date         *string                    `json:"date"`
+	Studio       *models.ScrapedStudio      `json:"studio"`
+	Tags         []*models.ScrapedTag       `json:"tags"`
+	Performers   []*models.ScrapedPerformer `json:"performers"`
+}
+
+func (ScrapedImage) IsScrapedContent() {}
+
+type ScrapedImageInput struct {
+	Title        *string  `json:"title"`
+	Code         *string  `json:"code"`
+	Details      *string  `json:"details"`
+	Photographer *string  `json:"photographer"`
+	URLs         []string `json:"urls"`
+	Date         *string  `json:"date"`
+	// path of the primary file of the image
+	Path *string `json:"path"`
+	// names of the performers of the image
+	Performers []string `json:"performers"`
+}
+
 func setPerformerImage(ctx context.Context, client *http.Client, p *models.ScrapedPerformer, globalConfig GlobalConfig) error {
 	// backwards compatibility: we fetch the image if it's a URL and set it to the first image
 	// Image is deprecated, so only do this if Images is unset
@@ -146,12 +173,14 @@ func getImage(ctx context.Context, url string, client *http.Client, globalConfig
 		return nil, err
 	}
 
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 400 {
+		// drain the body so that the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
 		return nil, fmt.Errorf("http error %d", resp.StatusCode)
 	}
 
-	defer resp.Body.Close()
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, err
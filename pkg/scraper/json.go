@@ -81,36 +81,7 @@ func (s *jsonScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeCont
 	}
 
 	q := s.getJsonQuery(doc)
-	// if these just return the return values from scraper.scrape* functions then
-	// it ends up returning ScrapedContent(nil) rather than nil
-	switch ty {
-	case ScrapeContentTypePerformer:
-		ret, err := scraper.scrapePerformer(ctx, q)
-		if err != nil || ret == nil {
-			return nil, err
-		}
-		return ret, nil
-	case ScrapeContentTypeScene:
-		ret, err := scraper.scrapeScene(ctx, q)
-		if err != nil || ret == nil {
-			return nil, err
-		}
-		return ret, nil
-	case ScrapeContentTypeGallery:
-		ret, err := scraper.scrapeGallery(ctx, q)
-		if err != nil || ret == nil {
-			return nil, err
-		}
-		return ret, nil
-	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
-		ret, err := scraper.scrapeGroup(ctx, q)
-		if err != nil || ret == nil {
-			return nil, err
-		}
-		return ret, nil
-	}
-
-	return nil, ErrNotSupported
+	return scraper.scrapeContent(ctx, q, ty)
 }
 
 func (s *jsonScraper) scrapeByName(ctx context.Context, name string, ty ScrapeContentType) ([]ScrapedContent, error) {
@@ -137,33 +108,7 @@ func (s *jsonScraper) scrapeByName(ctx context.Context, name string, ty ScrapeCo
 	q := s.getJsonQuery(doc)
 	q.setType(SearchQuery)
 
-	var content []ScrapedContent
-	switch ty {
-	case ScrapeContentTypePerformer:
-		performers, err := scraper.scrapePerformers(ctx, q)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, p := range performers {
-			content = append(content, p)
-		}
-
-		return content, nil
-	case ScrapeContentTypeScene:
-		scenes, err := scraper.scrapeScenes(ctx, q)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, s := range scenes {
-			content = append(content, s)
-		}
-
-		return content, nil
-	}
-
-	return nil, ErrNotSupported
+	return scraper.scrapeContents(ctx, q, ty)
 }
 
 func (s *jsonScraper) scrapeSceneByScene(ctx context.Context, scene *models.Scene) (*ScrapedScene, error) {
@@ -196,6 +141,8 @@ func (s *jsonScraper) scrapeByFragment(ctx context.Context, input Input) (Scrape
 		return nil, fmt.Errorf("%w: cannot use a json scraper as a gallery fragment scraper", ErrNotSupported)
 	case input.Performer != nil:
 		return nil, fmt.Errorf("%w: cannot use a json scraper as a performer fragment scraper", ErrNotSupported)
+	case input.Image != nil:
+		return s.scrapeImageByFragment(ctx, *input.Image)
 	case input.Scene == nil:
 		return nil, fmt.Errorf("%w: scene input is nil", ErrNotSupported)
 	}
@@ -249,6 +196,54 @@ func (s *jsonScraper) scrapeGalleryByGallery(ctx context.Context, gallery *model
 	return scraper.scrapeGallery(ctx, q)
 }
 
+func (s *jsonScraper) scrapeImageByFragment(ctx context.Context, image ScrapedImageInput) (ScrapedContent, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromScrapedImage(image)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getJsonScraper()
+
+	if scraper == nil {
+		return nil, errors.New("json scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getJsonQuery(doc)
+	return scraper.scrapeContent(ctx, q, ScrapeContentTypeImage)
+}
+
+func (s *jsonScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromImage(image)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getJsonScraper()
+
+	if scraper == nil {
+		return nil, errors.New("json scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getJsonQuery(doc)
+	return scraper.scrapeImage(ctx, q)
+}
+
 func (s *jsonScraper) getJsonQuery(doc string) *jsonQuery {
 	return &jsonQuery{
 		doc:     doc,
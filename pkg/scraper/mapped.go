@@ -228,6 +228,11 @@ func (s *mappedGalleryScraperConfig) UnmarshalYAML(unmarshal func(interface{}) e
 	return nil
 }
 
+// mappedImageScraperConfig has the same fields as the gallery config.
+type mappedImageScraperConfig struct {
+	mappedGalleryScraperConfig
+}
+
 type mappedPerformerScraperConfig struct {
 	mappedConfig
 
@@ -785,6 +790,7 @@ type mappedScraper struct {
 	Common    commonMappedConfig            `yaml:"common"`
 	Scene     *mappedSceneScraperConfig     `yaml:"scene"`
 	Gallery   *mappedGalleryScraperConfig   `yaml:"gallery"`
+	Image     *mappedImageScraperConfig     `yaml:"image"`
 	Performer *mappedPerformerScraperConfig `yaml:"performer"`
 	Movie     *mappedMovieScraperConfig     `yaml:"movie"`
 }
@@ -1079,6 +1085,88 @@ func (s mappedScraper) scrapeGallery(ctx context.Context, q mappedQuery) (*Scrap
 	return &ret, nil
 }
 
+// processImageRelationships sets the relationships on the ScrapedImage. It returns true if any relationships were set.
+func (s mappedScraper) processImageRelationships(ctx context.Context, q mappedQuery, resultIndex int, ret *ScrapedImage) bool {
+	imageScraperConfig := s.Image
+
+	imagePerformersMap := imageScraperConfig.Performers
+	imageTagsMap := imageScraperConfig.Tags
+	imageStudioMap := imageScraperConfig.Studio
+
+	if imagePerformersMap != nil {
+		logger.Debug(`Processing image performers:`)
+		ret.Performers = processRelationships[models.ScrapedPerformer](ctx, s, imagePerformersMap, q)
+	}
+
+	if imageTagsMap != nil {
+		logger.Debug(`Processing image tags:`)
+		ret.Tags = processRelationships[models.ScrapedTag](ctx, s, imageTagsMap, q)
+	}
+
+	if imageStudioMap != nil {
+		logger.Debug(`Processing image studio:`)
+		studioResults := imageStudioMap.process(ctx, q, s.Common)
+
+		if len(studioResults) > 0 && resultIndex < len(studioResults) {
+			studio := &models.ScrapedStudio{}
+			// when doing a `search` scrape get the related studio
+			studioResults[resultIndex].apply(studio)
+			ret.Studio = studio
+		}
+	}
+
+	return len(ret.Performers) > 0 || len(ret.Tags) > 0 || ret.Studio != nil
+}
+
+func (s mappedScraper) scrapeImages(ctx context.Context, q mappedQuery) ([]*ScrapedImage, error) {
+	var ret []*ScrapedImage
+
+	imageScraperConfig := s.Image
+	if imageScraperConfig == nil {
+		return nil, nil
+	}
+
+	imageMap := imageScraperConfig.mappedConfig
+
+	logger.Debug(`Processing images:`)
+	results := imageMap.process(ctx, q, s.Common)
+	for i, r := range results {
+		logger.Debug(`Processing image:`)
+
+		var thisImage ScrapedImage
+		r.apply(&thisImage)
+		s.processImageRelationships(ctx, q, i, &thisImage)
+		ret = append(ret, &thisImage)
+	}
+
+	return ret, nil
+}
+
+func (s mappedScraper) scrapeImage(ctx context.Context, q mappedQuery) (*ScrapedImage, error) {
+	imageScraperConfig := s.Image
+	if imageScraperConfig == nil {
+		return nil, nil
+	}
+
+	imageMap := imageScraperConfig.mappedConfig
+
+	logger.Debug(`Processing image:`)
+	results := imageMap.process(ctx, q, s.Common)
+
+	var ret ScrapedImage
+	if len(results) > 0 {
+		results[0].apply(&ret)
+	}
+	hasRelationships := s.processImageRelationships(ctx, q, 0, &ret)
+
+	// only return if we have results or relationships
+	if len(results) > 0 || hasRelationships {
+		return &ret, nil
+	}
+
+	return nil, nil
+}
+
 func (s mappedScraper) scrapeGroup(ctx context.Context, q mappedQuery) (*models.ScrapedMovie, error) {
 	var ret models.ScrapedMovie
 
@@ -1127,3 +1215,86 @@ func (s mappedScraper) scrapeGroup(ctx context.Context, q mappedQuery) (*models.
 
 	return &ret, nil
 }
+
+// scrapeContent scrapes a single item of the content type from the query.
+func (s mappedScraper) scrapeContent(ctx context.Context, q mappedQuery, ty ScrapeContentType) (ScrapedContent, error) {
+	// if these just return the return values from scraper.scrape* functions then
+	// it ends up returning ScrapedContent(nil) rather than nil
+	switch ty {
+	case ScrapeContentTypePerformer:
+		ret, err := s.scrapePerformer(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case ScrapeContentTypeScene:
+		ret, err := s.scrapeScene(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case ScrapeContentTypeGallery:
+		ret, err := s.scrapeGallery(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case ScrapeContentTypeImage:
+		ret, err := s.scrapeImage(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
+		ret, err := s.scrapeGroup(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
+	}
+
+	return nil, ErrNotSupported
+}
+
+// scrapeContents scrapes the search results of the content type from the
+// query.
+func (s mappedScraper) scrapeContents(ctx context.Context, q mappedQuery, ty ScrapeContentType) ([]ScrapedContent, error) {
+	var content []ScrapedContent
+	switch ty {
+	case ScrapeContentTypePerformer:
+		performers, err := s.scrapePerformers(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range performers {
+			content = append(content, p)
+		}
+
+		return content, nil
+	case ScrapeContentTypeScene:
+		scenes, err := s.scrapeScenes(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range scenes {
+			content = append(content, s)
+		}
+
+		return content, nil
+	case ScrapeContentTypeImage:
+		images, err := s.scrapeImages(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range images {
+			content = append(content, i)
+		}
+
+		return content, nil
+	}
+
+	return nil, ErrNotSupported
+}
@@ -73,6 +73,50 @@ func queryURLParametersFromGallery(gallery *models.Gallery) queryURLParameters {
 	return ret
 }
 
+func queryURLParametersFromImage(image *models.Image) queryURLParameters {
+	ret := make(queryURLParameters)
+	ret["checksum"] = image.Checksum
+
+	if image.Path != "" {
+		ret["filename"] = filepath.Base(image.Path)
+	}
+	if image.Title != "" {
+		ret["title"] = image.Title
+	}
+
+	if len(image.URLs.List()) > 0 {
+		ret["url"] = image.URLs.List()[0]
+	}
+
+	return ret
+}
+
+func queryURLParametersFromScrapedImage(image ScrapedImageInput) queryURLParameters {
+	ret := make(queryURLParameters)
+
+	setField := func(field string, value *string) {
+		if value != nil {
+			ret[field] = *value
+		}
+	}
+
+	setField("title", image.Title)
+	setField("code", image.Code)
+	if len(image.URLs) > 0 {
+		setField("url", &image.URLs[0])
+	}
+	setField("date", image.Date)
+	setField("details", image.Details)
+	setField("photographer", image.Photographer)
+	if image.Path != nil && *image.Path != "" {
+		ret["filename"] = filepath.Base(*image.Path)
+	}
+	if len(image.Performers) > 0 {
+		ret["performers"] = strings.Join(image.Performers, ", ")
+	}
+	return ret
+}
+
 func (p queryURLParameters) applyReplacements(r queryURLReplacements) {
 	for k, v := range p {
 		rpl, found := r[k]
@@ -0,0 +1,58 @@
+package scraper
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/ratelimit"
+)
+
+// rateLimits holds the limiters of a scraper. The limiters are shared by all
+// requests made by the scraper, so that the limits apply across concurrent
+// scrapes, such as those made by batch operations.
+type rateLimits struct {
+	limiter        *ratelimit.Limiter
+	domainLimiters map[string]*ratelimit.Limiter
+	maxRetries     int
+}
+
+func newRateLimits(c *scraperRateLimit) *rateLimits {
+	if c == nil {
+		return nil
+	}
+
+	ret := &rateLimits{
+		limiter:    ratelimit.NewLimiter(c.RequestsPerMinute, c.MaxConcurrent),
+		maxRetries: c.MaxRetries,
+	}
+
+	if len(c.Domains) > 0 {
+		ret.domainLimiters = make(map[string]*ratelimit.Limiter)
+		for domain, o := range c.Domains {
+			if o == nil {
+				continue
+			}
+			ret.domainLimiters[strings.ToLower(domain)] = ratelimit.NewLimiter(o.RequestsPerMinute, o.MaxConcurrent)
+		}
+	}
+
+	return ret
+}
+
+// client returns a copy of client that applies the rate limits to its
+// requests. Returns client if l is nil.
+func (l *rateLimits) client(client *http.Client) *http.Client {
+	if l == nil {
+		return client
+	}
+
+	ret := *client
+	ret.Transport = &ratelimit.Transport{
+		Base:           client.Transport,
+		Limiter:        l.limiter,
+		DomainLimiters: l.domainLimiters,
+		MaxRetries:     l.maxRetries,
+	}
+
+	return &ret
+}
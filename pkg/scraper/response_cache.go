@@ -0,0 +1,178 @@
+package scraper
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+)
+
+// responseCacheDir is the subdirectory of the cache directory where scraper
+// responses are stored.
+const responseCacheDir = "scrapers"
+
+const cachedInfoExt = ".json"
+
+// responseCache is an on-disk cache of the raw responses to scraper requests,
+// keyed by scraper ID and URL. Each response is stored as two files: the
+// response body, and a json file with the details of the request.
+type responseCache struct {
+	path string
+	ttl  time.Duration
+}
+
+type cachedResponseInfo struct {
+	URL         string    `json:"url"`
+	ScraperID   string    `json:"scraper_id"`
+	ContentType string    `json:"content_type"`
+	Fetched     time.Time `json:"fetched"`
+}
+
+type cachedResponse struct {
+	cachedResponseInfo
+	body []byte
+}
+
+func newResponseCache(globalConfig GlobalConfig) *responseCache {
+	ret := &responseCache{
+		ttl: globalConfig.GetScraperCacheTTL(),
+	}
+
+	if cachePath := globalConfig.GetCachePath(); cachePath != "" {
+		ret.path = filepath.Join(cachePath, responseCacheDir)
+	}
+
+	return ret
+}
+
+// enabled returns true if responses should be read from and written to the
+// cache. Responses are always cached when replaying.
+func (c *responseCache) enabled(replay bool) bool {
+	return c.path != "" && (c.ttl > 0 || replay)
+}
+
+func (c *responseCache) key(scraperID string, url string) string {
+	h := sha256.Sum256([]byte(scraperID + "\n" + url))
+	return hex.EncodeToString(h[:])
+}
+
+// get returns the cached response for the url. Returns nil if the response
+// is not cached, or if it has expired. Expired responses are returned if
+// ignoreTTL is true.
+func (c *responseCache) get(scraperID string, url string, ignoreTTL bool) (*cachedResponse, error) {
+	fn := filepath.Join(c.path, c.key(scraperID, url))
+
+	infoData, err := os.ReadFile(fn + cachedInfoExt)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var info cachedResponseInfo
+	if err := json.Unmarshal(infoData, &info); err != nil {
+		return nil, fmt.Errorf("reading cached response info: %w", err)
+	}
+
+	if !ignoreTTL && time.Since(info.Fetched) > c.ttl {
+		return nil, nil
+	}
+
+	body, err := os.ReadFile(fn)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &cachedResponse{
+		cachedResponseInfo: info,
+		body:               body,
+	}, nil
+}
+
+// put stores the response for the url in the cache. The body is written
+// before the info file so that incomplete responses are never read.
+func (c *responseCache) put(scraperID string, url string, contentType string, body []byte) error {
+	if err := fsutil.EnsureDir(c.path); err != nil {
+		return err
+	}
+
+	fn := filepath.Join(c.path, c.key(scraperID, url))
+
+	info, err := json.Marshal(cachedResponseInfo{
+		URL:         url,
+		ScraperID:   scraperID,
+		ContentType: contentType,
+		Fetched:     time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(fn, body, 0644); err != nil {
+		return err
+	}
+
+	return os.WriteFile(fn+cachedInfoExt, info, 0644)
+}
+
+// purge removes the cached responses of the scraper. All cached responses
+// are removed if scraperID is empty. Returns the number of responses removed.
+func (c *responseCache) purge(scraperID string) (int, error) {
+	if c.path == "" {
+		return 0, nil
+	}
+
+	entries, err := os.ReadDir(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, cachedInfoExt) {
+			continue
+		}
+
+		fn := filepath.Join(c.path, strings.TrimSuffix(name, cachedInfoExt))
+
+		if scraperID != "" {
+			data, err := os.ReadFile(fn + cachedInfoExt)
+			if err != nil {
+				return count, err
+			}
+
+			var info cachedResponseInfo
+			if err := json.Unmarshal(data, &info); err != nil || info.ScraperID != scraperID {
+				continue
+			}
+		}
+
+		// remove the info file first so that the response is no longer used
+		if err := os.Remove(fn + cachedInfoExt); err != nil {
+			return count, err
+		}
+		if err := os.Remove(fn); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
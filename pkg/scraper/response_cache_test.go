@@ -0,0 +1,75 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	c := &responseCache{
+		path: t.TempDir(),
+		ttl:  time.Minute,
+	}
+
+	const (
+		url  = "https://example.com/scene/1"
+		body = "<html></html>"
+	)
+
+	got, err := c.get("a", url, false)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got != nil {
+		t.Errorf("get: expected nil for uncached response")
+	}
+
+	if err := c.put("a", url, "text/html", []byte(body)); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if err := c.put("b", url, "text/html", []byte(body)); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	got, err = c.get("a", url, false)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got == nil || string(got.body) != body || got.ContentType != "text/html" {
+		t.Errorf("get: unexpected cached response %v", got)
+	}
+
+	// expired responses are only returned when ignoring the ttl
+	c.ttl = time.Nanosecond
+	time.Sleep(time.Millisecond)
+
+	if got, _ := c.get("a", url, false); got != nil {
+		t.Errorf("get: expected nil for expired response")
+	}
+	if got, _ := c.get("a", url, true); got == nil {
+		t.Errorf("get: expected expired response when ignoring ttl")
+	}
+
+	n, err := c.purge("a")
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("purge: removed %d responses, expected 1", n)
+	}
+
+	if got, _ := c.get("a", url, true); got != nil {
+		t.Errorf("get: expected nil for purged response")
+	}
+	if got, _ := c.get("b", url, true); got == nil {
+		t.Errorf("get: expected response of other scraper to remain")
+	}
+
+	n, err = c.purge("")
+	if err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("purge: removed %d responses, expected 1", n)
+	}
+}
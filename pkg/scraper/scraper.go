@@ -34,6 +34,7 @@ const (
 	ScrapeContentTypeGroup     ScrapeContentType = "GROUP"
 	ScrapeContentTypePerformer ScrapeContentType = "PERFORMER"
 	ScrapeContentTypeScene     ScrapeContentType = "SCENE"
+	ScrapeContentTypeImage     ScrapeContentType = "IMAGE"
 )
 
 var AllScrapeContentType = []ScrapeContentType{
@@ -42,11 +43,12 @@ var AllScrapeContentType = []ScrapeContentType{
 	ScrapeContentTypeGroup,
 	ScrapeContentTypePerformer,
 	ScrapeContentTypeScene,
+	ScrapeContentTypeImage,
 }
 
 func (e ScrapeContentType) IsValid() bool {
 	switch e {
-	case ScrapeContentTypeGallery, ScrapeContentTypeMovie, ScrapeContentTypeGroup, ScrapeContentTypePerformer, ScrapeContentTypeScene:
+	case ScrapeContentTypeGallery, ScrapeContentTypeMovie, ScrapeContentTypeGroup, ScrapeContentTypePerformer, ScrapeContentTypeScene, ScrapeContentTypeImage:
 		return true
 	}
 	return false
@@ -86,6 +88,8 @@ type Scraper struct {
 	Group *ScraperSpec `json:"group"`
 	// Details for movie scraper
 	Movie *ScraperSpec `json:"movie"`
+	// Details for image scraper
+	Image *ScraperSpec `json:"image"`
 }
 
 type ScraperSpec struct {
@@ -159,6 +163,7 @@ type Input struct {
 	Performer *ScrapedPerformerInput
 	Scene     *ScrapedSceneInput
 	Gallery   *ScrapedGalleryInput
+	Image     *ScrapedImageInput
 }
 
 // populateURL populates the URL field of the input based on the
@@ -230,3 +235,11 @@ type galleryScraper interface {
 
 	viaGallery(ctx context.Context, client *http.Client, gallery *models.Gallery) (*ScrapedGallery, error)
 }
+
+// imageScraper is a scraper which supports image scrapes with
+// image data as the input.
+type imageScraper interface {
+	scraper
+
+	viaImage(ctx context.Context, client *http.Client, image *models.Image) (*ScrapedImage, error)
+}
@@ -205,6 +205,42 @@ func galleryInputFromGallery(gallery *models.Gallery) galleryInput {
 	return ret
 }
 
+type imageInput struct {
+	ID           string   `json:"id"`
+	Title        string   `json:"title"`
+	Code         string   `json:"code,omitempty"`
+	Urls         []string `json:"urls"`
+	Date         *string  `json:"date"`
+	Details      string   `json:"details"`
+	Photographer string   `json:"photographer,omitempty"`
+
+	Files []fileInput `json:"files,omitempty"`
+}
+
+func imageInputFromImage(image *models.Image) imageInput {
+	var date *string
+	if image.Date != nil {
+		v := image.Date.String()
+		date = &v
+	}
+
+	ret := imageInput{
+		ID:           strconv.Itoa(image.ID),
+		Title:        image.GetTitle(),
+		Code:         image.Code,
+		Urls:         image.URLs.List(),
+		Date:         date,
+		Details:      image.Details,
+		Photographer: image.Photographer,
+	}
+
+	for _, f := range image.Files.List() {
+		ret.Files = append(ret.Files, fileInputFromFile(*f.Base()))
+	}
+
+	return ret
+}
+
 var ErrScraperScript = errors.New("scraper script error")
 
 type scriptScraper struct {
@@ -336,6 +372,15 @@ func (s *scriptScraper) scrapeByName(ctx context.Context, name string, ty Scrape
 				ret = append(ret, &v)
 			}
 		}
+	case ScrapeContentTypeImage:
+		var images []ScrapedImage
+		err = s.runScraperScript(ctx, input, &images)
+		if err == nil {
+			for _, i := range images {
+				v := i
+				ret = append(ret, &v)
+			}
+		}
 	default:
 		return nil, ErrNotSupported
 	}
@@ -357,6 +402,9 @@ func (s *scriptScraper) scrapeByFragment(ctx context.Context, input Input) (Scra
 	case input.Scene != nil:
 		inString, err = json.Marshal(*input.Scene)
 		ty = ScrapeContentTypeScene
+	case input.Image != nil:
+		inString, err = json.Marshal(*input.Image)
+		ty = ScrapeContentTypeImage
 	}
 
 	if err != nil {
@@ -384,6 +432,10 @@ func (s *scriptScraper) scrape(ctx context.Context, input string, ty ScrapeConte
 		var scene *ScrapedScene
 		err := s.runScraperScript(ctx, input, &scene)
 		return scene, err
+	case ScrapeContentTypeImage:
+		var image *ScrapedImage
+		err := s.runScraperScript(ctx, input, &image)
+		return image, err
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
 		var movie *models.ScrapedMovie
 		err := s.runScraperScript(ctx, input, &movie)
@@ -421,6 +473,20 @@ func (s *scriptScraper) scrapeGalleryByGallery(ctx context.Context, gallery *mod
 	return ret, err
 }
 
+func (s *scriptScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	inString, err := json.Marshal(imageInputFromImage(image))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ret *ScrapedImage
+
+	err = s.runScraperScript(ctx, string(inString), &ret)
+
+	return ret, err
+}
+
 func handleScraperStderr(name string, scraperOutputReader io.ReadCloser) {
 	const scraperPrefix = "[Scrape / %s] "
 
@@ -29,7 +29,7 @@ func newStashScraper(scraper scraperTypeConfig, client *http.Client, config conf
 
 func (s *stashScraper) getStashClient() *graphql.Client {
 	url := s.config.StashServer.URL
-	return graphql.NewClient(url+"/graphql", nil)
+	return graphql.NewClient(url+"/graphql", s.client)
 }
 
 type stashFindPerformerNamePerformer struct {
@@ -82,7 +82,7 @@ type scrapedPerformerStash struct {
 }
 
 func (s *stashScraper) scrapeByFragment(ctx context.Context, input Input) (ScrapedContent, error) {
-	if input.Gallery != nil || input.Scene != nil {
+	if input.Gallery != nil || input.Scene != nil || input.Image != nil {
 		return nil, fmt.Errorf("%w: using stash scraper as a fragment scraper", ErrNotSupported)
 	}
 
@@ -306,6 +306,46 @@ func (s *stashScraper) scrapeGalleryByGallery(ctx context.Context, gallery *mode
 	return &ret, nil
 }
 
+type scrapedImageStash struct {
+	ID           string                   `graphql:"id" json:"id"`
+	Title        *string                  `graphql:"title" json:"title"`
+	Code         *string                  `graphql:"code" json:"code"`
+	Details      *string                  `graphql:"details" json:"details"`
+	Photographer *string                  `graphql:"photographer" json:"photographer"`
+	URLs         []string                 `graphql:"urls" json:"urls"`
+	Date         *string                  `graphql:"date" json:"date"`
+	Studio       *scrapedStudioStash      `graphql:"studio" json:"studio"`
+	Tags         []*scrapedTagStash       `graphql:"tags" json:"tags"`
+	Performers   []*scrapedPerformerStash `graphql:"performers" json:"performers"`
+}
+
+func (s *stashScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	var q struct {
+		FindImage *scrapedImageStash `graphql:"findImage(checksum: $c)"`
+	}
+
+	vars := map[string]interface{}{
+		"c": graphql.String(image.Checksum),
+	}
+
+	client := s.getStashClient()
+	if err := client.Query(ctx, &q, vars); err != nil {
+		return nil, err
+	}
+
+	if q.FindImage == nil {
+		return nil, nil
+	}
+
+	// need to copy back to a scraped image
+	ret := ScrapedImage{}
+	if err := copier.Copy(&ret, q.FindImage); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
 func (s *stashScraper) scrapeByURL(_ context.Context, _ string, _ ScrapeContentType) (ScrapedContent, error) {
 	return nil, ErrNotSupported
 }
@@ -0,0 +1,56 @@
+package stashbox
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/ratelimit"
+)
+
+type endpointLimiter struct {
+	requestsPerMinute int
+	maxConcurrent     int
+	limiter           *ratelimit.Limiter
+}
+
+// Clients are created for each operation, so the limiters of each endpoint
+// are kept here to apply the limits across operations.
+var (
+	limitersMutex sync.Mutex
+	limiters      = make(map[string]*endpointLimiter)
+)
+
+// getLimiter returns the limiter for the stash-box endpoint. The limiter is
+// replaced if the limits of the endpoint have changed.
+func getLimiter(box models.StashBox) *ratelimit.Limiter {
+	limitersMutex.Lock()
+	defer limitersMutex.Unlock()
+
+	l := limiters[box.Endpoint]
+	if l == nil || l.requestsPerMinute != box.MaxRequestsPerMinute || l.maxConcurrent != box.MaxConcurrentRequests {
+		l = &endpointLimiter{
+			requestsPerMinute: box.MaxRequestsPerMinute,
+			maxConcurrent:     box.MaxConcurrentRequests,
+			limiter:           ratelimit.NewLimiter(box.MaxRequestsPerMinute, box.MaxConcurrentRequests),
+		}
+		limiters[box.Endpoint] = l
+	}
+
+	return l.limiter
+}
+
+// newHTTPClient returns the http client used for requests to the stash-box
+// server, applying the configured limits.
+func newHTTPClient(box models.StashBox) *http.Client {
+	if box.MaxRequestsPerMinute <= 0 && box.MaxConcurrentRequests <= 0 && box.MaxRetries <= 0 {
+		return http.DefaultClient
+	}
+
+	return &http.Client{
+		Transport: &ratelimit.Transport{
+			Limiter:    getLimiter(box),
+			MaxRetries: box.MaxRetries,
+		},
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/ratelimit"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/scraper/stashbox/graphql"
 	"github.com/stashapp/stash/pkg/sliceutil"
@@ -81,9 +82,11 @@ func (r *Repository) WithReadTxn(ctx context.Context, fn txn.TxnFunc) error {
 
 // Client represents the client interface to a stash-box server instance.
 type Client struct {
-	client     *graphql.Client
-	repository Repository
-	box        models.StashBox
+	client *graphql.Client
+	// mutationClient is used for mutations, which are not retried
+	mutationClient *graphql.Client
+	repository     Repository
+	box            models.StashBox
 }
 
 // NewClient returns a new instance of a stash-box client.
@@ -92,12 +95,21 @@ func NewClient(box models.StashBox, repo Repository) *Client {
 		req.Header.Set("ApiKey", box.APIKey)
 	}
 
-	client := &graphql.Client{
-		Client: client.NewClient(http.DefaultClient, box.Endpoint, authHeader),
+	// queries may be retried if they fail
+	queryOptions := func(req *http.Request) {
+		authHeader(req)
+		ratelimit.MarkIdempotent(req)
 	}
 
+	httpClient := newHTTPClient(box)
+
 	return &Client{
-		client:     client,
+		client: &graphql.Client{
+			Client: client.NewClient(httpClient, box.Endpoint, queryOptions),
+		},
+		mutationClient: &graphql.Client{
+			Client: client.NewClient(httpClient, box.Endpoint, authHeader),
+		},
 		repository: repo,
 		box:        box,
 	}
@@ -346,7 +358,7 @@ func (c Client) SubmitStashBoxFingerprints(ctx context.Context, sceneIDs []strin
 
 func (c Client) submitStashBoxFingerprints(ctx context.Context, fingerprints []graphql.FingerprintSubmission) (bool, error) {
 	for _, fingerprint := range fingerprints {
-		_, err := c.client.SubmitFingerprint(ctx, fingerprint)
+		_, err := c.mutationClient.SubmitFingerprint(ctx, fingerprint)
 		if err != nil {
 			return false, err
 		}
@@ -5,12 +5,9 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"net"
 	"net/http"
 	"net/url"
-	"os"
 	"regexp"
-	"strings"
 	"time"
 
 	"github.com/chromedp/cdproto/cdp"
@@ -21,34 +18,109 @@ import (
 	"golang.org/x/net/html/charset"
 
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/ratelimit"
 )
 
 const scrapeDefaultSleep = time.Second * 2
 
+// cdpContentType is the content type of pages loaded using chrome cdp, which
+// are always returned as UTF-8.
+const cdpContentType = "text/html; charset=utf-8"
+
+// loadURL returns the response body of the url, decoded to UTF-8. Responses
+// are read from the response cache if present and not expired. If the
+// scraper is in replay mode, then cached responses are used regardless of
+// their age.
 func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperConfig config, globalConfig GlobalConfig) (io.Reader, error) {
+	body, contentType, err := loadCached(loadURL, scraperConfig, globalConfig, func() ([]byte, string, error) {
+		return fetchURL(ctx, loadURL, client, scraperConfig, globalConfig)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return charset.NewReader(bytes.NewReader(body), contentType)
+}
+
+// loadCached returns the cached response for the key if present, otherwise
+// it calls fetch and caches the response. key is usually the requested url.
+func loadCached(key string, scraperConfig config, globalConfig GlobalConfig, fetch func() ([]byte, string, error)) ([]byte, string, error) {
+	cache := newResponseCache(globalConfig)
+	replay := scraperConfig.DebugOptions != nil && scraperConfig.DebugOptions.Replay
+
+	if cache.enabled(replay) {
+		cached, err := cache.get(scraperConfig.ID, key, replay)
+		if err != nil {
+			logger.Warnf("[scraper] error reading cached response for %s: %v", key, err)
+		}
+
+		if cached != nil {
+			logger.Debugf("[scraper] using cached response for %s fetched at %v", key, cached.Fetched)
+			return cached.body, cached.ContentType, nil
+		}
+
+		if replay {
+			logger.Infof("[scraper] no cached response for %s, fetching for replay", key)
+		}
+	}
+
+	body, contentType, err := fetch()
+	if err != nil {
+		return nil, "", err
+	}
+
+	if cache.enabled(replay) {
+		if err := cache.put(scraperConfig.ID, key, contentType, body); err != nil {
+			logger.Warnf("[scraper] error caching response for %s: %v", key, err)
+		}
+	}
+
+	return body, contentType, nil
+}
+
+// fetchURL returns the raw response body of the url and its content type.
+func fetchURL(ctx context.Context, loadURL string, client *http.Client, scraperConfig config, globalConfig GlobalConfig) ([]byte, string, error) {
 	driverOptions := scraperConfig.DriverOptions
 	if driverOptions != nil && driverOptions.UseCDP {
+		// pages loaded using chrome dp are not requested using the client,
+		// so the rate limits of the scraper are applied here
+		if t, ok := client.Transport.(*ratelimit.Transport); ok {
+			u, err := url.Parse(loadURL)
+			if err != nil {
+				return nil, "", fmt.Errorf("error parsing url %s: %w", loadURL, err)
+			}
+
+			release, err := t.Wait(ctx, u.Hostname())
+			if err != nil {
+				return nil, "", err
+			}
+			defer release()
+		}
+
 		// get the page using chrome dp
-		return urlFromCDP(ctx, loadURL, *driverOptions, globalConfig)
+		return urlFromCDP(ctx, loadURL, *driverOptions, scraperConfig.ID, globalConfig)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, loadURL, nil)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	jar, err := scraperConfig.jar()
-	if err != nil {
-		return nil, fmt.Errorf("error creating cookie jar: %w", err)
-	}
+	return doRequest(client, req, scraperConfig, globalConfig, nil)
+}
 
-	u, err := url.Parse(loadURL)
+// doRequest makes the request using the cookies and headers of the scraper.
+// The request headers are set after the headers of the scraper driver, so
+// that they override them. Returns the raw response body and its content
+// type.
+func doRequest(client *http.Client, req *http.Request, scraperConfig config, globalConfig GlobalConfig, headers []*header) ([]byte, string, error) {
+	jar, err := scraperConfig.jar()
 	if err != nil {
-		return nil, fmt.Errorf("error parsing url %s: %w", loadURL, err)
+		return nil, "", fmt.Errorf("error creating cookie jar: %w", err)
 	}
 
-	// Fetch relevant cookies from the jar for url u and add them to the request
-	cookies := jar.Cookies(u)
+	// Fetch relevant cookies from the jar for the url and add them to the request
+	cookies := jar.Cookies(req.URL)
 	for _, cookie := range cookies {
 		req.AddCookie(cookie)
 	}
@@ -58,6 +130,7 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 		req.Header.Set("User-Agent", userAgent)
 	}
 
+	driverOptions := scraperConfig.DriverOptions
 	if driverOptions != nil { // setting the Headers after the UA allows us to override it from inside the scraper
 		for _, h := range driverOptions.Headers {
 			if h.Key != "" {
@@ -67,33 +140,43 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 		}
 	}
 
+	for _, h := range headers {
+		if h.Key != "" {
+			req.Header.Set(h.Key, h.Value)
+		}
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
+	defer resp.Body.Close()
+
 	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("http error %d:%s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		// drain the body so that the connection can be reused
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, "", fmt.Errorf("http error %d:%s", resp.StatusCode, http.StatusText(resp.StatusCode))
 	}
 
-	defer resp.Body.Close()
-
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
-	bodyReader := bytes.NewReader(body)
 	printCookies(jar, scraperConfig, "Jar cookies found for scraper urls")
-	return charset.NewReader(bodyReader, resp.Header.Get("Content-Type"))
+	return body, resp.Header.Get("Content-Type"), nil
 }
 
 // func urlFromCDP uses chrome cdp and DOM to load and process the url
 // if remote is set as true in the scraperConfig  it will try to use localhost:9222
-// else it will look for google-chrome in path
-func urlFromCDP(ctx context.Context, urlCDP string, driverOptions scraperDriverOptions, globalConfig GlobalConfig) (io.Reader, error) {
+// else it will look for google-chrome in path.
+// If the driver uses a session, the page is loaded in a new tab of the
+// browser session of the scraper. Returns the page html, or the captured
+// responses if captureJSON is set, and its content type.
+func urlFromCDP(ctx context.Context, urlCDP string, driverOptions scraperDriverOptions, scraperID string, globalConfig GlobalConfig) ([]byte, string, error) {
 
 	if !driverOptions.UseCDP {
-		return nil, fmt.Errorf("url shouldn't be fetched through CDP")
+		return nil, "", fmt.Errorf("url shouldn't be fetched through CDP")
 	}
 
 	sleepDuration := scrapeDefaultSleep
@@ -102,79 +185,35 @@ func urlFromCDP(ctx context.Context, urlCDP string, driverOptions scraperDriverO
 		sleepDuration = time.Duration(driverOptions.Sleep) * time.Second
 	}
 
-	// if scraperCDPPath is a remote address, then allocate accordingly
-	cdpPath := globalConfig.GetScraperCDPPath()
-	if cdpPath != "" {
-		var cancelAct context.CancelFunc
+	useSession := driverOptions.Session && scraperID != ""
 
-		if isCDPPathHTTP(globalConfig) || isCDPPathWS(globalConfig) {
-			remote := cdpPath
-
-			// -------------------------------------------------------------------
-			// #1023
-			// when chromium is listening over RDP it only accepts requests
-			// with host headers that are either IPs or `localhost`
-			cdpURL, err := url.Parse(remote)
-			if err != nil {
-				return nil, fmt.Errorf("failed to parse CDP Path: %v", err)
-			}
-			hostname := cdpURL.Hostname()
-			if hostname != "localhost" {
-				if net.ParseIP(hostname) == nil { // not an IP
-					addr, err := net.LookupIP(hostname)
-					if err != nil || len(addr) == 0 { // can not resolve to IP
-						return nil, fmt.Errorf("CDP: hostname <%s> can not be resolved", hostname)
-					}
-					if len(addr[0]) == 0 { // nil IP
-						return nil, fmt.Errorf("CDP: hostname <%s> resolved to nil", hostname)
-					}
-					// addr is a valid IP
-					// replace the host part of the cdpURL with the IP
-					cdpURL.Host = strings.Replace(cdpURL.Host, hostname, addr[0].String(), 1)
-					// use that for remote
-					remote = cdpURL.String()
-				}
-			}
-			// --------------------------------------------------------------------
-
-			// if CDPPath is http(s) then we need to get the websocket URL
-			if isCDPPathHTTP(globalConfig) {
-				var err error
-				remote, err = getRemoteCDPWSAddress(ctx, remote)
-				if err != nil {
-					return nil, err
-				}
-			}
-
-			ctx, cancelAct = chromedp.NewRemoteAllocator(ctx, remote)
-		} else {
-			// use a temporary user directory for chrome
-			dir, err := os.MkdirTemp("", "stash-chromedp")
-			if err != nil {
-				return nil, err
-			}
-			defer os.RemoveAll(dir)
-
-			opts := append(chromedp.DefaultExecAllocatorOptions[:],
-				chromedp.UserDataDir(dir),
-				chromedp.ExecPath(cdpPath),
-			)
-			if globalConfig.GetProxy() != "" {
-				url, _, _ := splitProxyAuth(globalConfig.GetProxy())
-				opts = append(opts, chromedp.ProxyServer(url))
-			}
-
-			ctx, cancelAct = chromedp.NewExecAllocator(ctx, opts...)
+	var cancelAct context.CancelFunc
+	if useSession {
+		session, err := acquireCDPSession(scraperID, driverOptions, globalConfig)
+		if err != nil {
+			return nil, "", err
+		}
+		defer session.release()
+
+		// the tab is not derived from the request context, so close it if
+		// the request is cancelled
+		reqCtx := ctx
+		ctx, cancelAct = chromedp.NewContext(session.ctx)
+		stop := context.AfterFunc(reqCtx, cancelAct)
+		defer stop()
+	} else {
+		allocCtx, cancelAlloc, err := newCDPAllocator(ctx, globalConfig)
+		if err != nil {
+			return nil, "", err
 		}
+		defer cancelAlloc()
 
-		defer cancelAct()
+		ctx, cancelAct = chromedp.NewContext(allocCtx)
 	}
-
-	ctx, cancel := chromedp.NewContext(ctx)
-	defer cancel()
+	defer cancelAct()
 
 	// add a fixed timeout for the http request
-	ctx, cancel = context.WithTimeout(ctx, scrapeGetTimeout)
+	ctx, cancel := context.WithTimeout(ctx, scrapeGetTimeout)
 	defer cancel()
 
 	var res string
@@ -213,23 +252,51 @@ func urlFromCDP(ctx context.Context, urlCDP string, driverOptions scraperDriverO
 		})
 	}
 
-	err := chromedp.Run(ctx,
-		network.Enable(),
-		setCDPCookies(driverOptions),
+	var capture *cdpCapture
+	if driverOptions.CaptureJSON != "" {
+		var err error
+		capture, err = newCDPCapture(driverOptions.CaptureJSON)
+		if err != nil {
+			return nil, "", err
+		}
+		capture.listen(ctx)
+	}
+
+	tasks := chromedp.Tasks{network.Enable()}
+
+	// cookies of sessions are set when the session is started
+	if !useSession {
+		tasks = append(tasks, setCDPCookies(driverOptions))
+	}
+
+	tasks = append(tasks,
 		printCDPCookies(driverOptions, "Cookies found"),
 		network.SetExtraHTTPHeaders(network.Headers(headers)),
 		chromedp.Navigate(urlCDP),
 		chromedp.Sleep(sleepDuration),
 		setCDPClicks(driverOptions),
-		chromedp.OuterHTML("html", &res, chromedp.ByQuery),
-		printCDPCookies(driverOptions, "Cookies set"),
+		cdpActions(driverOptions),
 	)
 
-	if err != nil {
-		return nil, err
+	if capture == nil {
+		tasks = append(tasks, chromedp.OuterHTML("html", &res, chromedp.ByQuery))
+	}
+
+	tasks = append(tasks, printCDPCookies(driverOptions, "Cookies set"))
+
+	if err := chromedp.Run(ctx, tasks...); err != nil {
+		return nil, "", err
+	}
+
+	if capture != nil {
+		body, err := capture.result()
+		if err != nil {
+			return nil, "", err
+		}
+		return body, cdpCaptureContentType, nil
 	}
 
-	return strings.NewReader(res), nil
+	return []byte(res), cdpContentType, nil
 }
 
 // click all xpaths listed in the scraper config
@@ -83,6 +83,12 @@ func (s *xpathScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeCon
 			return nil, err
 		}
 		return ret, nil
+	case ScrapeContentTypeImage:
+		ret, err := scraper.scrapeImage(ctx, q)
+		if err != nil || ret == nil {
+			return nil, err
+		}
+		return ret, nil
 	case ScrapeContentTypeMovie, ScrapeContentTypeGroup:
 		ret, err := scraper.scrapeGroup(ctx, q)
 		if err != nil || ret == nil {
@@ -139,6 +145,16 @@ func (s *xpathScraper) scrapeByName(ctx context.Context, name string, ty ScrapeC
 			content = append(content, s)
 		}
 
+		return content, nil
+	case ScrapeContentTypeImage:
+		images, err := scraper.scrapeImages(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range images {
+			content = append(content, i)
+		}
+
 		return content, nil
 	}
 
@@ -175,6 +191,8 @@ func (s *xpathScraper) scrapeByFragment(ctx context.Context, input Input) (Scrap
 		return nil, fmt.Errorf("%w: cannot use an xpath scraper as a gallery fragment scraper", ErrNotSupported)
 	case input.Performer != nil:
 		return nil, fmt.Errorf("%w: cannot use an xpath scraper as a performer fragment scraper", ErrNotSupported)
+	case input.Image != nil:
+		return s.scrapeImageByFragment(ctx, *input.Image)
 	case input.Scene == nil:
 		return nil, fmt.Errorf("%w: scene input is nil", ErrNotSupported)
 	}
@@ -228,6 +246,54 @@ func (s *xpathScraper) scrapeGalleryByGallery(ctx context.Context, gallery *mode
 	return scraper.scrapeGallery(ctx, q)
 }
 
+func (s *xpathScraper) scrapeImageByFragment(ctx context.Context, image ScrapedImageInput) (ScrapedContent, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromScrapedImage(image)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getXpathScraper()
+
+	if scraper == nil {
+		return nil, errors.New("xpath scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getXPathQuery(doc)
+	return scraper.scrapeContent(ctx, q, ScrapeContentTypeImage)
+}
+
+func (s *xpathScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromImage(image)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getXpathScraper()
+
+	if scraper == nil {
+		return nil, errors.New("xpath scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getXPathQuery(doc)
+	return scraper.scrapeImage(ctx, q)
+}
+
 func (s *xpathScraper) loadURL(ctx context.Context, url string) (*html.Node, error) {
 	r, err := loadURL(ctx, url, s.client, s.config, s.globalConfig)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/antchfx/htmlquery"
 	"github.com/stashapp/stash/pkg/models"
@@ -838,6 +839,14 @@ func (mockGlobalConfig) GetProxy() string {
 	return ""
 }
 
+func (mockGlobalConfig) GetCachePath() string {
+	return ""
+}
+
+func (mockGlobalConfig) GetScraperCacheTTL() time.Duration {
+	return 0
+}
+
 func TestSubScrape(t *testing.T) {
 	retHTML := `
 	<div>
@@ -1,13 +1,18 @@
 package session
 
+import "github.com/stashapp/stash/pkg/models"
+
 type ExternalAccessConfig interface {
-	HasCredentials() bool
+	AuthenticationRequired() bool
 	GetDangerousAllowPublicWithoutAuth() bool
 	GetSecurityTripwireAccessedFromPublicInternet() string
 	IsNewSystem() bool
 }
 
 type SessionConfig interface {
+	ExternalAuthConfig
+	RestrictionConfig
+
 	GetUsername() string
 	GetAPIKey() string
 
@@ -15,3 +20,35 @@ type SessionConfig interface {
 	GetMaxSessionAge() int
 	ValidateCredentials(username string, password string) bool
 }
+
+// ExternalAuthConfig configures authentication by an OpenID Connect provider
+// or by a trusted reverse proxy.
+type ExternalAuthConfig interface {
+	GetOIDCIssuer() string
+	GetOIDCClientID() string
+	GetOIDCClientSecret() string
+	GetOIDCRedirectURL() string
+	GetOIDCScopes() []string
+	GetOIDCUsernameClaim() string
+
+	GetTrustedHeader() string
+	GetTrustedHeaderProxies() []string
+
+	GetExternalAuthCreateUsers() bool
+	GetExternalAuthDefaultRole() models.UserRole
+}
+
+// RestrictionConfig configures the restriction profiles that are not stored
+// in the session.
+type RestrictionConfig interface {
+	AuthenticationRequired() bool
+
+	// GetAPIKeyRestrictionProfile returns the ID of the restriction profile
+	// applied to requests authenticated with the configured API key.
+	GetAPIKeyRestrictionProfile() int
+
+	// GetRestrictionProfile returns the ID of the restriction profile applied
+	// to all requests when authentication is not required.
+	GetRestrictionProfile() int
+	SetRestrictionProfile(id int) error
+}
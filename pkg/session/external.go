@@ -0,0 +1,136 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// ErrUnknownExternalUser is returned when an externally authenticated user
+// does not have a local user, and local users are not created automatically.
+var ErrUnknownExternalUser = errors.New("externally authenticated user does not have a local user account")
+
+// trustedHeaderIssuer is the issuer of external identities provided by the
+// trusted header.
+const trustedHeaderIssuer = "trusted_header"
+
+// externalIdentity is a user authenticated by an external identity provider.
+// The issuer and subject uniquely identify the user. The username is only
+// used as the name of a newly created local user, since it is not guaranteed
+// to be unique or stable.
+type externalIdentity struct {
+	issuer   string
+	subject  string
+	username string
+}
+
+// findExternalUser returns the local user linked to the external identity.
+// If no local user is linked, one is created if configured to do so.
+// External identities are never mapped to the configured user.
+func (s *Store) findExternalUser(ctx context.Context, identity externalIdentity) (userID string, user *models.User, err error) {
+	if identity.issuer == "" || identity.subject == "" {
+		return "", nil, ErrUnauthorized
+	}
+
+	user, err = s.findUser(ctx, func(ctx context.Context) (*models.User, error) {
+		return s.repository.User.FindByExternalIdentity(ctx, identity.issuer, identity.subject)
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	if user == nil {
+		if !s.config.GetExternalAuthCreateUsers() {
+			return "", nil, ErrUnknownExternalUser
+		}
+
+		user, err = s.createExternalUser(ctx, identity)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	return user.Username, user, nil
+}
+
+func (s *Store) createExternalUser(ctx context.Context, identity externalIdentity) (*models.User, error) {
+	if s.repository.User == nil {
+		return nil, ErrUnknownExternalUser
+	}
+
+	username := identity.username
+	if username == "" {
+		username = identity.subject
+	}
+
+	// don't create a user that could be confused with the configured user
+	if strings.EqualFold(username, s.config.GetUsername()) {
+		return nil, fmt.Errorf("%w: username %q is in use", ErrUnknownExternalUser, username)
+	}
+
+	// the user cannot log in with a password until one is set by an admin
+	password := make([]byte, 32)
+	if _, err := rand.Read(password); err != nil {
+		return nil, err
+	}
+
+	hash, err := HashPassword(hex.EncodeToString(password))
+	if err != nil {
+		return nil, err
+	}
+
+	newUser := models.NewUser()
+	newUser.Username = username
+	newUser.PasswordHash = hash
+	newUser.Role = s.config.GetExternalAuthDefaultRole()
+	newUser.ExternalIssuer = identity.issuer
+	newUser.ExternalSubject = identity.subject
+
+	if err := s.repository.WithTxn(ctx, func(ctx context.Context) error {
+		// an existing local user with the same name is not linked, since the
+		// external username may be chosen by the user
+		existing, err := s.repository.User.FindByUsername(ctx, username)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("%w: username %q is in use", ErrUnknownExternalUser, username)
+		}
+
+		return s.repository.User.Create(ctx, &newUser)
+	}); err != nil {
+		return nil, fmt.Errorf("creating user: %w", err)
+	}
+
+	logger.Infof("Created %s user for externally authenticated user", newUser.Role)
+
+	return &newUser, nil
+}
+
+// loginExternal starts a session for the externally authenticated user.
+func (s *Store) loginExternal(w http.ResponseWriter, r *http.Request, identity externalIdentity) error {
+	userID, user, err := s.findExternalUser(r.Context(), identity)
+	if err != nil {
+		return err
+	}
+
+	// ignore error - we want a new session regardless
+	newSession, _ := s.sessionStore.Get(r, cookieName)
+	setSessionUser(newSession, userID, user)
+
+	if err := newSession.Save(r, w); err != nil {
+		return err
+	}
+
+	// don't leak the name
+	logger.Info("User logged in")
+
+	return nil
+}
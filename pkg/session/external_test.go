@@ -0,0 +1,201 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+type externalConfig struct {
+	SessionConfig
+
+	username    string
+	apiKey      string
+	createUsers bool
+}
+
+func (c *externalConfig) GetUsername() string {
+	return c.username
+}
+
+func (c *externalConfig) GetAPIKey() string {
+	return c.apiKey
+}
+
+func (c *externalConfig) GetTrustedHeader() string {
+	return ""
+}
+
+func (c *externalConfig) GetExternalAuthCreateUsers() bool {
+	return c.createUsers
+}
+
+func (c *externalConfig) GetExternalAuthDefaultRole() models.UserRole {
+	return models.UserRoleViewer
+}
+
+// userStore is an in-memory user store.
+type userStore struct {
+	models.UserReaderWriter
+
+	users []*models.User
+}
+
+func (s *userStore) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	for _, u := range s.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *userStore) FindByAPIKeyHash(ctx context.Context, apiKeyHash string) (*models.User, error) {
+	for _, u := range s.users {
+		if u.APIKeyHash != "" && u.APIKeyHash == apiKeyHash {
+			return u, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *userStore) FindByExternalIdentity(ctx context.Context, issuer string, subject string) (*models.User, error) {
+	for _, u := range s.users {
+		if u.ExternalIssuer == issuer && u.ExternalSubject == subject {
+			return u, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *userStore) Create(ctx context.Context, newUser *models.User) error {
+	newUser.ID = len(s.users) + 1
+	s.users = append(s.users, newUser)
+	return nil
+}
+
+func TestFindExternalUser(t *testing.T) {
+	const issuer = "https://issuer"
+
+	linked := &models.User{
+		ID:              1,
+		Username:        "linked",
+		Role:            models.UserRoleEditor,
+		ExternalIssuer:  issuer,
+		ExternalSubject: "linked-sub",
+	}
+	local := &models.User{
+		ID:       2,
+		Username: "local",
+		Role:     models.UserRoleAdmin,
+	}
+
+	testCases := []struct {
+		name        string
+		createUsers bool
+		identity    externalIdentity
+		wantUserID  string
+		wantErr     error
+	}{
+		{
+			"linked user",
+			false,
+			externalIdentity{issuer, "linked-sub", "renamed"},
+			"linked",
+			nil,
+		},
+		{
+			"same subject from other issuer",
+			false,
+			externalIdentity{"https://other", "linked-sub", "linked"},
+			"",
+			ErrUnknownExternalUser,
+		},
+		{
+			"configured username",
+			true,
+			externalIdentity{issuer, "admin-sub", "Admin"},
+			"",
+			ErrUnknownExternalUser,
+		},
+		{
+			"local username",
+			true,
+			externalIdentity{issuer, "local-sub", "local"},
+			"",
+			ErrUnknownExternalUser,
+		},
+		{
+			"create user",
+			true,
+			externalIdentity{issuer, "new-sub", "new"},
+			"new",
+			nil,
+		},
+		{
+			"create user without username",
+			true,
+			externalIdentity{issuer, "other-sub", ""},
+			"other-sub",
+			nil,
+		},
+		{
+			"missing subject",
+			true,
+			externalIdentity{issuer, "", "new"},
+			"",
+			ErrUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Store{
+				config: &externalConfig{
+					username:    "admin",
+					createUsers: tc.createUsers,
+				},
+				repository: Repository{
+					TxnManager: &mocks.Database{},
+					User:       &userStore{users: []*models.User{linked, local}},
+				},
+			}
+
+			userID, user, err := s.findExternalUser(context.Background(), tc.identity)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("findExternalUser() error = %v, want %v", err, tc.wantErr)
+			}
+
+			if userID != tc.wantUserID {
+				t.Errorf("findExternalUser() userID = %q, want %q", userID, tc.wantUserID)
+			}
+
+			if tc.wantErr != nil {
+				return
+			}
+
+			// external users are never the configured user
+			if user == nil {
+				t.Fatal("findExternalUser() user = nil")
+			}
+
+			if user.ExternalIssuer != tc.identity.issuer || user.ExternalSubject != tc.identity.subject {
+				t.Errorf("findExternalUser() user identity = %s/%s, want %s/%s", user.ExternalIssuer, user.ExternalSubject, tc.identity.issuer, tc.identity.subject)
+			}
+
+			if user.ID == linked.ID {
+				return
+			}
+
+			if user.Role != models.UserRoleViewer {
+				t.Errorf("findExternalUser() user role = %v, want %v", user.Role, models.UserRoleViewer)
+			}
+		})
+	}
+}
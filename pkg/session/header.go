@@ -0,0 +1,68 @@
+package session
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// trustedHeaderUsername returns the username in the trusted header, if the
+// request was made by a trusted proxy. Returns an empty string if header
+// authentication is disabled or does not apply to the request.
+func (s *Store) trustedHeaderUsername(r *http.Request) string {
+	header := s.config.GetTrustedHeader()
+	if header == "" {
+		return ""
+	}
+
+	username := strings.TrimSpace(r.Header.Get(header))
+	if username == "" {
+		return ""
+	}
+
+	// the header is only trusted from the proxy itself, so the remote address
+	// is used rather than any forwarded address
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	if !ipInList(net.ParseIP(host), s.config.GetTrustedHeaderProxies()) {
+		logger.Warnf("Ignoring %s header from untrusted address %s", header, host)
+		return ""
+	}
+
+	return username
+}
+
+// ipInList returns true if ip matches any of the IP addresses or CIDR ranges
+// in list.
+func ipInList(ip net.IP, list []string) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, v := range list {
+		if strings.Contains(v, "/") {
+			_, ipNet, err := net.ParseCIDR(v)
+			if err != nil {
+				logger.Warnf("Invalid trusted proxy range %q: %v", v, err)
+				continue
+			}
+
+			if ipNet.Contains(ip) {
+				return true
+			}
+
+			continue
+		}
+
+		if other := net.ParseIP(v); other != nil && other.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
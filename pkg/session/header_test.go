@@ -0,0 +1,29 @@
+package session
+
+import (
+	"net"
+	"testing"
+)
+
+func TestIPInList(t *testing.T) {
+	list := []string{"10.0.0.1", "192.168.1.0/24", "fd00::/8", "invalid/99"}
+
+	testCases := []struct {
+		ip   string
+		want bool
+	}{
+		{"10.0.0.1", true},
+		{"10.0.0.2", false},
+		{"192.168.1.200", true},
+		{"192.168.2.1", false},
+		{"fd00::1", true},
+		{"fe80::1", false},
+		{"", false},
+	}
+
+	for _, tc := range testCases {
+		if got := ipInList(net.ParseIP(tc.ip), list); got != tc.want {
+			t.Errorf("ipInList(%q) = %v, want %v", tc.ip, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,495 @@
+package session
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	oidcCookieName = "oidc"
+
+	oidcStateKey     = "state"
+	oidcNonceKey     = "nonce"
+	oidcVerifierKey  = "verifier"
+	oidcRedirectKey  = "redirect"
+	oidcReturnURLKey = "returnURL"
+
+	// the login must be completed within this time
+	oidcFlowMaxAge = 10 * 60
+
+	oidcHTTPTimeout = 30 * time.Second
+
+	// minimum time between fetching the signing keys of the provider
+	oidcKeysRefreshInterval = time.Minute
+)
+
+var ErrOIDCDisabled = errors.New("OpenID Connect login is not configured")
+
+// oidcMetadata is the subset of the provider metadata used to log in.
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// oidcProvider caches the metadata and signing keys of the configured
+// OpenID Connect provider.
+type oidcProvider struct {
+	client *http.Client
+
+	mutex       sync.Mutex
+	issuer      string
+	metadata    *oidcMetadata
+	keys        map[string]interface{}
+	keysFetched time.Time
+}
+
+func newOIDCProvider() *oidcProvider {
+	return &oidcProvider{
+		client: &http.Client{
+			Timeout: oidcHTTPTimeout,
+		},
+	}
+}
+
+func (p *oidcProvider) getJSON(ctx context.Context, u string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status %s", u, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// getMetadata returns the metadata of the issuer, fetching it using OpenID
+// Connect discovery if the issuer has changed.
+func (p *oidcProvider) getMetadata(ctx context.Context, issuer string) (*oidcMetadata, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.metadata != nil && p.issuer == issuer {
+		return p.metadata, nil
+	}
+
+	var ret oidcMetadata
+	discoveryURL := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	if err := p.getJSON(ctx, discoveryURL, &ret); err != nil {
+		return nil, fmt.Errorf("getting provider metadata: %w", err)
+	}
+
+	if strings.TrimSuffix(ret.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, fmt.Errorf("provider metadata issuer %q does not match configured issuer %q", ret.Issuer, issuer)
+	}
+
+	if ret.AuthorizationEndpoint == "" || ret.TokenEndpoint == "" || ret.JWKSURI == "" {
+		return nil, errors.New("provider metadata is missing required endpoints")
+	}
+
+	p.issuer = issuer
+	p.metadata = &ret
+	p.keys = nil
+	p.keysFetched = time.Time{}
+
+	return p.metadata, nil
+}
+
+// getKey returns the signing key with the provided key ID. The keys are
+// fetched again if the key is not found, to handle key rotation.
+func (p *oidcProvider) getKey(ctx context.Context, metadata *oidcMetadata, kid string) (interface{}, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if key := p.findKey(kid); key != nil {
+		return key, nil
+	}
+
+	if time.Since(p.keysFetched) < oidcKeysRefreshInterval {
+		return nil, fmt.Errorf("signing key %q not found", kid)
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.getJSON(ctx, metadata.JWKSURI, &jwks); err != nil {
+		return nil, fmt.Errorf("getting signing keys: %w", err)
+	}
+
+	p.keysFetched = time.Now()
+	p.keys = make(map[string]interface{})
+	for _, k := range jwks.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			logger.Warnf("Ignoring OpenID Connect signing key %q: %v", k.Kid, err)
+			continue
+		}
+
+		p.keys[k.Kid] = key
+	}
+
+	if key := p.findKey(kid); key != nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("signing key %q not found", kid)
+}
+
+// findKey returns the key with the provided ID. If the ID is empty, the key
+// is returned if the provider has a single key.
+func (p *oidcProvider) findKey(kid string) interface{} {
+	if kid == "" && len(p.keys) == 1 {
+		for _, k := range p.keys {
+			return k
+		}
+	}
+
+	return p.keys[kid]
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("decoding modulus: %w", err)
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("decoding exponent: %w", err)
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("decoding x: %w", err)
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("decoding y: %w", err)
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// OIDCEnabled returns true if OpenID Connect login is configured.
+func (s *Store) OIDCEnabled() bool {
+	return s.config.GetOIDCIssuer() != "" && s.config.GetOIDCClientID() != ""
+}
+
+// StartOIDCLogin returns the URL of the provider's authorization endpoint to
+// redirect the user to. The state of the login is stored in a cookie, to be
+// checked by FinishOIDCLogin. redirectURL is used if a redirect URL is not
+// configured. The user is redirected to returnURL once logged in.
+func (s *Store) StartOIDCLogin(w http.ResponseWriter, r *http.Request, redirectURL string, returnURL string) (string, error) {
+	if !s.OIDCEnabled() {
+		return "", ErrOIDCDisabled
+	}
+
+	metadata, err := s.oidc.getMetadata(r.Context(), s.config.GetOIDCIssuer())
+	if err != nil {
+		return "", err
+	}
+
+	if configured := s.config.GetOIDCRedirectURL(); configured != "" {
+		redirectURL = configured
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return "", err
+	}
+
+	// ignore error - we want a new flow regardless
+	flow, _ := s.sessionStore.Get(r, oidcCookieName)
+	flow.Options.MaxAge = oidcFlowMaxAge
+	flow.Values[oidcStateKey] = state
+	flow.Values[oidcNonceKey] = nonce
+	flow.Values[oidcVerifierKey] = verifier
+	flow.Values[oidcRedirectKey] = redirectURL
+	flow.Values[oidcReturnURLKey] = returnURL
+
+	if err := flow.Save(r, w); err != nil {
+		return "", err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+
+	scopes := append([]string{"openid"}, s.config.GetOIDCScopes()...)
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", s.config.GetOIDCClientID())
+	q.Set("redirect_uri", redirectURL)
+	q.Set("scope", strings.Join(scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	q.Set("code_challenge_method", "S256")
+
+	u, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("parsing authorization endpoint: %w", err)
+	}
+
+	// preserve any query parameters of the endpoint
+	existing := u.Query()
+	for k, v := range q {
+		existing[k] = v
+	}
+	u.RawQuery = existing.Encode()
+
+	return u.String(), nil
+}
+
+// FinishOIDCLogin handles the redirect from the provider. It exchanges the
+// authorization code for an ID token, and logs in the local user that the
+// token identifies. Returns the URL to redirect the user to.
+func (s *Store) FinishOIDCLogin(w http.ResponseWriter, r *http.Request) (string, error) {
+	if !s.OIDCEnabled() {
+		return "", ErrOIDCDisabled
+	}
+
+	flow, err := s.sessionStore.Get(r, oidcCookieName)
+	if err != nil || flow.IsNew {
+		return "", errors.New("login state not found or expired")
+	}
+
+	state, _ := flow.Values[oidcStateKey].(string)
+	nonce, _ := flow.Values[oidcNonceKey].(string)
+	verifier, _ := flow.Values[oidcVerifierKey].(string)
+	redirectURL, _ := flow.Values[oidcRedirectKey].(string)
+	returnURL, _ := flow.Values[oidcReturnURLKey].(string)
+
+	// the state may only be used once
+	flow.Options.MaxAge = -1
+	if err := flow.Save(r, w); err != nil {
+		return "", err
+	}
+
+	q := r.URL.Query()
+	if e := q.Get("error"); e != "" {
+		return "", fmt.Errorf("provider returned error: %s %s", e, q.Get("error_description"))
+	}
+
+	if state == "" || q.Get("state") != state {
+		return "", errors.New("login state does not match")
+	}
+
+	code := q.Get("code")
+	if code == "" {
+		return "", errors.New("authorization code not provided")
+	}
+
+	ctx := r.Context()
+	issuer := s.config.GetOIDCIssuer()
+	metadata, err := s.oidc.getMetadata(ctx, issuer)
+	if err != nil {
+		return "", err
+	}
+
+	idToken, err := s.exchangeOIDCCode(ctx, metadata, code, verifier, redirectURL)
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := s.verifyOIDCToken(ctx, metadata, idToken, nonce)
+	if err != nil {
+		return "", fmt.Errorf("verifying ID token: %w", err)
+	}
+
+	// the user is identified by the subject, which is unique and stable for
+	// the issuer. The username claim is only used to name new users.
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return "", errors.New("ID token does not contain the sub claim")
+	}
+
+	username, _ := claims[s.config.GetOIDCUsernameClaim()].(string)
+
+	identity := externalIdentity{
+		issuer:   issuer,
+		subject:  subject,
+		username: username,
+	}
+
+	if err := s.loginExternal(w, r, identity); err != nil {
+		return "", err
+	}
+
+	return returnURL, nil
+}
+
+func (s *Store) exchangeOIDCCode(ctx context.Context, metadata *oidcMetadata, code string, verifier string, redirectURL string) (string, error) {
+	clientID := s.config.GetOIDCClientID()
+	clientSecret := s.config.GetOIDCClientSecret()
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURL)
+	form.Set("code_verifier", verifier)
+	if clientSecret == "" {
+		// public client
+		form.Set("client_id", clientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if clientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+
+	resp, err := s.oidc.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("exchanging authorization code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading token response: %w", err)
+	}
+
+	var tokenResp struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return "", fmt.Errorf("decoding token response (status %s): %w", resp.Status, err)
+	}
+
+	if tokenResp.Error != "" {
+		return "", fmt.Errorf("exchanging authorization code: %s %s", tokenResp.Error, tokenResp.ErrorDescription)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("exchanging authorization code: unexpected status %s", resp.Status)
+	}
+
+	if tokenResp.IDToken == "" {
+		return "", errors.New("token response does not contain an ID token")
+	}
+
+	return tokenResp.IDToken, nil
+}
+
+func (s *Store) verifyOIDCToken(ctx context.Context, metadata *oidcMetadata, idToken string, nonce string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := jwt.ParseWithClaims(idToken, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return s.oidc.getKey(ctx, metadata, kid)
+	}, jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}))
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("token has expired or has no expiry time")
+	}
+
+	if !claims.VerifyIssuer(metadata.Issuer, true) {
+		return nil, errors.New("invalid issuer")
+	}
+
+	if !claims.VerifyAudience(s.config.GetOIDCClientID(), true) {
+		return nil, errors.New("invalid audience")
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, errors.New("invalid nonce")
+	}
+
+	return claims, nil
+}
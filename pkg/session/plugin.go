@@ -8,6 +8,7 @@ import (
 	"github.com/gorilla/securecookie"
 	"github.com/gorilla/sessions"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/plugin/hook"
 )
 
@@ -66,11 +67,18 @@ func (s *Store) MakePluginCookie(ctx context.Context) *http.Cookie {
 
 	session := sessions.NewSession(s.sessionStore, cookieName)
 	if currentUser != nil {
-		session.Values[userIDKey] = *currentUser
+		setSessionUser(session, *currentUser, GetCurrentUser(ctx))
 	}
 
 	session.Values[visitedPluginHooksKey] = visitedPlugins
 
+	// plugins must not be able to bypass the restriction of the session.
+	// Profiles assigned to the configured API key are not otherwise carried
+	// by the cookie.
+	if r := models.GetContentRestriction(ctx); r != nil {
+		session.Values[restrictionProfileKey] = r.ProfileID
+	}
+
 	encoded, err := securecookie.EncodeMulti(session.Name(), session.Values,
 		s.sessionStore.Codecs...)
 	if err != nil {
@@ -0,0 +1,34 @@
+package session
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// Repository provides access to local user accounts and restriction
+// profiles.
+type Repository struct {
+	TxnManager models.TxnManager
+
+	// the writer is used to create users for externally authenticated users
+	User               models.UserReaderWriter
+	RestrictionProfile models.RestrictionProfileReader
+}
+
+func NewRepository(repo models.Repository) Repository {
+	return Repository{
+		TxnManager:         repo.TxnManager,
+		User:               repo.User,
+		RestrictionProfile: repo.RestrictionProfile,
+	}
+}
+
+func (r *Repository) WithReadTxn(ctx context.Context, fn txn.TxnFunc) error {
+	return txn.WithReadTxn(ctx, r.TxnManager, fn)
+}
+
+func (r *Repository) WithTxn(ctx context.Context, fn txn.TxnFunc) error {
+	return txn.WithTxn(ctx, r.TxnManager, fn)
+}
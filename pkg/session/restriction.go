@@ -0,0 +1,269 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const restrictionProfileKey = "restrictionProfileID"
+
+const (
+	// maxPINAttempts is the number of invalid PINs that may be provided to
+	// leave a restriction profile before leaving it is locked out.
+	maxPINAttempts     = 5
+	pinLockoutDuration = 15 * time.Minute
+)
+
+var (
+	ErrInvalidPIN = errors.New("invalid PIN")
+	// ErrPINLockedOut is returned when leaving a restriction profile after
+	// too many invalid PINs have been provided.
+	ErrPINLockedOut = errors.New("too many invalid PIN attempts")
+	// ErrRestricted is returned when entering a restriction profile while
+	// another profile is applied to the session.
+	ErrRestricted = errors.New("a restriction profile is already applied")
+	// ErrRestrictionLocked is returned when leaving a restriction profile that
+	// is assigned to the user account.
+	ErrRestrictionLocked = errors.New("the restriction profile is assigned to the user account")
+)
+
+// pinAttempts counts the invalid PINs provided to leave each restriction
+// profile. Attempts are counted per profile rather than per session, so that
+// the limit cannot be avoided by discarding the session cookie.
+type pinAttempts struct {
+	mutex    sync.Mutex
+	profiles map[int]*profilePINAttempts
+}
+
+type profilePINAttempts struct {
+	failures    int
+	lockedUntil time.Time
+}
+
+func (a *pinAttempts) check(profileID int, now time.Time) error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if p := a.profiles[profileID]; p != nil && now.Before(p.lockedUntil) {
+		return ErrPINLockedOut
+	}
+
+	return nil
+}
+
+// fail records an invalid PIN, locking out the profile once the maximum number
+// of attempts is reached.
+func (a *pinAttempts) fail(profileID int, now time.Time) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.profiles == nil {
+		a.profiles = make(map[int]*profilePINAttempts)
+	}
+
+	p := a.profiles[profileID]
+	if p == nil {
+		p = &profilePINAttempts{}
+		a.profiles[profileID] = p
+	}
+
+	p.failures++
+	if p.failures >= maxPINAttempts {
+		p.failures = 0
+		p.lockedUntil = now.Add(pinLockoutDuration)
+	}
+}
+
+func (a *pinAttempts) reset(profileID int) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	delete(a.profiles, profileID)
+}
+
+// getAppliedRestrictionProfileID returns the ID of the restriction profile
+// applied using EnterRestrictionProfile. If authentication is not required,
+// the profile is stored in the configuration rather than in the session
+// cookie, so that it applies to all requests and cannot be removed by
+// discarding the cookie.
+func (s *Store) getAppliedRestrictionProfileID(r *http.Request) int {
+	if !s.config.AuthenticationRequired() {
+		return s.config.GetRestrictionProfile()
+	}
+
+	session, err := s.sessionStore.Get(r, cookieName)
+	if err != nil {
+		return 0
+	}
+
+	ret, _ := session.Values[restrictionProfileKey].(int)
+	return ret
+}
+
+// setAppliedRestrictionProfileID sets the ID of the restriction profile
+// applied using EnterRestrictionProfile. The profile is removed if id is 0.
+func (s *Store) setAppliedRestrictionProfileID(w http.ResponseWriter, r *http.Request, id int) error {
+	if !s.config.AuthenticationRequired() {
+		return s.config.SetRestrictionProfile(id)
+	}
+
+	// ignore error - we want a new session regardless
+	session, _ := s.sessionStore.Get(r, cookieName)
+
+	if id == 0 {
+		delete(session.Values, restrictionProfileKey)
+	} else {
+		session.Values[restrictionProfileKey] = id
+	}
+
+	return session.Save(r, w)
+}
+
+// getLockedRestrictionProfileID returns the ID of the restriction profile that
+// is assigned to the user account, or to the configured API key if the
+// request is authenticated with it. Returns 0 if there is no such profile.
+func (s *Store) getLockedRestrictionProfileID(r *http.Request, user *models.User) int {
+	if user != nil && user.RestrictionProfileID != nil {
+		return *user.RestrictionProfileID
+	}
+
+	if apiKey := getRequestAPIKey(r); apiKey != "" && apiKey == s.config.GetAPIKey() {
+		return s.config.GetAPIKeyRestrictionProfile()
+	}
+
+	return 0
+}
+
+// GetContentRestriction returns the content restriction that applies to the
+// request. The restriction profile assigned to the user account or the
+// configured API key takes precedence over the profile applied to the
+// session. Returns nil if content is not restricted.
+func (s *Store) GetContentRestriction(r *http.Request, user *models.User) (*models.ContentRestriction, error) {
+	if s.repository.RestrictionProfile == nil {
+		return nil, nil
+	}
+
+	locked := true
+	id := s.getLockedRestrictionProfileID(r, user)
+	if id == 0 {
+		locked = false
+		id = s.getAppliedRestrictionProfileID(r)
+	}
+
+	if id == 0 {
+		return nil, nil
+	}
+
+	var ret *models.ContentRestriction
+	if err := s.repository.WithReadTxn(r.Context(), func(ctx context.Context) error {
+		var err error
+		ret, err = s.repository.RestrictionProfile.GetContentRestriction(ctx, id)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("getting content restriction: %w", err)
+	}
+
+	// a deleted profile no longer restricts anything
+	if ret != nil {
+		ret.Locked = locked
+	}
+
+	return ret, nil
+}
+
+func (s *Store) findRestrictionProfile(r *http.Request, id int) (*models.RestrictionProfile, error) {
+	if s.repository.RestrictionProfile == nil {
+		return nil, nil
+	}
+
+	var ret *models.RestrictionProfile
+	if err := s.repository.WithReadTxn(r.Context(), func(ctx context.Context) error {
+		var err error
+		ret, err = s.repository.RestrictionProfile.Find(ctx, id)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("finding restriction profile: %w", err)
+	}
+
+	return ret, nil
+}
+
+// EnterRestrictionProfile applies the restriction profile to the current
+// session, or to all requests if authentication is not required. The profile
+// can only be left by providing its PIN.
+func (s *Store) EnterRestrictionProfile(w http.ResponseWriter, r *http.Request, profileID int) error {
+	current := s.getAppliedRestrictionProfileID(r)
+	if current == profileID {
+		return nil
+	}
+
+	// switching profiles would allow leaving a profile without the PIN
+	if current != 0 {
+		return ErrRestricted
+	}
+
+	profile, err := s.findRestrictionProfile(r, profileID)
+	if err != nil {
+		return err
+	}
+
+	if profile == nil {
+		return fmt.Errorf("restriction profile with id %d not found", profileID)
+	}
+
+	if err := s.setAppliedRestrictionProfileID(w, r, profileID); err != nil {
+		return err
+	}
+
+	logger.Infof("Restriction profile %q applied to session", profile.Name)
+
+	return nil
+}
+
+// ExitRestrictionProfile removes the restriction profile applied using
+// EnterRestrictionProfile, if the PIN is correct. Leaving the profile is
+// locked out for a time after too many invalid PINs.
+func (s *Store) ExitRestrictionProfile(w http.ResponseWriter, r *http.Request, user *models.User, pin string) error {
+	if s.getLockedRestrictionProfileID(r, user) != 0 {
+		return ErrRestrictionLocked
+	}
+
+	id := s.getAppliedRestrictionProfileID(r)
+	if id == 0 {
+		return nil
+	}
+
+	if err := s.pinAttempts.check(id, time.Now()); err != nil {
+		logger.Warnf("Attempt to leave restriction profile %d while locked out", id)
+		return err
+	}
+
+	profile, err := s.findRestrictionProfile(r, id)
+	if err != nil {
+		return err
+	}
+
+	// the PIN is not required if the profile has since been deleted
+	if profile != nil && !CheckPassword(profile.PinHash, pin) {
+		logger.Warnf("Invalid PIN provided to leave restriction profile %q", profile.Name)
+		s.pinAttempts.fail(id, time.Now())
+		return ErrInvalidPIN
+	}
+
+	s.pinAttempts.reset(id)
+
+	if err := s.setAppliedRestrictionProfileID(w, r, 0); err != nil {
+		return err
+	}
+
+	logger.Info("Restriction profile removed from session")
+
+	return nil
+}
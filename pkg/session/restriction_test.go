@@ -0,0 +1,187 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+type restrictionConfig struct {
+	externalConfig
+
+	authenticationRequired bool
+	apiKeyProfile          int
+	profile                int
+}
+
+func (c *restrictionConfig) AuthenticationRequired() bool {
+	return c.authenticationRequired
+}
+
+func (c *restrictionConfig) GetAPIKeyRestrictionProfile() int {
+	return c.apiKeyProfile
+}
+
+func (c *restrictionConfig) GetRestrictionProfile() int {
+	return c.profile
+}
+
+func (c *restrictionConfig) SetRestrictionProfile(id int) error {
+	c.profile = id
+	return nil
+}
+
+// restrictionProfileStore is an in-memory restriction profile store.
+type restrictionProfileStore struct {
+	models.RestrictionProfileReader
+
+	profiles []*models.RestrictionProfile
+}
+
+func (s *restrictionProfileStore) Find(ctx context.Context, id int) (*models.RestrictionProfile, error) {
+	for _, p := range s.profiles {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *restrictionProfileStore) GetContentRestriction(ctx context.Context, id int) (*models.ContentRestriction, error) {
+	p, _ := s.Find(ctx, id)
+	if p == nil {
+		return nil, nil
+	}
+
+	return &models.ContentRestriction{ProfileID: p.ID}, nil
+}
+
+func newRestrictionTestStore(t *testing.T, c *restrictionConfig) *Store {
+	t.Helper()
+
+	pinHash, err := HashPassword("1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.username = "admin"
+	c.apiKey = "admin-key"
+
+	return &Store{
+		sessionStore: sessions.NewCookieStore([]byte("test-session-store-key")),
+		config:       c,
+		repository: Repository{
+			TxnManager: &mocks.Database{},
+			RestrictionProfile: &restrictionProfileStore{
+				profiles: []*models.RestrictionProfile{
+					{ID: 1, Name: "profile", PinHash: pinHash},
+				},
+			},
+		},
+	}
+}
+
+func TestRestrictionWithoutAuthentication(t *testing.T) {
+	c := &restrictionConfig{}
+	s := newRestrictionTestStore(t, c)
+
+	// no cookies are stored, so the restriction must not rely on them
+	r := httptest.NewRequest("POST", "/restriction/enter", nil)
+	if err := s.EnterRestrictionProfile(httptest.NewRecorder(), r, 1); err != nil {
+		t.Fatalf("EnterRestrictionProfile() error = %v", err)
+	}
+
+	got, err := s.GetContentRestriction(httptest.NewRequest("GET", "/graphql", nil), nil)
+	if err != nil {
+		t.Fatalf("GetContentRestriction() error = %v", err)
+	}
+
+	if got == nil || got.ProfileID != 1 || got.Locked {
+		t.Fatalf("GetContentRestriction() = %v, want unlocked profile 1", got)
+	}
+
+	r = httptest.NewRequest("POST", "/restriction/exit", nil)
+	if err := s.ExitRestrictionProfile(httptest.NewRecorder(), r, nil, "1234"); err != nil {
+		t.Fatalf("ExitRestrictionProfile() error = %v", err)
+	}
+
+	if c.profile != 0 {
+		t.Errorf("ExitRestrictionProfile() did not remove profile %d", c.profile)
+	}
+}
+
+func TestExitRestrictionProfileLockout(t *testing.T) {
+	c := &restrictionConfig{profile: 1}
+	s := newRestrictionTestStore(t, c)
+
+	exit := func(pin string) error {
+		r := httptest.NewRequest("POST", "/restriction/exit", nil)
+		return s.ExitRestrictionProfile(httptest.NewRecorder(), r, nil, pin)
+	}
+
+	for i := 0; i < maxPINAttempts; i++ {
+		if err := exit("0000"); !errors.Is(err, ErrInvalidPIN) {
+			t.Fatalf("ExitRestrictionProfile() attempt %d error = %v, want %v", i+1, err, ErrInvalidPIN)
+		}
+	}
+
+	if err := exit("1234"); !errors.Is(err, ErrPINLockedOut) {
+		t.Fatalf("ExitRestrictionProfile() error = %v, want %v", err, ErrPINLockedOut)
+	}
+
+	if c.profile != 1 {
+		t.Fatalf("ExitRestrictionProfile() removed profile while locked out")
+	}
+
+	// expire the lockout
+	s.pinAttempts.profiles[1].lockedUntil = time.Now().Add(-time.Second)
+
+	if err := exit("1234"); err != nil {
+		t.Fatalf("ExitRestrictionProfile() error = %v", err)
+	}
+
+	if c.profile != 0 {
+		t.Errorf("ExitRestrictionProfile() did not remove profile %d", c.profile)
+	}
+}
+
+func TestAPIKeyRestriction(t *testing.T) {
+	c := &restrictionConfig{
+		authenticationRequired: true,
+		apiKeyProfile:          1,
+	}
+	s := newRestrictionTestStore(t, c)
+
+	r := httptest.NewRequest("GET", "/graphql", nil)
+	r.Header.Set(ApiKeyHeader, "admin-key")
+
+	got, err := s.GetContentRestriction(r, nil)
+	if err != nil {
+		t.Fatalf("GetContentRestriction() error = %v", err)
+	}
+
+	if got == nil || got.ProfileID != 1 || !got.Locked {
+		t.Fatalf("GetContentRestriction() = %v, want locked profile 1", got)
+	}
+
+	if err := s.ExitRestrictionProfile(httptest.NewRecorder(), r, nil, "1234"); !errors.Is(err, ErrRestrictionLocked) {
+		t.Errorf("ExitRestrictionProfile() error = %v, want %v", err, ErrRestrictionLocked)
+	}
+
+	// other requests are not restricted
+	got, err = s.GetContentRestriction(httptest.NewRequest("GET", "/graphql", nil), nil)
+	if err != nil {
+		t.Fatalf("GetContentRestriction() error = %v", err)
+	}
+
+	if got != nil {
+		t.Errorf("GetContentRestriction() = %v, want nil", got)
+	}
+}
@@ -3,10 +3,12 @@ package session
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/gorilla/sessions"
 	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
 )
 
 type key int
@@ -14,10 +16,12 @@ type key int
 const (
 	contextUser key = iota
 	contextVisitedPlugins
+	contextLocalUser
 )
 
 const (
 	userIDKey             = "userID"
+	localUserIDKey        = "localUserID"
 	visitedPluginHooksKey = "visitedPluginsHooks"
 )
 
@@ -46,12 +50,17 @@ var ErrUnauthorized = errors.New("unauthorized")
 type Store struct {
 	sessionStore *sessions.CookieStore
 	config       SessionConfig
+	repository   Repository
+	oidc         *oidcProvider
+	pinAttempts  pinAttempts
 }
 
-func NewStore(c SessionConfig) *Store {
+func NewStore(c SessionConfig, repository Repository) *Store {
 	ret := &Store{
 		sessionStore: sessions.NewCookieStore(c.GetSessionStoreKey()),
 		config:       c,
+		repository:   repository,
+		oidc:         newOIDCProvider(),
 	}
 
 	ret.sessionStore.MaxAge(c.GetMaxSessionAge())
@@ -68,16 +77,21 @@ func (s *Store) Login(w http.ResponseWriter, r *http.Request) error {
 	password := r.FormValue(passwordFormKey)
 
 	// authenticate the user
-	if !s.config.ValidateCredentials(username, password) {
+	user, valid, err := s.validateCredentials(r.Context(), username, password)
+	if err != nil {
+		return err
+	}
+
+	if !valid {
 		return &InvalidCredentialsError{Username: username}
 	}
 
-	// since we only have one user, don't leak the name
+	// don't leak the name
 	logger.Info("User logged in")
 
-	newSession.Values[userIDKey] = username
+	setSessionUser(newSession, username, user)
 
-	err := newSession.Save(r, w)
+	err = newSession.Save(r, w)
 	if err != nil {
 		return err
 	}
@@ -85,6 +99,61 @@ func (s *Store) Login(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// validateCredentials checks the username and password against the
+// configured credentials, and then against the local user accounts. The
+// local user account is returned if the credentials match one.
+func (s *Store) validateCredentials(ctx context.Context, username string, password string) (*models.User, bool, error) {
+	if s.config.ValidateCredentials(username, password) {
+		return nil, true, nil
+	}
+
+	user, err := s.findUser(ctx, func(ctx context.Context) (*models.User, error) {
+		return s.repository.User.FindByUsername(ctx, username)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if user == nil || !CheckPassword(user.PasswordHash, password) {
+		return nil, false, nil
+	}
+
+	return user, true, nil
+}
+
+// setSessionUser sets the user of the session. Local user accounts are
+// stored by ID, so that they cannot be confused with the configured user,
+// which is stored by name.
+func setSessionUser(session *sessions.Session, userID string, user *models.User) {
+	if user != nil {
+		delete(session.Values, userIDKey)
+		session.Values[localUserIDKey] = user.ID
+		return
+	}
+
+	delete(session.Values, localUserIDKey)
+	session.Values[userIDKey] = userID
+}
+
+// findUser calls fn to find a local user account, if the repository has been
+// set.
+func (s *Store) findUser(ctx context.Context, fn func(ctx context.Context) (*models.User, error)) (*models.User, error) {
+	if s.repository.User == nil {
+		return nil, nil
+	}
+
+	var ret *models.User
+	if err := s.repository.WithReadTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = fn(ctx)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("finding user: %w", err)
+	}
+
+	return ret, nil
+}
+
 func (s *Store) Logout(w http.ResponseWriter, r *http.Request) error {
 	session, err := s.sessionStore.Get(r, cookieName)
 	if err != nil {
@@ -92,42 +161,49 @@ func (s *Store) Logout(w http.ResponseWriter, r *http.Request) error {
 	}
 
 	delete(session.Values, userIDKey)
-	session.Options.MaxAge = -1
+	delete(session.Values, localUserIDKey)
+
+	// keep the cookie if a restriction profile is applied, so that logging
+	// out does not remove the restriction
+	if _, restricted := session.Values[restrictionProfileKey]; !restricted {
+		session.Options.MaxAge = -1
+	}
 
 	err = session.Save(r, w)
 	if err != nil {
 		return err
 	}
 
-	// since we only have one user, don't leak the name
+	// don't leak the name
 	logger.Infof("User logged out")
 
 	return nil
 }
 
-func (s *Store) GetSessionUserID(w http.ResponseWriter, r *http.Request) (string, error) {
+// getSessionUser returns the name of the configured user, or the ID of the
+// local user account, that is logged in to the session.
+func (s *Store) getSessionUser(w http.ResponseWriter, r *http.Request) (userID string, localUserID int, err error) {
 	session, err := s.sessionStore.Get(r, cookieName)
 	// ignore errors and treat as an empty user id, so that we handle expired
 	// cookie
 	if err != nil {
-		return "", nil
+		return "", 0, nil
 	}
 
 	if !session.IsNew {
-		val := session.Values[userIDKey]
+		userID, _ = session.Values[userIDKey].(string)
+		localUserID, _ = session.Values[localUserIDKey].(int)
 
 		// refresh the cookie
 		err = session.Save(r, w)
 		if err != nil {
-			return "", err
+			return "", 0, err
 		}
 
-		ret, _ := val.(string)
-
-		return ret, nil
+		return userID, localUserID, nil
 	}
 
-	return "", nil
+	return "", 0, nil
 }
 
 func SetCurrentUserID(ctx context.Context, userID string) context.Context {
@@ -145,34 +221,110 @@ func GetCurrentUserID(ctx context.Context) *string {
 	return nil
 }
 
-func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID string, err error) {
+// SetCurrentUser sets the local user account of the current user in the
+// context.
+func SetCurrentUser(ctx context.Context, user *models.User) context.Context {
+	ctx = models.WithUserID(ctx, user.ID)
+	return context.WithValue(ctx, contextLocalUser, user)
+}
+
+// GetCurrentUser gets the local user account of the current user from the
+// provided context. Returns nil if the current user is the configured user,
+// or if authentication is not required.
+func GetCurrentUser(ctx context.Context) *models.User {
+	ret, _ := ctx.Value(contextLocalUser).(*models.User)
+	return ret
+}
+
+// getRequestAPIKey returns the API key provided in the request header or
+// query parameters.
+func getRequestAPIKey(r *http.Request) string {
+	ret := r.Header.Get(ApiKeyHeader)
+
+	// try getting the api key as a query parameter
+	if ret == "" {
+		ret = r.URL.Query().Get(ApiKeyParameter)
+	}
+
+	return ret
+}
+
+// Authenticate returns the username of the authenticated user. If the user
+// is a local user account rather than the configured user, then the account
+// is also returned.
+func (s *Store) Authenticate(w http.ResponseWriter, r *http.Request) (userID string, user *models.User, err error) {
 	c := s.config
+	ctx := r.Context()
 
 	// translate api key into current user, if present
-	apiKey := r.Header.Get(ApiKeyHeader)
+	if apiKey := getRequestAPIKey(r); apiKey != "" {
+		// match against configured API key first, then against the
+		// API keys of local user accounts
+		if c.GetAPIKey() == apiKey {
+			return c.GetUsername(), nil, nil
+		}
 
-	// try getting the api key as a query parameter
-	if apiKey == "" {
-		apiKey = r.URL.Query().Get(ApiKeyParameter)
+		user, err = s.findUser(ctx, func(ctx context.Context) (*models.User, error) {
+			return s.repository.User.FindByAPIKeyHash(ctx, HashAPIKey(apiKey))
+		})
+		if err != nil {
+			// the database may not be available, for example if a migration
+			// is required
+			logger.Warnf("Error authenticating API key: %v", err)
+		}
+
+		if user == nil {
+			return "", nil, ErrUnauthorized
+		}
+
+		return user.Username, user, nil
+	}
+
+	// the trusted header takes precedence over the session, so that the
+	// proxy determines the current user
+	if username := s.trustedHeaderUsername(r); username != "" {
+		// the proxy is trusted to provide a unique username
+		identity := externalIdentity{
+			issuer:   trustedHeaderIssuer,
+			subject:  username,
+			username: username,
+		}
+
+		userID, user, err = s.findExternalUser(ctx, identity)
+		if err != nil {
+			logger.Warnf("Error authenticating trusted header user: %v", err)
+			return "", nil, ErrUnauthorized
+		}
+
+		return userID, user, nil
 	}
 
-	if apiKey != "" {
-		// match against configured API and set userID to the
-		// configured username. In future, we'll want to
-		// get the username from the key.
-		if c.GetAPIKey() != apiKey {
-			return "", ErrUnauthorized
+	// handle session
+	userID, localUserID, err := s.getSessionUser(w, r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if localUserID == 0 {
+		// only the configured user is stored by name
+		if userID != c.GetUsername() {
+			return "", nil, nil
 		}
 
-		userID = c.GetUsername()
-	} else {
-		// handle session
-		userID, err = s.GetSessionUserID(w, r)
+		return userID, nil, nil
 	}
 
+	user, err = s.findUser(ctx, func(ctx context.Context) (*models.User, error) {
+		return s.repository.User.Find(ctx, localUserID)
+	})
 	if err != nil {
-		return "", err
+		logger.Warnf("Error authenticating session user: %v", err)
+	}
+
+	if user == nil {
+		// user no longer exists - treat as unauthenticated
+		return "", nil, nil
 	}
 
-	return
+	return user.Username, user, nil
 }
@@ -0,0 +1,62 @@
+package session
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+)
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	user := &models.User{
+		ID:         1,
+		Username:   "user",
+		APIKeyHash: HashAPIKey("user-key"),
+	}
+
+	s := &Store{
+		config: &externalConfig{
+			username: "admin",
+			apiKey:   "admin-key",
+		},
+		repository: Repository{
+			TxnManager: &mocks.Database{},
+			User:       &userStore{users: []*models.User{user}},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		apiKey     string
+		wantUserID string
+		wantUser   *models.User
+		wantErr    error
+	}{
+		{"configured key", "admin-key", "admin", nil, nil},
+		{"user key", "user-key", "user", user, nil},
+		{"stored hash", user.APIKeyHash, "", nil, ErrUnauthorized},
+		{"invalid key", "invalid", "", nil, ErrUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/graphql", nil)
+			r.Header.Set(ApiKeyHeader, tc.apiKey)
+
+			userID, got, err := s.Authenticate(httptest.NewRecorder(), r)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tc.wantErr)
+			}
+
+			if userID != tc.wantUserID {
+				t.Errorf("Authenticate() userID = %q, want %q", userID, tc.wantUserID)
+			}
+
+			if got != tc.wantUser {
+				t.Errorf("Authenticate() user = %v, want %v", got, tc.wantUser)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	customFieldsFieldColumn = "field"
+	customFieldsTypeColumn  = "type"
+	customFieldsValueColumn = "value"
+)
+
+// customFieldsTable manages the custom fields of an object. Values are stored
+// in a column without type affinity, so that integers, floats and strings keep
+// their storage class and compare naturally. Booleans are stored as integers.
+type customFieldsTable struct {
+	table
+}
+
+type customFieldRow struct {
+	Field string      `db:"field"`
+	Type  string      `db:"type"`
+	Value interface{} `db:"value"`
+}
+
+func (r *customFieldRow) resolve() (models.CustomField, error) {
+	return models.CustomField{
+		Field: r.Field,
+		Type:  models.CustomFieldType(r.Type),
+		Value: r.Value,
+	}.Normalise()
+}
+
+func (t *customFieldsTable) get(ctx context.Context, id int) ([]models.CustomField, error) {
+	q := dialect.Select(customFieldsFieldColumn, customFieldsTypeColumn, customFieldsValueColumn).
+		From(t.table.table).
+		Where(t.idColumn.Eq(id)).
+		Order(t.table.table.Col(customFieldsFieldColumn).Asc())
+
+	const single = false
+	ret := []models.CustomField{}
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var v customFieldRow
+		if err := rows.StructScan(&v); err != nil {
+			return err
+		}
+
+		f, err := v.resolve()
+		if err != nil {
+			return err
+		}
+
+		ret = append(ret, f)
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting custom fields from %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return ret, nil
+}
+
+func (t *customFieldsTable) insertJoins(ctx context.Context, id int, v []models.CustomField) error {
+	fields, err := models.NormaliseCustomFields(v)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fields {
+		q := dialect.Insert(t.table.table).Prepared(true).
+			Cols(t.idColumn.GetCol(), customFieldsFieldColumn, customFieldsTypeColumn, customFieldsValueColumn).
+			Vals(goqu.Vals{id, f.Field, f.Type.String(), f.Value})
+
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("inserting into %s: %w", t.table.table.GetTable(), err)
+		}
+	}
+
+	return nil
+}
+
+func (t *customFieldsTable) replaceJoins(ctx context.Context, id int, v []models.CustomField) error {
+	if err := t.destroy(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	return t.insertJoins(ctx, id, v)
+}
+
+// addJoins adds the provided fields, replacing existing fields with the same
+// name.
+func (t *customFieldsTable) addJoins(ctx context.Context, id int, v []models.CustomField) error {
+	if err := t.destroyJoins(ctx, id, v); err != nil {
+		return err
+	}
+
+	return t.insertJoins(ctx, id, v)
+}
+
+// destroyJoins removes the fields with the provided names. Values are ignored.
+func (t *customFieldsTable) destroyJoins(ctx context.Context, id int, v []models.CustomField) error {
+	for _, vv := range v {
+		q := dialect.Delete(t.table.table).Where(
+			t.idColumn.Eq(id),
+			t.table.table.Col(customFieldsFieldColumn).Eq(strings.TrimSpace(vv.Field)),
+		)
+
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("destroying %s: %w", t.table.table.GetTable(), err)
+		}
+	}
+
+	return nil
+}
+
+func (t *customFieldsTable) modifyJoins(ctx context.Context, id int, v []models.CustomField, mode models.RelationshipUpdateMode) error {
+	switch mode {
+	case models.RelationshipUpdateModeSet:
+		return t.replaceJoins(ctx, id, v)
+	case models.RelationshipUpdateModeAdd:
+		return t.addJoins(ctx, id, v)
+	case models.RelationshipUpdateModeRemove:
+		return t.destroyJoins(ctx, id, v)
+	}
+
+	return nil
+}
+
+type customFieldsCriterionHandler struct {
+	criteria    []models.CustomFieldCriterionInput
+	table       *customFieldsTable
+	parentIDCol string
+}
+
+// customFieldFilterValue converts a filter value to the form in which values
+// are stored.
+func customFieldFilterValue(v interface{}) interface{} {
+	switch vv := v.(type) {
+	case json.Number:
+		if i, err := vv.Int64(); err == nil {
+			return i
+		}
+		if f, err := vv.Float64(); err == nil {
+			return f
+		}
+		return vv.String()
+	case int:
+		return int64(vv)
+	case bool:
+		if vv {
+			return int64(1)
+		}
+		return int64(0)
+	}
+
+	return v
+}
+
+func (h *customFieldsCriterionHandler) handle(ctx context.Context, f *filterBuilder) {
+	for _, c := range h.criteria {
+		h.handleCriterion(f, c)
+	}
+}
+
+func (h *customFieldsCriterionHandler) handleCriterion(f *filterBuilder, c models.CustomFieldCriterionInput) {
+	if !c.Modifier.IsValid() {
+		f.setError(fmt.Errorf("invalid custom field criterion modifier: %s", c.Modifier))
+		return
+	}
+
+	field := strings.TrimSpace(c.Field)
+	if field == "" {
+		f.setError(fmt.Errorf("custom field criterion requires a field name"))
+		return
+	}
+
+	values := make([]interface{}, len(c.Value))
+	for i, v := range c.Value {
+		values[i] = customFieldFilterValue(v)
+	}
+
+	requireValues := func(n int) bool {
+		if len(values) < n {
+			f.setError(fmt.Errorf("custom field criterion %s for %q requires %d value(s)", c.Modifier, field, n))
+			return false
+		}
+		return true
+	}
+
+	requireRegex := func() bool {
+		s, ok := values[0].(string)
+		if !ok {
+			f.setError(fmt.Errorf("custom field criterion %s for %q requires a string value", c.Modifier, field))
+			return false
+		}
+		if _, err := regexp.Compile(s); err != nil {
+			f.setError(err)
+			return false
+		}
+		return true
+	}
+
+	// equality for strings is case-insensitive, consistent with string criteria
+	equalsClause := func() string {
+		if _, ok := values[0].(string); ok {
+			return "value LIKE ?"
+		}
+		return "value = ?"
+	}
+
+	var (
+		clause string
+		args   []interface{}
+		not    bool
+	)
+
+	switch c.Modifier {
+	case models.CriterionModifierEquals, models.CriterionModifierNotEquals:
+		if !requireValues(1) {
+			return
+		}
+		clause = equalsClause()
+		args = values[:1]
+		not = c.Modifier == models.CriterionModifierNotEquals
+	case models.CriterionModifierIncludes, models.CriterionModifierExcludes:
+		if !requireValues(1) {
+			return
+		}
+		clause = "value LIKE ?"
+		args = []interface{}{fmt.Sprintf("%%%v%%", values[0])}
+		not = c.Modifier == models.CriterionModifierExcludes
+	case models.CriterionModifierMatchesRegex, models.CriterionModifierNotMatchesRegex:
+		if !requireValues(1) || !requireRegex() {
+			return
+		}
+		clause = "value regexp ?"
+		args = values[:1]
+		not = c.Modifier == models.CriterionModifierNotMatchesRegex
+	case models.CriterionModifierIsNull, models.CriterionModifierNotNull:
+		not = c.Modifier == models.CriterionModifierIsNull
+	case models.CriterionModifierGreaterThan:
+		if !requireValues(1) {
+			return
+		}
+		clause = "value > ?"
+		args = values[:1]
+	case models.CriterionModifierLessThan:
+		if !requireValues(1) {
+			return
+		}
+		clause = "value < ?"
+		args = values[:1]
+	case models.CriterionModifierBetween, models.CriterionModifierNotBetween:
+		if !requireValues(2) {
+			return
+		}
+		clause = "value BETWEEN ? AND ?"
+		if c.Modifier == models.CriterionModifierNotBetween {
+			clause = "value NOT BETWEEN ? AND ?"
+		}
+		args = values[:2]
+	default:
+		f.setError(fmt.Errorf("unsupported custom field criterion modifier: %s", c.Modifier))
+		return
+	}
+
+	where := "field = ?"
+	if clause != "" {
+		where += " AND " + clause
+	}
+
+	in := "IN"
+	if not {
+		in = "NOT IN"
+	}
+
+	t := h.table.table.table.GetTable()
+	fk := h.table.idColumn.GetCol()
+	f.addWhere(fmt.Sprintf("%s %s (SELECT %s FROM %s WHERE %s)", h.parentIDCol, in, fk, t, where), append([]interface{}{field}, args...)...)
+}
@@ -0,0 +1,195 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func setPerformerCustomFields(ctx context.Context, id int, fields []models.CustomField, mode models.RelationshipUpdateMode) error {
+	partial := models.NewPerformerPartial()
+	partial.CustomFields = &models.UpdateCustomFields{
+		CustomFields: fields,
+		Mode:         mode,
+	}
+
+	_, err := db.Performer.UpdatePartial(ctx, id, partial)
+	return err
+}
+
+func TestPerformerCustomFields(t *testing.T) {
+	runWithRollbackTxn(t, "custom fields", func(t *testing.T, ctx context.Context) {
+		id := performerIDs[performerIdxWithScene]
+
+		if err := setPerformerCustomFields(ctx, id, []models.CustomField{
+			{Field: " string ", Type: models.CustomFieldTypeString, Value: "value"},
+			{Field: "int", Type: models.CustomFieldTypeInt, Value: json.Number("5")},
+			{Field: "float", Type: models.CustomFieldTypeFloat, Value: 2.5},
+			{Field: "date", Type: models.CustomFieldTypeDate, Value: "2020-01-02"},
+			{Field: "bool", Type: models.CustomFieldTypeBoolean, Value: true},
+		}, models.RelationshipUpdateModeSet); err != nil {
+			t.Errorf("setting custom fields: %v", err)
+			return
+		}
+
+		// add replaces fields with the same name
+		if err := setPerformerCustomFields(ctx, id, []models.CustomField{
+			{Field: "int", Type: models.CustomFieldTypeInt, Value: 7},
+		}, models.RelationshipUpdateModeAdd); err != nil {
+			t.Errorf("adding custom fields: %v", err)
+			return
+		}
+
+		if err := setPerformerCustomFields(ctx, id, []models.CustomField{
+			{Field: "date"},
+		}, models.RelationshipUpdateModeRemove); err != nil {
+			t.Errorf("removing custom fields: %v", err)
+			return
+		}
+
+		got, err := db.Performer.GetCustomFields(ctx, id)
+		if err != nil {
+			t.Errorf("PerformerStore.GetCustomFields() error = %v", err)
+			return
+		}
+
+		assert.Equal(t, []models.CustomField{
+			{Field: "bool", Type: models.CustomFieldTypeBoolean, Value: true},
+			{Field: "float", Type: models.CustomFieldTypeFloat, Value: 2.5},
+			{Field: "int", Type: models.CustomFieldTypeInt, Value: 7},
+			{Field: "string", Type: models.CustomFieldTypeString, Value: "value"},
+		}, got)
+	})
+}
+
+func TestPerformerCustomFieldsInvalid(t *testing.T) {
+	id := performerIDs[performerIdxWithScene]
+
+	tests := []struct {
+		name   string
+		fields []models.CustomField
+	}{
+		{"empty name", []models.CustomField{{Field: " ", Type: models.CustomFieldTypeString, Value: "v"}}},
+		{"wrong type", []models.CustomField{{Field: "f", Type: models.CustomFieldTypeInt, Value: "v"}}},
+		{"fractional int", []models.CustomField{{Field: "f", Type: models.CustomFieldTypeInt, Value: 1.5}}},
+		{"invalid date", []models.CustomField{{Field: "f", Type: models.CustomFieldTypeDate, Value: "not a date"}}},
+		{"duplicate name", []models.CustomField{
+			{Field: "f", Type: models.CustomFieldTypeString, Value: "a"},
+			{Field: "f ", Type: models.CustomFieldTypeString, Value: "b"},
+		}},
+	}
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			err := setPerformerCustomFields(ctx, id, tt.fields, models.RelationshipUpdateModeSet)
+			assert.NotNil(t, err)
+		})
+	}
+}
+
+func TestPerformerQueryCustomFields(t *testing.T) {
+	id1 := performerIDs[performerIdxWithScene]
+	id2 := performerIDs[performerIdxWithImage]
+
+	tests := []struct {
+		name      string
+		criterion models.CustomFieldCriterionInput
+		want      []int
+		exclude   []int
+	}{
+		{
+			"equals string",
+			models.CustomFieldCriterionInput{Field: "string", Value: []interface{}{"FOO"}, Modifier: models.CriterionModifierEquals},
+			[]int{id1},
+			[]int{id2},
+		},
+		{
+			"not equals string",
+			models.CustomFieldCriterionInput{Field: "string", Value: []interface{}{"foo"}, Modifier: models.CriterionModifierNotEquals},
+			[]int{id2},
+			[]int{id1},
+		},
+		{
+			"includes",
+			models.CustomFieldCriterionInput{Field: "string", Value: []interface{}{"ar"}, Modifier: models.CriterionModifierIncludes},
+			[]int{id2},
+			[]int{id1},
+		},
+		{
+			"greater than",
+			models.CustomFieldCriterionInput{Field: "int", Value: []interface{}{json.Number("5")}, Modifier: models.CriterionModifierGreaterThan},
+			[]int{id2},
+			[]int{id1},
+		},
+		{
+			"between",
+			models.CustomFieldCriterionInput{Field: "int", Value: []interface{}{json.Number("1"), json.Number("5")}, Modifier: models.CriterionModifierBetween},
+			[]int{id1},
+			[]int{id2},
+		},
+		{
+			"boolean",
+			models.CustomFieldCriterionInput{Field: "bool", Value: []interface{}{true}, Modifier: models.CriterionModifierEquals},
+			[]int{id1},
+			[]int{id2},
+		},
+		{
+			"date less than",
+			models.CustomFieldCriterionInput{Field: "date", Value: []interface{}{"2021-01-01"}, Modifier: models.CriterionModifierLessThan},
+			[]int{id1},
+			[]int{id2},
+		},
+		{
+			"not null",
+			models.CustomFieldCriterionInput{Field: "bool", Modifier: models.CriterionModifierNotNull},
+			[]int{id1},
+			[]int{id2},
+		},
+		{
+			"is null",
+			models.CustomFieldCriterionInput{Field: "bool", Modifier: models.CriterionModifierIsNull},
+			[]int{id2},
+			[]int{id1},
+		},
+	}
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			if err := setPerformerCustomFields(ctx, id1, []models.CustomField{
+				{Field: "string", Type: models.CustomFieldTypeString, Value: "foo"},
+				{Field: "int", Type: models.CustomFieldTypeInt, Value: 3},
+				{Field: "bool", Type: models.CustomFieldTypeBoolean, Value: true},
+				{Field: "date", Type: models.CustomFieldTypeDate, Value: "2020-01-02"},
+			}, models.RelationshipUpdateModeSet); err != nil {
+				t.Errorf("setting custom fields: %v", err)
+				return
+			}
+			if err := setPerformerCustomFields(ctx, id2, []models.CustomField{
+				{Field: "string", Type: models.CustomFieldTypeString, Value: "bar"},
+				{Field: "int", Type: models.CustomFieldTypeInt, Value: 10},
+				{Field: "date", Type: models.CustomFieldTypeDate, Value: "2022-01-02"},
+			}, models.RelationshipUpdateModeSet); err != nil {
+				t.Errorf("setting custom fields: %v", err)
+				return
+			}
+
+			performers := queryPerformers(ctx, t, &models.PerformerFilterType{
+				CustomFields: []models.CustomFieldCriterionInput{tt.criterion},
+			}, nil)
+
+			ids := performersToIDs(performers)
+			for _, id := range tt.want {
+				assert.Contains(t, ids, id)
+			}
+			for _, id := range tt.exclude {
+				assert.NotContains(t, ids, id)
+			}
+		})
+	}
+}
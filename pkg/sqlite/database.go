@@ -30,7 +30,7 @@ const (
 	dbConnTimeout = 30
 )
 
-var appSchemaVersion uint = 64
+var appSchemaVersion uint = 72
 
 //go:embed migrations/*.sql
 var migrationsBox embed.FS
@@ -39,6 +39,11 @@ var (
 	// ErrDatabaseNotInitialized indicates that the database is not
 	// initialized, usually due to an incomplete configuration.
 	ErrDatabaseNotInitialized = errors.New("database not initialized")
+
+	// ErrFTS5NotSupported indicates that stash was built without the
+	// sqlite_fts5 build tag, which is required by the full-text search
+	// tables.
+	ErrFTS5NotSupported = errors.New("sqlite FTS5 module not available: stash must be built with the sqlite_fts5 build tag")
 )
 
 // ErrMigrationNeeded indicates that a database migration is needed
@@ -62,19 +67,24 @@ func (e *MismatchedSchemaVersionError) Error() string {
 }
 
 type storeRepository struct {
-	Blobs          *BlobStore
-	File           *FileStore
-	Folder         *FolderStore
-	Image          *ImageStore
-	Gallery        *GalleryStore
-	GalleryChapter *GalleryChapterStore
-	Scene          *SceneStore
-	SceneMarker    *SceneMarkerStore
-	Performer      *PerformerStore
-	SavedFilter    *SavedFilterStore
-	Studio         *StudioStore
-	Tag            *TagStore
-	Group          *GroupStore
+	Blobs              *BlobStore
+	File               *FileStore
+	Folder             *FolderStore
+	Image              *ImageStore
+	Gallery            *GalleryStore
+	GalleryChapter     *GalleryChapterStore
+	Scene              *SceneStore
+	SceneMarker        *SceneMarkerStore
+	Performer          *PerformerStore
+	SavedFilter        *SavedFilterStore
+	Search             *SearchStore
+	ScheduledTask      *ScheduledTaskStore
+	SceneUserData      *SceneUserDataStore
+	User               *UserStore
+	RestrictionProfile *RestrictionProfileStore
+	Studio             *StudioStore
+	Tag                *TagStore
+	Group              *GroupStore
 }
 
 type Database struct {
@@ -99,19 +109,24 @@ func NewDatabase() *Database {
 
 	r := &storeRepository{}
 	*r = storeRepository{
-		Blobs:          blobStore,
-		File:           fileStore,
-		Folder:         folderStore,
-		Scene:          NewSceneStore(r, blobStore),
-		SceneMarker:    NewSceneMarkerStore(),
-		Image:          NewImageStore(r),
-		Gallery:        galleryStore,
-		GalleryChapter: NewGalleryChapterStore(),
-		Performer:      performerStore,
-		Studio:         studioStore,
-		Tag:            tagStore,
-		Group:          NewGroupStore(blobStore),
-		SavedFilter:    NewSavedFilterStore(),
+		Blobs:              blobStore,
+		File:               fileStore,
+		Folder:             folderStore,
+		Scene:              NewSceneStore(r, blobStore),
+		SceneMarker:        NewSceneMarkerStore(),
+		Image:              NewImageStore(r),
+		Gallery:            galleryStore,
+		GalleryChapter:     NewGalleryChapterStore(),
+		Performer:          performerStore,
+		Studio:             studioStore,
+		Tag:                tagStore,
+		Group:              NewGroupStore(blobStore),
+		SavedFilter:        NewSavedFilterStore(),
+		Search:             NewSearchStore(),
+		ScheduledTask:      NewScheduledTaskStore(),
+		User:               NewUserStore(),
+		SceneUserData:      NewSceneUserDataStore(),
+		RestrictionProfile: NewRestrictionProfileStore(),
 	}
 
 	ret := &Database{
@@ -145,6 +160,10 @@ func (db *Database) Open(dbPath string) error {
 
 	db.dbPath = dbPath
 
+	if !fts5Enabled {
+		return ErrFTS5NotSupported
+	}
+
 	databaseSchemaVersion, err := db.getDatabaseSchemaVersion()
 	if err != nil {
 		return fmt.Errorf("getting database schema version: %w", err)
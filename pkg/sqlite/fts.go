@@ -0,0 +1,210 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+// relevanceSort is the sort option ordering results by how well they match
+// the search query.
+const relevanceSort = "relevance"
+
+// ftsTable is an FTS5 table indexing the searchable text of an object.
+// The rowid of the table is the id of the object.
+type ftsTable struct {
+	name    string
+	columns []string
+}
+
+var (
+	scenesFTSTable = ftsTable{
+		name:    "scenes_fts",
+		columns: []string{"title", "details", "code", "path", "fingerprints", "markers"},
+	}
+	imagesFTSTable = ftsTable{
+		name:    "images_fts",
+		columns: []string{"title", "details", "code", "path", "fingerprints"},
+	}
+	galleriesFTSTable = ftsTable{
+		name:    "galleries_fts",
+		columns: []string{"title", "details", "code", "path", "fingerprints", "chapters"},
+	}
+	performersFTSTable = ftsTable{
+		name:    "performers_fts",
+		columns: []string{"name", "aliases"},
+	}
+	tagsFTSTable = ftsTable{
+		name:    "tags_fts",
+		columns: []string{"name", "aliases"},
+	}
+	studiosFTSTable = ftsTable{
+		name:    "studios_fts",
+		columns: []string{"name", "aliases"},
+	}
+	groupsFTSTable = ftsTable{
+		name:    "movies_fts",
+		columns: []string{"name", "aliases"},
+	}
+	sceneMarkersFTSTable = ftsTable{
+		name:    "scene_markers_fts",
+		columns: []string{"title", "tag"},
+	}
+)
+
+func isTokenChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// isSingleToken returns true if the term is a single word to the FTS
+// tokenizer.
+func isSingleToken(term string) bool {
+	return term != "" && strings.IndexFunc(term, func(r rune) bool { return !isTokenChar(r) }) == -1
+}
+
+// ftsPhrase returns the FTS5 query matching the term as a phrase, with the
+// last word matched by prefix. Returns an empty string if the term does not
+// contain any words.
+func ftsPhrase(term string) string {
+	if strings.IndexFunc(term, isTokenChar) == -1 {
+		return ""
+	}
+
+	return `"` + strings.ReplaceAll(term, `"`, `""`) + `"*`
+}
+
+// ftsMatchExpression returns the FTS5 query matching all of the must have
+// terms and one of the terms of each any set.
+func ftsMatchExpression(specs models.SearchSpecs) string {
+	var parts []string
+
+	for _, t := range specs.MustHave {
+		if p := ftsPhrase(t); p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	for _, set := range specs.AnySets {
+		var anyParts []string
+		for _, t := range set {
+			if p := ftsPhrase(t); p != "" {
+				anyParts = append(anyParts, p)
+			}
+		}
+
+		if len(anyParts) > 0 {
+			parts = append(parts, "("+strings.Join(anyParts, " OR ")+")")
+		}
+	}
+
+	return strings.Join(parts, " AND ")
+}
+
+// likeClause returns a clause matching the term as a substring of any of
+// the indexed columns.
+func (t ftsTable) likeClause(term string) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+	for _, c := range t.columns {
+		clauses = append(clauses, c+" LIKE ?")
+		args = append(args, like(term))
+	}
+
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// termClauses returns the clauses matching a single term. Terms that are not
+// a single word, such as file names and quoted phrases, must also match
+// exactly, since the tokenizer ignores punctuation and whitespace.
+func (t ftsTable) termClauses(term string) ([]string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	if p := ftsPhrase(term); p != "" {
+		clauses = append(clauses, t.name+" MATCH ?")
+		args = append(args, p)
+	}
+
+	if !isSingleToken(term) {
+		clause, likeArgs := t.likeClause(term)
+		clauses = append(clauses, clause)
+		args = append(args, likeArgs...)
+	}
+
+	return clauses, args
+}
+
+// searchClauses returns the clauses on the table matching the search specs.
+func (t ftsTable) searchClauses(specs models.SearchSpecs) ([]string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	if expr := ftsMatchExpression(specs); expr != "" {
+		clauses = append(clauses, t.name+" MATCH ?")
+		args = append(args, expr)
+	}
+
+	for _, term := range specs.MustHave {
+		if isSingleToken(term) {
+			// already matched by the match expression
+			continue
+		}
+
+		clause, likeArgs := t.likeClause(term)
+		clauses = append(clauses, clause)
+		args = append(args, likeArgs...)
+	}
+
+	for _, term := range specs.MustNot {
+		termClauses, termArgs := t.termClauses(term)
+		if len(termClauses) == 0 {
+			continue
+		}
+
+		clauses = append(clauses, fmt.Sprintf("rowid NOT IN (SELECT rowid FROM %s WHERE %s)", t.name, strings.Join(termClauses, " AND ")))
+		args = append(args, termArgs...)
+	}
+
+	return clauses, args
+}
+
+// addFullTextSearch filters the query to objects matching the search string
+// q, using the full-text index of the object.
+func (qb *queryBuilder) addFullTextSearch(t ftsTable, idColumn string, q string) {
+	clauses, args := t.searchClauses(models.ParseSearchString(q))
+	if len(clauses) == 0 {
+		return
+	}
+
+	qb.addWhere(fmt.Sprintf("%s IN (SELECT rowid FROM %s WHERE %s)", idColumn, t.name, strings.Join(clauses, " AND ")))
+	qb.addArg(args...)
+}
+
+// getRelevanceSort returns the order by clause sorting objects by how well
+// they match the search query of the find filter. Descending order returns
+// the best matches first. Sorts by id if there is no search query.
+func (t ftsTable) getRelevanceSort(idColumn string, findFilter *models.FindFilterType) string {
+	direction := findFilter.GetDirection()
+
+	var expr string
+	if findFilter.Q != nil {
+		expr = ftsMatchExpression(models.ParseSearchString(*findFilter.Q))
+	}
+
+	if expr == "" {
+		return " ORDER BY " + idColumn + " " + direction
+	}
+
+	// rank is lower for better matches
+	rankDirection := "ASC"
+	if direction == "ASC" {
+		rankDirection = "DESC"
+	}
+
+	// the expression is a literal because the query arguments are shared
+	// with the count query, which is not sorted
+	literal := "'" + strings.ReplaceAll(expr, "'", "''") + "'"
+	return fmt.Sprintf(" ORDER BY (SELECT rank FROM %[1]s WHERE %[1]s MATCH %[2]s AND rowid = %[3]s) %[4]s", t.name, literal, idColumn, rankDirection)
+}
@@ -0,0 +1,8 @@
+//go:build !sqlite_fts5
+// +build !sqlite_fts5
+
+package sqlite
+
+// fts5Enabled is true if the sqlite driver is built with the FTS5 module.
+// The full-text search tables require the sqlite_fts5 build tag.
+const fts5Enabled = false
@@ -0,0 +1,7 @@
+//go:build sqlite_fts5
+// +build sqlite_fts5
+
+package sqlite
+
+// fts5Enabled is true if the sqlite driver is built with the FTS5 module.
+const fts5Enabled = true
@@ -0,0 +1,32 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ftsMatchExpression(t *testing.T) {
+	tests := []struct {
+		name string
+		q    string
+		want string
+	}{
+		{"single word", "foo", `"foo"*`},
+		{"multiple words", "foo bar", `"foo"* AND "bar"*`},
+		{"phrase", `"foo bar"`, `"foo bar"*`},
+		{"path", "foo_01.mp4", `"foo_01.mp4"*`},
+		{"quote in word", `it"s`, `"it""s"*`},
+		{"or", "foo bar | baz", `"foo"* AND ("bar"* OR "baz"*)`},
+		{"not", "foo -bar", `"foo"*`},
+		{"no words", "foo ---", `"foo"*`},
+		{"only punctuation", "... ---", ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ftsMatchExpression(models.ParseSearchString(tt.q))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
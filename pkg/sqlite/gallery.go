@@ -267,6 +267,12 @@ func (qb *GalleryStore) Create(ctx context.Context, newObject *models.Gallery, f
 		}
 	}
 
+	if newObject.CustomFields.Loaded() {
+		if err := galleriesCustomFieldsTableMgr.insertJoins(ctx, id, newObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -317,6 +323,12 @@ func (qb *GalleryStore) Update(ctx context.Context, updatedObject *models.Galler
 		}
 	}
 
+	if updatedObject.CustomFields.Loaded() {
+		if err := galleriesCustomFieldsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -362,6 +374,12 @@ func (qb *GalleryStore) UpdatePartial(ctx context.Context, id int, partial model
 		}
 	}
 
+	if partial.CustomFields != nil {
+		if err := galleriesCustomFieldsTableMgr.modifyJoins(ctx, id, partial.CustomFields.CustomFields, partial.CustomFields.Mode); err != nil {
+			return nil, err
+		}
+	}
+
 	return qb.find(ctx, id)
 }
 
@@ -421,13 +439,7 @@ func (qb *GalleryStore) FindMany(ctx context.Context, ids []int) ([]*models.Gall
 		return nil, err
 	}
 
-	for i := range galleries {
-		if galleries[i] == nil {
-			return nil, fmt.Errorf("gallery with id %d not found", ids[i])
-		}
-	}
-
-	return galleries, nil
+	return checkAllFound(ctx, galleries, ids, "gallery")
 }
 
 // returns nil, sql.ErrNoRows if not found
@@ -469,6 +481,8 @@ func (qb *GalleryStore) get(ctx context.Context, q *goqu.SelectDataset) (*models
 }
 
 func (qb *GalleryStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.Gallery, error) {
+	q = galleryRestriction.restrict(ctx, q)
+
 	const single = false
 	var ret []*models.Gallery
 	var lastID int
@@ -690,38 +704,7 @@ func (qb *GalleryStore) makeQuery(ctx context.Context, galleryFilter *models.Gal
 	distinctIDs(&query, galleryTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.addJoins(
-			join{
-				table:    galleriesFilesTable,
-				onClause: "galleries_files.gallery_id = galleries.id",
-			},
-			join{
-				table:    fileTable,
-				onClause: "galleries_files.file_id = files.id",
-			},
-			join{
-				table:    folderTable,
-				onClause: "files.parent_folder_id = folders.id",
-			},
-			join{
-				table:    fingerprintTable,
-				onClause: "files_fingerprints.file_id = galleries_files.file_id",
-			},
-			join{
-				table:    folderTable,
-				as:       "gallery_folder",
-				onClause: "galleries.folder_id = gallery_folder.id",
-			},
-			join{
-				table:    galleriesChaptersTable,
-				onClause: "galleries_chapters.gallery_id = galleries.id",
-			},
-		)
-
-		// add joins for files and checksum
-		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"galleries.title", "gallery_folder.path", filepathColumn, "files_fingerprints.fingerprint", "galleries_chapters.title"}
-		query.parseQueryString(searchColumns, *q)
+		query.addFullTextSearch(galleriesFTSTable, "galleries.id", *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &galleryFilterHandler{
@@ -732,6 +715,10 @@ func (qb *GalleryStore) makeQuery(ctx context.Context, galleryFilter *models.Gal
 		return nil, err
 	}
 
+	if err := query.addFilter(filterBuilderFromHandler(ctx, galleryRestriction)); err != nil {
+		return nil, err
+	}
+
 	if err := qb.setGallerySort(&query, findFilter); err != nil {
 		return nil, err
 	}
@@ -779,6 +766,7 @@ var gallerySortOptions = sortOptions{
 	"performer_count",
 	"random",
 	"rating",
+	"relevance",
 	"tag_count",
 	"title",
 	"updated_at",
@@ -846,6 +834,8 @@ func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.F
 		addFileTable()
 		addFolderTable()
 		query.sortAndPagination += " ORDER BY COALESCE(galleries.title, files.basename, basename(COALESCE(folders.path, ''))) COLLATE NATURAL_CI " + direction + ", file_folder.path COLLATE NATURAL_CI " + direction
+	case relevanceSort:
+		query.sortAndPagination += galleriesFTSTable.getRelevanceSort("galleries.id", findFilter)
 	default:
 		query.sortAndPagination += getSort(sort, direction, "galleries")
 	}
@@ -860,6 +850,10 @@ func (qb *GalleryStore) GetURLs(ctx context.Context, galleryID int) ([]string, e
 	return galleriesURLsTableMgr.get(ctx, galleryID)
 }
 
+func (qb *GalleryStore) GetCustomFields(ctx context.Context, galleryID int) ([]models.CustomField, error) {
+	return galleriesCustomFieldsTableMgr.get(ctx, galleryID)
+}
+
 func (qb *GalleryStore) AddFileID(ctx context.Context, id int, fileID models.FileID) error {
 	const firstPrimary = false
 	return galleriesFilesTableMgr.insertJoins(ctx, id, firstPrimary, []models.FileID{fileID})
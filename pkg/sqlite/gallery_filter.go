@@ -105,6 +105,12 @@ func (qb *galleryFilterHandler) criterionHandler() criterionHandler {
 		&timestampCriterionHandler{filter.CreatedAt, "galleries.created_at", nil},
 		&timestampCriterionHandler{filter.UpdatedAt, "galleries.updated_at", nil},
 
+		&customFieldsCriterionHandler{
+			criteria:    filter.CustomFields,
+			table:       galleriesCustomFieldsTableMgr,
+			parentIDCol: "galleries.id",
+		},
+
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes_galleries.scene_id",
 			relatedRepo:    sceneRepository.repository,
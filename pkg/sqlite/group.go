@@ -176,6 +176,12 @@ func (qb *GroupStore) Create(ctx context.Context, newObject *models.Group) error
 		return err
 	}
 
+	if newObject.CustomFields.Loaded() {
+		if err := groupsCustomFieldsTableMgr.insertJoins(ctx, id, newObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -211,6 +217,12 @@ func (qb *GroupStore) UpdatePartial(ctx context.Context, id int, partial models.
 		return nil, err
 	}
 
+	if partial.CustomFields != nil {
+		if err := groupsCustomFieldsTableMgr.modifyJoins(ctx, id, partial.CustomFields.CustomFields, partial.CustomFields.Mode); err != nil {
+			return nil, err
+		}
+	}
+
 	return qb.find(ctx, id)
 }
 
@@ -232,6 +244,12 @@ func (qb *GroupStore) Update(ctx context.Context, updatedObject *models.Group) e
 		return err
 	}
 
+	if updatedObject.CustomFields.Loaded() {
+		if err := groupsCustomFieldsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -274,13 +292,7 @@ func (qb *GroupStore) FindMany(ctx context.Context, ids []int) ([]*models.Group,
 		return nil, err
 	}
 
-	for i := range ret {
-		if ret[i] == nil {
-			return nil, fmt.Errorf("group with id %d not found", ids[i])
-		}
-	}
-
-	return ret, nil
+	return checkAllFound(ctx, ret, ids, "group")
 }
 
 // returns nil, sql.ErrNoRows if not found
@@ -310,6 +322,8 @@ func (qb *GroupStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.G
 }
 
 func (qb *GroupStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.Group, error) {
+	q = groupRestriction.restrict(ctx, q)
+
 	const single = false
 	var ret []*models.Group
 	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
@@ -412,6 +426,10 @@ func (qb *GroupStore) makeQuery(ctx context.Context, groupFilter *models.GroupFi
 		return nil, err
 	}
 
+	if err := query.addFilter(filterBuilderFromHandler(ctx, groupRestriction)); err != nil {
+		return nil, err
+	}
+
 	var err error
 	query.sortAndPagination, err = qb.getGroupSort(findFilter)
 	if err != nil {
@@ -592,3 +610,7 @@ WHERE movies.studio_id = ?
 func (qb *GroupStore) GetURLs(ctx context.Context, groupID int) ([]string, error) {
 	return groupsURLsTableMgr.get(ctx, groupID)
 }
+
+func (qb *GroupStore) GetCustomFields(ctx context.Context, groupID int) ([]models.CustomField, error) {
+	return groupsCustomFieldsTableMgr.get(ctx, groupID)
+}
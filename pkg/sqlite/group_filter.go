@@ -69,6 +69,12 @@ func (qb *groupFilterHandler) criterionHandler() criterionHandler {
 		&timestampCriterionHandler{groupFilter.CreatedAt, "movies.created_at", nil},
 		&timestampCriterionHandler{groupFilter.UpdatedAt, "movies.updated_at", nil},
 
+		&customFieldsCriterionHandler{
+			criteria:    groupFilter.CustomFields,
+			table:       groupsCustomFieldsTableMgr,
+			parentIDCol: "movies.id",
+		},
+
 		&relatedFilterHandler{
 			relatedIDCol:   "movies_scenes.scene_id",
 			relatedRepo:    sceneRepository.repository,
@@ -273,6 +273,12 @@ func (qb *ImageStore) Create(ctx context.Context, newObject *models.Image, fileI
 		}
 	}
 
+	if newObject.CustomFields.Loaded() {
+		if err := imagesCustomFieldsTableMgr.insertJoins(ctx, id, newObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -326,6 +332,12 @@ func (qb *ImageStore) UpdatePartial(ctx context.Context, id int, partial models.
 		}
 	}
 
+	if partial.CustomFields != nil {
+		if err := imagesCustomFieldsTableMgr.modifyJoins(ctx, id, partial.CustomFields.CustomFields, partial.CustomFields.Mode); err != nil {
+			return nil, err
+		}
+	}
+
 	return qb.find(ctx, id)
 }
 
@@ -371,6 +383,13 @@ func (qb *ImageStore) Update(ctx context.Context, updatedObject *models.Image) e
 			return err
 		}
 	}
+
+	if updatedObject.CustomFields.Loaded() {
+		if err := imagesCustomFieldsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -407,13 +426,7 @@ func (qb *ImageStore) FindMany(ctx context.Context, ids []int) ([]*models.Image,
 		return nil, err
 	}
 
-	for i := range images {
-		if images[i] == nil {
-			return nil, fmt.Errorf("image with id %d not found", ids[i])
-		}
-	}
-
-	return images, nil
+	return checkAllFound(ctx, images, ids, "image")
 }
 
 // returns nil, sql.ErrNoRows if not found
@@ -455,6 +468,8 @@ func (qb *ImageStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.I
 }
 
 func (qb *ImageStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.Image, error) {
+	q = imageRestriction.restrict(ctx, q)
+
 	const single = false
 	var ret []*models.Image
 	var lastID int
@@ -717,28 +732,7 @@ func (qb *ImageStore) makeQuery(ctx context.Context, imageFilter *models.ImageFi
 	distinctIDs(&query, imageTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.addJoins(
-			join{
-				table:    imagesFilesTable,
-				onClause: "images_files.image_id = images.id",
-			},
-			join{
-				table:    fileTable,
-				onClause: "images_files.file_id = files.id",
-			},
-			join{
-				table:    folderTable,
-				onClause: "files.parent_folder_id = folders.id",
-			},
-			join{
-				table:    fingerprintTable,
-				onClause: "files_fingerprints.file_id = images_files.file_id",
-			},
-		)
-
-		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"images.title", filepathColumn, "files_fingerprints.fingerprint"}
-		query.parseQueryString(searchColumns, *q)
+		query.addFullTextSearch(imagesFTSTable, "images.id", *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &imageFilterHandler{
@@ -749,6 +743,10 @@ func (qb *ImageStore) makeQuery(ctx context.Context, imageFilter *models.ImageFi
 		return nil, err
 	}
 
+	if err := query.addFilter(filterBuilderFromHandler(ctx, imageRestriction)); err != nil {
+		return nil, err
+	}
+
 	if err := qb.setImageSortAndPagination(&query, findFilter); err != nil {
 		return nil, err
 	}
@@ -858,6 +856,7 @@ var imageSortOptions = sortOptions{
 	"performer_count",
 	"random",
 	"rating",
+	"relevance",
 	"tag_count",
 	"title",
 	"updated_at",
@@ -918,6 +917,8 @@ func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *mod
 			addFilesJoin()
 			addFolderJoin()
 			sortClause = " ORDER BY COALESCE(images.title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
+		case relevanceSort:
+			sortClause = imagesFTSTable.getRelevanceSort("images.id", findFilter)
 		default:
 			sortClause = getSort(sort, direction, "images")
 		}
@@ -966,3 +967,7 @@ func (qb *ImageStore) UpdateTags(ctx context.Context, imageID int, tagIDs []int)
 func (qb *ImageStore) GetURLs(ctx context.Context, imageID int) ([]string, error) {
 	return imagesURLsTableMgr.get(ctx, imageID)
 }
+
+func (qb *ImageStore) GetCustomFields(ctx context.Context, imageID int) ([]models.CustomField, error) {
+	return imagesCustomFieldsTableMgr.get(ctx, imageID)
+}
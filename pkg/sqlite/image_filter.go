@@ -91,6 +91,12 @@ func (qb *imageFilterHandler) criterionHandler() criterionHandler {
 		&timestampCriterionHandler{imageFilter.CreatedAt, "images.created_at", nil},
 		&timestampCriterionHandler{imageFilter.UpdatedAt, "images.updated_at", nil},
 
+		&customFieldsCriterionHandler{
+			criteria:    imageFilter.CustomFields,
+			table:       imagesCustomFieldsTableMgr,
+			parentIDCol: "images.id",
+		},
+
 		&relatedFilterHandler{
 			relatedIDCol:   "galleries_images.gallery_id",
 			relatedRepo:    galleryRepository.repository,
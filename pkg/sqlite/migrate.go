@@ -20,6 +20,10 @@ type Migrator struct {
 }
 
 func NewMigrator(db *Database) (*Migrator, error) {
+	if !fts5Enabled {
+		return nil, ErrFTS5NotSupported
+	}
+
 	m := &Migrator{
 		db: db,
 	}
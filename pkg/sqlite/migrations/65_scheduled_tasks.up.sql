@@ -0,0 +1,24 @@
+CREATE TABLE `scheduled_tasks` (
+  `id` integer not null primary key autoincrement,
+  `name` varchar(255) not null,
+  `type` varchar(255) not null,
+  `schedule` varchar(255) not null,
+  `input` text,
+  `enabled` boolean not null default '1',
+  `next_run_at` datetime,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE TABLE `scheduled_task_runs` (
+  `id` integer not null primary key autoincrement,
+  `scheduled_task_id` integer not null,
+  `status` varchar(255) not null,
+  `add_time` datetime not null,
+  `start_time` datetime,
+  `end_time` datetime,
+  `error` text,
+  foreign key(`scheduled_task_id`) references `scheduled_tasks`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scheduled_task_runs_on_scheduled_task_id` on `scheduled_task_runs` (`scheduled_task_id`);
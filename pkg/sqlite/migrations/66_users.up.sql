@@ -0,0 +1,45 @@
+CREATE TABLE `users` (
+  `id` integer not null primary key autoincrement,
+  `username` varchar(255) not null,
+  `password` varchar(255) not null,
+  `role` varchar(255) not null,
+  `api_key` varchar(255),
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `index_users_on_username` on `users` (`username`);
+CREATE UNIQUE INDEX `index_users_on_api_key` on `users` (`api_key`);
+
+CREATE TABLE `scenes_users` (
+  `scene_id` integer not null,
+  `user_id` integer not null,
+  `rating` tinyint,
+  `resume_time` float not null default 0,
+  `play_duration` float not null default 0,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`user_id`) references `users`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `user_id`)
+);
+
+CREATE INDEX `index_scenes_users_on_user_id` on `scenes_users` (`user_id`);
+
+CREATE TABLE `scenes_users_view_dates` (
+  `scene_id` integer not null,
+  `user_id` integer not null,
+  `view_date` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`user_id`) references `users`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scenes_users_view_dates_on_user_id_scene_id` on `scenes_users_view_dates` (`user_id`, `scene_id`);
+
+CREATE TABLE `scenes_users_o_dates` (
+  `scene_id` integer not null,
+  `user_id` integer not null,
+  `o_date` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`user_id`) references `users`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_scenes_users_o_dates_on_user_id_scene_id` on `scenes_users_o_dates` (`user_id`, `scene_id`);
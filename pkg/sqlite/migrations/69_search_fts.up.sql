@@ -0,0 +1,58 @@
+-- Full-text search indexes for groups and scene markers, used by the global
+-- search query. Maintained in the same way as the indexes in 68_fts.
+
+CREATE VIEW `movies_fts_source` AS
+SELECT
+  `movies`.`id` AS `id`,
+  `movies`.`name` AS `name`,
+  `movies`.`aliases` AS `aliases`
+FROM `movies`;
+
+CREATE VIEW `scene_markers_fts_source` AS
+SELECT
+  `scene_markers`.`id` AS `id`,
+  `scene_markers`.`title` AS `title`,
+  `tags`.`name` AS `tag`
+FROM `scene_markers`
+LEFT JOIN `tags` ON `tags`.`id` = `scene_markers`.`primary_tag_id`;
+
+CREATE VIRTUAL TABLE `movies_fts` USING fts5(`name`, `aliases`, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+CREATE VIRTUAL TABLE `scene_markers_fts` USING fts5(`title`, `tag`, tokenize = 'unicode61 remove_diacritics 2', prefix = '2 3');
+
+INSERT INTO `movies_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `movies_fts_source`;
+INSERT INTO `scene_markers_fts` (`rowid`, `title`, `tag`) SELECT * FROM `scene_markers_fts_source`;
+
+-- groups
+
+CREATE TRIGGER `movies_fts_insert` AFTER INSERT ON `movies` BEGIN
+  INSERT INTO `movies_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `movies_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `movies_fts_update` AFTER UPDATE OF `name`, `aliases` ON `movies` BEGIN
+  DELETE FROM `movies_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `movies_fts` (`rowid`, `name`, `aliases`) SELECT * FROM `movies_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `movies_fts_delete` AFTER DELETE ON `movies` BEGIN
+  DELETE FROM `movies_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+-- scene markers
+
+CREATE TRIGGER `scene_markers_search_fts_insert` AFTER INSERT ON `scene_markers` BEGIN
+  INSERT INTO `scene_markers_fts` (`rowid`, `title`, `tag`) SELECT * FROM `scene_markers_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `scene_markers_search_fts_update` AFTER UPDATE OF `title`, `primary_tag_id` ON `scene_markers` BEGIN
+  DELETE FROM `scene_markers_fts` WHERE `rowid` = NEW.`id`;
+  INSERT INTO `scene_markers_fts` (`rowid`, `title`, `tag`) SELECT * FROM `scene_markers_fts_source` WHERE `id` = NEW.`id`;
+END;
+
+CREATE TRIGGER `scene_markers_search_fts_delete` AFTER DELETE ON `scene_markers` BEGIN
+  DELETE FROM `scene_markers_fts` WHERE `rowid` = OLD.`id`;
+END;
+
+CREATE TRIGGER `scene_markers_search_fts_tags_update` AFTER UPDATE OF `name` ON `tags` BEGIN
+  DELETE FROM `scene_markers_fts` WHERE `rowid` IN (SELECT `id` FROM `scene_markers` WHERE `primary_tag_id` = NEW.`id`);
+  INSERT INTO `scene_markers_fts` (`rowid`, `title`, `tag`) SELECT * FROM `scene_markers_fts_source` WHERE `id` IN (SELECT `id` FROM `scene_markers` WHERE `primary_tag_id` = NEW.`id`);
+END;
@@ -0,0 +1,80 @@
+-- Typed custom fields for scenes, images, galleries, performers, studios,
+-- groups and tags. The value column has no type affinity so that values keep
+-- the storage class they were inserted with.
+
+CREATE TABLE `scene_custom_fields` (
+  `scene_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `field`)
+);
+
+CREATE INDEX `index_scene_custom_fields_field_value` ON `scene_custom_fields` (`field`, `value`);
+
+CREATE TABLE `image_custom_fields` (
+  `image_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  foreign key(`image_id`) references `images`(`id`) on delete CASCADE,
+  PRIMARY KEY(`image_id`, `field`)
+);
+
+CREATE INDEX `index_image_custom_fields_field_value` ON `image_custom_fields` (`field`, `value`);
+
+CREATE TABLE `gallery_custom_fields` (
+  `gallery_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE,
+  PRIMARY KEY(`gallery_id`, `field`)
+);
+
+CREATE INDEX `index_gallery_custom_fields_field_value` ON `gallery_custom_fields` (`field`, `value`);
+
+CREATE TABLE `performer_custom_fields` (
+  `performer_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE,
+  PRIMARY KEY(`performer_id`, `field`)
+);
+
+CREATE INDEX `index_performer_custom_fields_field_value` ON `performer_custom_fields` (`field`, `value`);
+
+CREATE TABLE `studio_custom_fields` (
+  `studio_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  foreign key(`studio_id`) references `studios`(`id`) on delete CASCADE,
+  PRIMARY KEY(`studio_id`, `field`)
+);
+
+CREATE INDEX `index_studio_custom_fields_field_value` ON `studio_custom_fields` (`field`, `value`);
+
+CREATE TABLE `movie_custom_fields` (
+  `movie_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  foreign key(`movie_id`) references `movies`(`id`) on delete CASCADE,
+  PRIMARY KEY(`movie_id`, `field`)
+);
+
+CREATE INDEX `index_movie_custom_fields_field_value` ON `movie_custom_fields` (`field`, `value`);
+
+CREATE TABLE `tag_custom_fields` (
+  `tag_id` integer NOT NULL,
+  `field` varchar(64) NOT NULL,
+  `type` varchar(16) NOT NULL,
+  `value` BLOB NOT NULL,
+  foreign key(`tag_id`) references `tags`(`id`) on delete CASCADE,
+  PRIMARY KEY(`tag_id`, `field`)
+);
+
+CREATE INDEX `index_tag_custom_fields_field_value` ON `tag_custom_fields` (`field`, `value`);
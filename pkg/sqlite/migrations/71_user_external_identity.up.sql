@@ -0,0 +1,4 @@
+ALTER TABLE `users` ADD COLUMN `external_issuer` varchar(255);
+ALTER TABLE `users` ADD COLUMN `external_subject` varchar(255);
+
+CREATE UNIQUE INDEX `index_users_on_external_identity` on `users` (`external_issuer`, `external_subject`);
@@ -0,0 +1,6 @@
+-- API keys of local users are stored as hashes. Existing keys cannot be
+-- hashed here, so they are removed and must be generated again.
+DROP INDEX `index_users_on_api_key`;
+ALTER TABLE `users` RENAME COLUMN `api_key` TO `api_key_hash`;
+UPDATE `users` SET `api_key_hash` = NULL;
+CREATE UNIQUE INDEX `index_users_on_api_key_hash` on `users` (`api_key_hash`);
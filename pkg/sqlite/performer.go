@@ -282,6 +282,12 @@ func (qb *PerformerStore) Create(ctx context.Context, newObject *models.Performe
 		}
 	}
 
+	if newObject.CustomFields.Loaded() {
+		if err := performersCustomFieldsTableMgr.insertJoins(ctx, id, newObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -330,6 +336,12 @@ func (qb *PerformerStore) UpdatePartial(ctx context.Context, id int, partial mod
 		}
 	}
 
+	if partial.CustomFields != nil {
+		if err := performersCustomFieldsTableMgr.modifyJoins(ctx, id, partial.CustomFields.CustomFields, partial.CustomFields.Mode); err != nil {
+			return nil, err
+		}
+	}
+
 	return qb.find(ctx, id)
 }
 
@@ -365,6 +377,12 @@ func (qb *PerformerStore) Update(ctx context.Context, updatedObject *models.Perf
 		}
 	}
 
+	if updatedObject.CustomFields.Loaded() {
+		if err := performersCustomFieldsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -377,6 +395,61 @@ func (qb *PerformerStore) Destroy(ctx context.Context, id int) error {
 	return performerRepository.destroyExisting(ctx, []int{id})
 }
 
+// Merge moves the scene, image, gallery and restriction profile associations
+// of the source performers to the destination performer, then destroys the
+// source performers. Other performer fields are not merged.
+func (qb *PerformerStore) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	args := []interface{}{destination}
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args = append(args, srcArgs...)
+
+	performerTables := map[string]string{
+		performersScenesTable:              sceneIDColumn,
+		performersImagesTable:              imageIDColumn,
+		performersGalleriesTable:           galleryIDColumn,
+		restrictionProfilesPerformersTable: restrictionProfileIDColumn,
+	}
+
+	args = append(args, destination)
+	for table, idColumn := range performerTables {
+		_, err := dbWrapper.Exec(ctx, `UPDATE OR IGNORE `+table+`
+SET performer_id = ?
+WHERE performer_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idColumn+` AND o.performer_id = ?)`,
+			args...,
+		)
+		if err != nil {
+			return err
+		}
+
+		// delete source performer ids from the table where they couldn't be set
+		if _, err := dbWrapper.Exec(ctx, `DELETE FROM `+table+` WHERE performer_id IN `+inBinding, srcArgs...); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // returns nil, nil if not found
 func (qb *PerformerStore) Find(ctx context.Context, id int) (*models.Performer, error) {
 	ret, err := qb.find(ctx, id)
@@ -407,13 +480,7 @@ func (qb *PerformerStore) FindMany(ctx context.Context, ids []int) ([]*models.Pe
 		return nil, err
 	}
 
-	for i := range ret {
-		if ret[i] == nil {
-			return nil, fmt.Errorf("performer with id %d not found", ids[i])
-		}
-	}
-
-	return ret, nil
+	return checkAllFound(ctx, ret, ids, "performer")
 }
 
 // returns nil, sql.ErrNoRows if not found
@@ -455,6 +522,8 @@ func (qb *PerformerStore) get(ctx context.Context, q *goqu.SelectDataset) (*mode
 }
 
 func (qb *PerformerStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.Performer, error) {
+	q = performerRestriction.restrict(ctx, q)
+
 	const single = false
 	var ret []*models.Performer
 	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
@@ -599,9 +668,7 @@ func (qb *PerformerStore) makeQuery(ctx context.Context, performerFilter *models
 	distinctIDs(&query, performerTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(performersAliasesTable, "", "performer_aliases.performer_id = performers.id")
-		searchColumns := []string{"performers.name", "performer_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		query.addFullTextSearch(performersFTSTable, "performers.id", *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &performerFilterHandler{
@@ -612,6 +679,10 @@ func (qb *PerformerStore) makeQuery(ctx context.Context, performerFilter *models
 		return nil, err
 	}
 
+	if err := query.addFilter(filterBuilderFromHandler(ctx, performerRestriction)); err != nil {
+		return nil, err
+	}
+
 	var err error
 	query.sortAndPagination, err = qb.getPerformerSort(findFilter)
 	if err != nil {
@@ -723,6 +794,7 @@ var performerSortOptions = sortOptions{
 	"play_count",
 	"random",
 	"rating",
+	"relevance",
 	"scenes_count",
 	"tag_count",
 	"updated_at",
@@ -762,6 +834,8 @@ func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) (s
 		sortQuery += qb.sortByLastPlayedAt(direction)
 	case "last_o_at":
 		sortQuery += qb.sortByLastOAt(direction)
+	case relevanceSort:
+		sortQuery += performersFTSTable.getRelevanceSort("performers.id", findFilter)
 	default:
 		sortQuery += getSort(sort, direction, "performers")
 	}
@@ -799,6 +873,10 @@ func (qb *PerformerStore) GetURLs(ctx context.Context, performerID int) ([]strin
 	return performersURLsTableMgr.get(ctx, performerID)
 }
 
+func (qb *PerformerStore) GetCustomFields(ctx context.Context, performerID int) ([]models.CustomField, error) {
+	return performersCustomFieldsTableMgr.get(ctx, performerID)
+}
+
 func (qb *PerformerStore) GetStashIDs(ctx context.Context, performerID int) ([]models.StashID, error) {
 	return performersStashIDsTableMgr.get(ctx, performerID)
 }
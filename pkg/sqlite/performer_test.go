@@ -2051,6 +2051,78 @@ func TestPerformerStore_FindByStashIDStatus(t *testing.T) {
 	}
 }
 
+func TestPerformerMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	// merge tests - perform these in a transaction that we'll rollback
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		// try merging into same performer
+		err := qb.Merge(ctx, []int{performerIDs[performerIdxWithScene]}, performerIDs[performerIdxWithScene])
+		assert.NotNil(err)
+
+		srcIdxs := []int{
+			performerIdx1WithScene,
+			performerIdxWithImage,
+			performerIdxWithGallery,
+		}
+		var srcIDs []int
+		for _, idx := range srcIdxs {
+			srcIDs = append(srcIDs, performerIDs[idx])
+		}
+
+		destID := performerIDs[performerIdx2WithScene]
+		if err = qb.Merge(ctx, srcIDs, destID); err != nil {
+			return err
+		}
+
+		// ensure source performers are deleted
+		for _, id := range srcIDs {
+			p, err := qb.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			assert.Nil(p)
+		}
+
+		// ensure scene points to the destination only once
+		s, err := db.Scene.Find(ctx, sceneIDs[sceneIdxWithTwoPerformers])
+		if err != nil {
+			return err
+		}
+		if err := s.LoadPerformerIDs(ctx, db.Scene); err != nil {
+			return err
+		}
+		assert.Equal([]int{destID}, s.PerformerIDs.List())
+
+		// ensure image points to the destination
+		i, err := db.Image.Find(ctx, imageIDs[imageIdxWithPerformer])
+		if err != nil {
+			return err
+		}
+		if err := i.LoadPerformerIDs(ctx, db.Image); err != nil {
+			return err
+		}
+		assert.Contains(i.PerformerIDs.List(), destID)
+
+		// ensure gallery points to the destination
+		g, err := db.Gallery.Find(ctx, galleryIDs[galleryIdxWithPerformer])
+		if err != nil {
+			return err
+		}
+		if err := g.LoadPerformerIDs(ctx, db.Gallery); err != nil {
+			return err
+		}
+		assert.Contains(g.PerformerIDs.List(), destID)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 // TODO Update
 // TODO Destroy
 // TODO Find
@@ -141,9 +141,11 @@ func (qb *queryBuilder) addFilter(f *filterBuilder) error {
 		qb.args = append(args, qb.args...)
 	}
 
+	// the clauses of each filter are parenthesised, so that a sub-filter
+	// combined with OR does not absorb the clauses of the other filters
 	clause, args = f.generateWhereClauses()
 	if len(clause) > 0 {
-		qb.addWhere(clause)
+		qb.addWhere("(" + clause + ")")
 	}
 
 	if len(args) > 0 {
@@ -152,7 +154,7 @@ func (qb *queryBuilder) addFilter(f *filterBuilder) error {
 
 	clause, args = f.generateHavingClauses()
 	if len(clause) > 0 {
-		qb.addHaving(clause)
+		qb.addHaving("(" + clause + ")")
 	}
 
 	if len(args) > 0 {
@@ -0,0 +1,205 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+// restrictionTable describes how the content restriction applies to the
+// objects of a table.
+type restrictionTable struct {
+	table string
+
+	// join table between the objects and tags, and the object column in it.
+	// For the tags table, the excluded tags are matched by id.
+	tagsTable string
+	tagsFK    string
+
+	// join table between the objects and performers, and the object column
+	// in it. For the performers table, the excluded performers are matched
+	// by id.
+	performersTable string
+	performersFK    string
+
+	// hasStudio is true if the objects have a studio_id column. For the
+	// studios table, the excluded studios are matched by id.
+	hasStudio bool
+	hasRating bool
+
+	// objects are hidden if the parent object that they belong to is hidden
+	parent   *restrictionTable
+	parentFK string
+}
+
+var (
+	sceneRestriction = restrictionTable{
+		table:           sceneTable,
+		tagsTable:       scenesTagsTable,
+		tagsFK:          sceneIDColumn,
+		performersTable: performersScenesTable,
+		performersFK:    sceneIDColumn,
+		hasStudio:       true,
+		hasRating:       true,
+	}
+
+	imageRestriction = restrictionTable{
+		table:           imageTable,
+		tagsTable:       imagesTagsTable,
+		tagsFK:          imageIDColumn,
+		performersTable: performersImagesTable,
+		performersFK:    imageIDColumn,
+		hasStudio:       true,
+		hasRating:       true,
+	}
+
+	galleryRestriction = restrictionTable{
+		table:           galleryTable,
+		tagsTable:       galleriesTagsTable,
+		tagsFK:          galleryIDColumn,
+		performersTable: performersGalleriesTable,
+		performersFK:    galleryIDColumn,
+		hasStudio:       true,
+		hasRating:       true,
+	}
+
+	performerRestriction = restrictionTable{
+		table:     performerTable,
+		tagsTable: performersTagsTable,
+		tagsFK:    performerIDColumn,
+		hasRating: true,
+	}
+
+	groupRestriction = restrictionTable{
+		table:     groupTable,
+		tagsTable: groupsTagsTable,
+		tagsFK:    groupIDColumn,
+		hasStudio: true,
+		hasRating: true,
+	}
+
+	studioRestriction = restrictionTable{
+		table: studioTable,
+	}
+
+	tagRestriction = restrictionTable{
+		table: tagTable,
+	}
+
+	sceneMarkerRestriction = restrictionTable{
+		table:    sceneMarkerTable,
+		parent:   &sceneRestriction,
+		parentFK: sceneIDColumn,
+	}
+)
+
+func intsToSQLList(ids []int) string {
+	return "(" + strings.Join(sliceutil.Map(ids, strconv.Itoa), ",") + ")"
+}
+
+// clause returns the where clause that excludes the objects hidden by the
+// restriction. Returns an empty string if no objects are hidden.
+// The ids are written into the clause, rather than passed as arguments, so
+// that the clause can be added to queries independently of their arguments.
+func (t restrictionTable) clause(r *models.ContentRestriction) string {
+	if t.parent != nil {
+		parentClause := t.parent.clause(r)
+		if parentClause == "" {
+			return ""
+		}
+
+		return fmt.Sprintf("%s.%s IN (SELECT %s.id FROM %[3]s WHERE %s)", t.table, t.parentFK, t.parent.table, parentClause)
+	}
+
+	idCol := t.table + ".id"
+
+	var clauses []string
+
+	if len(r.TagIDs) > 0 {
+		switch {
+		case t.table == tagTable:
+			clauses = append(clauses, fmt.Sprintf("%s NOT IN %s", idCol, intsToSQLList(r.TagIDs)))
+		case t.tagsTable != "":
+			clauses = append(clauses, fmt.Sprintf("%s NOT IN (SELECT %s FROM %s WHERE %s IN %s)", idCol, t.tagsFK, t.tagsTable, tagIDColumn, intsToSQLList(r.TagIDs)))
+		}
+	}
+
+	if len(r.PerformerIDs) > 0 {
+		switch {
+		case t.table == performerTable:
+			clauses = append(clauses, fmt.Sprintf("%s NOT IN %s", idCol, intsToSQLList(r.PerformerIDs)))
+		case t.performersTable != "":
+			clauses = append(clauses, fmt.Sprintf("%s NOT IN (SELECT %s FROM %s WHERE %s IN %s)", idCol, t.performersFK, t.performersTable, performerIDColumn, intsToSQLList(r.PerformerIDs)))
+		}
+	}
+
+	if len(r.StudioIDs) > 0 {
+		switch {
+		case t.table == studioTable:
+			clauses = append(clauses, fmt.Sprintf("%s NOT IN %s", idCol, intsToSQLList(r.StudioIDs)))
+		case t.hasStudio:
+			clauses = append(clauses, fmt.Sprintf("(%[1]s.studio_id IS NULL OR %[1]s.studio_id NOT IN %s)", t.table, intsToSQLList(r.StudioIDs)))
+		}
+	}
+
+	// unrated content is not hidden
+	if r.MaxRating != nil && t.hasRating {
+		clauses = append(clauses, fmt.Sprintf("(%[1]s.rating IS NULL OR %[1]s.rating <= %d)", t.table, *r.MaxRating))
+	}
+
+	return strings.Join(clauses, " AND ")
+}
+
+// handle adds the restriction in the context to the filter. The restriction
+// must be added to the query as a separate filter, so that it cannot be
+// negated or combined with OR by the sub-filters of the user filter.
+func (t restrictionTable) handle(ctx context.Context, f *filterBuilder) {
+	r := models.GetContentRestriction(ctx)
+	if r == nil {
+		return
+	}
+
+	if clause := t.clause(r); clause != "" {
+		f.addWhere(clause)
+	}
+}
+
+// restrict adds the restriction in the context to the dataset.
+func (t restrictionTable) restrict(ctx context.Context, q *goqu.SelectDataset) *goqu.SelectDataset {
+	r := models.GetContentRestriction(ctx)
+	if r == nil {
+		return q
+	}
+
+	if clause := t.clause(r); clause != "" {
+		q = q.Where(goqu.L(clause))
+	}
+
+	return q
+}
+
+// checkAllFound returns an error for the first nil entry in objs. Entries
+// hidden by the restriction in the context are expected to be nil, and are
+// removed from the returned slice instead, so that hidden content is omitted
+// from the results.
+func checkAllFound[T any](ctx context.Context, objs []*T, ids []int, typeName string) ([]*T, error) {
+	if models.GetContentRestriction(ctx) != nil {
+		return sliceutil.Filter(objs, func(o *T) bool {
+			return o != nil
+		}), nil
+	}
+
+	for i := range objs {
+		if objs[i] == nil {
+			return nil, fmt.Errorf("%s with id %d not found", typeName, ids[i])
+		}
+	}
+
+	return objs, nil
+}
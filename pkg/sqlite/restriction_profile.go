@@ -0,0 +1,247 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	restrictionProfileTable            = "restriction_profiles"
+	restrictionProfileIDColumn         = "restriction_profile_id"
+	restrictionProfilesTagsTable       = "restriction_profiles_tags"
+	restrictionProfilesStudiosTable    = "restriction_profiles_studios"
+	restrictionProfilesPerformersTable = "restriction_profiles_performers"
+)
+
+type restrictionProfileRow struct {
+	ID      int    `db:"id" goqu:"skipinsert"`
+	Name    string `db:"name"`
+	PinHash string `db:"pin"`
+	// expressed as 1-100
+	MaxRating null.Int  `db:"max_rating"`
+	CreatedAt Timestamp `db:"created_at"`
+	UpdatedAt Timestamp `db:"updated_at"`
+}
+
+func (r *restrictionProfileRow) fromRestrictionProfile(o models.RestrictionProfile) {
+	r.ID = o.ID
+	r.Name = o.Name
+	r.PinHash = o.PinHash
+	r.MaxRating = intFromPtr(o.MaxRating)
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *restrictionProfileRow) resolve() *models.RestrictionProfile {
+	return &models.RestrictionProfile{
+		ID:        r.ID,
+		Name:      r.Name,
+		PinHash:   r.PinHash,
+		MaxRating: nullIntPtr(r.MaxRating),
+		CreatedAt: r.CreatedAt.Timestamp,
+		UpdatedAt: r.UpdatedAt.Timestamp,
+	}
+}
+
+type RestrictionProfileStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewRestrictionProfileStore() *RestrictionProfileStore {
+	return &RestrictionProfileStore{
+		repository: repository{
+			tableName: restrictionProfileTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: restrictionProfileTableMgr,
+	}
+}
+
+func (qb *RestrictionProfileStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *RestrictionProfileStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *RestrictionProfileStore) Create(ctx context.Context, newObject *models.RestrictionProfile) error {
+	var r restrictionProfileRow
+	r.fromRestrictionProfile(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	if err := qb.replaceJoins(ctx, id, *newObject); err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *RestrictionProfileStore) Update(ctx context.Context, updatedObject *models.RestrictionProfile) error {
+	var r restrictionProfileRow
+	r.fromRestrictionProfile(*updatedObject)
+
+	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
+		return err
+	}
+
+	return qb.replaceJoins(ctx, updatedObject.ID, *updatedObject)
+}
+
+func (qb *RestrictionProfileStore) replaceJoins(ctx context.Context, id int, o models.RestrictionProfile) error {
+	if err := restrictionProfilesTagsTableMgr.replaceJoins(ctx, id, o.TagIDs); err != nil {
+		return err
+	}
+
+	if err := restrictionProfilesStudiosTableMgr.replaceJoins(ctx, id, o.StudioIDs); err != nil {
+		return err
+	}
+
+	return restrictionProfilesPerformersTableMgr.replaceJoins(ctx, id, o.PerformerIDs)
+}
+
+func (qb *RestrictionProfileStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *RestrictionProfileStore) Find(ctx context.Context, id int) (*models.RestrictionProfile, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *RestrictionProfileStore) find(ctx context.Context, id int) (*models.RestrictionProfile, error) {
+	return qb.findOne(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+}
+
+// returns nil, nil if not found
+func (qb *RestrictionProfileStore) FindByName(ctx context.Context, name string) (*models.RestrictionProfile, error) {
+	q := qb.selectDataset().Where(qb.table().Col("name").Eq(name))
+
+	ret, err := qb.findOne(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *RestrictionProfileStore) findOne(ctx context.Context, q *goqu.SelectDataset) (*models.RestrictionProfile, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *RestrictionProfileStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.RestrictionProfile, error) {
+	const single = false
+	var ret []*models.RestrictionProfile
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f restrictionProfileRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// profiles are few and small, so load the joins for each
+	for _, p := range ret {
+		if err := qb.loadJoins(ctx, p); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+func (qb *RestrictionProfileStore) loadJoins(ctx context.Context, p *models.RestrictionProfile) error {
+	var err error
+	p.TagIDs, err = restrictionProfilesTagsTableMgr.get(ctx, p.ID)
+	if err != nil {
+		return fmt.Errorf("getting tag ids: %w", err)
+	}
+
+	p.StudioIDs, err = restrictionProfilesStudiosTableMgr.get(ctx, p.ID)
+	if err != nil {
+		return fmt.Errorf("getting studio ids: %w", err)
+	}
+
+	p.PerformerIDs, err = restrictionProfilesPerformersTableMgr.get(ctx, p.ID)
+	if err != nil {
+		return fmt.Errorf("getting performer ids: %w", err)
+	}
+
+	return nil
+}
+
+func (qb *RestrictionProfileStore) All(ctx context.Context) ([]*models.RestrictionProfile, error) {
+	return qb.getMany(ctx, qb.selectDataset().Order(qb.table().Col("name").Asc()))
+}
+
+// returns nil, nil if not found
+func (qb *RestrictionProfileStore) GetContentRestriction(ctx context.Context, id int) (*models.ContentRestriction, error) {
+	p, err := qb.Find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if p == nil {
+		return nil, nil
+	}
+
+	// include the descendants of the excluded tags
+	query := `WITH RECURSIVE excluded_tags AS (
+	SELECT tag_id AS id FROM ` + restrictionProfilesTagsTable + ` WHERE ` + restrictionProfileIDColumn + ` = ?
+	UNION
+	SELECT tr.child_id FROM ` + tagRelationsTable + ` tr INNER JOIN excluded_tags e ON e.id = tr.parent_id
+)
+SELECT id FROM excluded_tags
+`
+
+	tagIDs, err := qb.runIdsQuery(ctx, query, []interface{}{id})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ContentRestriction{
+		ProfileID:    p.ID,
+		MaxRating:    p.MaxRating,
+		TagIDs:       tagIDs,
+		StudioIDs:    p.StudioIDs,
+		PerformerIDs: p.PerformerIDs,
+	}, nil
+}
@@ -0,0 +1,156 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestRestrictionProfile(ctx context.Context, t *testing.T, p models.RestrictionProfile) *models.RestrictionProfile {
+	newProfile := models.NewRestrictionProfile()
+	newProfile.Name = p.Name
+	newProfile.PinHash = "hash"
+	newProfile.MaxRating = p.MaxRating
+	newProfile.TagIDs = p.TagIDs
+	newProfile.StudioIDs = p.StudioIDs
+	newProfile.PerformerIDs = p.PerformerIDs
+
+	if err := db.RestrictionProfile.Create(ctx, &newProfile); err != nil {
+		t.Fatalf("RestrictionProfileStore.Create() error = %v", err)
+	}
+
+	return &newProfile
+}
+
+func TestRestrictionProfileStore_GetContentRestriction(t *testing.T) {
+	runWithRollbackTxn(t, "get content restriction", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		p := createTestRestrictionProfile(ctx, t, models.RestrictionProfile{
+			Name:      "profile",
+			TagIDs:    []int{tagIDs[tagIdxWithGrandChild]},
+			StudioIDs: []int{studioIDs[studioIdxWithScene]},
+		})
+
+		got, err := db.RestrictionProfile.Find(ctx, p.ID)
+		if err != nil {
+			t.Errorf("RestrictionProfileStore.Find() error = %v", err)
+		}
+		assert.Equal(p, got)
+
+		r, err := db.RestrictionProfile.GetContentRestriction(ctx, p.ID)
+		if err != nil {
+			t.Errorf("RestrictionProfileStore.GetContentRestriction() error = %v", err)
+		}
+
+		// child tags are included
+		assert.ElementsMatch([]int{
+			tagIDs[tagIdxWithGrandChild],
+			tagIDs[tagIdxWithParentAndChild],
+			tagIDs[tagIdxWithGrandParent],
+		}, r.TagIDs)
+		assert.Equal(p.StudioIDs, r.StudioIDs)
+
+		if err := db.RestrictionProfile.Destroy(ctx, p.ID); err != nil {
+			t.Errorf("RestrictionProfileStore.Destroy() error = %v", err)
+		}
+
+		r, err = db.RestrictionProfile.GetContentRestriction(ctx, p.ID)
+		if err != nil {
+			t.Errorf("RestrictionProfileStore.GetContentRestriction() error = %v", err)
+		}
+		assert.Nil(r)
+	})
+}
+
+func TestSceneRestriction(t *testing.T) {
+	runWithRollbackTxn(t, "scene restriction", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		ctx = models.WithContentRestriction(ctx, &models.ContentRestriction{
+			TagIDs:       []int{tagIDs[tagIdxWithScene]},
+			StudioIDs:    []int{studioIDs[studioIdxWithScene]},
+			PerformerIDs: []int{performerIDs[performerIdxWithScene]},
+		})
+
+		hidden := []int{
+			sceneIDs[sceneIdxWithTag],
+			sceneIDs[sceneIdxWithStudio],
+			sceneIDs[sceneIdxWithPerformer],
+		}
+
+		for _, id := range hidden {
+			s, err := db.Scene.Find(ctx, id)
+			if err != nil {
+				t.Errorf("SceneStore.Find() error = %v", err)
+			}
+			assert.Nil(s)
+		}
+
+		// hidden scenes are returned as nil rather than an error
+		visibleID := sceneIDs[sceneIdxWithGallery]
+		scenes, err := db.Scene.FindMany(ctx, append([]int{visibleID}, hidden...))
+		if err != nil {
+			t.Errorf("SceneStore.FindMany() error = %v", err)
+		}
+		// hidden scenes are omitted
+		if assert.Len(scenes, 1) {
+			assert.Equal(visibleID, scenes[0].ID)
+		}
+
+		// the restriction cannot be bypassed by filtering for hidden content
+		pp := -1
+		scenes = queryScene(ctx, t, db.Scene, &models.SceneFilterType{
+			Tags: &models.HierarchicalMultiCriterionInput{
+				Value:    []string{strconv.Itoa(tagIDs[tagIdxWithScene])},
+				Modifier: models.CriterionModifierIncludes,
+			},
+			OperatorFilter: models.OperatorFilter[models.SceneFilterType]{
+				Or: &models.SceneFilterType{
+					ID: &models.IntCriterionInput{
+						Value:    visibleID,
+						Modifier: models.CriterionModifierNotEquals,
+					},
+				},
+			},
+		}, &models.FindFilterType{PerPage: &pp})
+
+		ids := sliceutil.Map(scenes, func(s *models.Scene) int { return s.ID })
+		assert.NotContains(ids, visibleID)
+		for _, id := range hidden {
+			assert.NotContains(ids, id)
+		}
+	})
+}
+
+func TestPerformerRestriction(t *testing.T) {
+	runWithRollbackTxn(t, "performer restriction", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		ctx = models.WithContentRestriction(ctx, &models.ContentRestriction{
+			TagIDs:       []int{tagIDs[tagIdxWithParentAndChild]},
+			PerformerIDs: []int{performerIDs[performerIdxWithScene]},
+		})
+
+		for _, idx := range []int{performerIdxWithScene, performerIdxWithParentTag} {
+			p, err := db.Performer.Find(ctx, performerIDs[idx])
+			if err != nil {
+				t.Errorf("PerformerStore.Find() error = %v", err)
+			}
+			assert.Nil(p)
+		}
+
+		performers := queryPerformers(ctx, t, nil, nil)
+		for _, p := range performers {
+			assert.NotEqual(performerIDs[performerIdxWithScene], p.ID)
+			assert.NotEqual(performerIDs[performerIdxWithParentTag], p.ID)
+		}
+	})
+}
@@ -349,6 +349,12 @@ func (qb *SceneStore) Create(ctx context.Context, newObject *models.Scene, fileI
 		}
 	}
 
+	if newObject.CustomFields.Loaded() {
+		if err := scenesCustomFieldsTableMgr.insertJoins(ctx, id, newObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -410,6 +416,12 @@ func (qb *SceneStore) UpdatePartial(ctx context.Context, id int, partial models.
 		}
 	}
 
+	if partial.CustomFields != nil {
+		if err := scenesCustomFieldsTableMgr.modifyJoins(ctx, id, partial.CustomFields.CustomFields, partial.CustomFields.Mode); err != nil {
+			return nil, err
+		}
+	}
+
 	return qb.find(ctx, id)
 }
 
@@ -468,6 +480,12 @@ func (qb *SceneStore) Update(ctx context.Context, updatedObject *models.Scene) e
 		}
 	}
 
+	if updatedObject.CustomFields.Loaded() {
+		if err := scenesCustomFieldsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -513,13 +531,7 @@ func (qb *SceneStore) FindMany(ctx context.Context, ids []int) ([]*models.Scene,
 		return nil, err
 	}
 
-	for i := range scenes {
-		if scenes[i] == nil {
-			return nil, fmt.Errorf("scene with id %d not found", ids[i])
-		}
-	}
-
-	return scenes, nil
+	return checkAllFound(ctx, scenes, ids, "scene")
 }
 
 // returns nil, sql.ErrNoRows if not found
@@ -561,6 +573,8 @@ func (qb *SceneStore) get(ctx context.Context, q *goqu.SelectDataset) (*models.S
 }
 
 func (qb *SceneStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.Scene, error) {
+	q = sceneRestriction.restrict(ctx, q)
+
 	const single = false
 	var ret []*models.Scene
 	var lastID int
@@ -931,32 +945,7 @@ func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFi
 	distinctIDs(&query, sceneTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.addJoins(
-			join{
-				table:    scenesFilesTable,
-				onClause: "scenes_files.scene_id = scenes.id",
-			},
-			join{
-				table:    fileTable,
-				onClause: "scenes_files.file_id = files.id",
-			},
-			join{
-				table:    folderTable,
-				onClause: "files.parent_folder_id = folders.id",
-			},
-			join{
-				table:    fingerprintTable,
-				onClause: "files_fingerprints.file_id = scenes_files.file_id",
-			},
-			join{
-				table:    sceneMarkerTable,
-				onClause: "scene_markers.scene_id = scenes.id",
-			},
-		)
-
-		filepathColumn := "folders.path || '" + string(filepath.Separator) + "' || files.basename"
-		searchColumns := []string{"scenes.title", "scenes.details", filepathColumn, "files_fingerprints.fingerprint", "scene_markers.title"}
-		query.parseQueryString(searchColumns, *q)
+		query.addFullTextSearch(scenesFTSTable, "scenes.id", *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &sceneFilterHandler{
@@ -967,7 +956,11 @@ func (qb *SceneStore) makeQuery(ctx context.Context, sceneFilter *models.SceneFi
 		return nil, err
 	}
 
-	if err := qb.setSceneSort(&query, findFilter); err != nil {
+	if err := query.addFilter(filterBuilderFromHandler(ctx, sceneRestriction)); err != nil {
+		return nil, err
+	}
+
+	if err := qb.setSceneSort(ctx, &query, findFilter); err != nil {
 		return nil, err
 	}
 	query.sortAndPagination += getPagination(findFilter)
@@ -1091,12 +1084,13 @@ var sceneSortOptions = sortOptions{
 	"perceptual_similarity",
 	"random",
 	"rating",
+	"relevance",
 	"tag_count",
 	"title",
 	"updated_at",
 }
 
-func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindFilterType) error {
+func (qb *SceneStore) setSceneSort(ctx context.Context, query *queryBuilder, findFilter *models.FindFilterType) error {
 	if findFilter == nil || findFilter.Sort == nil || *findFilter.Sort == "" {
 		return nil
 	}
@@ -1193,13 +1187,23 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 		addFolderTable()
 		query.sortAndPagination += " ORDER BY COALESCE(scenes.title, files.basename) COLLATE NATURAL_CI " + direction + ", folders.path COLLATE NATURAL_CI " + direction
 	case "play_count":
-		query.sortAndPagination += getCountSort(sceneTable, scenesViewDatesTable, sceneIDColumn, direction)
+		query.sortAndPagination += getCountSort(sceneTable, sceneHistoryTable(ctx, scenesViewDatesTable, scenesUsersViewDatesTable), sceneIDColumn, direction)
 	case "last_played_at":
-		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(view_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", scenesViewDatesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(view_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", sceneHistoryTable(ctx, scenesViewDatesTable, scenesUsersViewDatesTable), sceneIDColumn, sceneTable, getSortDirection(direction))
 	case "last_o_at":
-		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", scenesODatesTable, sceneIDColumn, sceneTable, getSortDirection(direction))
+		query.sortAndPagination += fmt.Sprintf(" ORDER BY (SELECT MAX(o_date) FROM %s AS sort WHERE sort.%s = %s.id) %s", sceneHistoryTable(ctx, scenesODatesTable, scenesUsersODatesTable), sceneIDColumn, sceneTable, getSortDirection(direction))
 	case "o_counter":
-		query.sortAndPagination += getCountSort(sceneTable, scenesODatesTable, sceneIDColumn, direction)
+		query.sortAndPagination += getCountSort(sceneTable, sceneHistoryTable(ctx, scenesODatesTable, scenesUsersODatesTable), sceneIDColumn, direction)
+	case "rating", "resume_time", "play_duration":
+		// ratings and playback state are held per local user account
+		if j, ok := sceneUserDataJoin(ctx); ok {
+			query.addJoins(j)
+			query.sortAndPagination += getSort(sort, direction, sceneUserAlias)
+		} else {
+			query.sortAndPagination += getSort(sort, direction, sceneTable)
+		}
+	case relevanceSort:
+		query.sortAndPagination += scenesFTSTable.getRelevanceSort("scenes.id", findFilter)
 	default:
 		query.sortAndPagination += getSort(sort, direction, "scenes")
 	}
@@ -1238,6 +1242,10 @@ func (qb *SceneStore) GetURLs(ctx context.Context, sceneID int) ([]string, error
 	return scenesURLsTableMgr.get(ctx, sceneID)
 }
 
+func (qb *SceneStore) GetCustomFields(ctx context.Context, sceneID int) ([]models.CustomField, error) {
+	return scenesCustomFieldsTableMgr.get(ctx, sceneID)
+}
+
 func (qb *SceneStore) GetCover(ctx context.Context, sceneID int) ([]byte, error) {
 	return qb.GetImage(ctx, sceneID, sceneCoverBlobColumn)
 }
@@ -1357,7 +1365,8 @@ func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int, duration
 
 	var duplicates [][]*models.Scene
 	for _, sceneIds := range dupeIds {
-		if scenes, err := qb.FindMany(ctx, sceneIds); err == nil {
+		// hidden scenes are omitted, which may leave no duplicates
+		if scenes, err := qb.FindMany(ctx, sceneIds); err == nil && len(scenes) > 1 {
 			duplicates = append(duplicates, scenes)
 		}
 	}
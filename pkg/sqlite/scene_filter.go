@@ -92,7 +92,10 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 
 		qb.phashDistanceCriterionHandler(sceneFilter.PhashDistance),
 
-		intCriterionHandler(sceneFilter.Rating100, "scenes.rating", nil),
+		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
+			column, addJoinFn := sceneUserDataColumn(ctx, "rating")
+			intCriterionHandler(sceneFilter.Rating100, column, addJoinFn)(ctx, f)
+		}),
 		qb.oCountCriterionHandler(sceneFilter.OCounter),
 		boolCriterionHandler(sceneFilter.Organized, "scenes.organized", nil),
 
@@ -127,13 +130,19 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 
 		qb.captionCriterionHandler(sceneFilter.Captions),
 
-		floatIntCriterionHandler(sceneFilter.ResumeTime, "scenes.resume_time", nil),
-		floatIntCriterionHandler(sceneFilter.PlayDuration, "scenes.play_duration", nil),
+		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
+			column, addJoinFn := sceneUserDataColumn(ctx, "resume_time")
+			floatIntCriterionHandler(sceneFilter.ResumeTime, "IFNULL("+column+", 0)", addJoinFn)(ctx, f)
+		}),
+		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
+			column, addJoinFn := sceneUserDataColumn(ctx, "play_duration")
+			floatIntCriterionHandler(sceneFilter.PlayDuration, "IFNULL("+column+", 0)", addJoinFn)(ctx, f)
+		}),
 		qb.playCountCriterionHandler(sceneFilter.PlayCount),
 		criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 			if sceneFilter.LastPlayedAt != nil {
 				f.addLeftJoin(
-					fmt.Sprintf("(SELECT %s, MAX(%s) as last_played_at FROM %s GROUP BY %s)", sceneIDColumn, sceneViewDateColumn, scenesViewDatesTable, sceneIDColumn),
+					fmt.Sprintf("(SELECT %s, MAX(%s) as last_played_at FROM %s GROUP BY %s)", sceneIDColumn, sceneViewDateColumn, sceneHistoryTable(ctx, scenesViewDatesTable, scenesUsersViewDatesTable), sceneIDColumn),
 					"scene_last_view",
 					fmt.Sprintf("scene_last_view.%s = scenes.id", sceneIDColumn),
 				)
@@ -160,6 +169,12 @@ func (qb *sceneFilterHandler) criterionHandler() criterionHandler {
 		&timestampCriterionHandler{sceneFilter.CreatedAt, "scenes.created_at", nil},
 		&timestampCriterionHandler{sceneFilter.UpdatedAt, "scenes.updated_at", nil},
 
+		&customFieldsCriterionHandler{
+			criteria:    sceneFilter.CustomFields,
+			table:       scenesCustomFieldsTableMgr,
+			parentIDCol: "scenes.id",
+		},
+
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes_galleries.gallery_id",
 			relatedRepo:    galleryRepository.repository,
@@ -233,23 +248,27 @@ func (qb *sceneFilterHandler) addVideoFilesTable(f *filterBuilder) {
 }
 
 func (qb *sceneFilterHandler) playCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
-	h := countCriterionHandlerBuilder{
-		primaryTable: sceneTable,
-		joinTable:    scenesViewDatesTable,
-		primaryFK:    sceneIDColumn,
-	}
+	return func(ctx context.Context, f *filterBuilder) {
+		h := countCriterionHandlerBuilder{
+			primaryTable: sceneTable,
+			joinTable:    sceneHistoryTable(ctx, scenesViewDatesTable, scenesUsersViewDatesTable),
+			primaryFK:    sceneIDColumn,
+		}
 
-	return h.handler(count)
+		h.handler(count)(ctx, f)
+	}
 }
 
 func (qb *sceneFilterHandler) oCountCriterionHandler(count *models.IntCriterionInput) criterionHandlerFunc {
-	h := countCriterionHandlerBuilder{
-		primaryTable: sceneTable,
-		joinTable:    scenesODatesTable,
-		primaryFK:    sceneIDColumn,
-	}
+	return func(ctx context.Context, f *filterBuilder) {
+		h := countCriterionHandlerBuilder{
+			primaryTable: sceneTable,
+			joinTable:    sceneHistoryTable(ctx, scenesODatesTable, scenesUsersODatesTable),
+			primaryFK:    sceneIDColumn,
+		}
 
-	return h.handler(count)
+		h.handler(count)(ctx, f)
+	}
 }
 
 func (qb *sceneFilterHandler) fileCountCriterionHandler(fileCount *models.IntCriterionInput) criterionHandlerFunc {
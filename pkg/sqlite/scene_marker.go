@@ -192,13 +192,7 @@ func (qb *SceneMarkerStore) FindMany(ctx context.Context, ids []int) ([]*models.
 		ret[i] = s
 	}
 
-	for i := range ret {
-		if ret[i] == nil {
-			return nil, fmt.Errorf("scene marker with id %d not found", ids[i])
-		}
-	}
-
-	return ret, nil
+	return checkAllFound(ctx, ret, ids, "scene marker")
 }
 
 // returns nil, sql.ErrNoRows if not found
@@ -228,6 +222,8 @@ func (qb *SceneMarkerStore) get(ctx context.Context, q *goqu.SelectDataset) (*mo
 }
 
 func (qb *SceneMarkerStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.SceneMarker, error) {
+	q = sceneMarkerRestriction.restrict(ctx, q)
+
 	const single = false
 	var ret []*models.SceneMarker
 	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
@@ -315,6 +311,10 @@ func (qb *SceneMarkerStore) makeQuery(ctx context.Context, sceneMarkerFilter *mo
 		return nil, err
 	}
 
+	if err := query.addFilter(filterBuilderFromHandler(ctx, sceneMarkerRestriction)); err != nil {
+		return nil, err
+	}
+
 	if err := qb.setSceneMarkerSort(&query, findFilter); err != nil {
 		return nil, err
 	}
@@ -4441,6 +4441,38 @@ func TestSceneQueryQTrim(t *testing.T) {
 	}
 }
 
+func TestSceneQueryQPrefix(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		qb := db.Scene
+
+		expectedID := sceneIDs[sceneIdxWithSpacedName]
+
+		for _, query := range []string{"yy", "zzz xx", "yyy | qqq"} {
+			sort := "relevance"
+			direction := models.SortDirectionEnumDesc
+			f := models.FindFilterType{
+				Q:         &query,
+				Sort:      &sort,
+				Direction: &direction,
+			}
+			scenes := queryScene(ctx, t, qb, nil, &f)
+
+			if assert.Len(t, scenes, 1, query) {
+				assert.Equal(t, expectedID, scenes[0].ID, query)
+			}
+		}
+
+		query := "zzz -xx"
+		f := models.FindFilterType{
+			Q: &query,
+		}
+		scenes := queryScene(ctx, t, qb, nil, &f)
+		assert.Len(t, scenes, 0)
+
+		return nil
+	})
+}
+
 func TestSceneStore_All(t *testing.T) {
 	qb := db.Scene
 
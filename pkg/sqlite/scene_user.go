@@ -0,0 +1,256 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	scenesUsersTable          = "scenes_users"
+	scenesUsersViewDatesTable = "scenes_users_view_dates"
+	scenesUsersODatesTable    = "scenes_users_o_dates"
+)
+
+// sceneUserAlias is the alias of the scenes_users table when joined to the
+// scenes table for filtering and sorting.
+const sceneUserAlias = "scene_user"
+
+// sceneUserDataColumn returns the column of the scene data of the local user
+// in the context, and a function joining the table of the column. The column
+// of the scenes table, and a nil function, is returned if the current user is
+// the configured user.
+func sceneUserDataColumn(ctx context.Context, column string) (string, func(f *filterBuilder)) {
+	j, ok := sceneUserDataJoin(ctx)
+	if !ok {
+		return getColumn(sceneTable, column), nil
+	}
+
+	return getColumn(sceneUserAlias, column), func(f *filterBuilder) {
+		f.addLeftJoin(j.table, j.as, j.onClause)
+	}
+}
+
+func sceneUserDataJoin(ctx context.Context) (join, bool) {
+	userID, ok := models.GetUserID(ctx)
+	if !ok {
+		return join{}, false
+	}
+
+	return join{
+		table:    scenesUsersTable,
+		as:       sceneUserAlias,
+		onClause: fmt.Sprintf("%s.%s = %s.id AND %s.%s = %d", sceneUserAlias, sceneIDColumn, sceneTable, sceneUserAlias, userIDColumn, userID),
+		joinType: "LEFT",
+	}, true
+}
+
+// sceneHistoryTable returns the table of the play or o history of scenes for
+// the local user in the context, or the provided table of the history stored
+// against the scenes if the current user is the configured user. userTable
+// must have the same columns as table, with the addition of user_id.
+func sceneHistoryTable(ctx context.Context, table string, userTable string) string {
+	userID, ok := models.GetUserID(ctx)
+	if !ok {
+		return table
+	}
+
+	return fmt.Sprintf("(SELECT * FROM %s WHERE %s = %d)", userTable, userIDColumn, userID)
+}
+
+type sceneUserRow struct {
+	SceneID      int      `db:"scene_id"`
+	UserID       int      `db:"user_id"`
+	Rating       null.Int `db:"rating"`
+	ResumeTime   float64  `db:"resume_time"`
+	PlayDuration float64  `db:"play_duration"`
+}
+
+func (r *sceneUserRow) resolve() *models.SceneUserData {
+	return &models.SceneUserData{
+		SceneID:      r.SceneID,
+		UserID:       r.UserID,
+		Rating:       nullIntPtr(r.Rating),
+		ResumeTime:   r.ResumeTime,
+		PlayDuration: r.PlayDuration,
+	}
+}
+
+// SceneUserDataStore stores the ratings, resume points and play and o
+// history of scenes for local user accounts.
+type SceneUserDataStore struct {
+	tableMgr     *table
+	viewTableMgr *viewHistoryTable
+	oTableMgr    *viewHistoryTable
+}
+
+func NewSceneUserDataStore() *SceneUserDataStore {
+	return &SceneUserDataStore{
+		tableMgr:     scenesUsersTableMgr,
+		viewTableMgr: scenesUsersViewTableMgr,
+		oTableMgr:    scenesUsersOTableMgr,
+	}
+}
+
+// ForUser returns a store restricted to the scene data of the provided user.
+func (qb *SceneUserDataStore) ForUser(userID int) models.SceneUserDataReaderWriter {
+	return &sceneUserDataStore{
+		userID:          userID,
+		tableMgr:        qb.tableMgr,
+		viewDateManager: viewDateManager{qb.viewTableMgr.forUser(userID)},
+		oDateManager:    oDateManager{qb.oTableMgr.forUser(userID)},
+	}
+}
+
+type sceneUserDataStore struct {
+	userID   int
+	tableMgr *table
+
+	viewDateManager
+	oDateManager
+}
+
+func (qb *sceneUserDataStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *sceneUserDataStore) byScene(sceneID int) exp.Expression {
+	return goqu.And(
+		qb.table().Col(sceneIDColumn).Eq(sceneID),
+		qb.table().Col(userIDColumn).Eq(qb.userID),
+	)
+}
+
+// ensureRow inserts an empty row for the scene if one does not already exist.
+func (qb *sceneUserDataStore) ensureRow(ctx context.Context, sceneID int) error {
+	if err := sceneTableMgr.checkIDExists(ctx, sceneID); err != nil {
+		return err
+	}
+
+	q := dialect.Insert(qb.table()).Rows(goqu.Record{
+		sceneIDColumn: sceneID,
+		userIDColumn:  qb.userID,
+	}).OnConflict(goqu.DoNothing())
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("inserting into %s: %w", scenesUsersTable, err)
+	}
+
+	return nil
+}
+
+func (qb *sceneUserDataStore) update(ctx context.Context, sceneID int, record goqu.Record) error {
+	if err := qb.ensureRow(ctx, sceneID); err != nil {
+		return err
+	}
+
+	q := dialect.Update(qb.table()).Prepared(true).Set(record).Where(qb.byScene(sceneID))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("updating %s: %w", scenesUsersTable, err)
+	}
+
+	return nil
+}
+
+func (qb *sceneUserDataStore) SaveActivity(ctx context.Context, sceneID int, resumeTime *float64, playDuration *float64) (bool, error) {
+	record := goqu.Record{}
+
+	if resumeTime != nil {
+		record["resume_time"] = resumeTime
+	}
+
+	if playDuration != nil {
+		record["play_duration"] = goqu.L("play_duration + ?", playDuration)
+	}
+
+	if len(record) == 0 {
+		return true, sceneTableMgr.checkIDExists(ctx, sceneID)
+	}
+
+	if err := qb.update(ctx, sceneID, record); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (qb *sceneUserDataStore) UpdateRating(ctx context.Context, sceneID int, rating *int) error {
+	return qb.update(ctx, sceneID, goqu.Record{
+		"rating": intFromPtr(rating),
+	})
+}
+
+func (qb *sceneUserDataStore) PlayDuration(ctx context.Context) (float64, error) {
+	table := qb.table()
+	q := dialect.Select(goqu.COALESCE(goqu.SUM("play_duration"), 0)).From(table).Where(
+		table.Col(userIDColumn).Eq(qb.userID),
+	)
+
+	var ret float64
+	if err := querySimple(ctx, q, &ret); err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
+// returns nil, nil if the user has no data for the scene
+func (qb *sceneUserDataStore) GetUserData(ctx context.Context, sceneID int) (*models.SceneUserData, error) {
+	q := dialect.From(qb.table()).Select(qb.table().All()).Where(qb.byScene(sceneID))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *sceneUserDataStore) GetManyUserData(ctx context.Context, sceneIDs []int) ([]*models.SceneUserData, error) {
+	table := qb.table()
+	q := dialect.From(table).Select(table.All()).Where(
+		table.Col(sceneIDColumn).In(sceneIDs),
+		table.Col(userIDColumn).Eq(qb.userID),
+	)
+
+	unsorted, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]*models.SceneUserData, len(sceneIDs))
+	idToIndex := idToIndexMap(sceneIDs)
+	for _, d := range unsorted {
+		ret[idToIndex[d.SceneID]] = d
+	}
+
+	return ret, nil
+}
+
+func (qb *sceneUserDataStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.SceneUserData, error) {
+	const single = false
+	var ret []*models.SceneUserData
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f sceneUserRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
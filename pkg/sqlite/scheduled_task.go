@@ -0,0 +1,320 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+const (
+	scheduledTaskTable    = "scheduled_tasks"
+	scheduledTaskRunTable = "scheduled_task_runs"
+	scheduledTaskIDColumn = "scheduled_task_id"
+)
+
+type scheduledTaskRow struct {
+	ID        int                      `db:"id" goqu:"skipinsert"`
+	Name      string                   `db:"name"`
+	Type      models.ScheduledTaskType `db:"type"`
+	Schedule  string                   `db:"schedule"`
+	Input     string                   `db:"input"`
+	Enabled   bool                     `db:"enabled"`
+	NextRunAt NullTimestamp            `db:"next_run_at"`
+	CreatedAt Timestamp                `db:"created_at"`
+	UpdatedAt Timestamp                `db:"updated_at"`
+}
+
+func (r *scheduledTaskRow) fromScheduledTask(o models.ScheduledTask) {
+	r.ID = o.ID
+	r.Name = o.Name
+	r.Type = o.Type
+	r.Schedule = o.Schedule
+	r.Input = encodeJSONOrEmpty(o.Input)
+	r.Enabled = o.Enabled
+	r.NextRunAt = NullTimestampFromTimePtr(o.NextRunAt)
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *scheduledTaskRow) resolve() *models.ScheduledTask {
+	ret := &models.ScheduledTask{
+		ID:        r.ID,
+		Name:      r.Name,
+		Type:      r.Type,
+		Schedule:  r.Schedule,
+		Enabled:   r.Enabled,
+		NextRunAt: r.NextRunAt.TimePtr(),
+		CreatedAt: r.CreatedAt.Timestamp,
+		UpdatedAt: r.UpdatedAt.Timestamp,
+	}
+
+	if r.Input != "" {
+		ret.Input = make(map[string]interface{})
+		decodeJSON(r.Input, &ret.Input)
+	}
+
+	return ret
+}
+
+type scheduledTaskRunRow struct {
+	ID              int           `db:"id" goqu:"skipinsert"`
+	ScheduledTaskID int           `db:"scheduled_task_id"`
+	Status          string        `db:"status"`
+	AddTime         Timestamp     `db:"add_time"`
+	StartTime       NullTimestamp `db:"start_time"`
+	EndTime         NullTimestamp `db:"end_time"`
+	Error           null.String   `db:"error"`
+}
+
+func (r *scheduledTaskRunRow) fromScheduledTaskRun(o models.ScheduledTaskRun) {
+	r.ID = o.ID
+	r.ScheduledTaskID = o.ScheduledTaskID
+	r.Status = o.Status
+	r.AddTime = Timestamp{Timestamp: o.AddTime}
+	r.StartTime = NullTimestampFromTimePtr(o.StartTime)
+	r.EndTime = NullTimestampFromTimePtr(o.EndTime)
+	r.Error = null.StringFromPtr(o.Error)
+}
+
+func (r *scheduledTaskRunRow) resolve() *models.ScheduledTaskRun {
+	return &models.ScheduledTaskRun{
+		ID:              r.ID,
+		ScheduledTaskID: r.ScheduledTaskID,
+		Status:          r.Status,
+		AddTime:         r.AddTime.Timestamp,
+		StartTime:       r.StartTime.TimePtr(),
+		EndTime:         r.EndTime.TimePtr(),
+		Error:           r.Error.Ptr(),
+	}
+}
+
+type ScheduledTaskStore struct {
+	repository
+	tableMgr    *table
+	runTableMgr *table
+}
+
+func NewScheduledTaskStore() *ScheduledTaskStore {
+	return &ScheduledTaskStore{
+		repository: repository{
+			tableName: scheduledTaskTable,
+			idColumn:  idColumn,
+		},
+		tableMgr:    scheduledTaskTableMgr,
+		runTableMgr: scheduledTaskRunTableMgr,
+	}
+}
+
+func (qb *ScheduledTaskStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *ScheduledTaskStore) runTable() exp.IdentifierExpression {
+	return qb.runTableMgr.table
+}
+
+func (qb *ScheduledTaskStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *ScheduledTaskStore) selectRunDataset() *goqu.SelectDataset {
+	return dialect.From(qb.runTable()).Select(qb.runTable().All())
+}
+
+func (qb *ScheduledTaskStore) Create(ctx context.Context, newObject *models.ScheduledTask) error {
+	var r scheduledTaskRow
+	r.fromScheduledTask(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *ScheduledTaskStore) Update(ctx context.Context, updatedObject *models.ScheduledTask) error {
+	var r scheduledTaskRow
+	r.fromScheduledTask(*updatedObject)
+
+	if err := qb.tableMgr.updateByID(ctx, updatedObject.ID, r); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (qb *ScheduledTaskStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *ScheduledTaskStore) Find(ctx context.Context, id int) (*models.ScheduledTask, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *ScheduledTaskStore) FindMany(ctx context.Context, ids []int, ignoreNotFound bool) ([]*models.ScheduledTask, error) {
+	ret := make([]*models.ScheduledTask, len(ids))
+
+	table := qb.table()
+	q := qb.selectDataset().Prepared(true).Where(table.Col(idColumn).In(ids))
+	unsorted, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range unsorted {
+		i := sliceutil.Index(ids, s.ID)
+		ret[i] = s
+	}
+
+	if !ignoreNotFound {
+		for i := range ret {
+			if ret[i] == nil {
+				return nil, fmt.Errorf("scheduled task with id %d not found", ids[i])
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *ScheduledTaskStore) find(ctx context.Context, id int) (*models.ScheduledTask, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *ScheduledTaskStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.ScheduledTask, error) {
+	const single = false
+	var ret []*models.ScheduledTask
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f scheduledTaskRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *ScheduledTaskStore) All(ctx context.Context) ([]*models.ScheduledTask, error) {
+	return qb.getMany(ctx, qb.selectDataset().Order(qb.table().Col("name").Asc()))
+}
+
+func (qb *ScheduledTaskStore) CreateRun(ctx context.Context, newObject *models.ScheduledTaskRun) error {
+	var r scheduledTaskRunRow
+	r.fromScheduledTaskRun(*newObject)
+
+	id, err := qb.runTableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	newObject.ID = id
+
+	return nil
+}
+
+func (qb *ScheduledTaskStore) UpdateRun(ctx context.Context, updatedObject *models.ScheduledTaskRun) error {
+	var r scheduledTaskRunRow
+	r.fromScheduledTaskRun(*updatedObject)
+
+	return qb.runTableMgr.updateByID(ctx, updatedObject.ID, r)
+}
+
+func (qb *ScheduledTaskStore) FindRuns(ctx context.Context, taskID int, limit int) ([]*models.ScheduledTaskRun, error) {
+	table := qb.runTable()
+	q := qb.selectRunDataset().Where(table.Col(scheduledTaskIDColumn).Eq(taskID)).Order(
+		table.Col("add_time").Desc(),
+		table.Col(idColumn).Desc(),
+	)
+
+	if limit > 0 {
+		q = q.Limit(uint(limit))
+	}
+
+	return qb.getManyRuns(ctx, q)
+}
+
+func (qb *ScheduledTaskStore) FindUnfinishedRuns(ctx context.Context) ([]*models.ScheduledTaskRun, error) {
+	table := qb.runTable()
+	q := qb.selectRunDataset().Where(table.Col("end_time").IsNull()).Order(table.Col(idColumn).Asc())
+
+	return qb.getManyRuns(ctx, q)
+}
+
+func (qb *ScheduledTaskStore) PruneRuns(ctx context.Context, taskID int, keep int) error {
+	table := qb.runTable()
+
+	keepQuery := dialect.From(table).Select(table.Col(idColumn)).Where(
+		table.Col(scheduledTaskIDColumn).Eq(taskID),
+	).Order(
+		table.Col("add_time").Desc(),
+		table.Col(idColumn).Desc(),
+	).Limit(uint(keep))
+
+	q := dialect.Delete(table).Where(
+		table.Col(scheduledTaskIDColumn).Eq(taskID),
+		table.Col(idColumn).NotIn(keepQuery),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("pruning scheduled task runs: %w", err)
+	}
+
+	return nil
+}
+
+func (qb *ScheduledTaskStore) getManyRuns(ctx context.Context, q *goqu.SelectDataset) ([]*models.ScheduledTaskRun, error) {
+	const single = false
+	var ret []*models.ScheduledTaskRun
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f scheduledTaskRunRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,226 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func createScheduledTask(ctx context.Context, t *testing.T, name string) *models.ScheduledTask {
+	t.Helper()
+
+	nextRunAt := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	newTask := models.NewScheduledTask()
+	newTask.Name = name
+	newTask.Type = models.ScheduledTaskTypeScan
+	newTask.Schedule = "0 3 * * *"
+	newTask.Input = map[string]interface{}{
+		"rescan": true,
+	}
+	newTask.NextRunAt = &nextRunAt
+
+	if err := db.ScheduledTask.Create(ctx, &newTask); err != nil {
+		t.Fatalf("ScheduledTaskStore.Create() error = %v", err)
+	}
+
+	return &newTask
+}
+
+func createScheduledTaskRun(ctx context.Context, t *testing.T, taskID int, addTime time.Time) *models.ScheduledTaskRun {
+	t.Helper()
+
+	run := &models.ScheduledTaskRun{
+		ScheduledTaskID: taskID,
+		Status:          "READY",
+		AddTime:         addTime,
+	}
+
+	if err := db.ScheduledTask.CreateRun(ctx, run); err != nil {
+		t.Fatalf("ScheduledTaskStore.CreateRun() error = %v", err)
+	}
+
+	return run
+}
+
+func TestScheduledTaskStore_CreateUpdate(t *testing.T) {
+	runWithRollbackTxn(t, "create and update", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+		qb := db.ScheduledTask
+
+		created := createScheduledTask(ctx, t, "b task")
+		other := createScheduledTask(ctx, t, "a task")
+
+		got, err := qb.Find(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("ScheduledTaskStore.Find() error = %v", err)
+		}
+
+		if assert.NotNil(got) {
+			assert.Equal("b task", got.Name)
+			assert.Equal(models.ScheduledTaskTypeScan, got.Type)
+			assert.Equal("0 3 * * *", got.Schedule)
+			assert.Equal(map[string]interface{}{"rescan": true}, got.Input)
+			assert.True(got.Enabled)
+			if assert.NotNil(got.NextRunAt) {
+				assert.True(created.NextRunAt.Equal(*got.NextRunAt))
+			}
+		}
+
+		created.Enabled = false
+		created.NextRunAt = nil
+		created.Input = nil
+		if err := qb.Update(ctx, created); err != nil {
+			t.Fatalf("ScheduledTaskStore.Update() error = %v", err)
+		}
+
+		got, err = qb.Find(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("ScheduledTaskStore.Find() error = %v", err)
+		}
+
+		if assert.NotNil(got) {
+			assert.False(got.Enabled)
+			assert.Nil(got.NextRunAt)
+			assert.Nil(got.Input)
+		}
+
+		// ordered by name
+		all, err := qb.All(ctx)
+		if err != nil {
+			t.Fatalf("ScheduledTaskStore.All() error = %v", err)
+		}
+
+		if assert.Len(all, 2) {
+			assert.Equal(other.ID, all[0].ID)
+			assert.Equal(created.ID, all[1].ID)
+		}
+	})
+}
+
+func TestScheduledTaskStore_FindMany(t *testing.T) {
+	runWithRollbackTxn(t, "find many", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+		qb := db.ScheduledTask
+
+		task := createScheduledTask(ctx, t, "task")
+		missingID := task.ID + 1
+
+		got, err := qb.FindMany(ctx, []int{missingID, task.ID}, true)
+		if err != nil {
+			t.Fatalf("ScheduledTaskStore.FindMany() error = %v", err)
+		}
+
+		if assert.Len(got, 2) {
+			assert.Nil(got[0])
+			if assert.NotNil(got[1]) {
+				assert.Equal(task.ID, got[1].ID)
+			}
+		}
+
+		_, err = qb.FindMany(ctx, []int{missingID, task.ID}, false)
+		assert.Error(err)
+
+		got1, err := qb.Find(ctx, missingID)
+		assert.NoError(err)
+		assert.Nil(got1)
+	})
+}
+
+func TestScheduledTaskStore_Runs(t *testing.T) {
+	runWithRollbackTxn(t, "runs", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+		qb := db.ScheduledTask
+
+		task := createScheduledTask(ctx, t, "task")
+		otherTask := createScheduledTask(ctx, t, "other task")
+
+		start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		var runs []*models.ScheduledTaskRun
+		for i := 0; i < 4; i++ {
+			runs = append(runs, createScheduledTaskRun(ctx, t, task.ID, start.Add(time.Duration(i)*time.Hour)))
+		}
+		otherRun := createScheduledTaskRun(ctx, t, otherTask.ID, start)
+
+		// finish all but the last run of the task
+		for _, run := range runs[:3] {
+			startTime := run.AddTime.Add(time.Minute)
+			endTime := startTime.Add(time.Minute)
+			errStr := "failed"
+
+			run.Status = "FAILED"
+			run.StartTime = &startTime
+			run.EndTime = &endTime
+			run.Error = &errStr
+
+			if err := qb.UpdateRun(ctx, run); err != nil {
+				t.Fatalf("ScheduledTaskStore.UpdateRun() error = %v", err)
+			}
+		}
+
+		// newest first
+		got, err := qb.FindRuns(ctx, task.ID, 2)
+		if err != nil {
+			t.Fatalf("ScheduledTaskStore.FindRuns() error = %v", err)
+		}
+
+		if assert.Len(got, 2) {
+			assert.Equal(runs[3].ID, got[0].ID)
+			assert.Equal(runs[2].ID, got[1].ID)
+			assert.Equal("FAILED", got[1].Status)
+			if assert.NotNil(got[1].Error) {
+				assert.Equal("failed", *got[1].Error)
+			}
+			assert.True(got[1].Finished())
+		}
+
+		unfinished, err := qb.FindUnfinishedRuns(ctx)
+		if err != nil {
+			t.Fatalf("ScheduledTaskStore.FindUnfinishedRuns() error = %v", err)
+		}
+
+		unfinishedIDs := make([]int, len(unfinished))
+		for i, run := range unfinished {
+			unfinishedIDs[i] = run.ID
+		}
+		assert.Equal([]int{runs[3].ID, otherRun.ID}, unfinishedIDs)
+
+		// only the runs of the task are pruned
+		if err := qb.PruneRuns(ctx, task.ID, 2); err != nil {
+			t.Fatalf("ScheduledTaskStore.PruneRuns() error = %v", err)
+		}
+
+		got, err = qb.FindRuns(ctx, task.ID, 0)
+		if err != nil {
+			t.Fatalf("ScheduledTaskStore.FindRuns() error = %v", err)
+		}
+
+		if assert.Len(got, 2) {
+			assert.Equal(runs[3].ID, got[0].ID)
+			assert.Equal(runs[2].ID, got[1].ID)
+		}
+
+		got, err = qb.FindRuns(ctx, otherTask.ID, 0)
+		if err != nil {
+			t.Fatalf("ScheduledTaskStore.FindRuns() error = %v", err)
+		}
+		assert.Len(got, 1)
+
+		// runs are destroyed with the task
+		if err := qb.Destroy(ctx, task.ID); err != nil {
+			t.Fatalf("ScheduledTaskStore.Destroy() error = %v", err)
+		}
+
+		got, err = qb.FindRuns(ctx, task.ID, 0)
+		if err != nil {
+			t.Fatalf("ScheduledTaskStore.FindRuns() error = %v", err)
+		}
+		assert.Len(got, 0)
+	})
+}
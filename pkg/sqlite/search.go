@@ -0,0 +1,119 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+type searchSource struct {
+	fts ftsTable
+
+	// restriction is nil for objects that are not hidden by content
+	// restrictions
+	restriction *restrictionTable
+}
+
+var searchSources = map[models.SearchResultType]searchSource{
+	models.SearchResultTypeScene:       {fts: scenesFTSTable, restriction: &sceneRestriction},
+	models.SearchResultTypeImage:       {fts: imagesFTSTable, restriction: &imageRestriction},
+	models.SearchResultTypeGallery:     {fts: galleriesFTSTable, restriction: &galleryRestriction},
+	models.SearchResultTypePerformer:   {fts: performersFTSTable, restriction: &performerRestriction},
+	models.SearchResultTypeStudio:      {fts: studiosFTSTable, restriction: &studioRestriction},
+	models.SearchResultTypeGroup:       {fts: groupsFTSTable, restriction: &groupRestriction},
+	models.SearchResultTypeTag:         {fts: tagsFTSTable, restriction: &tagRestriction},
+	models.SearchResultTypeSceneMarker: {fts: sceneMarkersFTSTable, restriction: &sceneMarkerRestriction},
+}
+
+type searchResultRow struct {
+	Type  string  `db:"type"`
+	ID    int     `db:"id"`
+	Score float64 `db:"score"`
+}
+
+// SearchStore searches the full-text indexes of all object types.
+type SearchStore struct{}
+
+func NewSearchStore() *SearchStore {
+	return &SearchStore{}
+}
+
+// query returns the query returning the objects of the source matching the
+// search specs. Returns an empty string if the specs cannot be ranked.
+func (s searchSource) query(ctx context.Context, t models.SearchResultType, specs models.SearchSpecs) (string, []interface{}) {
+	// rank is only available for queries with a match expression
+	if ftsMatchExpression(specs) == "" {
+		return "", nil
+	}
+
+	clauses, args := s.fts.searchClauses(specs)
+
+	if r := models.GetContentRestriction(ctx); r != nil && s.restriction != nil {
+		if clause := s.restriction.clause(r); clause != "" {
+			clauses = append(clauses, fmt.Sprintf("rowid IN (SELECT %[1]s.id FROM %[1]s WHERE %s)", s.restriction.table, clause))
+		}
+	}
+
+	// bm25 rank is lower for better matches
+	query := fmt.Sprintf("SELECT '%s' AS type, rowid AS id, -rank AS score FROM %s WHERE %s", t, s.fts.name, strings.Join(clauses, " AND "))
+	return query, args
+}
+
+func (qb *SearchStore) Search(ctx context.Context, q string, types []models.SearchResultType, limit int) ([]*models.SearchResult, error) {
+	if len(types) == 0 {
+		types = models.AllSearchResultType
+	}
+	types = sliceutil.AppendUniques(nil, types)
+
+	specs := models.ParseSearchString(q)
+
+	var queries []string
+	var args []interface{}
+	for _, t := range types {
+		source, ok := searchSources[t]
+		if !ok {
+			return nil, fmt.Errorf("invalid search result type: %s", t)
+		}
+
+		query, queryArgs := source.query(ctx, t, specs)
+		if query == "" {
+			continue
+		}
+
+		queries = append(queries, query)
+		args = append(args, queryArgs...)
+	}
+
+	if len(queries) == 0 {
+		return nil, nil
+	}
+
+	switch {
+	case limit <= 0:
+		limit = models.DefaultSearchLimit
+	case limit > models.MaxSearchLimit:
+		limit = models.MaxSearchLimit
+	}
+
+	query := strings.Join(queries, " UNION ALL ") + " ORDER BY score DESC, type, id LIMIT ?"
+	args = append(args, limit)
+
+	var rows []searchResultRow
+	if err := dbWrapper.Select(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("searching: %w", err)
+	}
+
+	ret := make([]*models.SearchResult, len(rows))
+	for i, r := range rows {
+		ret[i] = &models.SearchResult{
+			Type:  models.SearchResultType(r.Type),
+			ID:    r.ID,
+			Score: r.Score,
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,146 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	// tag names have case-insensitive duplicates, aliases are unique
+	tagAlias := getTagStringValue(tagIdxWithScene, "Alias")
+
+	tests := []struct {
+		name  string
+		q     string
+		types []models.SearchResultType
+		limit int
+		want  []models.SearchResult
+	}{
+		{
+			"scene",
+			"zzz yy",
+			nil,
+			0,
+			[]models.SearchResult{
+				{Type: models.SearchResultTypeScene, ID: sceneIDs[sceneIdxWithSpacedName]},
+			},
+		},
+		{
+			"tag alias",
+			tagAlias,
+			[]models.SearchResultType{models.SearchResultTypeTag},
+			0,
+			[]models.SearchResult{
+				{Type: models.SearchResultTypeTag, ID: tagIDs[tagIdxWithScene]},
+			},
+		},
+		{
+			"excluded type",
+			"zzz",
+			[]models.SearchResultType{models.SearchResultTypeTag},
+			0,
+			nil,
+		},
+		{
+			"only excluded terms",
+			"-zzz",
+			nil,
+			0,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		runWithRollbackTxn(t, tt.name, func(t *testing.T, ctx context.Context) {
+			got, err := db.Search.Search(ctx, tt.q, tt.types, tt.limit)
+			if err != nil {
+				t.Errorf("SearchStore.Search() error = %v", err)
+				return
+			}
+
+			var results []models.SearchResult
+			for _, r := range got {
+				assert.Greater(t, r.Score, 0.0)
+				results = append(results, models.SearchResult{Type: r.Type, ID: r.ID})
+			}
+
+			assert.Equal(t, tt.want, results)
+		})
+	}
+}
+
+func TestSearchRestriction(t *testing.T) {
+	tagAlias := getTagStringValue(tagIdxWithScene, "Alias")
+	studioAlias := getStudioStringValue(studioIdxWithGroup, "Alias")
+
+	types := []models.SearchResultType{models.SearchResultTypeTag, models.SearchResultTypeStudio}
+
+	runWithRollbackTxn(t, "search restriction", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		for _, q := range []string{tagAlias, studioAlias} {
+			got, err := db.Search.Search(ctx, q, types, 0)
+			if err != nil {
+				t.Errorf("SearchStore.Search() error = %v", err)
+				return
+			}
+			assert.Len(got, 1)
+		}
+
+		ctx = models.WithContentRestriction(ctx, &models.ContentRestriction{
+			TagIDs:    []int{tagIDs[tagIdxWithScene]},
+			StudioIDs: []int{studioIDs[studioIdxWithGroup]},
+		})
+
+		for _, q := range []string{tagAlias, studioAlias} {
+			got, err := db.Search.Search(ctx, q, types, 0)
+			if err != nil {
+				t.Errorf("SearchStore.Search() error = %v", err)
+				return
+			}
+			assert.Empty(got)
+		}
+	})
+}
+
+func TestSearchLimit(t *testing.T) {
+	withTxn(func(ctx context.Context) error {
+		got, err := db.Search.Search(ctx, "scene", []models.SearchResultType{models.SearchResultTypeScene}, 2)
+		if err != nil {
+			t.Errorf("SearchStore.Search() error = %v", err)
+			return nil
+		}
+
+		assert.Len(t, got, 2)
+		if len(got) == 2 {
+			assert.GreaterOrEqual(t, got[0].Score, got[1].Score)
+		}
+
+		// invalid limits must not remove the limit
+		for _, limit := range []int{0, -1} {
+			got, err = db.Search.Search(ctx, "scene", nil, limit)
+			if err != nil {
+				t.Errorf("SearchStore.Search() error = %v", err)
+				return nil
+			}
+
+			assert.Len(t, got, models.DefaultSearchLimit)
+		}
+
+		got, err = db.Search.Search(ctx, "scene", nil, models.MaxSearchLimit+1)
+		if err != nil {
+			t.Errorf("SearchStore.Search() error = %v", err)
+			return nil
+		}
+
+		assert.LessOrEqual(t, len(got), models.MaxSearchLimit)
+
+		return nil
+	})
+}
@@ -200,6 +200,12 @@ func (qb *StudioStore) Create(ctx context.Context, newObject *models.Studio) err
 		}
 	}
 
+	if newObject.CustomFields.Loaded() {
+		if err := studiosCustomFieldsTableMgr.insertJoins(ctx, id, newObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -244,6 +250,12 @@ func (qb *StudioStore) UpdatePartial(ctx context.Context, input models.StudioPar
 		}
 	}
 
+	if input.CustomFields != nil {
+		if err := studiosCustomFieldsTableMgr.modifyJoins(ctx, input.ID, input.CustomFields.CustomFields, input.CustomFields.Mode); err != nil {
+			return nil, err
+		}
+	}
+
 	return qb.Find(ctx, input.ID)
 }
 
@@ -272,6 +284,12 @@ func (qb *StudioStore) Update(ctx context.Context, updatedObject *models.Studio)
 		}
 	}
 
+	if updatedObject.CustomFields.Loaded() {
+		if err := studiosCustomFieldsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -284,6 +302,65 @@ func (qb *StudioStore) Destroy(ctx context.Context, id int) error {
 	return studioRepository.destroyExisting(ctx, []int{id})
 }
 
+// Merge moves the scenes, images, galleries, groups, child studios and
+// restriction profile associations of the source studios to the destination
+// studio, then destroys the source studios. Other studio fields are not
+// merged. A source studio that is the parent of the destination is not
+// reparented; the destination loses its parent when the source is destroyed.
+func (qb *StudioStore) Merge(ctx context.Context, source []int, destination int) error {
+	if len(source) == 0 {
+		return nil
+	}
+
+	inBinding := getInBinding(len(source))
+
+	args := []interface{}{destination}
+	srcArgs := make([]interface{}, len(source))
+	for i, id := range source {
+		if id == destination {
+			return errors.New("cannot merge where source == destination")
+		}
+		srcArgs[i] = id
+	}
+
+	args = append(args, srcArgs...)
+
+	for _, table := range []string{sceneTable, imageTable, galleryTable, groupTable} {
+		if _, err := dbWrapper.Exec(ctx, "UPDATE "+table+" SET studio_id = ? WHERE studio_id IN "+inBinding, args...); err != nil {
+			return err
+		}
+	}
+
+	// reparent child studios, ignoring the destination itself
+	if _, err := dbWrapper.Exec(ctx, "UPDATE "+studioTable+" SET parent_id = ? WHERE parent_id IN "+inBinding+" AND id != ?", append(args, destination)...); err != nil {
+		return err
+	}
+
+	const table = restrictionProfilesStudiosTable
+	const idColumn = restrictionProfileIDColumn
+	if _, err := dbWrapper.Exec(ctx, `UPDATE OR IGNORE `+table+`
+SET studio_id = ?
+WHERE studio_id IN `+inBinding+`
+AND NOT EXISTS(SELECT 1 FROM `+table+` o WHERE o.`+idColumn+` = `+table+`.`+idColumn+` AND o.studio_id = ?)`,
+		append(args, destination)...,
+	); err != nil {
+		return err
+	}
+
+	// delete source studio ids from the table where they couldn't be set
+	if _, err := dbWrapper.Exec(ctx, `DELETE FROM `+table+` WHERE studio_id IN `+inBinding, srcArgs...); err != nil {
+		return err
+	}
+
+	for _, id := range source {
+		if err := qb.Destroy(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // returns nil, nil if not found
 func (qb *StudioStore) Find(ctx context.Context, id int) (*models.Studio, error) {
 	ret, err := qb.find(ctx, id)
@@ -525,9 +602,7 @@ func (qb *StudioStore) makeQuery(ctx context.Context, studioFilter *models.Studi
 	distinctIDs(&query, studioTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(studioAliasesTable, "", "studio_aliases.studio_id = studios.id")
-		searchColumns := []string{"studios.name", "studio_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		query.addFullTextSearch(studiosFTSTable, "studios.id", *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &studioFilterHandler{
@@ -586,6 +661,7 @@ var studioSortOptions = sortOptions{
 	"scenes_count",
 	"random",
 	"rating",
+	"relevance",
 	"updated_at",
 }
 
@@ -617,6 +693,8 @@ func (qb *StudioStore) getStudioSort(findFilter *models.FindFilterType) (string,
 		sortQuery += getCountSort(studioTable, galleryTable, studioIDColumn, direction)
 	case "child_count":
 		sortQuery += getCountSort(studioTable, studioTable, studioParentIDColumn, direction)
+	case relevanceSort:
+		sortQuery += studiosFTSTable.getRelevanceSort("studios.id", findFilter)
 	default:
 		sortQuery += getSort(sort, direction, "studios")
 	}
@@ -649,3 +727,7 @@ func (qb *StudioStore) GetStashIDs(ctx context.Context, studioID int) ([]models.
 func (qb *StudioStore) GetAliases(ctx context.Context, studioID int) ([]string, error) {
 	return studiosAliasesTableMgr.get(ctx, studioID)
 }
+
+func (qb *StudioStore) GetCustomFields(ctx context.Context, studioID int) ([]models.CustomField, error) {
+	return studiosCustomFieldsTableMgr.get(ctx, studioID)
+}
@@ -85,6 +85,12 @@ func (qb *studioFilterHandler) criterionHandler() criterionHandler {
 		&timestampCriterionHandler{studioFilter.CreatedAt, studioTable + ".created_at", nil},
 		&timestampCriterionHandler{studioFilter.UpdatedAt, studioTable + ".updated_at", nil},
 
+		&customFieldsCriterionHandler{
+			criteria:    studioFilter.CustomFields,
+			table:       studiosCustomFieldsTableMgr,
+			parentIDCol: studioTable + ".id",
+		},
+
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes.id",
 			relatedRepo:    sceneRepository.repository,
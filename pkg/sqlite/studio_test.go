@@ -1126,6 +1126,107 @@ func TestStudioQueryFast(t *testing.T) {
 // TODO Update
 // TODO Destroy
 // TODO Find
+func TestStudioMerge(t *testing.T) {
+	assert := assert.New(t)
+
+	// merge tests - perform these in a transaction that we'll rollback
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Studio
+
+		// try merging into same studio
+		err := qb.Merge(ctx, []int{studioIDs[studioIdxWithScene]}, studioIDs[studioIdxWithScene])
+		assert.NotNil(err)
+
+		srcIdxs := []int{
+			studioIdxWithScene,
+			studioIdxWithGroup,
+			studioIdxWithImage,
+			studioIdxWithGallery,
+			studioIdxWithChildStudio,
+		}
+		var srcIDs []int
+		for _, idx := range srcIdxs {
+			srcIDs = append(srcIDs, studioIDs[idx])
+		}
+
+		destID := studioIDs[studioIdxWithTwoScenes]
+		if err = qb.Merge(ctx, srcIDs, destID); err != nil {
+			return err
+		}
+
+		// ensure source studios are deleted
+		for _, id := range srcIDs {
+			s, err := qb.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			assert.Nil(s)
+		}
+
+		s, err := db.Scene.Find(ctx, sceneIDs[sceneIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, s.StudioID)
+
+		i, err := db.Image.Find(ctx, imageIDs[imageIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, i.StudioID)
+
+		g, err := db.Gallery.Find(ctx, galleryIDs[galleryIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, g.StudioID)
+
+		m, err := db.Group.Find(ctx, groupIDs[groupIdxWithStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, m.StudioID)
+
+		// ensure child studio is reparented
+		child, err := qb.Find(ctx, studioIDs[studioIdxWithParentStudio])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, child.ParentID)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+
+	// merge a parent into its child
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Studio
+
+		destID := studioIDs[studioIdxWithParentAndChild]
+		if err := qb.Merge(ctx, []int{studioIDs[studioIdxWithGrandChild]}, destID); err != nil {
+			return err
+		}
+
+		dest, err := qb.Find(ctx, destID)
+		if err != nil {
+			return err
+		}
+		assert.Nil(dest.ParentID)
+
+		child, err := qb.Find(ctx, studioIDs[studioIdxWithGrandParent])
+		if err != nil {
+			return err
+		}
+		assert.Equal(&destID, child.ParentID)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 // TODO FindBySceneID
 // TODO Count
 // TODO All
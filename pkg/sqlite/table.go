@@ -787,6 +787,28 @@ func (t *relatedFilesTable) setPrimary(ctx context.Context, id int, fileID model
 type viewHistoryTable struct {
 	table
 	dateColumn exp.IdentifierExpression
+
+	// userColumn is set for tables that store the history of individual
+	// users. Queries are restricted to the rows of userID.
+	userColumn exp.IdentifierExpression
+	userID     int
+}
+
+// forUser returns a copy of the table manager that is restricted to the
+// history of the provided user.
+func (t *viewHistoryTable) forUser(userID int) *viewHistoryTable {
+	ret := *t
+	ret.userID = userID
+	return &ret
+}
+
+// where returns the provided expressions, along with the user restriction
+// if applicable.
+func (t *viewHistoryTable) where(e ...exp.Expression) []exp.Expression {
+	if t.userColumn != nil {
+		e = append(e, t.userColumn.Eq(t.userID))
+	}
+	return e
 }
 
 func (t *viewHistoryTable) getDates(ctx context.Context, id int) ([]time.Time, error) {
@@ -795,7 +817,7 @@ func (t *viewHistoryTable) getDates(ctx context.Context, id int) ([]time.Time, e
 	q := dialect.Select(
 		t.dateColumn,
 	).From(table).Where(
-		t.idColumn.Eq(id),
+		t.where(t.idColumn.Eq(id))...,
 	).Order(t.dateColumn.Desc())
 
 	const single = false
@@ -821,7 +843,7 @@ func (t *viewHistoryTable) getManyDates(ctx context.Context, ids []int) ([][]tim
 		t.idColumn,
 		t.dateColumn,
 	).From(table).Where(
-		t.idColumn.In(ids),
+		t.where(t.idColumn.In(ids))...,
 	).Order(t.dateColumn.Desc())
 
 	ret := make([][]time.Time, len(ids))
@@ -848,7 +870,7 @@ func (t *viewHistoryTable) getManyDates(ctx context.Context, ids []int) ([][]tim
 func (t *viewHistoryTable) getLastDate(ctx context.Context, id int) (*time.Time, error) {
 	table := t.table.table
 	q := dialect.Select(t.dateColumn).From(table).Where(
-		t.idColumn.Eq(id),
+		t.where(t.idColumn.Eq(id))...,
 	).Order(t.dateColumn.Desc()).Limit(1)
 
 	var date NullTimestamp
@@ -866,7 +888,7 @@ func (t *viewHistoryTable) getManyLastDate(ctx context.Context, ids []int) ([]*t
 		t.idColumn,
 		goqu.MAX(t.dateColumn),
 	).From(table).Where(
-		t.idColumn.In(ids),
+		t.where(t.idColumn.In(ids))...,
 	).GroupBy(t.idColumn)
 
 	ret := make([]*time.Time, len(ids))
@@ -900,7 +922,7 @@ func (t *viewHistoryTable) getManyLastDate(ctx context.Context, ids []int) ([]*t
 
 func (t *viewHistoryTable) getCount(ctx context.Context, id int) (int, error) {
 	table := t.table.table
-	q := dialect.Select(goqu.COUNT("*")).From(table).Where(t.idColumn.Eq(id))
+	q := dialect.Select(goqu.COUNT("*")).From(table).Where(t.where(t.idColumn.Eq(id))...)
 
 	const single = true
 	var ret int
@@ -923,7 +945,7 @@ func (t *viewHistoryTable) getManyCount(ctx context.Context, ids []int) ([]int,
 		t.idColumn,
 		goqu.COUNT(t.dateColumn),
 	).From(table).Where(
-		t.idColumn.In(ids),
+		t.where(t.idColumn.In(ids))...,
 	).GroupBy(t.idColumn)
 
 	ret := make([]int, len(ids))
@@ -949,7 +971,7 @@ func (t *viewHistoryTable) getManyCount(ctx context.Context, ids []int) ([]int,
 
 func (t *viewHistoryTable) getAllCount(ctx context.Context) (int, error) {
 	table := t.table.table
-	q := dialect.Select(goqu.COUNT("*")).From(table)
+	q := dialect.Select(goqu.COUNT("*")).From(table).Where(t.where()...)
 
 	const single = true
 	var ret int
@@ -967,7 +989,7 @@ func (t *viewHistoryTable) getAllCount(ctx context.Context) (int, error) {
 
 func (t *viewHistoryTable) getUniqueCount(ctx context.Context) (int, error) {
 	table := t.table.table
-	q := dialect.Select(goqu.COUNT(goqu.DISTINCT(t.idColumn))).From(table)
+	q := dialect.Select(goqu.COUNT(goqu.DISTINCT(t.idColumn))).From(table).Where(t.where()...)
 
 	const single = true
 	var ret int
@@ -990,11 +1012,19 @@ func (t *viewHistoryTable) addDates(ctx context.Context, id int, dates []time.Ti
 		dates = []time.Time{time.Now()}
 	}
 
+	cols := []interface{}{t.idColumn.GetCol(), t.dateColumn.GetCol()}
+	if t.userColumn != nil {
+		cols = append(cols, t.userColumn.GetCol())
+	}
+
 	for _, d := range dates {
-		q := dialect.Insert(table).Cols(t.idColumn.GetCol(), t.dateColumn.GetCol()).Vals(
-			// convert all dates to UTC
-			goqu.Vals{id, UTCTimestamp{Timestamp{d}}},
-		)
+		// convert all dates to UTC
+		vals := goqu.Vals{id, UTCTimestamp{Timestamp{d}}}
+		if t.userColumn != nil {
+			vals = append(vals, t.userID)
+		}
+
+		q := dialect.Insert(table).Cols(cols...).Vals(vals)
 
 		if _, err := exec(ctx, q); err != nil {
 			return nil, fmt.Errorf("inserting into %s: %w", table.GetTable(), err)
@@ -1018,12 +1048,14 @@ func (t *viewHistoryTable) deleteDates(ctx context.Context, id int, dates []time
 		if mostRecent {
 			// delete the most recent
 			subquery = dialect.Select("rowid").From(table).Where(
-				t.idColumn.Eq(id),
+				t.where(t.idColumn.Eq(id))...,
 			).Order(t.dateColumn.Desc()).Limit(1)
 		} else {
 			subquery = dialect.Select("rowid").From(table).Where(
-				t.idColumn.Eq(id),
-				t.dateColumn.Eq(UTCTimestamp{Timestamp{date}}),
+				t.where(
+					t.idColumn.Eq(id),
+					t.dateColumn.Eq(UTCTimestamp{Timestamp{date}}),
+				)...,
 			).Limit(1)
 		}
 
@@ -1039,7 +1071,7 @@ func (t *viewHistoryTable) deleteDates(ctx context.Context, id int, dates []time
 
 func (t *viewHistoryTable) deleteAllDates(ctx context.Context, id int) (int, error) {
 	table := t.table.table
-	q := dialect.Delete(table).Where(t.idColumn.Eq(id))
+	q := dialect.Delete(table).Where(t.where(t.idColumn.Eq(id))...)
 
 	if _, err := exec(ctx, q); err != nil {
 		return 0, fmt.Errorf("resetting dates for id %v: %w", id, err)
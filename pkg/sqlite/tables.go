@@ -9,39 +9,50 @@ import (
 var dialect = goqu.Dialect("sqlite3")
 
 var (
-	galleriesImagesJoinTable  = goqu.T(galleriesImagesTable)
-	imagesTagsJoinTable       = goqu.T(imagesTagsTable)
-	performersImagesJoinTable = goqu.T(performersImagesTable)
-	imagesFilesJoinTable      = goqu.T(imagesFilesTable)
-	imagesURLsJoinTable       = goqu.T(imagesURLsTable)
-
-	galleriesFilesJoinTable      = goqu.T(galleriesFilesTable)
-	galleriesTagsJoinTable       = goqu.T(galleriesTagsTable)
-	performersGalleriesJoinTable = goqu.T(performersGalleriesTable)
-	galleriesScenesJoinTable     = goqu.T(galleriesScenesTable)
-	galleriesURLsJoinTable       = goqu.T(galleriesURLsTable)
-
-	scenesFilesJoinTable      = goqu.T(scenesFilesTable)
-	scenesTagsJoinTable       = goqu.T(scenesTagsTable)
-	scenesPerformersJoinTable = goqu.T(performersScenesTable)
-	scenesStashIDsJoinTable   = goqu.T("scene_stash_ids")
-	scenesGroupsJoinTable     = goqu.T(groupsScenesTable)
-	scenesURLsJoinTable       = goqu.T(scenesURLsTable)
-
-	performersAliasesJoinTable  = goqu.T(performersAliasesTable)
-	performersURLsJoinTable     = goqu.T(performerURLsTable)
-	performersTagsJoinTable     = goqu.T(performersTagsTable)
-	performersStashIDsJoinTable = goqu.T("performer_stash_ids")
-
-	studiosAliasesJoinTable  = goqu.T(studioAliasesTable)
-	studiosTagsJoinTable     = goqu.T(studiosTagsTable)
-	studiosStashIDsJoinTable = goqu.T("studio_stash_ids")
-
-	groupsURLsJoinTable = goqu.T(groupURLsTable)
-	groupsTagsJoinTable = goqu.T(groupsTagsTable)
-
-	tagsAliasesJoinTable  = goqu.T(tagAliasesTable)
-	tagRelationsJoinTable = goqu.T(tagRelationsTable)
+	galleriesImagesJoinTable    = goqu.T(galleriesImagesTable)
+	imagesTagsJoinTable         = goqu.T(imagesTagsTable)
+	performersImagesJoinTable   = goqu.T(performersImagesTable)
+	imagesFilesJoinTable        = goqu.T(imagesFilesTable)
+	imagesURLsJoinTable         = goqu.T(imagesURLsTable)
+	imagesCustomFieldsJoinTable = goqu.T("image_custom_fields")
+
+	galleriesFilesJoinTable        = goqu.T(galleriesFilesTable)
+	galleriesTagsJoinTable         = goqu.T(galleriesTagsTable)
+	performersGalleriesJoinTable   = goqu.T(performersGalleriesTable)
+	galleriesScenesJoinTable       = goqu.T(galleriesScenesTable)
+	galleriesURLsJoinTable         = goqu.T(galleriesURLsTable)
+	galleriesCustomFieldsJoinTable = goqu.T("gallery_custom_fields")
+
+	scenesFilesJoinTable        = goqu.T(scenesFilesTable)
+	scenesTagsJoinTable         = goqu.T(scenesTagsTable)
+	scenesPerformersJoinTable   = goqu.T(performersScenesTable)
+	scenesStashIDsJoinTable     = goqu.T("scene_stash_ids")
+	scenesGroupsJoinTable       = goqu.T(groupsScenesTable)
+	scenesURLsJoinTable         = goqu.T(scenesURLsTable)
+	scenesCustomFieldsJoinTable = goqu.T("scene_custom_fields")
+
+	performersAliasesJoinTable      = goqu.T(performersAliasesTable)
+	performersURLsJoinTable         = goqu.T(performerURLsTable)
+	performersTagsJoinTable         = goqu.T(performersTagsTable)
+	performersStashIDsJoinTable     = goqu.T("performer_stash_ids")
+	performersCustomFieldsJoinTable = goqu.T("performer_custom_fields")
+
+	studiosAliasesJoinTable      = goqu.T(studioAliasesTable)
+	studiosTagsJoinTable         = goqu.T(studiosTagsTable)
+	studiosStashIDsJoinTable     = goqu.T("studio_stash_ids")
+	studiosCustomFieldsJoinTable = goqu.T("studio_custom_fields")
+
+	groupsURLsJoinTable         = goqu.T(groupURLsTable)
+	groupsTagsJoinTable         = goqu.T(groupsTagsTable)
+	groupsCustomFieldsJoinTable = goqu.T("movie_custom_fields")
+
+	tagsAliasesJoinTable      = goqu.T(tagAliasesTable)
+	tagRelationsJoinTable     = goqu.T(tagRelationsTable)
+	tagsCustomFieldsJoinTable = goqu.T("tag_custom_fields")
+
+	restrictionProfilesTagsJoinTable       = goqu.T(restrictionProfilesTagsTable)
+	restrictionProfilesStudiosJoinTable    = goqu.T(restrictionProfilesStudiosTable)
+	restrictionProfilesPerformersJoinTable = goqu.T(restrictionProfilesPerformersTable)
 )
 
 var (
@@ -88,6 +99,13 @@ var (
 		},
 		valueColumn: imagesURLsJoinTable.Col(imageURLColumn),
 	}
+
+	imagesCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    imagesCustomFieldsJoinTable,
+			idColumn: imagesCustomFieldsJoinTable.Col(imageIDColumn),
+		},
+	}
 )
 
 var (
@@ -139,6 +157,13 @@ var (
 		},
 		valueColumn: galleriesURLsJoinTable.Col(galleriesURLColumn),
 	}
+
+	galleriesCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    galleriesCustomFieldsJoinTable,
+			idColumn: galleriesCustomFieldsJoinTable.Col(galleryIDColumn),
+		},
+	}
 )
 
 var (
@@ -214,6 +239,36 @@ var (
 		},
 		dateColumn: goqu.T(scenesODatesTable).Col(sceneODateColumn),
 	}
+
+	scenesUsersTableMgr = &table{
+		table:    goqu.T(scenesUsersTable),
+		idColumn: goqu.T(scenesUsersTable).Col(sceneIDColumn),
+	}
+
+	scenesUsersViewTableMgr = &viewHistoryTable{
+		table: table{
+			table:    goqu.T(scenesUsersViewDatesTable),
+			idColumn: goqu.T(scenesUsersViewDatesTable).Col(sceneIDColumn),
+		},
+		dateColumn: goqu.T(scenesUsersViewDatesTable).Col(sceneViewDateColumn),
+		userColumn: goqu.T(scenesUsersViewDatesTable).Col(userIDColumn),
+	}
+
+	scenesUsersOTableMgr = &viewHistoryTable{
+		table: table{
+			table:    goqu.T(scenesUsersODatesTable),
+			idColumn: goqu.T(scenesUsersODatesTable).Col(sceneIDColumn),
+		},
+		dateColumn: goqu.T(scenesUsersODatesTable).Col(sceneODateColumn),
+		userColumn: goqu.T(scenesUsersODatesTable).Col(userIDColumn),
+	}
+
+	scenesCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    scenesCustomFieldsJoinTable,
+			idColumn: scenesCustomFieldsJoinTable.Col(sceneIDColumn),
+		},
+	}
 )
 
 var (
@@ -279,6 +334,13 @@ var (
 			idColumn: performersStashIDsJoinTable.Col(performerIDColumn),
 		},
 	}
+
+	performersCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    performersCustomFieldsJoinTable,
+			idColumn: performersCustomFieldsJoinTable.Col(performerIDColumn),
+		},
+	}
 )
 
 var (
@@ -309,6 +371,13 @@ var (
 			idColumn: studiosStashIDsJoinTable.Col(studioIDColumn),
 		},
 	}
+
+	studiosCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    studiosCustomFieldsJoinTable,
+			idColumn: studiosCustomFieldsJoinTable.Col(studioIDColumn),
+		},
+	}
 )
 
 var (
@@ -334,6 +403,13 @@ var (
 	}
 
 	tagsChildTagsTableMgr = *tagsParentTagsTableMgr.invert()
+
+	tagsCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    tagsCustomFieldsJoinTable,
+			idColumn: tagsCustomFieldsJoinTable.Col(tagIDColumn),
+		},
+	}
 )
 
 var (
@@ -359,6 +435,13 @@ var (
 		foreignTable: tagTableMgr,
 		orderBy:      tagTableMgr.table.Col("name").Asc(),
 	}
+
+	groupsCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    groupsCustomFieldsJoinTable,
+			idColumn: groupsCustomFieldsJoinTable.Col(groupIDColumn),
+		},
+	}
 )
 
 var (
@@ -373,4 +456,50 @@ var (
 		table:    goqu.T(savedFilterTable),
 		idColumn: goqu.T(savedFilterTable).Col(idColumn),
 	}
+
+	scheduledTaskTableMgr = &table{
+		table:    goqu.T(scheduledTaskTable),
+		idColumn: goqu.T(scheduledTaskTable).Col(idColumn),
+	}
+
+	scheduledTaskRunTableMgr = &table{
+		table:    goqu.T(scheduledTaskRunTable),
+		idColumn: goqu.T(scheduledTaskRunTable).Col(idColumn),
+	}
+
+	userTableMgr = &table{
+		table:    goqu.T(userTable),
+		idColumn: goqu.T(userTable).Col(idColumn),
+	}
+)
+
+var (
+	restrictionProfileTableMgr = &table{
+		table:    goqu.T(restrictionProfileTable),
+		idColumn: goqu.T(restrictionProfileTable).Col(idColumn),
+	}
+
+	restrictionProfilesTagsTableMgr = &joinTable{
+		table: table{
+			table:    restrictionProfilesTagsJoinTable,
+			idColumn: restrictionProfilesTagsJoinTable.Col(restrictionProfileIDColumn),
+		},
+		fkColumn: restrictionProfilesTagsJoinTable.Col(tagIDColumn),
+	}
+
+	restrictionProfilesStudiosTableMgr = &joinTable{
+		table: table{
+			table:    restrictionProfilesStudiosJoinTable,
+			idColumn: restrictionProfilesStudiosJoinTable.Col(restrictionProfileIDColumn),
+		},
+		fkColumn: restrictionProfilesStudiosJoinTable.Col(studioIDColumn),
+	}
+
+	restrictionProfilesPerformersTableMgr = &joinTable{
+		table: table{
+			table:    restrictionProfilesPerformersJoinTable,
+			idColumn: restrictionProfilesPerformersJoinTable.Col(restrictionProfileIDColumn),
+		},
+		fkColumn: restrictionProfilesPerformersJoinTable.Col(performerIDColumn),
+	}
 )
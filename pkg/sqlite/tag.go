@@ -195,6 +195,12 @@ func (qb *TagStore) Create(ctx context.Context, newObject *models.Tag) error {
 		}
 	}
 
+	if newObject.CustomFields.Loaded() {
+		if err := tagsCustomFieldsTableMgr.insertJoins(ctx, id, newObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	updated, err := qb.find(ctx, id)
 	if err != nil {
 		return fmt.Errorf("finding after create: %w", err)
@@ -238,6 +244,12 @@ func (qb *TagStore) UpdatePartial(ctx context.Context, id int, partial models.Ta
 		}
 	}
 
+	if partial.CustomFields != nil {
+		if err := tagsCustomFieldsTableMgr.modifyJoins(ctx, id, partial.CustomFields.CustomFields, partial.CustomFields.Mode); err != nil {
+			return nil, err
+		}
+	}
+
 	return qb.find(ctx, id)
 }
 
@@ -267,6 +279,12 @@ func (qb *TagStore) Update(ctx context.Context, updatedObject *models.Tag) error
 		}
 	}
 
+	if updatedObject.CustomFields.Loaded() {
+		if err := tagsCustomFieldsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.CustomFields.List()); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -602,9 +620,7 @@ func (qb *TagStore) Query(ctx context.Context, tagFilter *models.TagFilterType,
 	distinctIDs(&query, tagTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		query.join(tagAliasesTable, "", "tag_aliases.tag_id = tags.id")
-		searchColumns := []string{"tags.name", "tag_aliases.alias"}
-		query.parseQueryString(searchColumns, *q)
+		query.addFullTextSearch(tagsFTSTable, "tags.id", *q)
 	}
 
 	filter := filterBuilderFromHandler(ctx, &tagFilterHandler{
@@ -645,6 +661,7 @@ var tagSortOptions = sortOptions{
 	"name",
 	"performers_count",
 	"random",
+	"relevance",
 	"scene_markers_count",
 	"scenes_count",
 	"updated_at",
@@ -686,6 +703,8 @@ func (qb *TagStore) getTagSort(query *queryBuilder, findFilter *models.FindFilte
 		sortQuery += getCountSort(tagTable, studiosTagsTable, tagIDColumn, direction)
 	case "movies_count", "groups_count":
 		sortQuery += getCountSort(tagTable, groupsTagsTable, tagIDColumn, direction)
+	case relevanceSort:
+		sortQuery += tagsFTSTable.getRelevanceSort("tags.id", findFilter)
 	default:
 		sortQuery += getSort(sort, direction, "tags")
 	}
@@ -755,6 +774,10 @@ func (qb *TagStore) GetAliases(ctx context.Context, tagID int) ([]string, error)
 	return tagRepository.aliases.get(ctx, tagID)
 }
 
+func (qb *TagStore) GetCustomFields(ctx context.Context, tagID int) ([]models.CustomField, error) {
+	return tagsCustomFieldsTableMgr.get(ctx, tagID)
+}
+
 func (qb *TagStore) UpdateAliases(ctx context.Context, tagID int, aliases []string) error {
 	return tagRepository.aliases.replace(ctx, tagID, aliases)
 }
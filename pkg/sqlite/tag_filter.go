@@ -79,6 +79,12 @@ func (qb *tagFilterHandler) criterionHandler() criterionHandler {
 		&timestampCriterionHandler{tagFilter.CreatedAt, "tags.created_at", nil},
 		&timestampCriterionHandler{tagFilter.UpdatedAt, "tags.updated_at", nil},
 
+		&customFieldsCriterionHandler{
+			criteria:    tagFilter.CustomFields,
+			table:       tagsCustomFieldsTableMgr,
+			parentIDCol: "tags.id",
+		},
+
 		&relatedFilterHandler{
 			relatedIDCol:   "scenes_tags.scene_id",
 			relatedRepo:    sceneRepository.repository,
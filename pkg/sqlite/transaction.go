@@ -139,5 +139,11 @@ func (db *Database) Repository() models.Repository {
 		Studio:         db.Studio,
 		Tag:            db.Tag,
 		SavedFilter:    db.SavedFilter,
+		Search:         db.Search,
+		ScheduledTask:  db.ScheduledTask,
+		User:           db.User,
+		SceneUserData:  db.SceneUserData,
+
+		RestrictionProfile: db.RestrictionProfile,
 	}
 }
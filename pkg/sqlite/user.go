@@ -0,0 +1,214 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	userTable    = "users"
+	userIDColumn = "user_id"
+)
+
+type userRow struct {
+	ID                   int             `db:"id" goqu:"skipinsert"`
+	Username             string          `db:"username"`
+	PasswordHash         string          `db:"password"`
+	Role                 models.UserRole `db:"role"`
+	APIKeyHash           null.String     `db:"api_key_hash"`
+	ExternalIssuer       null.String     `db:"external_issuer"`
+	ExternalSubject      null.String     `db:"external_subject"`
+	RestrictionProfileID null.Int        `db:"restriction_profile_id"`
+	CreatedAt            Timestamp       `db:"created_at"`
+	UpdatedAt            Timestamp       `db:"updated_at"`
+}
+
+func (r *userRow) fromUser(o models.User) {
+	r.ID = o.ID
+	r.Username = o.Username
+	r.PasswordHash = o.PasswordHash
+	r.Role = o.Role
+	// store empty api keys as null so that they don't violate the unique index
+	r.APIKeyHash = null.NewString(o.APIKeyHash, o.APIKeyHash != "")
+	r.ExternalIssuer = null.NewString(o.ExternalIssuer, o.ExternalIssuer != "")
+	r.ExternalSubject = null.NewString(o.ExternalSubject, o.ExternalSubject != "")
+	r.RestrictionProfileID = intFromPtr(o.RestrictionProfileID)
+	r.CreatedAt = Timestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = Timestamp{Timestamp: o.UpdatedAt}
+}
+
+func (r *userRow) resolve() *models.User {
+	return &models.User{
+		ID:           r.ID,
+		Username:     r.Username,
+		PasswordHash: r.PasswordHash,
+		Role:         r.Role,
+		APIKeyHash:   r.APIKeyHash.String,
+		CreatedAt:    r.CreatedAt.Timestamp,
+		UpdatedAt:    r.UpdatedAt.Timestamp,
+
+		ExternalIssuer:       r.ExternalIssuer.String,
+		ExternalSubject:      r.ExternalSubject.String,
+		RestrictionProfileID: nullIntPtr(r.RestrictionProfileID),
+	}
+}
+
+type UserStore struct {
+	repository
+	tableMgr *table
+}
+
+func NewUserStore() *UserStore {
+	return &UserStore{
+		repository: repository{
+			tableName: userTable,
+			idColumn:  idColumn,
+		},
+		tableMgr: userTableMgr,
+	}
+}
+
+func (qb *UserStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *UserStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *UserStore) Create(ctx context.Context, newObject *models.User) error {
+	var r userRow
+	r.fromUser(*newObject)
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	updated, err := qb.find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *updated
+
+	return nil
+}
+
+func (qb *UserStore) Update(ctx context.Context, updatedObject *models.User) error {
+	var r userRow
+	r.fromUser(*updatedObject)
+
+	return qb.tableMgr.updateByID(ctx, updatedObject.ID, r)
+}
+
+func (qb *UserStore) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+// returns nil, nil if not found
+func (qb *UserStore) Find(ctx context.Context, id int) (*models.User, error) {
+	ret, err := qb.find(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, sql.ErrNoRows if not found
+func (qb *UserStore) find(ctx context.Context, id int) (*models.User, error) {
+	return qb.findOne(ctx, qb.selectDataset().Where(qb.tableMgr.byID(id)))
+}
+
+// returns nil, nil if not found
+func (qb *UserStore) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	q := qb.selectDataset().Where(qb.table().Col("username").Eq(username))
+
+	ret, err := qb.findOne(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, nil if not found
+func (qb *UserStore) FindByAPIKeyHash(ctx context.Context, apiKeyHash string) (*models.User, error) {
+	if apiKeyHash == "" {
+		return nil, nil
+	}
+
+	q := qb.selectDataset().Where(qb.table().Col("api_key_hash").Eq(apiKeyHash))
+
+	ret, err := qb.findOne(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+// returns nil, nil if not found
+func (qb *UserStore) FindByExternalIdentity(ctx context.Context, issuer string, subject string) (*models.User, error) {
+	if issuer == "" || subject == "" {
+		return nil, nil
+	}
+
+	q := qb.selectDataset().Where(
+		qb.table().Col("external_issuer").Eq(issuer),
+		qb.table().Col("external_subject").Eq(subject),
+	)
+
+	ret, err := qb.findOne(ctx, q)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return ret, err
+}
+
+func (qb *UserStore) findOne(ctx context.Context, q *goqu.SelectDataset) (*models.User, error) {
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return ret[0], nil
+}
+
+func (qb *UserStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.User, error) {
+	const single = false
+	var ret []*models.User
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f userRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		ret = append(ret, f.resolve())
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *UserStore) All(ctx context.Context) ([]*models.User, error) {
+	return qb.getMany(ctx, qb.selectDataset().Order(qb.table().Col("username").Asc()))
+}
+
+func (qb *UserStore) Count(ctx context.Context) (int, error) {
+	q := dialect.Select(goqu.COUNT("*")).From(qb.table())
+	return count(ctx, q)
+}
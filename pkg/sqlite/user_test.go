@@ -0,0 +1,323 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestUser(ctx context.Context, t *testing.T, username string, apiKeyHash string) *models.User {
+	newUser := models.NewUser()
+	newUser.Username = username
+	newUser.PasswordHash = "hash"
+	newUser.APIKeyHash = apiKeyHash
+
+	if err := db.User.Create(ctx, &newUser); err != nil {
+		t.Fatalf("UserStore.Create() error = %v", err)
+	}
+
+	return &newUser
+}
+
+func TestUserStore_Create(t *testing.T) {
+	runWithRollbackTxn(t, "create", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		u := createTestUser(ctx, t, "user1", "key1")
+		assert.NotZero(u.ID)
+
+		// a second user without an API key must not conflict
+		createTestUser(ctx, t, "user2", "")
+		createTestUser(ctx, t, "user3", "")
+
+		// duplicate username
+		dup := models.NewUser()
+		dup.Username = "user1"
+		dup.PasswordHash = "hash"
+		assert.Error(db.User.Create(ctx, &dup))
+
+		count, err := db.User.Count(ctx)
+		if err != nil {
+			t.Errorf("UserStore.Count() error = %v", err)
+		}
+		assert.Equal(3, count)
+	})
+}
+
+func TestUserStore_Find(t *testing.T) {
+	runWithRollbackTxn(t, "find", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		u := createTestUser(ctx, t, "user1", "key1")
+
+		got, err := db.User.FindByUsername(ctx, "user1")
+		if err != nil {
+			t.Errorf("UserStore.FindByUsername() error = %v", err)
+		}
+		assert.Equal(u, got)
+
+		got, err = db.User.FindByAPIKeyHash(ctx, "key1")
+		if err != nil {
+			t.Errorf("UserStore.FindByAPIKeyHash() error = %v", err)
+		}
+		assert.Equal(u, got)
+
+		got, err = db.User.FindByAPIKeyHash(ctx, "")
+		if err != nil {
+			t.Errorf("UserStore.FindByAPIKeyHash() error = %v", err)
+		}
+		assert.Nil(got)
+
+		got, err = db.User.FindByUsername(ctx, "missing")
+		if err != nil {
+			t.Errorf("UserStore.FindByUsername() error = %v", err)
+		}
+		assert.Nil(got)
+	})
+}
+
+func TestUserStore_FindByExternalIdentity(t *testing.T) {
+	runWithRollbackTxn(t, "find by external identity", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		newUser := models.NewUser()
+		newUser.Username = "external"
+		newUser.PasswordHash = "hash"
+		newUser.ExternalIssuer = "issuer"
+		newUser.ExternalSubject = "subject"
+
+		if err := db.User.Create(ctx, &newUser); err != nil {
+			t.Fatalf("UserStore.Create() error = %v", err)
+		}
+
+		// users without an external identity must not conflict
+		createTestUser(ctx, t, "user1", "")
+		createTestUser(ctx, t, "user2", "")
+
+		got, err := db.User.FindByExternalIdentity(ctx, "issuer", "subject")
+		if err != nil {
+			t.Errorf("UserStore.FindByExternalIdentity() error = %v", err)
+		}
+		assert.Equal(&newUser, got)
+
+		got, err = db.User.FindByExternalIdentity(ctx, "other", "subject")
+		if err != nil {
+			t.Errorf("UserStore.FindByExternalIdentity() error = %v", err)
+		}
+		assert.Nil(got)
+
+		got, err = db.User.FindByExternalIdentity(ctx, "", "")
+		if err != nil {
+			t.Errorf("UserStore.FindByExternalIdentity() error = %v", err)
+		}
+		assert.Nil(got)
+
+		// duplicate identity
+		dup := models.NewUser()
+		dup.Username = "external2"
+		dup.PasswordHash = "hash"
+		dup.ExternalIssuer = "issuer"
+		dup.ExternalSubject = "subject"
+		assert.Error(db.User.Create(ctx, &dup))
+	})
+}
+
+func TestSceneUserDataStore_History(t *testing.T) {
+	runWithRollbackTxn(t, "history", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		u1 := createTestUser(ctx, t, "user1", "")
+		u2 := createTestUser(ctx, t, "user2", "")
+
+		sceneID := sceneIDs[sceneIdx1WithPerformer]
+
+		globalCount, err := db.Scene.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+		}
+
+		qb1 := db.SceneUserData.ForUser(u1.ID)
+		qb2 := db.SceneUserData.ForUser(u2.ID)
+
+		views, err := qb1.AddViews(ctx, sceneID, nil)
+		if err != nil {
+			t.Errorf("AddViews() error = %v", err)
+		}
+		assert.Len(views, 1)
+
+		if _, err := qb1.AddO(ctx, sceneID, nil); err != nil {
+			t.Errorf("AddO() error = %v", err)
+		}
+
+		count, err := qb1.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("CountViews() error = %v", err)
+		}
+		assert.Equal(1, count)
+
+		// other users and the scene history are unaffected
+		count, err = qb2.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("CountViews() error = %v", err)
+		}
+		assert.Equal(0, count)
+
+		oCount, err := qb2.GetOCount(ctx, sceneID)
+		if err != nil {
+			t.Errorf("GetOCount() error = %v", err)
+		}
+		assert.Equal(0, oCount)
+
+		count, err = db.Scene.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("SceneStore.CountViews() error = %v", err)
+		}
+		assert.Equal(globalCount, count)
+
+		// deleting the most recent view only affects the user
+		if _, err := qb2.AddViews(ctx, sceneID, nil); err != nil {
+			t.Errorf("AddViews() error = %v", err)
+		}
+		views, err = qb1.DeleteViews(ctx, sceneID, nil)
+		if err != nil {
+			t.Errorf("DeleteViews() error = %v", err)
+		}
+		assert.Len(views, 0)
+
+		count, err = qb2.CountViews(ctx, sceneID)
+		if err != nil {
+			t.Errorf("CountViews() error = %v", err)
+		}
+		assert.Equal(1, count)
+	})
+}
+
+func TestSceneUserDataStore_UserData(t *testing.T) {
+	runWithRollbackTxn(t, "user data", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		u1 := createTestUser(ctx, t, "user1", "")
+		u2 := createTestUser(ctx, t, "user2", "")
+
+		sceneID := sceneIDs[sceneIdx1WithPerformer]
+		otherSceneID := sceneIDs[sceneIdxWithGallery]
+
+		qb1 := db.SceneUserData.ForUser(u1.ID)
+		qb2 := db.SceneUserData.ForUser(u2.ID)
+
+		resumeTime := 12.5
+		playDuration := 30.0
+		rating := 60
+
+		if _, err := qb1.SaveActivity(ctx, sceneID, &resumeTime, &playDuration); err != nil {
+			t.Errorf("SaveActivity() error = %v", err)
+		}
+		if _, err := qb1.SaveActivity(ctx, sceneID, nil, &playDuration); err != nil {
+			t.Errorf("SaveActivity() error = %v", err)
+		}
+		if err := qb1.UpdateRating(ctx, sceneID, &rating); err != nil {
+			t.Errorf("UpdateRating() error = %v", err)
+		}
+
+		got, err := qb1.GetUserData(ctx, sceneID)
+		if err != nil {
+			t.Errorf("GetUserData() error = %v", err)
+		}
+		assert.Equal(&models.SceneUserData{
+			SceneID:      sceneID,
+			UserID:       u1.ID,
+			Rating:       &rating,
+			ResumeTime:   resumeTime,
+			PlayDuration: playDuration * 2,
+		}, got)
+
+		many, err := qb1.GetManyUserData(ctx, []int{otherSceneID, sceneID})
+		if err != nil {
+			t.Errorf("GetManyUserData() error = %v", err)
+		}
+		assert.Equal([]*models.SceneUserData{nil, got}, many)
+
+		got, err = qb2.GetUserData(ctx, sceneID)
+		if err != nil {
+			t.Errorf("GetUserData() error = %v", err)
+		}
+		assert.Nil(got)
+
+		// invalid scene
+		_, err = qb1.SaveActivity(ctx, invalidID, &resumeTime, nil)
+		assert.Error(err)
+	})
+}
+
+func TestSceneQuery_UserData(t *testing.T) {
+	runWithRollbackTxn(t, "query user data", func(t *testing.T, ctx context.Context) {
+		assert := assert.New(t)
+
+		u1 := createTestUser(ctx, t, "user1", "")
+		u2 := createTestUser(ctx, t, "user2", "")
+
+		sceneID := sceneIDs[sceneIdx1WithPerformer]
+		qb1 := db.SceneUserData.ForUser(u1.ID)
+
+		rating := 60
+		resumeTime := 12.0
+		if err := qb1.UpdateRating(ctx, sceneID, &rating); err != nil {
+			t.Errorf("UpdateRating() error = %v", err)
+		}
+		if _, err := qb1.SaveActivity(ctx, sceneID, &resumeTime, nil); err != nil {
+			t.Errorf("SaveActivity() error = %v", err)
+		}
+		if _, err := qb1.AddViews(ctx, sceneID, nil); err != nil {
+			t.Errorf("AddViews() error = %v", err)
+		}
+		if _, err := qb1.AddO(ctx, sceneID, nil); err != nil {
+			t.Errorf("AddO() error = %v", err)
+		}
+
+		ctx1 := models.WithUserID(ctx, u1.ID)
+		ctx2 := models.WithUserID(ctx, u2.ID)
+
+		filters := map[string]*models.SceneFilterType{
+			"rating": {
+				Rating100: &models.IntCriterionInput{Value: rating, Modifier: models.CriterionModifierEquals},
+			},
+			"resume time": {
+				ResumeTime: &models.IntCriterionInput{Value: int(resumeTime), Modifier: models.CriterionModifierEquals},
+			},
+			"play count": {
+				PlayCount: &models.IntCriterionInput{Value: 0, Modifier: models.CriterionModifierGreaterThan},
+			},
+			"o counter": {
+				OCounter: &models.IntCriterionInput{Value: 0, Modifier: models.CriterionModifierGreaterThan},
+			},
+		}
+
+		for name, f := range filters {
+			scenes := queryScene(ctx1, t, db.Scene, f, nil)
+			if assert.Len(scenes, 1, name) {
+				assert.Equal(sceneID, scenes[0].ID, name)
+			}
+
+			// other users do not see the data of the user
+			assert.Len(queryScene(ctx2, t, db.Scene, f, nil), 0, name)
+		}
+
+		for _, sort := range []string{"rating", "resume_time", "play_count", "o_counter", "last_played_at", "last_o_at"} {
+			direction := models.SortDirectionEnumDesc
+			perPage := 1
+			scenes := queryScene(ctx1, t, db.Scene, nil, &models.FindFilterType{
+				Sort:      &sort,
+				Direction: &direction,
+				PerPage:   &perPage,
+			})
+			if assert.Len(scenes, 1, sort) {
+				assert.Equal(sceneID, scenes[0].ID, sort)
+			}
+		}
+	})
+}
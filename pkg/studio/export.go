@@ -15,6 +15,7 @@ type FinderImageStashIDGetter interface {
 	models.StudioGetter
 	models.AliasLoader
 	models.StashIDLoader
+	models.CustomFieldsLoader
 	GetImage(ctx context.Context, studioID int) ([]byte, error)
 }
 
@@ -55,6 +56,11 @@ func ToJSON(ctx context.Context, reader FinderImageStashIDGetter, studio *models
 	}
 	newStudioJSON.StashIDs = studio.StashIDs.List()
 
+	if err := studio.LoadCustomFields(ctx, reader); err != nil {
+		return nil, fmt.Errorf("loading studio custom fields: %w", err)
+	}
+	newStudioJSON.CustomFields = studio.CustomFields.List()
+
 	image, err := reader.GetImage(ctx, studio.ID)
 	if err != nil {
 		logger.Errorf("Error getting studio image: %v", err)
@@ -49,6 +49,11 @@ var stashIDs = []models.StashID{
 	stashID,
 }
 
+var customFields = []models.CustomField{
+	{Field: "string", Type: models.CustomFieldTypeString, Value: "value"},
+	{Field: "int", Type: models.CustomFieldTypeInt, Value: 1},
+}
+
 const image = "aW1hZ2VCeXRlcw=="
 
 var (
@@ -70,6 +75,7 @@ func createFullStudio(id int, parentID int) models.Studio {
 		Aliases:       models.NewRelatedStrings(aliases),
 		TagIDs:        models.NewRelatedIDs([]int{}),
 		StashIDs:      models.NewRelatedStashIDs(stashIDs),
+		CustomFields:  models.NewRelatedCustomFields(customFields),
 	}
 
 	if parentID != 0 {
@@ -81,12 +87,13 @@ func createFullStudio(id int, parentID int) models.Studio {
 
 func createEmptyStudio(id int) models.Studio {
 	return models.Studio{
-		ID:        id,
-		CreatedAt: createTime,
-		UpdatedAt: updateTime,
-		Aliases:   models.NewRelatedStrings([]string{}),
-		TagIDs:    models.NewRelatedIDs([]int{}),
-		StashIDs:  models.NewRelatedStashIDs([]models.StashID{}),
+		ID:           id,
+		CreatedAt:    createTime,
+		UpdatedAt:    updateTime,
+		Aliases:      models.NewRelatedStrings([]string{}),
+		TagIDs:       models.NewRelatedIDs([]int{}),
+		StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+		CustomFields: models.NewRelatedCustomFields([]models.CustomField{}),
 	}
 }
 
@@ -107,6 +114,7 @@ func createFullJSONStudio(parentStudio, image string, aliases []string) *jsonsch
 		Rating:        rating,
 		Aliases:       aliases,
 		StashIDs:      stashIDs,
+		CustomFields:  customFields,
 		IgnoreAutoTag: autoTagIgnored,
 	}
 }
@@ -119,8 +127,9 @@ func createEmptyJSONStudio() *jsonschema.Studio {
 		UpdatedAt: json.JSONTime{
 			Time: updateTime,
 		},
-		Aliases:  []string{},
-		StashIDs: []models.StashID{},
+		Aliases:      []string{},
+		StashIDs:     []models.StashID{},
+		CustomFields: []models.CustomField{},
 	}
 }
 
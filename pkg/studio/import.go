@@ -226,6 +226,8 @@ func studioJSONtoStudio(studioJSON jsonschema.Studio) models.Studio {
 
 		TagIDs:   models.NewRelatedIDs([]int{}),
 		StashIDs: models.NewRelatedStashIDs(studioJSON.StashIDs),
+
+		CustomFields: models.NewRelatedCustomFields(studioJSON.CustomFields),
 	}
 
 	if studioJSON.Rating != 0 {
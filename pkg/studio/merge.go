@@ -0,0 +1,245 @@
+package studio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+)
+
+var (
+	ErrMergeParentCycle    = errors.New("merge would make the destination studio an ancestor of itself")
+	ErrMergeParentIsSource = errors.New("parent studio cannot be one of the source studios")
+)
+
+type MergeRepository interface {
+	models.StudioReader
+	UpdatePartial(ctx context.Context, updatedStudio models.StudioPartial) (*models.Studio, error)
+	UpdateImage(ctx context.Context, studioID int, image []byte) error
+	Merge(ctx context.Context, source []int, destination int) error
+}
+
+// Merge merges the source studios into the destination studio.
+//
+// Scenes, images, galleries, groups and child studios of the source studios
+// are moved to the destination. The names and aliases of the source studios
+// are added as aliases, and their tags and stash IDs are added to the
+// destination, unless the corresponding field is set in values. If the
+// destination has no URL or image, the first one found in the source studios
+// is used. Other fields in values overwrite the destination. The source
+// studios are destroyed.
+//
+// Returns ErrMergeParentCycle if the destination would become its own
+// ancestor.
+func Merge(ctx context.Context, sourceIDs []int, destinationID int, values models.StudioPartial, r MergeRepository) error {
+	// ensure source ids are unique
+	sourceIDs = sliceutil.AppendUniques(nil, sourceIDs)
+
+	// ensure destination is not in source list
+	if sliceutil.Contains(sourceIDs, destinationID) {
+		return errors.New("destination studio cannot be in source list")
+	}
+
+	dest, err := r.Find(ctx, destinationID)
+	if err != nil {
+		return fmt.Errorf("finding destination studio ID %d: %w", destinationID, err)
+	}
+	if dest == nil {
+		return fmt.Errorf("studio with id %d not found", destinationID)
+	}
+
+	sources, err := r.FindMany(ctx, sourceIDs)
+	if err != nil {
+		return fmt.Errorf("finding source studios: %w", err)
+	}
+
+	if parentID := values.ParentID.Ptr(); parentID != nil && sliceutil.Contains(sourceIDs, *parentID) {
+		return ErrMergeParentIsSource
+	}
+
+	if !values.ParentID.Set {
+		if err := validateMergeParent(ctx, dest, sourceIDs, r); err != nil {
+			return err
+		}
+	}
+
+	if err := loadMergeRelationships(ctx, dest, r); err != nil {
+		return err
+	}
+
+	var (
+		aliases  []string
+		url      string
+		tagIDs   []int
+		stashIDs = dest.StashIDs.List()
+	)
+
+	for _, src := range sources {
+		if err := loadMergeRelationships(ctx, src, r); err != nil {
+			return err
+		}
+
+		aliases = append(aliases, src.Name)
+		aliases = append(aliases, src.Aliases.List()...)
+		tagIDs = sliceutil.AppendUniques(tagIDs, src.TagIDs.List())
+		stashIDs = sliceutil.AppendUniques(stashIDs, src.StashIDs.List())
+
+		if url == "" {
+			url = src.URL
+		}
+	}
+
+	if values.Aliases == nil {
+		name := dest.Name
+		if values.Name.Set {
+			name = values.Name.Value
+		}
+
+		values.Aliases = &models.UpdateStrings{
+			Values: mergeAliases(name, dest.Aliases.List(), aliases),
+			Mode:   models.RelationshipUpdateModeAdd,
+		}
+	}
+	if values.TagIDs == nil {
+		values.TagIDs = &models.UpdateIDs{
+			IDs:  tagIDs,
+			Mode: models.RelationshipUpdateModeAdd,
+		}
+	}
+	if values.StashIDs == nil {
+		values.StashIDs = &models.UpdateStashIDs{
+			StashIDs: stashIDs,
+			Mode:     models.RelationshipUpdateModeSet,
+		}
+	}
+	if !values.URL.Set && dest.URL == "" && url != "" {
+		values.URL = models.NewOptionalString(url)
+	}
+
+	if err := mergeImage(ctx, destinationID, sources, r); err != nil {
+		return err
+	}
+
+	// move associations and destroy the sources before validating, so that
+	// the destination may take the name or aliases of a source studio
+	if err := r.Merge(ctx, sourceIDs, destinationID); err != nil {
+		return fmt.Errorf("merging studios: %w", err)
+	}
+
+	values.ID = destinationID
+	if err := ValidateModify(ctx, values, r); err != nil {
+		return err
+	}
+
+	if _, err := r.UpdatePartial(ctx, values); err != nil {
+		return fmt.Errorf("updating studio: %w", err)
+	}
+
+	return nil
+}
+
+// validateMergeParent returns ErrMergeParentCycle if a source studio is an
+// ancestor of the destination, other than through a chain of source studios
+// from the destination's parent. Child studios of the sources are moved to
+// the destination, so such an ancestor would become a descendant of the
+// destination. If the destination's parent is a source studio, the
+// destination loses its parent instead.
+func validateMergeParent(ctx context.Context, dest *models.Studio, sourceIDs []int, r models.StudioGetter) error {
+	if dest.ParentID == nil || sliceutil.Contains(sourceIDs, *dest.ParentID) {
+		return nil
+	}
+
+	// guard against existing cycles
+	visited := map[int]bool{dest.ID: true}
+
+	parentID := dest.ParentID
+	for parentID != nil && !visited[*parentID] {
+		if sliceutil.Contains(sourceIDs, *parentID) {
+			return ErrMergeParentCycle
+		}
+
+		visited[*parentID] = true
+
+		parent, err := r.Find(ctx, *parentID)
+		if err != nil {
+			return fmt.Errorf("finding parent studio: %w", err)
+		}
+		if parent == nil {
+			return nil
+		}
+
+		parentID = parent.ParentID
+	}
+
+	return nil
+}
+
+func loadMergeRelationships(ctx context.Context, s *models.Studio, r models.StudioReader) error {
+	if err := s.LoadAliases(ctx, r); err != nil {
+		return fmt.Errorf("loading studio aliases from %d: %w", s.ID, err)
+	}
+
+	if err := s.LoadTagIDs(ctx, r); err != nil {
+		return fmt.Errorf("loading studio tags from %d: %w", s.ID, err)
+	}
+
+	if err := s.LoadStashIDs(ctx, r); err != nil {
+		return fmt.Errorf("loading studio stash IDs from %d: %w", s.ID, err)
+	}
+
+	return nil
+}
+
+func mergeImage(ctx context.Context, destinationID int, sources []*models.Studio, r MergeRepository) error {
+	hasImage, err := r.HasImage(ctx, destinationID)
+	if err != nil {
+		return fmt.Errorf("checking studio image: %w", err)
+	}
+
+	if hasImage {
+		return nil
+	}
+
+	for _, src := range sources {
+		image, err := r.GetImage(ctx, src.ID)
+		if err != nil {
+			return fmt.Errorf("getting image for studio %d: %w", src.ID, err)
+		}
+
+		if len(image) > 0 {
+			if err := r.UpdateImage(ctx, destinationID, image); err != nil {
+				return fmt.Errorf("updating studio image: %w", err)
+			}
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// mergeAliases returns the aliases in toAdd that do not match, case
+// insensitively, the name, an existing alias or an earlier alias in toAdd.
+func mergeAliases(name string, existing []string, toAdd []string) []string {
+	seen := map[string]bool{
+		strings.ToLower(name): true,
+	}
+	for _, a := range existing {
+		seen[strings.ToLower(a)] = true
+	}
+
+	var ret []string
+	for _, a := range toAdd {
+		aL := strings.ToLower(a)
+		if a == "" || seen[aL] {
+			continue
+		}
+
+		seen[aL] = true
+		ret = append(ret, a)
+	}
+
+	return ret
+}
@@ -0,0 +1,121 @@
+package studio
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMerge(t *testing.T) {
+	const (
+		destID = 1
+		srcID  = 2
+	)
+
+	db := mocks.NewDatabase()
+
+	dest := &models.Studio{
+		ID:   destID,
+		Name: "dest",
+	}
+	src := &models.Studio{
+		ID:   srcID,
+		Name: "src",
+		URL:  "url",
+	}
+
+	stashID1 := models.StashID{StashID: "stash1", Endpoint: "endpoint"}
+	stashID2 := models.StashID{StashID: "stash2", Endpoint: "endpoint"}
+
+	db.Studio.On("Find", testCtx, destID).Return(dest, nil)
+	db.Studio.On("FindMany", testCtx, []int{srcID}).Return([]*models.Studio{src}, nil)
+
+	db.Studio.On("GetAliases", testCtx, destID).Return([]string{"dest alias"}, nil).Once()
+	db.Studio.On("GetTagIDs", testCtx, destID).Return([]int{1}, nil).Once()
+	db.Studio.On("GetStashIDs", testCtx, destID).Return([]models.StashID{stashID1}, nil).Once()
+
+	db.Studio.On("GetAliases", testCtx, srcID).Return([]string{"DEST ALIAS", "src alias"}, nil).Once()
+	db.Studio.On("GetTagIDs", testCtx, srcID).Return([]int{1, 2}, nil).Once()
+	db.Studio.On("GetStashIDs", testCtx, srcID).Return([]models.StashID{stashID2}, nil).Once()
+
+	db.Studio.On("HasImage", testCtx, destID).Return(true, nil).Once()
+
+	db.Studio.On("Merge", testCtx, []int{srcID}, destID).Return(nil).Once()
+
+	// alias uniqueness checks
+	db.Studio.On("Query", testCtx, mock.Anything, mock.Anything).Return(nil, 0, nil)
+
+	db.Studio.On("UpdatePartial", testCtx, mock.MatchedBy(func(p models.StudioPartial) bool {
+		return assert.Equal(t, destID, p.ID) &&
+			assert.Equal(t, models.NewOptionalString("url"), p.URL) &&
+			assert.Equal(t, &models.UpdateStrings{
+				Values: []string{"src", "src alias"},
+				Mode:   models.RelationshipUpdateModeAdd,
+			}, p.Aliases) &&
+			assert.Equal(t, &models.UpdateIDs{
+				IDs:  []int{1, 2},
+				Mode: models.RelationshipUpdateModeAdd,
+			}, p.TagIDs) &&
+			assert.Equal(t, &models.UpdateStashIDs{
+				StashIDs: []models.StashID{stashID1, stashID2},
+				Mode:     models.RelationshipUpdateModeSet,
+			}, p.StashIDs)
+	})).Return(dest, nil).Once()
+
+	err := Merge(testCtx, []int{srcID}, destID, models.NewStudioPartial(), db.Studio)
+	assert.Nil(t, err)
+
+	db.AssertExpectations(t)
+}
+
+func TestMergeParentIsSource(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	db.Studio.On("Find", testCtx, 1).Return(&models.Studio{ID: 1}, nil).Once()
+	db.Studio.On("FindMany", testCtx, []int{2}).Return([]*models.Studio{{ID: 2}}, nil).Once()
+
+	values := models.NewStudioPartial()
+	values.ParentID = models.NewOptionalInt(2)
+
+	err := Merge(testCtx, []int{2}, 1, values, db.Studio)
+	assert.Equal(t, ErrMergeParentIsSource, err)
+
+	db.AssertExpectations(t)
+}
+
+func TestValidateMergeParent(t *testing.T) {
+	db := mocks.NewDatabase()
+
+	intPtr := func(i int) *int {
+		return &i
+	}
+
+	// 1 <- 2 <- 3 <- 4
+	db.Studio.On("Find", testCtx, 1).Return(&models.Studio{ID: 1}, nil)
+	db.Studio.On("Find", testCtx, 2).Return(&models.Studio{ID: 2, ParentID: intPtr(1)}, nil)
+	db.Studio.On("Find", testCtx, 3).Return(&models.Studio{ID: 3, ParentID: intPtr(2)}, nil)
+
+	dest := &models.Studio{ID: 4, ParentID: intPtr(3)}
+
+	tests := []struct {
+		name      string
+		sourceIDs []int
+		want      error
+	}{
+		{"unrelated source", []int{5}, nil},
+		{"parent", []int{3}, nil},
+		{"parent and grandparent", []int{3, 2}, nil},
+		{"grandparent", []int{2}, ErrMergeParentCycle},
+		{"great-grandparent", []int{1}, ErrMergeParentCycle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMergeParent(testCtx, dest, tt.sourceIDs, db.Studio)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
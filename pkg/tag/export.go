@@ -15,6 +15,7 @@ type FinderAliasImageGetter interface {
 	GetAliases(ctx context.Context, studioID int) ([]string, error)
 	GetImage(ctx context.Context, tagID int) ([]byte, error)
 	FindByChildTagID(ctx context.Context, childID int) ([]*models.Tag, error)
+	models.CustomFieldsLoader
 }
 
 // ToJSON converts a Tag object into its JSON equivalent.
@@ -35,6 +36,11 @@ func ToJSON(ctx context.Context, reader FinderAliasImageGetter, tag *models.Tag)
 
 	newTagJSON.Aliases = aliases
 
+	if err := tag.LoadCustomFields(ctx, reader); err != nil {
+		return nil, fmt.Errorf("error getting tag custom fields: %v", err)
+	}
+	newTagJSON.CustomFields = tag.CustomFields.List()
+
 	image, err := reader.GetImage(ctx, tag.ID)
 	if err != nil {
 		logger.Errorf("Error getting tag image: %v", err)
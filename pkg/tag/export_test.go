@@ -45,7 +45,11 @@ func createTag(id int) models.Tag {
 	}
 }
 
-func createJSONTag(aliases []string, image string, parents []string) *jsonschema.Tag {
+var customFields = []models.CustomField{
+	{Field: "string", Type: models.CustomFieldTypeString, Value: "value"},
+}
+
+func createJSONTag(aliases []string, image string, parents []string, customFields []models.CustomField) *jsonschema.Tag {
 	return &jsonschema.Tag{
 		Name:          tagName,
 		Favorite:      true,
@@ -58,8 +62,9 @@ func createJSONTag(aliases []string, image string, parents []string) *jsonschema
 		UpdatedAt: json.JSONTime{
 			Time: updateTime,
 		},
-		Image:   image,
-		Parents: parents,
+		Image:        image,
+		Parents:      parents,
+		CustomFields: customFields,
 	}
 }
 
@@ -75,17 +80,17 @@ func initTestTable() {
 	scenarios = []testScenario{
 		{
 			createTag(tagID),
-			createJSONTag([]string{"alias"}, image, nil),
+			createJSONTag([]string{"alias"}, image, nil, customFields),
 			false,
 		},
 		{
 			createTag(noImageID),
-			createJSONTag(nil, "", nil),
+			createJSONTag(nil, "", nil, []models.CustomField{}),
 			false,
 		},
 		{
 			createTag(errImageID),
-			createJSONTag(nil, "", nil),
+			createJSONTag(nil, "", nil, []models.CustomField{}),
 			// getting the image should not cause an error
 			false,
 		},
@@ -96,7 +101,7 @@ func initTestTable() {
 		},
 		{
 			createTag(withParentsID),
-			createJSONTag(nil, image, []string{"parent"}),
+			createJSONTag(nil, image, []string{"parent"}, []models.CustomField{}),
 			false,
 		},
 		{
@@ -123,6 +128,12 @@ func TestToJSON(t *testing.T) {
 	db.Tag.On("GetAliases", testCtx, withParentsID).Return(nil, nil).Once()
 	db.Tag.On("GetAliases", testCtx, errParentsID).Return(nil, nil).Once()
 
+	db.Tag.On("GetCustomFields", testCtx, tagID).Return(customFields, nil).Once()
+	db.Tag.On("GetCustomFields", testCtx, noImageID).Return(nil, nil).Once()
+	db.Tag.On("GetCustomFields", testCtx, errImageID).Return(nil, nil).Once()
+	db.Tag.On("GetCustomFields", testCtx, withParentsID).Return(nil, nil).Once()
+	db.Tag.On("GetCustomFields", testCtx, errParentsID).Return(nil, nil).Once()
+
 	db.Tag.On("GetImage", testCtx, tagID).Return(imageBytes, nil).Once()
 	db.Tag.On("GetImage", testCtx, noImageID).Return(nil, nil).Once()
 	db.Tag.On("GetImage", testCtx, errImageID).Return(nil, imageErr).Once()
@@ -43,6 +43,8 @@ func (i *Importer) PreImport(ctx context.Context) error {
 		IgnoreAutoTag: i.Input.IgnoreAutoTag,
 		CreatedAt:     i.Input.CreatedAt.GetTime(),
 		UpdatedAt:     i.Input.UpdatedAt.GetTime(),
+
+		CustomFields: models.NewRelatedCustomFields(i.Input.CustomFields),
 	}
 
 	var err error
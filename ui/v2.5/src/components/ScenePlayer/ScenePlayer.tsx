@@ -573,6 +573,17 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
       );
     }
 
+    // codecs other than H.264 that the browser can play, so that
+    // transcodes can use more efficient codecs, and generated transcodes
+    // in other codecs are not served to the browser
+    const videoElement = document.createElement("video");
+    const clientCodecs = [
+      { codec: "hevc", type: 'video/mp4; codecs="hvc1.1.6.L93.B0"' },
+      { codec: "av1", type: 'video/mp4; codecs="av01.0.05M.08"' },
+    ]
+      .filter((c) => videoElement.canPlayType(c.type) === "probably")
+      .map((c) => c.codec);
+
     const { duration } = file;
     const sourceSelector = player.sourceSelector();
     sourceSelector.setSources(
@@ -585,9 +596,12 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
         })
         .map((stream) => {
           const src = new URL(stream.url);
+          if (clientCodecs.length > 0) {
+            src.searchParams.set("codecs", clientCodecs.join(","));
+          }
 
           return {
-            src: stream.url,
+            src: src.toString(),
             type: stream.mime_type ?? undefined,
             label: stream.label ?? undefined,
             offset: !isDirect(src),
@@ -610,10 +624,10 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
       return languageCode;
     }
 
-    if (scene.captions && scene.captions.length > 0) {
-      const languageCode = getDefaultLanguageCode();
-      let hasDefault = false;
+    const languageCode = getDefaultLanguageCode();
+    let hasDefault = false;
 
+    if (scene.captions && scene.captions.length > 0) {
       for (let caption of scene.captions) {
         const lang = caption.language_code;
         let label = lang;
@@ -639,6 +653,34 @@ export const ScenePlayer: React.FC<IScenePlayerProps> = ({
       }
     }
 
+    // embedded subtitles are the same for all streams
+    const subtitleTracks = scene.sceneStreams[0]?.subtitle_tracks ?? [];
+    for (const track of subtitleTracks) {
+      if (!track.url) continue;
+
+      const lang = track.language_code ?? "";
+      let label = track.title ?? languageMap.get(lang) ?? lang;
+      if (!label) {
+        label = `Track ${track.index + 1}`;
+      }
+      label = label + " (embedded)";
+
+      const setAsDefault = !hasDefault && !!lang && languageCode == lang;
+      if (setAsDefault) {
+        hasDefault = true;
+      }
+      sourceSelector.addTextTrack(
+        {
+          src: track.url,
+          kind: "captions",
+          srclang: lang,
+          label: label,
+          default: setAsDefault,
+        },
+        false
+      );
+    }
+
     auto.current =
       autoplay ||
       (interfaceConfig?.autostartVideo ?? false) ||
@@ -2,6 +2,7 @@ import React, { PropsWithChildren, useMemo, useState } from "react";
 import { FormattedMessage, useIntl } from "react-intl";
 import { Button } from "react-bootstrap";
 import {
+  mutatePurgeScraperCache,
   mutateReloadScrapers,
   useListGroupScrapers,
   useListPerformerScrapers,
@@ -15,10 +16,15 @@ import { Icon } from "../Shared/Icon";
 import { LoadingIndicator } from "../Shared/LoadingIndicator";
 import { ScrapeType } from "src/core/generated-graphql";
 import { SettingSection } from "./SettingSection";
-import { BooleanSetting, StringListSetting, StringSetting } from "./Inputs";
+import {
+  BooleanSetting,
+  NumberSetting,
+  StringListSetting,
+  StringSetting,
+} from "./Inputs";
 import { useSettings } from "./context";
 import { StashBoxSetting } from "./StashBoxConfiguration";
-import { faSyncAlt } from "@fortawesome/free-solid-svg-icons";
+import { faSyncAlt, faTrashAlt } from "@fortawesome/free-solid-svg-icons";
 import {
   AvailableScraperPackages,
   InstalledScraperPackages,
@@ -213,6 +219,20 @@ const ScrapersSection: React.FC = () => {
     }
   }
 
+  async function onPurgeScraperCache() {
+    try {
+      const result = await mutatePurgeScraperCache();
+      Toast.success(
+        intl.formatMessage(
+          { id: "config.scraping.purged_scraper_cache" },
+          { count: result.data?.purgeScraperCache ?? 0 }
+        )
+      );
+    } catch (e) {
+      Toast.error(e);
+    }
+  }
+
   if (loadingScenes || loadingGalleries || loadingPerformers || loadingGroups)
     return (
       <SettingSection headingID="config.scraping.scrapers">
@@ -237,6 +257,15 @@ const ScrapersSection: React.FC = () => {
             <FormattedMessage id="actions.reload_scrapers" />
           </span>
         </Button>
+
+        <Button onClick={() => onPurgeScraperCache()}>
+          <span className="fa-icon">
+            <Icon icon={faTrashAlt} />
+          </span>
+          <span>
+            <FormattedMessage id="actions.purge_scraper_cache" />
+          </span>
+        </Button>
       </div>
 
       <div className="content">
@@ -343,6 +372,14 @@ export const SettingsScrapingPanel: React.FC = () => {
           onChange={(v) => saveScraping({ scraperCDPPath: v })}
         />
 
+        <NumberSetting
+          id="scraper-cache-ttl"
+          headingID="config.scraping.scraper_cache_ttl_head"
+          subHeadingID="config.scraping.scraper_cache_ttl_desc"
+          value={scraping.scraperCacheTTL ?? undefined}
+          onChange={(v) => saveScraping({ scraperCacheTTL: v })}
+        />
+
         <BooleanSetting
           id="scraper-cert-check"
           headingID="config.general.check_for_insecure_certificates"
@@ -471,6 +471,14 @@ export const SettingsServicesPanel: React.FC = () => {
               </option>
             ))}
           </SelectSetting>
+
+          <NumberSetting
+            id="dlna-minimum-play-percent"
+            headingID="config.dlna.minimum_play_percent"
+            subHeadingID="config.dlna.minimum_play_percent_desc"
+            value={dlna.minimumPlayPercent ?? undefined}
+            onChange={(v) => saveDLNA({ minimumPlayPercent: v })}
+          />
         </SettingSection>
       </>
     );
@@ -302,6 +302,25 @@ export const SettingsConfigurationPanel: React.FC = () => {
           ))}
         </SelectSetting>
 
+        <SelectSetting
+          advanced
+          id="transcode-video-codec"
+          headingID="config.general.transcode_video_codec_head"
+          subHeadingID="config.general.transcode_video_codec_desc"
+          value={general.transcodeVideoCodec ?? undefined}
+          onChange={(v) =>
+            saveGeneral({
+              transcodeVideoCodec: (v as GQL.TranscodeVideoCodec) ?? undefined,
+            })
+          }
+        >
+          {Object.values(GQL.TranscodeVideoCodec).map((c) => (
+            <option value={c} key={c}>
+              {c}
+            </option>
+          ))}
+        </SelectSetting>
+
         <SelectSetting
           id="streaming-transcode-size"
           headingID="config.general.maximum_streaming_transcode_size_head"
@@ -94,6 +94,66 @@ export const StashBoxModal: React.FC<IStashBoxModal> = ({ value, close }) => {
             />
           </Form.Group>
 
+          <Form.Group id="stashbox-max-requests-per-minute">
+            <h6>
+              {intl.formatMessage({
+                id: "config.stashbox.max_requests_per_minute",
+              })}
+            </h6>
+            <Form.Control
+              type="number"
+              min={0}
+              className="text-input stash-box-max-requests-per-minute"
+              value={v?.max_requests_per_minute ?? 0}
+              onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+                setValue({
+                  ...v!,
+                  max_requests_per_minute: Number.parseInt(e.currentTarget.value || "0", 10),
+                })
+              }
+            />
+          </Form.Group>
+
+          <Form.Group id="stashbox-max-concurrent-requests">
+            <h6>
+              {intl.formatMessage({
+                id: "config.stashbox.max_concurrent_requests",
+              })}
+            </h6>
+            <Form.Control
+              type="number"
+              min={0}
+              className="text-input stash-box-max-concurrent-requests"
+              value={v?.max_concurrent_requests ?? 0}
+              onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+                setValue({
+                  ...v!,
+                  max_concurrent_requests: Number.parseInt(e.currentTarget.value || "0", 10),
+                })
+              }
+            />
+          </Form.Group>
+
+          <Form.Group id="stashbox-max-retries">
+            <h6>
+              {intl.formatMessage({
+                id: "config.stashbox.max_retries",
+              })}
+            </h6>
+            <Form.Control
+              type="number"
+              min={0}
+              className="text-input stash-box-max-retries"
+              value={v?.max_retries ?? 0}
+              onChange={(e: React.ChangeEvent<HTMLInputElement>) =>
+                setValue({
+                  ...v!,
+                  max_retries: Number.parseInt(e.currentTarget.value || "0", 10),
+                })
+              }
+            />
+          </Form.Group>
+
           <Form.Group>
             <Button
               disabled={loading}
@@ -157,6 +217,9 @@ export const StashBoxSetting: React.FC<IStashBoxSetting> = ({
             endpoint: "",
             api_key: "",
             name: "",
+            max_requests_per_minute: 0,
+            max_concurrent_requests: 0,
+            max_retries: 0,
           }}
           close={(v) => {
             if (v) onChange([...value, v]);
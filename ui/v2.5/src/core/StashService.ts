@@ -2278,6 +2278,12 @@ export const mutateReloadScrapers = () =>
     },
   });
 
+export const mutatePurgeScraperCache = (scraperID?: string) =>
+  client.mutate<GQL.PurgeScraperCacheMutation>({
+    mutation: GQL.PurgeScraperCacheDocument,
+    variables: { scraper_id: scraperID },
+  });
+
 // all plugin-related queries
 export const pluginMutationImpactedQueries = [
   GQL.PluginsDocument,
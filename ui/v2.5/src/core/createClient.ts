@@ -106,6 +106,16 @@ const typePolicies: TypePolicies = {
 const possibleTypes = {
   BaseFile: ["VideoFile", "ImageFile", "GalleryFile"],
   VisualFile: ["VideoFile", "ImageFile"],
+  SearchResultItem: [
+    "Scene",
+    "Image",
+    "Gallery",
+    "Performer",
+    "Studio",
+    "Group",
+    "Tag",
+    "SceneMarker",
+  ],
 };
 
 export const baseURL =
@@ -24,7 +24,7 @@ import { PathCriterionOption } from "./criteria/path";
 
 const defaultSortBy = "path";
 
-const sortByOptions = ["date", ...MediaSortByOptions]
+const sortByOptions = ["date", "relevance", ...MediaSortByOptions]
   .map(ListFilterOptions.createSortBy)
   .concat([
     {
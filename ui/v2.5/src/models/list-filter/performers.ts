@@ -30,6 +30,7 @@ const sortByOptions = [
   "play_count",
   "last_played_at",
   "last_o_at",
+  "relevance",
 ]
   .map(ListFilterOptions.createSortBy)
   .concat([
@@ -50,6 +50,7 @@ const sortByOptions = [
   "interactive",
   "interactive_speed",
   "perceptual_similarity",
+  "relevance",
   ...MediaSortByOptions,
 ]
   .map(ListFilterOptions.createSortBy)
@@ -16,7 +16,7 @@ import {
 import { FavoriteTagCriterionOption } from "./criteria/favorite";
 
 const defaultSortBy = "name";
-const sortByOptions = ["name", "random"]
+const sortByOptions = ["name", "random", "relevance"]
   .map(ListFilterOptions.createSortBy)
   .concat([
     {